@@ -7,14 +7,24 @@ import (
 	"os"
 	"os/signal"
 	dockerDomain "remote-server-api/internal/domain/docker"
+	driftDomain "remote-server-api/internal/domain/drift"
 	"syscall"
 	"time"
 
 	"remote-server-api/config"
 	"remote-server-api/internal/api/router"
 	"remote-server-api/internal/api/server"
+	accountsDomain "remote-server-api/internal/domain/accounts"
+	alertsDomain "remote-server-api/internal/domain/alerts"
 	"remote-server-api/internal/domain/auth"
+	cronDomain "remote-server-api/internal/domain/cron"
+	firewallDomain "remote-server-api/internal/domain/firewall"
+	kernelDomain "remote-server-api/internal/domain/kernel"
+	logsDomain "remote-server-api/internal/domain/logs"
+	metricsDomain "remote-server-api/internal/domain/metrics"
 	serverDomain "remote-server-api/internal/domain/server"
+	snapshotsDomain "remote-server-api/internal/domain/snapshots"
+	systemdDomain "remote-server-api/internal/domain/systemd"
 	"remote-server-api/internal/infrastructure/persistence/memory"
 	"remote-server-api/internal/infrastructure/ssh"
 	"remote-server-api/internal/infrastructure/token"
@@ -46,9 +56,39 @@ func main() {
 	authService := auth.NewService(sessionRepo, sshClient, tokenService)
 	serverService := serverDomain.NewService(sessionRepo)
 	dockerService := dockerDomain.NewService(sessionRepo)
+	systemdService := systemdDomain.NewService(sessionRepo)
+	logsService := logsDomain.NewService(sessionRepo, cfg.Logs.AllowedDirs)
+	accountsService := accountsDomain.NewService(sessionRepo)
+	cronService := cronDomain.NewService(sessionRepo)
+	firewallService := firewallDomain.NewService(sessionRepo)
+	kernelService := kernelDomain.NewService(sessionRepo)
+	metricsService := metricsDomain.NewService(sessionRepo, serverService, dockerService, cfg.Metrics.Interval, cfg.Metrics.Retention)
+	alertsService := alertsDomain.NewService(sessionRepo, &http.Client{Timeout: alertsDomain.WebhookTimeout})
+	snapshotsService := snapshotsDomain.NewService(sessionRepo, snapshotsDomain.Sources{
+		Server:   serverService,
+		Systemd:  systemdService,
+		Docker:   dockerService,
+		Accounts: accountsService,
+		Cron:     cronService,
+		Kernel:   kernelService,
+	}, cfg.Snapshots.Dir)
+	driftService := driftDomain.NewService(sessionRepo, driftDomain.Sources{
+		Server:  serverService,
+		Systemd: systemdService,
+		Docker:  dockerService,
+	})
+
+	// Evaluate alert rules after every collection
+	metricsService.AddObserver(alertsService)
+
+	// Start collecting metrics and checking drift specs in the background
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go metricsService.Run(backgroundCtx)
+	go driftService.Run(backgroundCtx)
 
 	// Setup router with all dependencies
-	r := router.New(authService, serverService, dockerService)
+	r := router.New(authService, serverService, dockerService, systemdService, logsService, accountsService, cronService, firewallService, kernelService, metricsService, cfg.Metrics.Token, alertsService, snapshotsService, driftService)
 
 	// Initialize HTTP server
 	srv := server.NewServer(r, cfg.Server)
@@ -68,6 +108,7 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopBackground()
 
 	// Create a deadline for server shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -2,13 +2,17 @@ package config
 
 import (
 	"os"
+	"strings"
 	"time"
 )
 
 // Config holds all application configuration settings
 type Config struct {
-	Server ServerConfig
-	JWT    JWTConfig
+	Server    ServerConfig
+	JWT       JWTConfig
+	Logs      LogsConfig
+	Metrics   MetricsConfig
+	Snapshots SnapshotsConfig
 }
 
 // ServerConfig holds HTTP server configurations
@@ -25,6 +29,23 @@ type JWTConfig struct {
 	ExpiresIn time.Duration
 }
 
+// LogsConfig holds log retrieval configurations
+type LogsConfig struct {
+	AllowedDirs []string // Directories on the managed servers that log files may be read from
+}
+
+// MetricsConfig holds metrics collection configurations
+type MetricsConfig struct {
+	Interval  time.Duration // Interval between collections from each host, zero to disable collection
+	Retention time.Duration // How long downsampled samples are kept
+	Token     string        // Static bearer token accepted by the Prometheus endpoint, empty to require a session token
+}
+
+// SnapshotsConfig holds server state snapshot configurations
+type SnapshotsConfig struct {
+	Dir string // Directory on the API server the snapshots are stored in
+}
+
 // NewConfig creates a new configuration from environment variables
 func NewConfig() *Config {
 	return &Config{
@@ -38,6 +59,17 @@ func NewConfig() *Config {
 			Secret:    []byte(getEnv("JWT_SECRET", "your_secret_key_please_change_in_production")),
 			ExpiresIn: time.Hour * 24,
 		},
+		Logs: LogsConfig{
+			AllowedDirs: strings.Split(getEnv("LOG_ALLOWED_DIRS", "/var/log"), ","),
+		},
+		Metrics: MetricsConfig{
+			Interval:  getEnvDuration("METRICS_INTERVAL", 30*time.Second),
+			Retention: getEnvDuration("METRICS_RETENTION", 30*24*time.Hour),
+			Token:     getEnv("METRICS_TOKEN", ""),
+		},
+		Snapshots: SnapshotsConfig{
+			Dir: getEnv("SNAPSHOTS_DIR", "data/snapshots"),
+		},
 	}
 }
 
@@ -48,3 +80,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvDuration retrieves a duration from an environment variable or returns a default value when it is
+// unset or invalid
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
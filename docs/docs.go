@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/docker/container/{container_id}": {
+        "/accounts/authorized-keys": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about a specific Docker container using docker inspect",
+                "description": "Retrieves the public keys in ~/.ssh/authorized_keys and ~/.ssh/authorized_keys2 of every user, or of one user, with type, size, SHA256 fingerprint, comment and options. Lines that cannot be parsed are reported with an error. Home directories of other users need root or passwordless sudo",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,9 +30,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "docker"
+                    "accounts"
                 ],
-                "summary": "Get detailed Docker container information",
+                "summary": "List authorized SSH keys",
                 "parameters": [
                     {
                         "type": "string",
@@ -43,21 +43,23 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Container ID",
-                        "name": "container_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only list the keys of this user",
+                        "name": "user",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Docker container details retrieved successfully",
+                        "description": "Authorized keys retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docker.ContainerDetail"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/accounts.AuthorizedKey"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid container ID",
+                        "description": "Invalid user name",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -69,7 +71,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Container not found",
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -83,14 +85,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/containers": {
+        "/accounts/groups": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves information about Docker containers from the server",
+                "description": "Retrieves the groups of the group database with their supplementary members, the users having them as primary group and whether they grant sudo access",
                 "consumes": [
                     "application/json"
                 ],
@@ -98,9 +100,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "docker"
+                    "accounts"
                 ],
-                "summary": "Get Docker container information",
+                "summary": "List groups",
                 "parameters": [
                     {
                         "type": "string",
@@ -112,11 +114,11 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Docker container information retrieved successfully",
+                        "description": "Groups retrieved successfully",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/docker.Container"
+                                "$ref": "#/definitions/accounts.Group"
                             }
                         }
                     },
@@ -135,14 +137,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/image/run": {
-            "post": {
+        "/accounts/logins": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates and runs a new Docker container from an image with optional configuration",
+                "description": "Retrieves the most recent logins recorded in wtmp by last and the failed logins recorded in btmp by lastb. Reading btmp needs root or passwordless sudo; otherwise \"failures_readable\" is false",
                 "consumes": [
                     "application/json"
                 ],
@@ -150,9 +152,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "docker"
+                    "accounts"
                 ],
-                "summary": "Run a Docker container",
+                "summary": "Get login history",
                 "parameters": [
                     {
                         "type": "string",
@@ -162,24 +164,22 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Container configuration",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docker.ContainerRunRequest"
-                        }
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of records of each kind (max 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Container created successfully",
+                    "200": {
+                        "description": "Login history retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docker.ContainerRunResponse"
+                            "$ref": "#/definitions/accounts.LoginHistory"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid limit",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -190,12 +190,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "Image not found",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -205,14 +199,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/image/{image_id}": {
+        "/accounts/sudoers": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about a specific Docker image using docker image inspect",
+                "description": "Parses /etc/sudoers and the files in /etc/sudoers.d and resolves users, groups and User_Alias definitions to the users that may run commands as root. Reading the files needs root or passwordless sudo; otherwise \"readable\" is false and access is inferred from the sudo, wheel and admin groups",
                 "consumes": [
                     "application/json"
                 ],
@@ -220,9 +214,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "docker"
+                    "accounts"
                 ],
-                "summary": "Get detailed Docker image information",
+                "summary": "Get sudoers configuration",
                 "parameters": [
                     {
                         "type": "string",
@@ -230,26 +224,13 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Image ID or name:tag",
-                        "name": "image_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Docker image details retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/docker.ImageDetail"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid image ID",
+                        "description": "Sudoers configuration retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/accounts.SudoersReport"
                         }
                     },
                     "401": {
@@ -258,12 +239,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "Image not found",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -271,14 +246,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/accounts/users": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes a Docker image by ID or name",
+                "description": "Retrieves the users of the passwd database with their groups, password and lock status, account expiry, sudo access, last login and number of authorized SSH keys. Shadow and sudoers details need root or passwordless sudo; without them the password status is \"unknown\" and sudo access is inferred from the sudo, wheel and admin groups",
                 "consumes": [
                     "application/json"
                 ],
@@ -286,9 +263,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "docker"
+                    "accounts"
                 ],
-                "summary": "Delete a Docker image",
+                "summary": "List local users",
                 "parameters": [
                     {
                         "type": "string",
@@ -297,30 +274,26 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "Image ID or name:tag",
-                        "name": "image_id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "boolean",
                         "default": false,
-                        "description": "Force deletion even if image is being used by containers",
-                        "name": "force",
+                        "description": "Include system accounts outside the UID_MIN..UID_MAX range of /etc/login.defs",
+                        "name": "include_system",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Docker image deleted successfully",
+                        "description": "Users retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docker.ImageDeleteResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/accounts.User"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid image ID or name",
+                        "description": "Invalid parameter",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -340,14 +313,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/images": {
+        "/alerts": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves information about all Docker images on the server",
+                "description": "Lists the alerts of the host of the session: series for which the condition of a rule holds. An alert is pending until the condition has held for the duration of the rule, then firing. Rules are evaluated after every background metrics collection",
                 "consumes": [
                     "application/json"
                 ],
@@ -355,9 +328,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "docker"
+                    "alerts"
                 ],
-                "summary": "Get Docker images",
+                "summary": "List alerts",
                 "parameters": [
                     {
                         "type": "string",
@@ -369,11 +342,11 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Docker images retrieved successfully",
+                        "description": "Alerts retrieved successfully",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/docker.Image"
+                                "$ref": "#/definitions/alerts.Alert"
                             }
                         }
                     },
@@ -392,14 +365,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/filesystem/details": {
+        "/alerts/deliveries": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about a specific file or directory",
+                "description": "Lists the latest 100 notifications posted to the webhooks of the host of the session, newest first, with the outcome of each",
                 "consumes": [
                     "application/json"
                 ],
@@ -407,9 +380,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "filesystem"
+                    "alerts"
                 ],
-                "summary": "Get file details",
+                "summary": "List notification deliveries",
                 "parameters": [
                     {
                         "type": "string",
@@ -417,20 +390,16 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Path to the file or directory",
-                        "name": "path",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "File details retrieved successfully",
+                        "description": "Deliveries retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/server.FileSystemEntry"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/alerts.Delivery"
+                            }
                         }
                     },
                     "401": {
@@ -439,12 +408,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "File not found",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -454,14 +417,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/filesystem/list": {
+        "/alerts/rules": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a listing of files and directories at the specified path",
+                "description": "Lists the alert rules of the host of the session. Rules and webhooks are kept in memory and lost when the API restarts",
                 "consumes": [
                     "application/json"
                 ],
@@ -469,9 +432,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "filesystem"
+                    "alerts"
                 ],
-                "summary": "List files and directories",
+                "summary": "List alert rules",
                 "parameters": [
                     {
                         "type": "string",
@@ -479,33 +442,16 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Path to list (defaults to /)",
-                        "name": "path",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "default": false,
-                        "description": "Whether to list recursively",
-                        "name": "recursive",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "default": false,
-                        "description": "Whether to include hidden files/directories",
-                        "name": "include_hidden",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "File system listing retrieved successfully",
+                        "description": "Alert rules retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/server.FileSystemListing"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/alerts.Rule"
+                            }
                         }
                     },
                     "401": {
@@ -521,16 +467,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/filesystem/search": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Searches for files matching a pattern in the specified directory",
+                "description": "Adds a rule comparing a collected metric of the host of the session with a threshold, for every series matching the labels. Examples: disk.used_percent with labels {\"mount\": \"/\"} \u003e 90; container.unexpected_exit \u003e 0 for containers stopped after a non-zero exit or an OOM kill; load.1m \u003e 2 with per_cpu for 5m. The alert of a series fires once the condition has held for the duration and resolves when it no longer holds or the series disappears; every webhook of the host is notified once of each",
                 "consumes": [
                     "application/json"
                 ],
@@ -538,9 +482,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "filesystem"
+                    "alerts"
                 ],
-                "summary": "Search for files",
+                "summary": "Create an alert rule",
                 "parameters": [
                     {
                         "type": "string",
@@ -550,35 +494,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Path to search in",
-                        "name": "path",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search pattern (glob or regex)",
-                        "name": "pattern",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Maximum search depth",
-                        "name": "max_depth",
-                        "in": "query"
+                        "description": "Alert rule to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/alerts.RuleSpec"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Search results retrieved successfully",
+                    "201": {
+                        "description": "Alert rule created successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/server.FileSystemEntry"
-                            }
+                            "$ref": "#/definitions/alerts.Rule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid alert rule",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "401": {
@@ -596,9 +531,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Authenticates user against an SSH server and returns a JWT token for subsequent API requests",
+        "/alerts/rules/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes an alert rule of the host of the session together with its alerts, without sending resolve notifications",
                 "consumes": [
                     "application/json"
                 ],
@@ -606,35 +546,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "alerts"
                 ],
-                "summary": "Login to SSH and generate JWT token",
+                "summary": "Delete an alert rule",
                 "parameters": [
                     {
-                        "description": "SSH login credentials",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully logged in and token generated",
+                        "description": "Alert rule deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginResponse"
+                            "$ref": "#/definitions/alerts.Rule"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request payload",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Alert rule not found",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to connect to SSH server or generate token",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -642,14 +593,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/server-details": {
+        "/alerts/webhooks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves basic server information like hostname, OS, kernel version, etc.",
+                "description": "Lists the webhooks receiving the alert notifications of the host of the session",
                 "consumes": [
                     "application/json"
                 ],
@@ -657,9 +608,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "server"
+                    "alerts"
                 ],
-                "summary": "Get basic server details",
+                "summary": "List webhooks",
                 "parameters": [
                     {
                         "type": "string",
@@ -671,9 +622,12 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Server details retrieved successfully",
+                        "description": "Webhooks retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/server.ServerDetails"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/alerts.Webhook"
+                            }
                         }
                     },
                     "401": {
@@ -689,16 +643,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/server-details/cpu-info": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves detailed CPU information from the server",
+                "description": "Adds a webhook that receives the firing and resolved notifications of all alert rules of the host of the session as a JSON POST request. Without a template the notification itself is posted; a template is a Go text/template rendering the body from the notification, where the json function encodes a value, e.g. {\"text\": {{json .Summary}}} for Slack or Mattermost. The template must render valid JSON. Failed requests are retried twice, unless the receiver answered with a client error",
                 "consumes": [
                     "application/json"
                 ],
@@ -706,9 +658,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "server"
+                    "alerts"
                 ],
-                "summary": "Get CPU information",
+                "summary": "Create a webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -716,16 +668,28 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Webhook to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/alerts.WebhookSpec"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "CPU information retrieved successfully",
+                    "201": {
+                        "description": "Webhook created successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/server.CPUInfo"
-                            }
+                            "$ref": "#/definitions/alerts.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "401": {
@@ -743,14 +707,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/server-details/disk-usage": {
-            "get": {
+        "/alerts/webhooks/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves disk usage information from the server",
+                "description": "Removes a webhook of the host of the session",
                 "consumes": [
                     "application/json"
                 ],
@@ -758,9 +722,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "server"
+                    "alerts"
                 ],
-                "summary": "Get disk usage information",
+                "summary": "Delete a webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -768,16 +732,20 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Disk usage information retrieved successfully",
+                        "description": "Webhook deleted successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/server.DiskUsage"
-                            }
+                            "$ref": "#/definitions/alerts.Webhook"
                         }
                     },
                     "401": {
@@ -786,6 +754,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -795,14 +769,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/server-details/libraries": {
-            "get": {
+        "/alerts/webhooks/{id}/test": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves information about installed libraries and packages on the server",
+                "description": "Posts a notification with status test to a webhook once and returns the outcome, including the status code of the receiver. A failed delivery is reported in the response rather than as an error",
                 "consumes": [
                     "application/json"
                 ],
@@ -810,9 +784,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "server"
+                    "alerts"
                 ],
-                "summary": "Get installed libraries information",
+                "summary": "Test a webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -820,16 +794,20 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Installed libraries information retrieved successfully",
+                        "description": "Test notification sent",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/server.Library"
-                            }
+                            "$ref": "#/definitions/alerts.Delivery"
                         }
                     },
                     "401": {
@@ -838,6 +816,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -847,14 +831,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/server-details/running-processes": {
+        "/cron/crontabs/{user}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves information about running processes on the server",
+                "description": "Retrieves the entries of a user crontab with their next run. The version, also returned in the ETag header, must be sent in the If-Match header of changes",
                 "consumes": [
                     "application/json"
                 ],
@@ -862,9 +846,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "server"
+                    "cron"
                 ],
-                "summary": "Get running processes information",
+                "summary": "Get a user crontab",
                 "parameters": [
                     {
                         "type": "string",
@@ -872,16 +856,26 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Running processes information retrieved successfully",
+                        "description": "Crontab retrieved successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/server.ProcessInfo"
-                            }
+                            "$ref": "#/definitions/cron.Crontab"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user name",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "401": {
@@ -890,776 +884,9399 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "403": {
+                        "description": "Crontabs of other users need root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
+                    },
+                    "501": {
+                        "description": "crontab is not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.LoginRequest": {
-            "type": "object",
-            "required": [
-                "ip",
-                "password",
-                "port",
-                "username"
-            ],
-            "properties": {
-                "ip": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
-                },
-                "port": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "auth.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
-                }
-            }
         },
-        "docker.Container": {
-            "type": "object",
-            "properties": {
-                "command": {
-                    "type": "string"
-                },
-                "container_id": {
-                    "type": "string"
-                },
-                "created_on": {
-                    "type": "string"
-                },
-                "image": {
-                    "type": "string"
-                },
-                "names": {
-                    "type": "string"
-                },
-                "ports": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
+        "/cron/crontabs/{user}/entries": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Appends an entry to a user crontab, creating the crontab if needed. The change is only applied if the crontab still has the version given in the If-Match header",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cron"
+                ],
+                "summary": "Add a crontab entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crontab version as returned in the ETag header",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule and command",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cron.CrontabEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Entry added, the crontab is returned",
+                        "schema": {
+                            "$ref": "#/definitions/cron.Crontab"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid entry",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Crontabs of other users need root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Crontab was changed since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "crontab is not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
                 }
             }
         },
-        "docker.ContainerDetail": {
-            "type": "object",
-            "properties": {
-                "command": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        "/cron/crontabs/{user}/entries/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     }
-                },
-                "created": {
-                    "type": "string"
-                },
-                "host_config": {
-                    "$ref": "#/definitions/docker.HostConfig"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "image": {
-                    "type": "string"
-                },
-                "labels": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
+                ],
+                "description": "Replaces an entry of a user crontab. Entry IDs are line numbers of the version given in the If-Match header, and the change is only applied if the crontab still has that version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cron"
+                ],
+                "summary": "Update a crontab entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crontab version as returned in the ETag header",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule and command",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cron.CrontabEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Entry updated, the crontab is returned",
+                        "schema": {
+                            "$ref": "#/definitions/cron.Crontab"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid entry",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Crontabs of other users need root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User or entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Crontab was changed since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "crontab is not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes an entry from a user crontab. Entry IDs are line numbers of the version given in the If-Match header, and the change is only applied if the crontab still has that version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cron"
+                ],
+                "summary": "Delete a crontab entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crontab version as returned in the ETag header",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Entry deleted, the crontab is returned",
+                        "schema": {
+                            "$ref": "#/definitions/cron.Crontab"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid entry ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Crontabs of other users need root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User or entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Crontab was changed since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "crontab is not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cron/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the entries of all user crontabs, /etc/crontab and /etc/cron.d, the scripts in /etc/cron.hourly, daily, weekly and monthly, and the systemd timers, with their next run and, where cron logs to the journal, their last run during the past week. User crontabs need root or passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cron"
+                ],
+                "summary": "List scheduled jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scheduled jobs retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/cron.JobInventory"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/container/{container_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed information about a specific Docker container using docker inspect",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docker"
+                ],
+                "summary": "Get detailed Docker container information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "container_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Docker container details retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docker.ContainerDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid container ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/containers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves information about Docker containers from the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docker"
+                ],
+                "summary": "Get Docker container information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Docker container information retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/docker.Container"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/image/run": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates and runs a new Docker container from an image with optional configuration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docker"
+                ],
+                "summary": "Run a Docker container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Container configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docker.ContainerRunRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Container created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docker.ContainerRunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/image/{image_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed information about a specific Docker image using docker image inspect",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docker"
+                ],
+                "summary": "Get detailed Docker image information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image ID or name:tag",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Docker image details retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docker.ImageDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid image ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a Docker image by ID or name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docker"
+                ],
+                "summary": "Delete a Docker image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image ID or name:tag",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Force deletion even if image is being used by containers",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Docker image deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docker.ImageDeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid image ID or name",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/images": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves information about all Docker images on the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docker"
+                ],
+                "summary": "Get Docker images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Docker images retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/docker.Image"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/states": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the state, exit code, OOM kill flag, restart count and restart policy of all containers from 'docker inspect'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docker"
+                ],
+                "summary": "Get Docker container states",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Docker container states retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/docker.ContainerStatus"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Takes a single 'docker stats' sample of the running containers: CPU and memory usage, and cumulative network and block I/O",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docker"
+                ],
+                "summary": "Get Docker container resource usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Docker container resource usage retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/docker.ContainerStats"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drift/check": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Checks the host of the session against a spec of its expected state and returns the drift report. The spec is written in YAML, or JSON, and declares packages that must be installed, optionally within version constraints such as \"\u003e= 1.18, \u003c 1.25\", or must not be; systemd services that must be enabled or disabled and optionally running or stopped; Docker containers that must be running, optionally created from an image reference and pinned by repository digest; and files that must exist with a SHA-256 checksum, mode, owner and group, or must not exist. Each item yields a result with status ok, drift or error when its actual state could not be read",
+                "consumes": [
+                    "application/x-yaml",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drift"
+                ],
+                "summary": "Check for drift",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Expected state",
+                        "name": "spec",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/drift.Spec"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Host checked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/drift.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid spec",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drift/specs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the specs stored for the host of the session with their schedule and latest report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drift"
+                ],
+                "summary": "List drift specs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Specs retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/drift.StoredSpec"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stores a spec of the expected state of the host of the session, in the format of POST /drift/check. With an interval the host is checked on that schedule, starting right away, through any active session to it; without one it is checked on demand. The latest reports are kept with the spec. Specs are kept in memory and are lost on restart",
+                "consumes": [
+                    "application/x-yaml",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drift"
+                ],
+                "summary": "Store a drift spec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Check interval, e.g. 15m or 1h, at least 1m",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Expected state",
+                        "name": "spec",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/drift.Spec"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Spec stored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/drift.StoredSpec"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid spec or interval",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drift/specs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a spec stored for the host of the session with its schedule and latest report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drift"
+                ],
+                "summary": "Get a drift spec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spec ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Spec retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/drift.StoredSpec"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Spec not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a spec stored for the host of the session together with its reports",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drift"
+                ],
+                "summary": "Delete a drift spec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spec ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Spec deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/drift.StoredSpec"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Spec not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drift/specs/{id}/check": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Checks the host of the session against a stored spec right away, regardless of its schedule, and keeps the report with the spec",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drift"
+                ],
+                "summary": "Check a drift spec now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spec ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Host checked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/drift.Report"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Spec not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drift/specs/{id}/reports": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the latest reports of a stored spec, newest first, whether the checks were scheduled or on demand. A scheduled check that found no active session to the host yields a report with an error and no results",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drift"
+                ],
+                "summary": "List drift reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spec ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reports retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/drift.Report"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Spec not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/filesystem/details": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed information about a specific file or directory",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filesystem"
+                ],
+                "summary": "Get file details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path to the file or directory",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File details retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.FileSystemEntry"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "File not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/filesystem/disk-usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Runs a depth-limited du below a path and returns a size-sorted tree with byte totals and file counts per directory, or the largest files in largest-files mode. Stays on the filesystem of the path. Large trees should use the background analysis endpoints instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filesystem"
+                ],
+                "summary": "Analyze directory sizes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute directory to analyze",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "tree",
+                            "largest-files"
+                        ],
+                        "type": "string",
+                        "default": "tree",
+                        "description": "Analysis mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Tree depth below the path (max 10)",
+                        "name": "max_depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Children kept per directory in tree mode, number of files in largest-files mode (max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Directory usage analyzed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.DirectoryUsageResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Directory not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/filesystem/disk-usage/analyses": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Starts a directory size analysis that keeps running after the request returns. Poll the returned analysis for progress and the result, or cancel it. At most two analyses run per session and finished analyses are kept for an hour",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filesystem"
+                ],
+                "summary": "Start a background directory analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Analysis to run",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.DirectoryUsageQuery"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Analysis started",
+                        "schema": {
+                            "$ref": "#/definitions/server.DirectoryAnalysis"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many running analyses",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/filesystem/disk-usage/analyses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the status and progress of a background analysis, including the result once it has completed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filesystem"
+                ],
+                "summary": "Get a background directory analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Analysis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Analysis retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.DirectoryAnalysis"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Analysis not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancels a running analysis and terminates its commands on the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filesystem"
+                ],
+                "summary": "Cancel a background directory analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Analysis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Analysis cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/server.DirectoryAnalysis"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Analysis not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Analysis is not running",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/filesystem/download": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams the contents of a regular file on the host without buffering it. The file is read as the SSH user unless sudo is set, which reads it through passwordless sudo and needs root or passwordless sudo. The Content-Type comes from file --mime-type, falling back to application/octet-stream, and the ETag from the modification time and size. Single and multiple byte ranges are supported for resuming large downloads, as are conditional requests with If-None-Match, If-Modified-Since and If-Range",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "filesystem"
+                ],
+                "summary": "Download a file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute path of the file",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Read the file through passwordless sudo (default false)",
+                        "name": "sudo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Byte ranges, e.g. bytes=1048576-",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File contents",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Requested ranges of the file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "File not modified"
+                    },
+                    "400": {
+                        "description": "Invalid path or not a regular file",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "File not readable, or sudo requested without root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "File not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "416": {
+                        "description": "Range not satisfiable"
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/filesystem/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a listing of files and directories at the specified path",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filesystem"
+                ],
+                "summary": "List files and directories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path to list (defaults to /)",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Whether to list recursively",
+                        "name": "recursive",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Whether to include hidden files/directories",
+                        "name": "include_hidden",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File system listing retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.FileSystemListing"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/filesystem/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Searches for files matching a pattern in the specified directory",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filesystem"
+                ],
+                "summary": "Search for files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path to search in",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search pattern (glob or regex)",
+                        "name": "pattern",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum search depth",
+                        "name": "max_depth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.FileSystemEntry"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/filesystem/upload": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams the request body, raw or as the \"file\" part of a multipart form, to a temporary file next to the target on the host, verifies its SHA-256 against the uploaded content and, when given, the expected checksum, then renames it into place atomically, so that readers never see a partial file. A symbolic link at the path is resolved and its target replaced. Without mode, owner and group, those of the replaced file are kept; new files get mode 0644 and the SSH user. The file is written as the SSH user unless sudo is set, which writes it through passwordless sudo and needs root or passwordless sudo; writing outside the home of the SSH user or changing the owner usually needs it",
+                "consumes": [
+                    "application/octet-stream",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filesystem"
+                ],
+                "summary": "Upload a file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute path of the file",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Octal permissions, e.g. 0640",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User name or ID",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group name or ID",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Create missing parent directories",
+                        "name": "create_parents",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected SHA-256 of the content",
+                        "name": "sha256",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Write the file through passwordless sudo",
+                        "name": "sudo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "File content, for multipart uploads",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File replaced successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.FileUploadResult"
+                        }
+                    },
+                    "201": {
+                        "description": "File created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.FileUploadResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid path, attributes or body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied, or sudo requested without root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Parent directory not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Checksum mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/firewall/changes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the state of a rule change made through this session: pending, confirmed, rolled_back or failed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "firewall"
+                ],
+                "summary": "Get a firewall rule change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Change ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Change retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/firewall.RuleChange"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Change not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/firewall/changes/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Keeps a pending rule change and cancels its rollback timer. Confirming from a new request also proves the API can still reach the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "firewall"
+                ],
+                "summary": "Confirm a firewall rule change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Change ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Change confirmed",
+                        "schema": {
+                            "$ref": "#/definitions/firewall.RuleChange"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Changing firewall rules needs root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Change not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Change is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/firewall/changes/{id}/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restores the ruleset saved before a pending rule change without waiting for the timer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "firewall"
+                ],
+                "summary": "Roll back a firewall rule change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Change ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Change rolled back",
+                        "schema": {
+                            "$ref": "#/definitions/firewall.RuleChange"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Changing firewall rules needs root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Change not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Change is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/firewall/rules": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reads the nftables ruleset, the iptables and ip6tables rules and the ufw status and normalizes them into a common rule model. Tables created by iptables-nft are reported by iptables only. Reading the rules usually needs root or passwordless sudo; backends that could not be read carry an error",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "firewall"
+                ],
+                "summary": "List firewall rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only rules of this backend (nftables, iptables or ufw)",
+                        "name": "backend",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only rules of this chain, case-insensitive",
+                        "name": "chain",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Firewall rules retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/firewall.Ruleset"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a rule after saving the current ruleset on the server. A timer on the server restores the saved ruleset unless the change is confirmed before it expires, so a rule that cuts off the API is undone without it. Only one change per server can await confirmation. Needs root or passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "firewall"
+                ],
+                "summary": "Add a firewall rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time to confirm the change before it is rolled back, between 10s and 10m (default 1m)",
+                        "name": "confirm_timeout",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Rule to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/firewall.RuleSpec"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Rule added, awaiting confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/firewall.RuleChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rule or confirmation timeout",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Changing firewall rules needs root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Another change is awaiting confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Firewall backend is not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/firewall/rules/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a rule by the ID returned when listing rules, after saving the current ruleset on the server. The rule is restored unless the change is confirmed before it expires. Needs root or passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "firewall"
+                ],
+                "summary": "Delete a firewall rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time to confirm the change before it is rolled back, between 10s and 10m (default 1m)",
+                        "name": "confirm_timeout",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Rule deleted, awaiting confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/firewall.RuleChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid confirmation timeout or rule rejected by the backend",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Changing firewall rules needs root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Another change is awaiting confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/kernel/cmdline": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the kernel release and the command line the kernel was booted with, split into parameters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kernel"
+                ],
+                "summary": "Get the kernel command line",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kernel command line retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/kernel.CommandLine"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/kernel/modules": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the loaded kernel modules as lsmod does, with the modules each one depends on and is used by",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kernel"
+                ],
+                "summary": "List kernel modules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kernel modules retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/kernel.KernelModule"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/kernel/sysctl": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the kernel parameters of 'sysctl -a' as key/value pairs. Parameters set in sysctl configuration files carry the effective configured value and file, and are flagged when the runtime value differs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kernel"
+                ],
+                "summary": "List kernel parameters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only parameters under this key, e.g. net.ipv4",
+                        "name": "prefix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kernel parameters retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/kernel.SysctlReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid prefix",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/kernel/sysctl/diff": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Compares the kernel parameters with a baseline given as a key/value map, as the text of a sysctl.conf file or 'sysctl -a' output, or both. Keys may use the glob patterns of sysctl.d. Values are compared with whitespace normalized",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kernel"
+                ],
+                "summary": "Compare kernel parameters with a baseline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Baseline",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/kernel.SysctlBaseline"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kernel parameters compared successfully",
+                        "schema": {
+                            "$ref": "#/definitions/kernel.SysctlDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid baseline",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/kernel/sysctl/{key}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sets a writable kernel parameter and returns the previous value and the value read back from the kernel. With persist, the value is also written to /etc/sysctl.d/99-remote-server-api.conf, replacing an earlier line for the key; if that fails, the previous runtime value is restored. A configuration file applied later at boot, such as /etc/sysctl.conf, that sets another value is reported in overridden_by. Needs root or passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kernel"
+                ],
+                "summary": "Set a kernel parameter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Parameter key, e.g. vm.swappiness",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Value to set",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/kernel.SysctlUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kernel parameter set successfully",
+                        "schema": {
+                            "$ref": "#/definitions/kernel.SysctlUpdateResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid key or value",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Setting kernel parameters needs root or passwordless sudo",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Parameter not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Parameter is read-only",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Authenticates user against an SSH server and returns a JWT token for subsequent API requests",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Login to SSH and generate JWT token",
+                "parameters": [
+                    {
+                        "description": "SSH login credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully logged in and token generated",
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to connect to SSH server or generate token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/file": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the last lines of a log file, optionally filtered by a Go (RE2) regular expression, returning the last matching lines. Only files inside the permitted directories (LOG_ALLOWED_DIRS, default /var/log) can be read, after resolving symbolic links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logs"
+                ],
+                "summary": "Tail a log file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute path of the log file",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Go (RE2) regular expression matched against each line",
+                        "name": "grep",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Number of most recent lines (max 5000)",
+                        "name": "lines",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Log lines retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/logs.LogFile"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Path not permitted or permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Log file not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/file/follow": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Emits the last lines of a log file and then every appended line as a \"log-line\" event until the client disconnects. Log rotation is followed. Path errors are reported as an \"error\" event",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "logs"
+                ],
+                "summary": "Follow a log file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute path of the log file",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Go (RE2) regular expression matched against each line",
+                        "name": "grep",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Number of lines to emit before following (max 5000)",
+                        "name": "lines",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of log lines",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/journal": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the most recent journal entries, optionally filtered by unit, priority, time range and a Go (RE2) regular expression on the message, returning the last matching entries. Uses sudo when available so system entries are visible",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logs"
+                ],
+                "summary": "Query the systemd journal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Systemd unit (e.g. nginx.service)",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum priority as name or number, or a range (e.g. err, 4, warning..err)",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time as RFC 3339 or a duration before now (e.g. 1h)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time as RFC 3339 or a duration before now",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Go (RE2) regular expression matched against the message",
+                        "name": "grep",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Number of most recent entries (max 5000)",
+                        "name": "lines",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Journal entries retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/logs.JournalEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "journalctl is not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/journal/follow": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Emits the most recent matching entries and then every new entry as a \"journal-entry\" event until the client disconnects",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "logs"
+                ],
+                "summary": "Follow the systemd journal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Systemd unit (e.g. nginx.service)",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum priority as name or number, or a range (e.g. err, 4, warning..err)",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time as RFC 3339 or a duration before now (e.g. 1h)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Go (RE2) regular expression matched against the message",
+                        "name": "grep",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Number of entries to emit before following (max 5000)",
+                        "name": "lines",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of journal entries",
+                        "schema": {
+                            "$ref": "#/definitions/logs.JournalEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the samples of a metric collected in the background from the host of the session, one series per label set. Samples are kept at the collection interval for two hours, in five-minute steps for two days and in hourly steps for the retention period; the finest resolution still holding the start of the range is used and averaged into steps. Metrics: cpu.usage_percent, cpu.iowait_percent, cpu.steal_percent, cpu.count, load.1m, load.5m, load.15m, memory.used_bytes, memory.available_bytes, memory.used_percent, swap.used_bytes, disk.used_bytes, disk.used_percent, disk.inodes_used_percent (label mount), network.rx_bytes_per_sec, network.tx_bytes_per_sec (label interface), container.cpu_percent, container.memory_bytes, container.memory_percent, container.running, container.unexpected_exit (label container)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "Get metric history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metric name, e.g. cpu.usage_percent",
+                        "name": "metric",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range as RFC 3339 or as a duration before now (default 1h)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range as RFC 3339 or as a duration before now (default now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Interval between points as a duration, e.g. 5m (default: about 300 points)",
+                        "name": "step",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only series with this label value, as name=value (e.g. mount=/)",
+                        "name": "label",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Metric history retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/metrics.History"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/hosts/{host}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Takes the current measurements of a host over SSH and returns them in the Prometheus text exposition format, without installing anything on the host. Load, memory, filesystem and network metrics use the node_exporter names; CPU utilization, Docker container states and container resource usage are reported as cerberus_* metrics. The host is the SSH address of an active session, with the port left out for port 22. With a session token only the host of that session can be scraped; the static token configured with METRICS_TOKEN can scrape any host with an active session",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "Scrape host metrics for Prometheus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003csession token or metrics token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Host address, e.g. 203.0.113.7 or 203.0.113.7:2222",
+                        "name": "host",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Metrics in the Prometheus text format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No active session to the host",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/series": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the metric series stored for the host of the session with their latest value, together with the outcome of the latest collection. Metrics are collected from the hosts of all active sessions; a host reached through several sessions is collected once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "List collected series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Series retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/metrics.SeriesList"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves basic server information like hostname, OS, kernel version, etc.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get basic server details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Server details retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.ServerDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/capabilities": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the profile used to pick command dialects: GNU, BusyBox or BSD userland, package manager, supported stat, find, ps and df options, privileges and optional tools. The server is probed once per session; set refresh to probe it again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get server userland capabilities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Probe the server again instead of using the cached profile",
+                        "name": "refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Capabilities retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.Capabilities"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid refresh parameter",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/cpu-info": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed CPU information from the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get CPU information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CPU information retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.CPUInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/cpu-usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Samples /proc/stat twice over the given interval and returns per-core and aggregate utilization percentages",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get CPU utilization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1s",
+                        "description": "Sampling interval as a duration (e.g. 500ms, 2s)",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CPU utilization retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.CPUUsage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid interval",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/cpu-usage/stream": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Continuously samples /proc/stat on the server and emits a \"cpu-usage\" event per interval until the client disconnects",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Stream CPU utilization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2s",
+                        "description": "Sampling interval as a duration (e.g. 500ms, 2s)",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of CPU utilization events",
+                        "schema": {
+                            "$ref": "#/definitions/server.CPUUsage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid interval",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/disk-usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves space and inode usage in bytes, filesystem type and mount options of the mounted filesystems. Pseudo filesystems such as tmpfs, overlay and squashfs are excluded unless requested",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get disk usage information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include pseudo filesystems such as tmpfs and overlay",
+                        "name": "include_pseudo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Disk usage information retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.DiskUsage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid include_pseudo parameter",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/facts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves operating system release, kernel, architecture, virtualization and container detection, CPU sockets, cores and threads, total memory, timezone, boot time and machine ID, gathered in a single round trip. Facts are cached per session for five minutes; set refresh to gather them again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get host facts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Gather the facts again instead of using the cached ones",
+                        "name": "refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Host facts retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.HostFacts"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid refresh parameter",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/libraries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the installed packages with version, architecture and state from dpkg, rpm, pacman or apk",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get installed libraries information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Installed libraries information retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.Library"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "No supported package manager",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/listening-ports": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists listening TCP and UDP sockets using ss, falling back to /proc/net/tcp* and /proc/net/udp* when ss is missing. Each socket includes the owning PID, process name and Docker container when available",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get listening ports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by protocol (tcp or udp)",
+                        "name": "protocol",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by local port",
+                        "name": "port",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Listening ports retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.ListeningSocket"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/memory-usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves memory and swap usage from /proc/meminfo. Used memory is total minus available memory, so page cache that can be reclaimed counts as available",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get memory usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Memory usage retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.MemoryUsage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/network": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves network interfaces with addresses, MTU, state and MAC, traffic counters and rates derived from two /proc/net/dev samples, the routing table and DNS resolvers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get network information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1s",
+                        "description": "Interval between the two counter samples as a duration (e.g. 500ms, 2s)",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Network information retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.NetworkInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid interval",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/packages/updates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the packages with a newer version available from apt, dnf, yum, zypper, pacman or apk. Security updates are flagged where the package manager provides advisories (apt security suites, dnf/yum updateinfo, arch-audit). Set refresh to update the package metadata first, which requires root or passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Get pending package updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Refresh the package metadata before checking",
+                        "name": "refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pending updates retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.PendingUpdates"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid refresh parameter",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "No supported package manager",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/packages/{operation}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Runs the package manager non-interactively with root privileges and streams every output line as an \"output\" event, followed by a \"result\" event with the exit code. Upgrading without packages upgrades the whole system. Essential packages such as the package manager, libc, sudo and the SSH server cannot be removed, directly or as dependents of the requested packages, which is checked by simulating the removal first. A transaction keeps running to completion if the client disconnects. Validation and permission errors are returned before the stream starts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Install, remove or upgrade packages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "install",
+                            "remove",
+                            "upgrade"
+                        ],
+                        "type": "string",
+                        "description": "Operation",
+                        "name": "operation",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Packages to act on",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.PackageOperationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of output lines and the operation result",
+                        "schema": {
+                            "$ref": "#/definitions/server.PackageOperationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid operation or package name",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Protected package or insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "No supported package manager",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/processes/{pid}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the command line, redacted environment, open file count, cgroups, threads and parent PID of a process",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processes"
+                ],
+                "summary": "Get process details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Process ID",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Process details retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.ProcessDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid process ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Process not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/processes/{pid}/kill": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sends SIGKILL to a process. PID 1, kernel threads and the SSH session used by the API are refused, and processes of other users require passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processes"
+                ],
+                "summary": "Kill a process",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Process ID",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Process killed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.ProcessActionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid process ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Protected process or permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Process not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/processes/{pid}/renice": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Changes the nice value of a process. Raising the priority (negative values) usually requires root or passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processes"
+                ],
+                "summary": "Renice a process",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Process ID",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New priority",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.ProcessReniceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Priority changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.ProcessActionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid process ID or priority",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Protected process or permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Process not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/processes/{pid}/signal": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sends a signal (HUP, INT, QUIT, KILL, USR1, USR2, TERM, CONT, STOP) to a process. PID 1, kernel threads and the SSH session used by the API are refused, and processes of other users require passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processes"
+                ],
+                "summary": "Send a signal to a process",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Process ID",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signal to send",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.ProcessSignalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Signal sent successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.ProcessActionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid process ID or signal",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Protected process or permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Process not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/running-processes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves running processes with typed CPU, memory and size fields. Supports filtering by user, command regex and state, sorting, pagination and a tree mode that nests children under their parent. The response is a paged ProcessList when page, page_size or tree is given; without them, all matching processes are returned as a plain array, as before paging was added",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get running processes information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only processes of this user",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Regular expression matched against the command line",
+                        "name": "command",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Process state letters to include, e.g. R,D",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "pid",
+                        "description": "Sort key: cpu, mem, rss, start or pid",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order: asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Processes per page (max 1000)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Nest child processes under their parent",
+                        "name": "tree",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Running processes information retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.ProcessList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/security-audit": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Runs read-only checks in one round trip: sshd root login, password and empty password authentication, world-writable files and directories in system paths, unexpected setuid binaries, pending security updates, failed logins of the last day, automatic updates and Docker daemon exposure on TCP. Failed checks deduct a penalty by severity (critical 30, high 15, medium 8, low 3) from a score of 100 and carry remediation hints. Some checks need root or passwordless sudo and are reported as unknown without it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Audit server security",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Security audit completed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.SecurityAudit"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/storage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves block devices from lsblk, LVM physical volumes, volume groups and logical volumes, and software RAID arrays from /proc/mdstat in one call",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "storage"
+                ],
+                "summary": "Get storage inventory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Storage inventory retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.StorageInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/storage/block-devices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves disks, partitions and other block devices with size, model, rotational flag, filesystem UUID and mountpoints, nested by parent device",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "storage"
+                ],
+                "summary": "Get block devices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Block devices retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.BlockDevice"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/storage/lvm": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves LVM physical volumes, volume groups and logical volumes with sizes in bytes. Requires root or passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "storage"
+                ],
+                "summary": "Get LVM volumes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "LVM information retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.LVMInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-details/storage/raid": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves md arrays from /proc/mdstat with member state and sync progress. Arrays with missing or failed members are flagged as degraded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "storage"
+                ],
+                "summary": "Get software RAID status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "RAID status retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/server.RAIDInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists systemd units with their load, active, sub and unit file state. Only services are listed unless another type is requested",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "List systemd units",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "service",
+                        "description": "Unit type (service, socket, target, timer, mount, ...) or 'all'",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only units whose load, active or sub state matches (e.g. running, failed, inactive)",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Units retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/systemd.Unit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid unit type",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "systemd is not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{unit}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the state, unit file, main PID, dependencies and all properties reported by systemctl show. Names without a suffix are treated as services",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Get systemd unit details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit name (e.g. nginx or nginx.service)",
+                        "name": "unit",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unit details retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/systemd.UnitDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid unit name",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Unit not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "systemd is not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{unit}/{action}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Runs systemctl start, stop, restart, reload, enable or disable for a unit and returns the resulting state. Requires root or passwordless sudo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Perform an action on a systemd unit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit name (e.g. nginx or nginx.service)",
+                        "name": "unit",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "start",
+                            "stop",
+                            "restart",
+                            "reload",
+                            "enable",
+                            "disable"
+                        ],
+                        "type": "string",
+                        "description": "Action to perform",
+                        "name": "action",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Action performed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/systemd.UnitActionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid unit name or action",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Unit not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "systemd is not available",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the stored snapshots of the hosts the session's user has an active session to, newest first, with the number of items per captured section, so that hosts can be compared. Snapshots of other hosts are left out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "List snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only snapshots of this host, as host:port",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshots retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/snapshots.SnapshotInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Captures the installed packages, systemd services, Docker containers and images, listening ports, users, scheduled jobs and kernel parameters of the host of the session and stores them on the API server. Values that change on their own, such as last logins, next job runs and kernel counters, are left out. A section that cannot be captured, e.g. containers on a host without Docker, carries an error instead of items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Take a snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Snapshot label",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/snapshots.SnapshotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Snapshot taken successfully",
+                        "schema": {
+                            "$ref": "#/definitions/snapshots.Snapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/diff": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the items added, removed and changed from snapshot a to snapshot b, per section. The snapshots may be of the same host, to see what changed over time, or of different hosts, to see how they differ. Items are matched by key, e.g. package name, unit name or protocol, address and port; changed items list the attributes with different values. Sections not captured in either snapshot are not compared and carry an error. Only snapshots of hosts the session's user has an active session to are found",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Compare snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the older or reference snapshot",
+                        "name": "a",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the newer or compared snapshot",
+                        "name": "b",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only these sections (packages, services, containers, images, ports, users, cron, sysctl)",
+                        "name": "section",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshots compared successfully",
+                        "schema": {
+                            "$ref": "#/definitions/snapshots.Diff"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing snapshot ID or unknown section",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a stored snapshot with all its items. Only snapshots of hosts the session's user has an active session to are found",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Get a snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/snapshots.Snapshot"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a stored snapshot of the session's host; snapshots of other hosts are not found",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Delete a snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/snapshots.SnapshotInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "accounts.AuthorizedKey": {
+            "type": "object",
+            "properties": {
+                "bits": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Set when the line could not be parsed",
+                    "type": "string"
+                },
+                "file": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "description": "SHA256 fingerprint as printed by ssh-keygen -l",
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "accounts.Group": {
+            "type": "object",
+            "properties": {
+                "gid": {
+                    "type": "integer"
+                },
+                "members": {
+                    "description": "Supplementary members listed in the group entry",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "primary_users": {
+                    "description": "Users with this group as their primary group",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sudo": {
+                    "description": "Members may run commands as root through sudo",
+                    "type": "boolean"
+                }
+            }
+        },
+        "accounts.LoginHistory": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/accounts.LoginRecord"
+                    }
+                },
+                "failures_readable": {
+                    "description": "False when lastb could not read /var/log/btmp",
+                    "type": "boolean"
+                },
+                "logins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/accounts.LoginRecord"
+                    }
+                }
+            }
+        },
+        "accounts.LoginRecord": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "login_at": {
+                    "type": "string"
+                },
+                "logout_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"logged out\", \"still logged in\", \"crash\", \"down\" or \"gone - no logout\"",
+                    "type": "string"
+                },
+                "terminal": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "accounts.SudoAccess": {
+            "type": "object",
+            "properties": {
+                "no_password": {
+                    "type": "boolean"
+                },
+                "user": {
+                    "type": "string"
+                },
+                "via": {
+                    "description": "e.g. \"user\", \"%sudo\", \"User_Alias ADMINS\", \"uid 0\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "accounts.SudoRule": {
+            "type": "object",
+            "properties": {
+                "no_password": {
+                    "type": "boolean"
+                },
+                "principals": {
+                    "description": "Users, %groups or aliases the rule applies to",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source": {
+                    "description": "File the rule was read from",
+                    "type": "string"
+                },
+                "spec": {
+                    "description": "Host, runas and command specification",
+                    "type": "string"
+                }
+            }
+        },
+        "accounts.SudoersReport": {
+            "type": "object",
+            "properties": {
+                "readable": {
+                    "description": "False when the sudoers files could not be read and access was inferred from group membership",
+                    "type": "boolean"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/accounts.SudoRule"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/accounts.SudoAccess"
+                    }
+                }
+            }
+        },
+        "accounts.User": {
+            "type": "object",
+            "properties": {
+                "authorized_keys": {
+                    "description": "Number of keys in ~/.ssh/authorized_keys",
+                    "type": "integer"
+                },
+                "comment": {
+                    "description": "GECOS field",
+                    "type": "string"
+                },
+                "expired": {
+                    "description": "Account expiry date has passed",
+                    "type": "boolean"
+                },
+                "gid": {
+                    "type": "integer"
+                },
+                "groups": {
+                    "description": "Primary group first",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "home": {
+                    "type": "string"
+                },
+                "last_login": {
+                    "type": "string"
+                },
+                "last_login_from": {
+                    "type": "string"
+                },
+                "last_login_tty": {
+                    "type": "string"
+                },
+                "locked": {
+                    "type": "boolean"
+                },
+                "login_shell": {
+                    "description": "Whether the shell allows interactive logins",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password_status": {
+                    "type": "string"
+                },
+                "shell": {
+                    "type": "string"
+                },
+                "sudo": {
+                    "description": "User may run commands as root through sudo",
+                    "type": "boolean"
+                },
+                "system": {
+                    "description": "UID below UID_MIN of /etc/login.defs",
+                    "type": "boolean"
+                },
+                "uid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "alerts.Alert": {
+            "type": "object",
+            "properties": {
+                "active_at": {
+                    "description": "Collection at which the condition started to hold",
+                    "type": "string"
+                },
+                "fired_at": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "labels": {
+                    "description": "Labels of the series",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "string"
+                },
+                "rule_name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "pending or firing",
+                    "type": "string"
+                },
+                "threshold": {
+                    "description": "Threshold in effect, multiplied by the CPUs for per-CPU rules",
+                    "type": "number"
+                },
+                "value": {
+                    "description": "Latest value of the series",
+                    "type": "number"
+                }
+            }
+        },
+        "alerts.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "delivered": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "notification": {
+                    "$ref": "#/definitions/alerts.Notification"
+                },
+                "sent_at": {
+                    "description": "Time of the last attempt",
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "Status of the last response",
+                    "type": "integer"
+                },
+                "webhook_id": {
+                    "type": "string"
+                },
+                "webhook_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "alerts.Notification": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "description": "Resolved notifications only",
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "string"
+                },
+                "rule_name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "firing, resolved or test",
+                    "type": "string"
+                },
+                "summary": {
+                    "description": "One-line description, e.g. for chat messages",
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "number"
+                },
+                "value": {
+                    "description": "Latest value of the series",
+                    "type": "number"
+                }
+            }
+        },
+        "alerts.Rule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "for": {
+                    "description": "How long the condition must hold before the alert fires, e.g. 5m (default 0)",
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "labels": {
+                    "description": "Only series with these label values, e.g. {\"mount\": \"/\"}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "metric": {
+                    "description": "Collected metric, e.g. disk.used_percent",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "\u003e, \u003e=, \u003c, \u003c=, == or !=",
+                    "type": "string"
+                },
+                "per_cpu": {
+                    "description": "Multiply the threshold by the number of CPUs of the host",
+                    "type": "boolean"
+                },
+                "severity": {
+                    "description": "info, warning or critical (default warning)",
+                    "type": "string"
+                },
+                "threshold": {
+                    "description": "Value the metric is compared with",
+                    "type": "number"
+                },
+                "user": {
+                    "description": "SSH user whose collected metrics the rule is evaluated against",
+                    "type": "string"
+                }
+            }
+        },
+        "alerts.RuleSpec": {
+            "type": "object",
+            "properties": {
+                "for": {
+                    "description": "How long the condition must hold before the alert fires, e.g. 5m (default 0)",
+                    "type": "string"
+                },
+                "labels": {
+                    "description": "Only series with these label values, e.g. {\"mount\": \"/\"}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "metric": {
+                    "description": "Collected metric, e.g. disk.used_percent",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "\u003e, \u003e=, \u003c, \u003c=, == or !=",
+                    "type": "string"
+                },
+                "per_cpu": {
+                    "description": "Multiply the threshold by the number of CPUs of the host",
+                    "type": "boolean"
+                },
+                "severity": {
+                    "description": "info, warning or critical (default warning)",
+                    "type": "string"
+                },
+                "threshold": {
+                    "description": "Value the metric is compared with",
+                    "type": "number"
+                }
+            }
+        },
+        "alerts.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "headers": {
+                    "description": "Extra request headers, e.g. Authorization",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "host": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "template": {
+                    "description": "Template is a Go text/template rendering the JSON request body from the notification, e.g.\n{\"text\": {{json .Summary}}}. The json function encodes a value as JSON. Empty to post the\nnotification as JSON.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "http or https URL the notifications are posted to",
+                    "type": "string"
+                }
+            }
+        },
+        "alerts.WebhookSpec": {
+            "type": "object",
+            "properties": {
+                "headers": {
+                    "description": "Extra request headers, e.g. Authorization",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "template": {
+                    "description": "Template is a Go text/template rendering the JSON request body from the notification, e.g.\n{\"text\": {{json .Summary}}}. The json function encodes a value as JSON. Empty to post the\nnotification as JSON.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "http or https URL the notifications are posted to",
+                    "type": "string"
+                }
+            }
+        },
+        "auth.LoginRequest": {
+            "type": "object",
+            "required": [
+                "ip",
+                "password",
+                "port",
+                "username"
+            ],
+            "properties": {
+                "ip": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "cron.Crontab": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cron.CrontabEntry"
+                    }
+                },
+                "exists": {
+                    "type": "boolean"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "SHA-256 of the crontab content",
+                    "type": "string"
+                }
+            }
+        },
+        "cron.CrontabEntry": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Line number, valid for the version it was read with",
+                    "type": "integer"
+                },
+                "next_run": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "string"
+                }
+            }
+        },
+        "cron.CrontabEntryRequest": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "Five cron fields or @reboot, @hourly, @daily, @weekly, @monthly, @yearly",
+                    "type": "string"
+                }
+            }
+        },
+        "cron.Job": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "last_result": {
+                    "description": "Result of the last run of a timer",
+                    "type": "string"
+                },
+                "last_run": {
+                    "description": "From the cron entries of the journal, or the last trigger of a timer",
+                    "type": "string"
+                },
+                "line": {
+                    "description": "Line number in the source file",
+                    "type": "integer"
+                },
+                "next_run": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "Cron expression, @keyword or timer specification",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "File the job was read from, or the timer unit",
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "Unit activated by a timer",
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "cron.JobInventory": {
+            "type": "object",
+            "properties": {
+                "collected_at": {
+                    "type": "string"
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cron.Job"
+                    }
+                },
+                "timezone": {
+                    "description": "Time zone cron schedules are evaluated in",
+                    "type": "string"
+                },
+                "user_crontabs_readable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "docker.Container": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "container_id": {
+                    "type": "string"
+                },
+                "created_on": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "names": {
+                    "type": "string"
+                },
+                "ports": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "docker.ContainerDetail": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created": {
+                    "type": "string"
+                },
+                "host_config": {
+                    "$ref": "#/definitions/docker.HostConfig"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "mounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docker.Mount"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "network_mode": {
+                    "type": "string"
+                },
+                "network_settings": {
+                    "$ref": "#/definitions/docker.NetworkSettings"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "ports": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "restart_policy": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/docker.ContainerState"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "docker.ContainerRunRequest": {
+            "type": "object",
+            "required": [
+                "image"
+            ],
+            "properties": {
+                "command": {
+                    "description": "Command to run (overrides default)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "detached": {
+                    "description": "Run in detached mode",
+                    "type": "boolean",
+                    "default": true
+                },
+                "environment": {
+                    "description": "Environment variables",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "image": {
+                    "description": "Docker image to run",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Optional container name",
+                    "type": "string"
+                },
+                "network": {
+                    "description": "Network to connect to",
+                    "type": "string"
+                },
+                "ports": {
+                    "description": "Port mappings",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docker.PortMapping"
+                    }
+                },
+                "restart": {
+                    "description": "Restart policy (no, always, on-failure, unless-stopped)",
+                    "type": "string"
+                },
+                "volumes": {
+                    "description": "Volume mappings",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docker.VolumeMapping"
+                    }
+                }
+            }
+        },
+        "docker.ContainerRunResponse": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "description": "ID of the created container",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the container",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Container status (e.g., \"created\", \"running\")",
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Any warnings generated during container creation",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "docker.ContainerState": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "exit_code": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "restarting": {
+                    "type": "boolean"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "docker.ContainerStats": {
+            "type": "object",
+            "properties": {
+                "block_read_bytes": {
+                    "description": "Cumulative since the container started",
+                    "type": "integer"
+                },
+                "block_written_bytes": {
+                    "type": "integer"
+                },
+                "container_id": {
+                    "type": "string"
+                },
+                "cpu_percentage": {
+                    "description": "Share of one CPU, so it can exceed 100 on multi-core hosts",
+                    "type": "number"
+                },
+                "memory_limit_bytes": {
+                    "type": "integer"
+                },
+                "memory_percentage": {
+                    "type": "number"
+                },
+                "memory_usage_bytes": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "network_rx_bytes": {
+                    "description": "Cumulative since the container started",
+                    "type": "integer"
+                },
+                "network_tx_bytes": {
+                    "type": "integer"
+                },
+                "pids": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docker.ContainerStatus": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string"
+                },
+                "exit_code": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "image": {
+                    "description": "Image reference the container was created from",
+                    "type": "string"
+                },
+                "image_id": {
+                    "description": "ID of the image, e.g. \"sha256:...\"",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "oom_killed": {
+                    "type": "boolean"
+                },
+                "restart_count": {
+                    "type": "integer"
+                },
+                "restart_policy": {
+                    "description": "no, always, unless-stopped or on-failure",
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "created, running, paused, restarting, removing, exited or dead",
+                    "type": "string"
+                }
+            }
+        },
+        "docker.HostConfig": {
+            "type": "object",
+            "properties": {
+                "auto_remove": {
+                    "type": "boolean"
+                },
+                "cap_add": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cap_drop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "privileged": {
+                    "type": "boolean"
+                },
+                "publish_all_ports": {
+                    "type": "boolean"
+                },
+                "restart_policy": {
+                    "type": "string"
+                }
+            }
+        },
+        "docker.Image": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "digest": {
+                    "type": "string"
+                },
+                "image_id": {
+                    "type": "string"
+                },
+                "repository": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "vulnerability": {
+                    "type": "string"
+                }
+            }
+        },
+        "docker.ImageDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "List of deleted image layers",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "errors": {
+                    "description": "List of errors encountered during deletion",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "untagged": {
+                    "description": "List of untagged images",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "docker.ImageDetail": {
+            "type": "object",
+            "properties": {
+                "architecture": {
+                    "type": "string"
+                },
+                "author": {
+                    "type": "string"
+                },
+                "cmd": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "container": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "docker_version": {
+                    "type": "string"
+                },
+                "entrypoint": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "env": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exposed_ports": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object"
+                    }
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docker.ImageHistory"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "layers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "os": {
+                    "type": "string"
+                },
+                "repo_digests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "repo_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "shared_size": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "virtual_size": {
+                    "type": "integer"
+                },
+                "volumes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object"
+                    }
+                },
+                "working_dir": {
+                    "type": "string"
+                }
+            }
+        },
+        "docker.ImageHistory": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "empty_layer": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "docker.Mount": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "rw": {
+                    "type": "boolean"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "docker.NetworkSettings": {
+            "type": "object",
+            "properties": {
+                "endpoint_id": {
+                    "type": "string"
+                },
+                "gateway": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "mac_address": {
+                    "type": "string"
+                },
+                "network_id": {
+                    "type": "string"
+                },
+                "network_name": {
+                    "type": "string"
+                },
+                "port_mappings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docker.PortMapping"
+                    }
+                },
+                "subnet_mask": {
+                    "type": "string"
+                },
+                "subnet_prefix": {
+                    "type": "string"
+                }
+            }
+        },
+        "docker.PortMapping": {
+            "type": "object",
+            "properties": {
+                "container_port": {
+                    "type": "string"
+                },
+                "host_port": {
+                    "type": "string"
+                },
+                "protocol": {
+                    "type": "string"
+                }
+            }
+        },
+        "docker.VolumeMapping": {
+            "type": "object",
+            "properties": {
+                "container_path": {
+                    "description": "Path in the container",
+                    "type": "string"
+                },
+                "host_path": {
+                    "description": "Path on the host",
+                    "type": "string"
+                },
+                "read_only": {
+                    "description": "Mount as read-only",
+                    "type": "boolean",
+                    "default": false
+                }
+            }
+        },
+        "drift.CheckResult": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "string"
+                },
+                "expected": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "package, service, container or file",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "What drifted, or why the state could not be read",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Package, unit or container name, or file path",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "ok, drift or error",
+                    "type": "string"
+                }
+            }
+        },
+        "drift.ContainerSpec": {
+            "type": "object",
+            "properties": {
+                "digest": {
+                    "description": "Repository digest of its image, e.g. sha256:4c1e...",
+                    "type": "string"
+                },
+                "image": {
+                    "description": "Image reference the container was created from, e.g. nginx:1.25",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "running": {
+                    "description": "Default true",
+                    "type": "boolean"
+                }
+            }
+        },
+        "drift.FileSpec": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "mode": {
+                    "description": "Octal permissions, e.g. \"0644\"",
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "present (default) or absent",
+                    "type": "string"
+                }
+            }
+        },
+        "drift.PackageSpec": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "present (default) or absent",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Comma-separated constraints, e.g. \"\u003e= 1.18, \u003c 1.25\" or \"1.24.0-2ubuntu7\"",
+                    "type": "string"
+                }
+            }
+        },
+        "drift.Report": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "compliant": {
+                    "description": "Every item matches the spec",
+                    "type": "boolean"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Why a scheduled check could not run, e.g. no active session",
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/drift.CheckResult"
+                    }
+                },
+                "spec_id": {
+                    "description": "Stored spec the check ran for",
+                    "type": "string"
+                },
+                "spec_name": {
+                    "type": "string"
+                },
+                "summary": {
+                    "$ref": "#/definitions/drift.ReportSummary"
+                }
+            }
+        },
+        "drift.ReportSummary": {
+            "type": "object",
+            "properties": {
+                "drift": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "ok": {
+                    "type": "integer"
+                }
+            }
+        },
+        "drift.ServiceSpec": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Default true",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Unit name; .service is appended when there is no suffix",
+                    "type": "string"
+                },
+                "running": {
+                    "description": "Not checked by default",
+                    "type": "boolean"
+                }
+            }
+        },
+        "drift.Spec": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/drift.ContainerSpec"
+                    }
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/drift.FileSpec"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "packages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/drift.PackageSpec"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/drift.ServiceSpec"
+                    }
+                }
+            }
+        },
+        "drift.StoredSpec": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interval": {
+                    "description": "Empty when checked on demand only",
+                    "type": "string"
+                },
+                "last_report": {
+                    "$ref": "#/definitions/drift.Report"
+                },
+                "next_check_at": {
+                    "type": "string"
+                },
+                "spec": {
+                    "$ref": "#/definitions/drift.Spec"
+                },
+                "user": {
+                    "description": "SSH user that stored the spec, whose sessions run the scheduled checks",
+                    "type": "string"
+                }
+            }
+        },
+        "firewall.BackendStatus": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "The backend has rules or, for ufw, is enabled",
+                    "type": "boolean"
+                },
+                "available": {
+                    "description": "The tool is installed",
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "variant": {
+                    "type": "string"
+                }
+            }
+        },
+        "firewall.Chain": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "type": "string"
+                },
+                "family": {
+                    "description": "ip, ip6, inet, arp, bridge or netdev",
+                    "type": "string"
+                },
+                "hook": {
+                    "description": "Base chains only",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "policy": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "table": {
+                    "type": "string"
+                }
+            }
+        },
+        "firewall.Rule": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "accept, drop, reject, jump \u003cchain\u003e, goto \u003cchain\u003e, return, limit, ...",
+                    "type": "string"
+                },
+                "backend": {
+                    "type": "string"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "destination_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "extra": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "family": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Stable identifier used to delete the rule",
+                    "type": "string"
+                },
+                "in_interface": {
+                    "type": "string"
+                },
+                "out_interface": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "1-based position in the chain",
+                    "type": "integer"
+                },
+                "protocol": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Address or prefix, \"!\" prefixed when negated",
+                    "type": "string"
+                },
+                "source_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "states": {
+                    "description": "Connection tracking states",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "table": {
+                    "type": "string"
+                }
+            }
+        },
+        "firewall.RuleChange": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "type": "string"
+                },
+                "command": {
+                    "description": "Command that applied the change",
+                    "type": "string"
+                },
+                "confirmed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "Rollback time of a pending change",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "Rule added or deleted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/firewall.Rule"
+                        }
+                    ]
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "firewall.RuleSpec": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "accept, drop or reject",
+                    "type": "string"
+                },
+                "backend": {
+                    "description": "nftables, iptables or ufw",
+                    "type": "string"
+                },
+                "chain": {
+                    "description": "ufw: input or output",
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "destination_ports": {
+                    "description": "Ports or ranges (\"8000-8080\"), requires tcp or udp",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "family": {
+                    "description": "nftables: table family (default inet); iptables: ip or ip6 (default ip)",
+                    "type": "string"
+                },
+                "in_interface": {
+                    "type": "string"
+                },
+                "insert": {
+                    "description": "Insert at the top of the chain instead of appending",
+                    "type": "boolean"
+                },
+                "protocol": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "table": {
+                    "description": "nftables: required; iptables: default filter; ufw: unused",
+                    "type": "string"
+                }
+            }
+        },
+        "firewall.Ruleset": {
+            "type": "object",
+            "properties": {
+                "backends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/firewall.BackendStatus"
+                    }
+                },
+                "chains": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/firewall.Chain"
+                    }
+                },
+                "collected_at": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/firewall.Rule"
+                    }
+                }
+            }
+        },
+        "kernel.CommandLine": {
+            "type": "object",
+            "properties": {
+                "init_args": {
+                    "description": "Arguments after \"--\", passed to init",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/kernel.CommandLineParameter"
+                    }
+                },
+                "raw": {
+                    "type": "string"
+                },
+                "release": {
+                    "description": "uname -r",
+                    "type": "string"
+                }
+            }
+        },
+        "kernel.CommandLineParameter": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "kernel.KernelModule": {
+            "type": "object",
+            "properties": {
+                "depends_on": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Memory size in bytes",
+                    "type": "integer"
+                },
+                "state": {
+                    "description": "Live, Loading or Unloading",
+                    "type": "string"
+                },
+                "use_count": {
+                    "description": "References held by other modules and processes",
+                    "type": "integer"
+                },
+                "used_by": {
+                    "description": "Modules depending on this module",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "kernel.SysctlBaseline": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "kernel.SysctlDiff": {
+            "type": "object",
+            "properties": {
+                "collected_at": {
+                    "type": "string"
+                },
+                "compared": {
+                    "description": "Number of baseline parameters",
+                    "type": "integer"
+                },
+                "differences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/kernel.SysctlDifference"
+                    }
+                },
+                "matching": {
+                    "type": "integer"
+                }
+            }
+        },
+        "kernel.SysctlDifference": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "changed or missing",
+                    "type": "string"
+                }
+            }
+        },
+        "kernel.SysctlParameter": {
+            "type": "object",
+            "properties": {
+                "configured_in": {
+                    "description": "Configuration file with the effective setting",
+                    "type": "string"
+                },
+                "configured_value": {
+                    "type": "string"
+                },
+                "drift": {
+                    "description": "The runtime value differs from the configured one",
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Runtime value, whitespace-separated fields joined by single spaces",
+                    "type": "string"
+                }
+            }
+        },
+        "kernel.SysctlReport": {
+            "type": "object",
+            "properties": {
+                "collected_at": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/kernel.SysctlParameter"
+                    }
+                }
+            }
+        },
+        "kernel.SysctlUpdate": {
+            "type": "object",
+            "properties": {
+                "persist": {
+                    "description": "Also write the value to /etc/sysctl.d so that it survives reboots",
+                    "type": "boolean"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "kernel.SysctlUpdateResult": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "overridden_by": {
+                    "description": "OverriddenBy is a configuration file applied after PersistFile, e.g. /etc/sysctl.conf, that sets\nthe parameter to another value, which is the one applied at boot",
+                    "type": "string"
+                },
+                "persist_file": {
+                    "type": "string"
+                },
+                "persisted": {
+                    "type": "boolean"
+                },
+                "previous_value": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value read back from the kernel",
+                    "type": "string"
+                }
+            }
+        },
+        "logs.JournalEntry": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "description": "Journal cursor, usable to resume reading after this entry",
+                    "type": "string"
+                },
+                "hostname": {
+                    "type": "string"
+                },
+                "identifier": {
+                    "description": "Syslog identifier, usually the program name",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "description": "0 (emerg) to 7 (debug)",
+                    "type": "integer"
+                },
+                "priority_name": {
+                    "description": "e.g. \"err\", \"warning\", \"info\"",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "Systemd unit that logged the entry",
+                    "type": "string"
+                }
+            }
+        },
+        "logs.LogFile": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "path": {
+                    "description": "Resolved path of the log file",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "File size in bytes",
+                    "type": "integer"
+                }
+            }
+        },
+        "metrics.CollectionStatus": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "description": "Sources that failed, e.g. Docker on hosts without it",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "last_collected_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "metrics.History": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "resolution_ms": {
+                    "description": "Resolution of the stored samples the points were computed from",
+                    "type": "integer"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/metrics.Series"
+                    }
+                },
+                "step_ms": {
+                    "description": "Interval between points in milliseconds",
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "SSH user the samples were collected as",
+                    "type": "string"
+                }
+            }
+        },
+        "metrics.Point": {
+            "type": "object",
+            "properties": {
+                "timestamp": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "metrics.Series": {
+            "type": "object",
+            "properties": {
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/metrics.Point"
+                    }
+                }
+            }
+        },
+        "metrics.SeriesInfo": {
+            "type": "object",
+            "properties": {
+                "first_seen": {
+                    "description": "Start of the oldest retained step, in the coarsest resolution",
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "last_seen": {
+                    "type": "string"
+                },
+                "last_value": {
+                    "type": "number"
+                },
+                "metric": {
+                    "type": "string"
+                }
+            }
+        },
+        "metrics.SeriesList": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/metrics.CollectionStatus"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "interval_ms": {
+                    "description": "Collection interval in milliseconds",
+                    "type": "integer"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/metrics.SeriesInfo"
+                    }
+                },
+                "user": {
+                    "description": "SSH user the series were collected as",
+                    "type": "string"
+                }
+            }
+        },
+        "response.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.BlockDevice": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.BlockDevice"
+                    }
+                },
+                "fs_type": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "mountpoints": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "read_only": {
+                    "type": "boolean"
+                },
+                "removable": {
+                    "type": "boolean"
+                },
+                "rotational": {
+                    "description": "True for spinning disks",
+                    "type": "boolean"
+                },
+                "serial": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "transport": {
+                    "description": "e.g. \"sata\", \"nvme\", \"usb\"",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "e.g. \"disk\", \"part\", \"lvm\", \"raid1\", \"loop\", \"rom\"",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "Filesystem UUID",
+                    "type": "string"
+                }
+            }
+        },
+        "server.CPUCoreUsage": {
+            "type": "object",
+            "properties": {
+                "cpu": {
+                    "description": "\"all\" for the aggregate, \"cpu0\", \"cpu1\", ... for cores",
+                    "type": "string"
+                },
+                "idle": {
+                    "description": "Idle time, in percent",
+                    "type": "number"
+                },
+                "iowait": {
+                    "description": "Time spent waiting for I/O, in percent",
+                    "type": "number"
+                },
+                "steal": {
+                    "description": "Time stolen by the hypervisor, in percent",
+                    "type": "number"
+                },
+                "system": {
+                    "description": "Time spent in kernel mode (including irq and softirq), in percent",
+                    "type": "number"
+                },
+                "usage": {
+                    "description": "Busy time (100 - idle - iowait), in percent",
+                    "type": "number"
+                },
+                "user": {
+                    "description": "Time spent in user mode (including nice), in percent",
+                    "type": "number"
+                }
+            }
+        },
+        "server.CPUInfo": {
+            "type": "object",
+            "properties": {
+                "address_sizes": {
+                    "type": "string"
+                },
+                "apicid": {
+                    "type": "string"
+                },
+                "bogomips": {
+                    "type": "string"
+                },
+                "cache_alignment": {
+                    "type": "string"
+                },
+                "cache_size": {
+                    "type": "string"
+                },
+                "clflush_size": {
+                    "type": "string"
+                },
+                "core_id": {
+                    "type": "string"
+                },
+                "cpu_cores": {
+                    "type": "string"
+                },
+                "cpu_family": {
+                    "type": "string"
+                },
+                "cpu_mhz": {
+                    "type": "string"
+                },
+                "cpuid_level": {
+                    "type": "string"
+                },
+                "flags": {
+                    "type": "string"
+                },
+                "fpu": {
+                    "type": "string"
+                },
+                "fpu_exception": {
+                    "type": "string"
+                },
+                "initial_apicid": {
+                    "type": "string"
+                },
+                "microcode": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "model_name": {
+                    "type": "string"
+                },
+                "physical_id": {
+                    "type": "string"
+                },
+                "power_management": {
+                    "type": "string"
+                },
+                "processor": {
+                    "type": "string"
+                },
+                "siblings": {
+                    "type": "string"
+                },
+                "stepping": {
+                    "type": "string"
+                },
+                "vendor_id": {
+                    "type": "string"
+                },
+                "wp": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.CPUSummary": {
+            "type": "object",
+            "properties": {
+                "cores": {
+                    "description": "Physical cores across all sockets",
+                    "type": "integer"
+                },
+                "model_name": {
+                    "type": "string"
+                },
+                "sockets": {
+                    "type": "integer"
+                },
+                "threads": {
+                    "description": "Logical processors",
+                    "type": "integer"
+                },
+                "threads_per_core": {
+                    "type": "integer"
+                },
+                "vendor": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.CPUUsage": {
+            "type": "object",
+            "properties": {
+                "cores": {
+                    "description": "Per-core utilization",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.CPUCoreUsage"
+                    }
+                },
+                "interval_ms": {
+                    "description": "Sampling interval in milliseconds",
+                    "type": "integer"
+                },
+                "load_average": {
+                    "description": "Load averages from /proc/loadavg",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/server.LoadAverage"
+                        }
+                    ]
+                },
+                "timestamp": {
+                    "description": "Time at which the second sample was taken",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Aggregate utilization over all cores",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/server.CPUCoreUsage"
+                        }
+                    ]
+                }
+            }
+        },
+        "server.Capabilities": {
+            "type": "object",
+            "properties": {
+                "df_output": {
+                    "description": "df --output is supported",
+                    "type": "boolean"
+                },
+                "find_exec_plus": {
+                    "description": "find -exec ... {} + is supported",
+                    "type": "boolean"
+                },
+                "os_id": {
+                    "type": "string"
+                },
+                "os_version": {
+                    "type": "string"
+                },
+                "package_manager": {
+                    "description": "Empty when none is supported",
+                    "type": "string"
+                },
+                "probed_at": {
+                    "type": "string"
+                },
+                "procfs": {
+                    "description": "/proc is mounted",
+                    "type": "boolean"
+                },
+                "ps_format": {
+                    "description": "ps -eo with procps columns such as etimes is supported",
+                    "type": "boolean"
+                },
+                "root": {
+                    "description": "The SSH user is root",
+                    "type": "boolean"
+                },
+                "stat_format": {
+                    "description": "stat -c FORMAT is supported",
+                    "type": "boolean"
+                },
+                "sudo": {
+                    "description": "Passwordless sudo is available",
+                    "type": "boolean"
+                },
+                "tools": {
+                    "description": "Optional tools found in PATH",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userland": {
+                    "description": "gnu, busybox, bsd or unknown",
+                    "type": "string"
+                }
+            }
+        },
+        "server.DNSConfig": {
+            "type": "object",
+            "properties": {
+                "nameservers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "search": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "server.DirectoryAnalysis": {
+            "type": "object",
+            "properties": {
+                "entries_scanned": {
+                    "description": "Output lines processed so far, as a progress indicator",
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "query": {
+                    "$ref": "#/definitions/server.DirectoryUsageQuery"
+                },
+                "result": {
+                    "$ref": "#/definitions/server.DirectoryUsageResult"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"running\", \"completed\", \"failed\" or \"cancelled\"",
+                    "type": "string"
+                }
+            }
+        },
+        "server.DirectoryUsageNode": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.DirectoryUsageNode"
+                    }
+                },
+                "file_count": {
+                    "description": "Regular files in the directory and all descendants",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "omitted_children": {
+                    "description": "Subdirectories dropped by the per-directory limit",
+                    "type": "integer"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "description": "Disk usage of the directory including all descendants",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.DirectoryUsageQuery": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "description": "Children kept per directory in tree mode, number of files in largest-files mode",
+                    "type": "integer"
+                },
+                "max_depth": {
+                    "description": "Depth of the tree below the path (tree mode)",
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "\"tree\" (default) or \"largest-files\"",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Absolute directory to analyze",
+                    "type": "string"
+                }
+            }
+        },
+        "server.DirectoryUsageResult": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "largest_files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.LargeFile"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "tree": {
+                    "$ref": "#/definitions/server.DirectoryUsageNode"
+                }
+            }
+        },
+        "server.DiskUsage": {
+            "type": "object",
+            "properties": {
+                "available_bytes": {
+                    "description": "Space available to unprivileged users",
+                    "type": "integer"
+                },
+                "filesystem": {
+                    "description": "Source device, e.g. \"/dev/sda1\"",
+                    "type": "string"
+                },
+                "inodes": {
+                    "description": "Zero for filesystems without a fixed inode table",
+                    "type": "integer"
+                },
+                "inodes_free": {
+                    "type": "integer"
+                },
+                "inodes_use_percentage": {
+                    "type": "number"
+                },
+                "inodes_used": {
+                    "type": "integer"
+                },
+                "mount_options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mounted_on": {
+                    "type": "string"
+                },
+                "pseudo": {
+                    "description": "Whether the filesystem is virtual or memory-backed, such as tmpfs or overlay",
+                    "type": "boolean"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Filesystem type, e.g. \"ext4\"",
+                    "type": "string"
+                },
+                "use_percentage": {
+                    "description": "Used space relative to used plus available space, as df reports it",
+                    "type": "number"
+                },
+                "used_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.FileSystemEntry": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "is_hidden": {
+                    "description": "Whether the file/directory is hidden (starts with .)",
+                    "type": "boolean"
+                },
+                "last_modified": {
+                    "type": "string"
+                },
+                "mime_type": {
+                    "description": "MIME type of the file (only for files)",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "string"
+                },
+                "preview": {
+                    "description": "Preview of text files (first few lines)",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size in bytes, only applicable for files",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "\"file\", \"directory\", \"symlink\", etc.",
+                    "type": "string"
+                }
+            }
+        },
+        "server.FileSystemListing": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.FileSystemEntry"
+                    }
+                },
+                "path": {
+                    "type": "string"
+                },
+                "recursive": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.FileUploadResult": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "replaced": {
+                    "description": "Whether an existing file was replaced",
+                    "type": "boolean"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.HostFacts": {
+            "type": "object",
+            "properties": {
+                "architecture": {
+                    "type": "string"
+                },
+                "boot_time": {
+                    "type": "string"
+                },
+                "collected_at": {
+                    "type": "string"
+                },
+                "cpu": {
+                    "$ref": "#/definitions/server.CPUSummary"
+                },
+                "fqdn": {
+                    "type": "string"
+                },
+                "hostname": {
+                    "type": "string"
+                },
+                "kernel": {
+                    "$ref": "#/definitions/server.KernelInfo"
+                },
+                "machine_id": {
+                    "type": "string"
+                },
+                "memory_total_bytes": {
+                    "type": "integer"
+                },
+                "os": {
+                    "$ref": "#/definitions/server.OSRelease"
+                },
+                "swap_total_bytes": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "uptime_seconds": {
+                    "type": "integer"
+                },
+                "virtualization": {
+                    "$ref": "#/definitions/server.Virtualization"
+                }
+            }
+        },
+        "server.InterfaceAddress": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "family": {
+                    "description": "\"inet\" or \"inet6\"",
+                    "type": "string"
+                },
+                "prefix_length": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.InterfaceCounters": {
+            "type": "object",
+            "properties": {
+                "rx_bytes": {
+                    "type": "integer"
+                },
+                "rx_dropped": {
+                    "type": "integer"
+                },
+                "rx_errors": {
+                    "type": "integer"
+                },
+                "rx_packets": {
+                    "type": "integer"
+                },
+                "tx_bytes": {
+                    "type": "integer"
+                },
+                "tx_dropped": {
+                    "type": "integer"
+                },
+                "tx_errors": {
+                    "type": "integer"
+                },
+                "tx_packets": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.InterfaceRates": {
+            "type": "object",
+            "properties": {
+                "rx_bytes_per_sec": {
+                    "type": "number"
+                },
+                "rx_errors_per_sec": {
+                    "type": "number"
+                },
+                "rx_packets_per_sec": {
+                    "type": "number"
+                },
+                "tx_bytes_per_sec": {
+                    "type": "number"
+                },
+                "tx_errors_per_sec": {
+                    "type": "number"
+                },
+                "tx_packets_per_sec": {
+                    "type": "number"
+                }
+            }
+        },
+        "server.KernelInfo": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "e.g. \"Linux\"",
+                    "type": "string"
+                },
+                "release": {
+                    "description": "e.g. \"6.1.0-18-amd64\"",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Build string",
+                    "type": "string"
+                }
+            }
+        },
+        "server.LVMInfo": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "Whether the LVM tools are installed",
+                    "type": "boolean"
+                },
+                "logical_volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.LogicalVolume"
                     }
                 },
-                "mounts": {
+                "physical_volumes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/docker.Mount"
+                        "$ref": "#/definitions/server.PhysicalVolume"
                     }
                 },
-                "name": {
+                "volume_groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.VolumeGroup"
+                    }
+                }
+            }
+        },
+        "server.LargeFile": {
+            "type": "object",
+            "properties": {
+                "path": {
                     "type": "string"
                 },
-                "network_mode": {
+                "size_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.Library": {
+            "type": "object",
+            "properties": {
+                "architecture": {
                     "type": "string"
                 },
-                "network_settings": {
-                    "$ref": "#/definitions/docker.NetworkSettings"
-                },
-                "platform": {
+                "manager": {
+                    "description": "Package manager that installed the package, e.g. \"apt\" or \"dnf\"",
                     "type": "string"
                 },
-                "ports": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "restart_policy": {
+                "name": {
                     "type": "string"
                 },
-                "state": {
-                    "$ref": "#/definitions/docker.ContainerState"
-                },
                 "status": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "string"
                 }
             }
         },
-        "docker.ContainerRunRequest": {
+        "server.ListeningSocket": {
             "type": "object",
-            "required": [
-                "image"
-            ],
             "properties": {
-                "command": {
-                    "description": "Command to run (overrides default)",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "detached": {
-                    "description": "Run in detached mode",
-                    "type": "boolean",
-                    "default": true
-                },
-                "environment": {
-                    "description": "Environment variables",
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "image": {
-                    "description": "Docker image to run",
+                "container_id": {
+                    "description": "Docker container the owning process belongs to",
                     "type": "string"
                 },
-                "name": {
-                    "description": "Optional container name",
+                "container_name": {
+                    "description": "Name of that Docker container",
                     "type": "string"
                 },
-                "network": {
-                    "description": "Network to connect to",
+                "local_address": {
                     "type": "string"
                 },
-                "ports": {
-                    "description": "Port mappings",
+                "local_port": {
+                    "type": "integer"
+                },
+                "pid": {
+                    "description": "Owning process, if visible to the SSH user",
+                    "type": "integer"
+                },
+                "pids": {
+                    "description": "All processes sharing the socket (e.g. pre-forked workers)",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/docker.PortMapping"
+                        "type": "integer"
                     }
                 },
-                "restart": {
-                    "description": "Restart policy (no, always, on-failure, unless-stopped)",
+                "process_name": {
+                    "description": "Name of the owning process",
                     "type": "string"
                 },
-                "volumes": {
-                    "description": "Volume mappings",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docker.VolumeMapping"
-                    }
+                "protocol": {
+                    "description": "\"tcp\", \"tcp6\", \"udp\" or \"udp6\"",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "\"ss\" or \"procfs\", depending on how the socket was discovered",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "\"LISTEN\" for TCP, \"UNCONN\" for UDP",
+                    "type": "string"
                 }
             }
         },
-        "docker.ContainerRunResponse": {
+        "server.LoadAverage": {
             "type": "object",
             "properties": {
-                "container_id": {
-                    "description": "ID of the created container",
+                "load1": {
+                    "type": "number"
+                },
+                "load15": {
+                    "type": "number"
+                },
+                "load5": {
+                    "type": "number"
+                }
+            }
+        },
+        "server.LogicalVolume": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "attributes": {
                     "type": "string"
                 },
+                "data_percent": {
+                    "description": "Data usage of thin pools, thin volumes and snapshots",
+                    "type": "number"
+                },
                 "name": {
-                    "description": "Name of the container",
                     "type": "string"
                 },
-                "status": {
-                    "description": "Container status (e.g., \"created\", \"running\")",
+                "path": {
                     "type": "string"
                 },
-                "warnings": {
-                    "description": "Any warnings generated during container creation",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "pool": {
+                    "description": "Thin pool of a thin volume",
+                    "type": "string"
+                },
+                "segment_type": {
+                    "description": "e.g. \"linear\", \"striped\", \"thin-pool\"",
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "volume_group": {
+                    "type": "string"
                 }
             }
         },
-        "docker.ContainerState": {
+        "server.MemoryUsage": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "available_bytes": {
+                    "description": "Memory available to new processes without swapping",
+                    "type": "integer"
                 },
-                "exit_code": {
+                "buffers_bytes": {
                     "type": "integer"
                 },
-                "finished_at": {
-                    "type": "string"
+                "cached_bytes": {
+                    "description": "Page cache and reclaimable slab",
+                    "type": "integer"
                 },
-                "paused": {
-                    "type": "boolean"
+                "free_bytes": {
+                    "description": "Memory not used at all",
+                    "type": "integer"
                 },
-                "restarting": {
-                    "type": "boolean"
+                "swap_free_bytes": {
+                    "type": "integer"
                 },
-                "running": {
-                    "type": "boolean"
+                "swap_total_bytes": {
+                    "type": "integer"
                 },
-                "started_at": {
-                    "type": "string"
+                "swap_use_percentage": {
+                    "type": "number"
                 },
-                "status": {
-                    "type": "string"
+                "swap_used_bytes": {
+                    "type": "integer"
+                },
+                "total_bytes": {
+                    "type": "integer"
+                },
+                "use_percentage": {
+                    "type": "number"
+                },
+                "used_bytes": {
+                    "description": "Total minus available memory",
+                    "type": "integer"
                 }
             }
         },
-        "docker.HostConfig": {
+        "server.NetworkInfo": {
             "type": "object",
             "properties": {
-                "auto_remove": {
-                    "type": "boolean"
+                "dns": {
+                    "$ref": "#/definitions/server.DNSConfig"
                 },
-                "cap_add": {
+                "interfaces": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/server.NetworkInterface"
                     }
                 },
-                "cap_drop": {
+                "interval_ms": {
+                    "description": "Interval between the two counter samples in milliseconds",
+                    "type": "integer"
+                },
+                "routes": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/server.Route"
                     }
                 },
-                "dns": {
+                "timestamp": {
+                    "description": "Time at which the second counter sample was taken",
+                    "type": "string"
+                }
+            }
+        },
+        "server.NetworkInterface": {
+            "type": "object",
+            "properties": {
+                "addresses": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/server.InterfaceAddress"
                     }
                 },
-                "privileged": {
-                    "type": "boolean"
+                "counters": {
+                    "description": "Cumulative counters from /proc/net/dev",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/server.InterfaceCounters"
+                        }
+                    ]
                 },
-                "publish_all_ports": {
-                    "type": "boolean"
+                "mac": {
+                    "type": "string"
                 },
-                "restart_policy": {
+                "mtu": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rates": {
+                    "description": "Throughput derived from two counter samples",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/server.InterfaceRates"
+                        }
+                    ]
+                },
+                "speed_mbps": {
+                    "description": "Link speed, only reported for physical links",
+                    "type": "integer"
+                },
+                "state": {
+                    "description": "Operational state (\"up\", \"down\", \"unknown\", ...)",
                     "type": "string"
                 }
             }
         },
-        "docker.Image": {
+        "server.OSRelease": {
             "type": "object",
             "properties": {
-                "created": {
-                    "type": "string"
-                },
-                "digest": {
+                "id": {
                     "type": "string"
                 },
-                "image_id": {
-                    "type": "string"
+                "id_like": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "repository": {
+                "name": {
                     "type": "string"
                 },
-                "size": {
+                "pretty_name": {
                     "type": "string"
                 },
-                "tag": {
+                "version_codename": {
                     "type": "string"
                 },
-                "vulnerability": {
+                "version_id": {
                     "type": "string"
                 }
             }
         },
-        "docker.ImageDeleteResponse": {
+        "server.PackageOperationRequest": {
             "type": "object",
             "properties": {
-                "deleted": {
-                    "description": "List of deleted image layers",
+                "dry_run": {
+                    "description": "Only simulate the transaction",
+                    "type": "boolean"
+                },
+                "packages": {
+                    "description": "Package names; an empty list upgrades all packages",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                }
+            }
+        },
+        "server.PackageOperationResult": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "errors": {
-                    "description": "List of errors encountered during deletion",
+                "exit_code": {
+                    "type": "integer"
+                },
+                "manager": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "packages": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "untagged": {
-                    "description": "List of untagged images",
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.PendingUpdate": {
+            "type": "object",
+            "properties": {
+                "architecture": {
+                    "type": "string"
+                },
+                "available_version": {
+                    "type": "string"
+                },
+                "current_version": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "repository": {
+                    "type": "string"
+                },
+                "security": {
+                    "description": "Whether the update fixes a security issue",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.PendingUpdates": {
+            "type": "object",
+            "properties": {
+                "manager": {
+                    "type": "string"
+                },
+                "refreshed": {
+                    "description": "Whether the package metadata was refreshed before checking",
+                    "type": "boolean"
+                },
+                "security_count": {
+                    "type": "integer"
+                },
+                "security_info_available": {
+                    "description": "Whether the package manager reports security updates",
+                    "type": "boolean"
+                },
+                "updates": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/server.PendingUpdate"
                     }
                 }
             }
         },
-        "docker.ImageDetail": {
+        "server.PhysicalVolume": {
             "type": "object",
             "properties": {
-                "architecture": {
+                "format": {
                     "type": "string"
                 },
-                "author": {
+                "free_bytes": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "cmd": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "size_bytes": {
+                    "type": "integer"
                 },
-                "container": {
+                "volume_group": {
                     "type": "string"
-                },
-                "created": {
+                }
+            }
+        },
+        "server.ProcessActionResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "\"signal\", \"kill\" or \"renice\"",
                     "type": "string"
                 },
-                "docker_version": {
+                "message": {
                     "type": "string"
                 },
-                "entrypoint": {
+                "nice": {
+                    "description": "Nice value after the action, for renice actions",
+                    "type": "integer"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "running": {
+                    "description": "Whether the process still exists after the action",
+                    "type": "boolean"
+                },
+                "signal": {
+                    "description": "Signal that was sent, for signal and kill actions",
+                    "type": "string"
+                }
+            }
+        },
+        "server.ProcessDetail": {
+            "type": "object",
+            "properties": {
+                "cgroups": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "env": {
+                "command_line": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "exposed_ports": {
+                "environment": {
+                    "description": "Sensitive values are redacted",
                     "type": "object",
                     "additionalProperties": {
-                        "type": "object"
+                        "type": "string"
                     }
                 },
-                "history": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docker.ImageHistory"
-                    }
+                "executable": {
+                    "type": "string"
                 },
-                "id": {
+                "name": {
                     "type": "string"
                 },
-                "labels": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "nice": {
+                    "type": "integer"
                 },
-                "layers": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "open_files": {
+                    "description": "Number of open file descriptors, omitted if not readable",
+                    "type": "integer"
                 },
-                "os": {
-                    "type": "string"
+                "parent_pid": {
+                    "type": "integer"
                 },
-                "repo_digests": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "pid": {
+                    "type": "integer"
                 },
-                "repo_tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "state": {
+                    "description": "State from /proc/\u003cpid\u003e/status, e.g. \"S (sleeping)\"",
+                    "type": "string"
                 },
-                "shared_size": {
+                "threads": {
                     "type": "integer"
                 },
-                "size": {
+                "uid": {
                     "type": "integer"
                 },
-                "virtual_size": {
+                "user": {
+                    "type": "string"
+                },
+                "vm_rss_kb": {
                     "type": "integer"
                 },
-                "volumes": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "object"
-                    }
+                "vm_size_kb": {
+                    "type": "integer"
                 },
                 "working_dir": {
                     "type": "string"
                 }
             }
         },
-        "docker.ImageHistory": {
+        "server.ProcessInfo": {
             "type": "object",
             "properties": {
-                "comment": {
+                "children": {
+                    "description": "Child processes, only populated in tree mode",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.ProcessInfo"
+                    }
+                },
+                "command": {
                     "type": "string"
                 },
-                "created": {
+                "cpu_consumption": {
+                    "description": "CPU usage in percent",
+                    "type": "number"
+                },
+                "memory_consumption": {
+                    "description": "Share of physical memory in percent",
+                    "type": "number"
+                },
+                "parent_process_id": {
+                    "type": "integer"
+                },
+                "process_id": {
+                    "type": "integer"
+                },
+                "rss": {
+                    "description": "Resident set size in KiB",
+                    "type": "integer"
+                },
+                "started": {
                     "type": "string"
                 },
-                "created_by": {
+                "stat": {
                     "type": "string"
                 },
-                "empty_layer": {
-                    "type": "boolean"
+                "time": {
+                    "description": "Accumulated CPU time",
+                    "type": "string"
+                },
+                "tty": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                },
+                "vsz": {
+                    "description": "Virtual memory size in KiB",
+                    "type": "integer"
                 }
             }
         },
-        "docker.Mount": {
+        "server.ProcessList": {
             "type": "object",
             "properties": {
-                "destination": {
-                    "type": "string"
+                "page": {
+                    "type": "integer"
                 },
-                "mode": {
-                    "type": "string"
+                "page_size": {
+                    "type": "integer"
                 },
-                "rw": {
-                    "type": "boolean"
+                "processes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.ProcessInfo"
+                    }
                 },
-                "source": {
-                    "type": "string"
+                "total": {
+                    "description": "Number of matching processes (root processes in tree mode)",
+                    "type": "integer"
                 },
-                "type": {
+                "tree": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.ProcessReniceRequest": {
+            "type": "object",
+            "properties": {
+                "priority": {
+                    "description": "Nice value between -20 (highest priority) and 19 (lowest priority)",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.ProcessSignalRequest": {
+            "type": "object",
+            "required": [
+                "signal"
+            ],
+            "properties": {
+                "signal": {
+                    "description": "Signal name (e.g. \"TERM\", \"SIGHUP\") or number",
                     "type": "string"
                 }
             }
         },
-        "docker.NetworkSettings": {
+        "server.RAIDArray": {
             "type": "object",
             "properties": {
-                "endpoint_id": {
+                "active_devices": {
+                    "type": "integer"
+                },
+                "degraded": {
+                    "type": "boolean"
+                },
+                "level": {
                     "type": "string"
                 },
-                "gateway": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.RAIDMember"
+                    }
+                },
+                "name": {
                     "type": "string"
                 },
-                "ip_address": {
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "state": {
+                    "description": "\"active\" or \"inactive\", optionally followed by e.g. \"(read-only)\"",
                     "type": "string"
                 },
-                "mac_address": {
+                "status": {
+                    "description": "Member status map, e.g. \"UU\" or \"U_\"",
                     "type": "string"
                 },
-                "network_id": {
+                "sync_action": {
+                    "description": "e.g. \"recovery\", \"resync\", \"check\", \"reshape\"",
                     "type": "string"
                 },
-                "network_name": {
+                "sync_finish": {
+                    "description": "Estimated time to completion, e.g. \"12.5min\"",
                     "type": "string"
                 },
-                "port_mappings": {
+                "sync_progress": {
+                    "description": "Progress of the sync action in percent",
+                    "type": "number"
+                },
+                "total_devices": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.RAIDInfo": {
+            "type": "object",
+            "properties": {
+                "arrays": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/docker.PortMapping"
+                        "$ref": "#/definitions/server.RAIDArray"
                     }
                 },
-                "subnet_mask": {
-                    "type": "string"
+                "available": {
+                    "description": "Whether the md driver is loaded",
+                    "type": "boolean"
                 },
-                "subnet_prefix": {
-                    "type": "string"
+                "degraded": {
+                    "description": "True if any array is degraded",
+                    "type": "boolean"
+                },
+                "personalities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "docker.PortMapping": {
+        "server.RAIDMember": {
             "type": "object",
             "properties": {
-                "container_port": {
+                "device": {
                     "type": "string"
                 },
-                "host_port": {
-                    "type": "string"
+                "failed": {
+                    "type": "boolean"
                 },
-                "protocol": {
-                    "type": "string"
+                "role": {
+                    "type": "integer"
+                },
+                "spare": {
+                    "type": "boolean"
+                },
+                "write_mostly": {
+                    "type": "boolean"
                 }
             }
         },
-        "docker.VolumeMapping": {
+        "server.Route": {
             "type": "object",
             "properties": {
-                "container_path": {
-                    "description": "Path in the container",
+                "default": {
+                    "type": "boolean"
+                },
+                "destination": {
+                    "description": "Destination network in CIDR notation",
                     "type": "string"
                 },
-                "host_path": {
-                    "description": "Path on the host",
+                "family": {
+                    "description": "\"inet\" or \"inet6\"",
                     "type": "string"
                 },
-                "read_only": {
-                    "description": "Mount as read-only",
-                    "type": "boolean",
-                    "default": false
+                "gateway": {
+                    "type": "string"
+                },
+                "interface": {
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "integer"
                 }
             }
         },
-        "response.Response": {
+        "server.SecurityAudit": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {
+                "collected_at": {
                     "type": "string"
                 },
-                "success": {
+                "findings": {
+                    "description": "Failed checks first, by severity",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.SecurityFinding"
+                    }
+                },
+                "privileged": {
+                    "description": "Checks ran as root or through passwordless sudo",
                     "type": "boolean"
+                },
+                "score": {
+                    "description": "100 minus the penalties of failed checks, at least 0",
+                    "type": "integer"
+                },
+                "summary": {
+                    "$ref": "#/definitions/server.SecurityAuditSummary"
+                }
+            }
+        },
+        "server.SecurityAuditSummary": {
+            "type": "object",
+            "properties": {
+                "critical": {
+                    "type": "integer"
+                },
+                "high": {
+                    "type": "integer"
+                },
+                "low": {
+                    "type": "integer"
+                },
+                "medium": {
+                    "type": "integer"
+                },
+                "passed": {
+                    "type": "integer"
+                },
+                "unknown": {
+                    "type": "integer"
                 }
             }
         },
-        "server.CPUInfo": {
+        "server.SecurityFinding": {
             "type": "object",
             "properties": {
-                "address_sizes": {
+                "category": {
+                    "description": "ssh, filesystem, packages, authentication or docker",
                     "type": "string"
                 },
-                "apicid": {
+                "details": {
                     "type": "string"
                 },
-                "bogomips": {
-                    "type": "string"
+                "evidence": {
+                    "description": "Offending paths, settings or sources, truncated",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "cache_alignment": {
+                "id": {
+                    "description": "Stable check identifier, e.g. \"ssh-root-login\"",
                     "type": "string"
                 },
-                "cache_size": {
-                    "type": "string"
+                "penalty": {
+                    "description": "Points deducted from the score",
+                    "type": "integer"
                 },
-                "clflush_size": {
+                "remediation": {
                     "type": "string"
                 },
-                "core_id": {
+                "severity": {
+                    "description": "Severity of the issue when the check fails",
                     "type": "string"
                 },
-                "cpu_cores": {
+                "status": {
+                    "description": "pass, fail or unknown",
                     "type": "string"
                 },
-                "cpu_family": {
+                "title": {
                     "type": "string"
-                },
-                "cpu_mhz": {
+                }
+            }
+        },
+        "server.ServerDetails": {
+            "type": "object",
+            "properties": {
+                "hostname": {
                     "type": "string"
                 },
-                "cpuid_level": {
+                "kernel_version": {
                     "type": "string"
                 },
-                "flags": {
+                "os": {
                     "type": "string"
                 },
-                "fpu": {
+                "uptime": {
                     "type": "string"
+                }
+            }
+        },
+        "server.StorageInfo": {
+            "type": "object",
+            "properties": {
+                "block_devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.BlockDevice"
+                    }
                 },
-                "fpu_exception": {
-                    "type": "string"
+                "lvm": {
+                    "$ref": "#/definitions/server.LVMInfo"
                 },
-                "initial_apicid": {
+                "raid": {
+                    "$ref": "#/definitions/server.RAIDInfo"
+                }
+            }
+        },
+        "server.Virtualization": {
+            "type": "object",
+            "properties": {
+                "container": {
+                    "description": "Container runtime such as \"docker\" or \"lxc\", \"none\" outside containers",
                     "type": "string"
                 },
-                "microcode": {
+                "role": {
+                    "description": "\"guest\" when running virtualized or containerized, \"host\" otherwise",
                     "type": "string"
                 },
-                "model": {
+                "type": {
+                    "description": "Hypervisor such as \"kvm\" or \"vmware\", \"none\" on bare metal",
                     "type": "string"
-                },
-                "model_name": {
+                }
+            }
+        },
+        "server.VolumeGroup": {
+            "type": "object",
+            "properties": {
+                "attributes": {
                     "type": "string"
                 },
-                "physical_id": {
-                    "type": "string"
+                "free_bytes": {
+                    "type": "integer"
                 },
-                "power_management": {
+                "lv_count": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "processor": {
+                "pv_count": {
+                    "type": "integer"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "snapshots.AttributeChange": {
+            "type": "object",
+            "properties": {
+                "a": {
                     "type": "string"
                 },
-                "siblings": {
+                "attribute": {
                     "type": "string"
                 },
-                "stepping": {
+                "b": {
                     "type": "string"
+                }
+            }
+        },
+        "snapshots.Diff": {
+            "type": "object",
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/snapshots.SnapshotInfo"
                 },
-                "vendor_id": {
+                "b": {
+                    "$ref": "#/definitions/snapshots.SnapshotInfo"
+                },
+                "changes": {
+                    "description": "Total number of added, removed and changed items",
+                    "type": "integer"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/snapshots.SectionDiff"
+                    }
+                }
+            }
+        },
+        "snapshots.Item": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "key": {
                     "type": "string"
+                }
+            }
+        },
+        "snapshots.ItemChange": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/snapshots.AttributeChange"
+                    }
                 },
-                "wp": {
+                "key": {
                     "type": "string"
                 }
             }
         },
-        "server.DiskUsage": {
+        "snapshots.Section": {
             "type": "object",
             "properties": {
-                "available": {
+                "error": {
+                    "description": "Reason the section could not be captured, e.g. Docker missing",
                     "type": "string"
                 },
-                "filesystem": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/snapshots.Item"
+                    }
                 },
-                "mounted_on": {
+                "name": {
                     "type": "string"
+                }
+            }
+        },
+        "snapshots.SectionDiff": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Items only in b",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/snapshots.Item"
+                    }
                 },
-                "size": {
-                    "type": "string"
+                "changed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/snapshots.ItemChange"
+                    }
                 },
-                "use_percentage": {
+                "error": {
+                    "description": "Why the section was not compared, when it is missing from a snapshot",
                     "type": "string"
                 },
-                "used": {
+                "name": {
                     "type": "string"
+                },
+                "removed": {
+                    "description": "Items only in a",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/snapshots.Item"
+                    }
                 }
             }
         },
-        "server.FileSystemEntry": {
+        "snapshots.Snapshot": {
             "type": "object",
             "properties": {
-                "group": {
+                "created_at": {
                     "type": "string"
                 },
-                "is_hidden": {
-                    "description": "Whether the file/directory is hidden (starts with .)",
-                    "type": "boolean"
-                },
-                "last_modified": {
+                "host": {
                     "type": "string"
                 },
-                "mime_type": {
-                    "description": "MIME type of the file (only for files)",
+                "id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "items": {
+                    "description": "Number of items per captured section",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 },
-                "owner": {
+                "label": {
                     "type": "string"
                 },
-                "path": {
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/snapshots.Section"
+                    }
+                }
+            }
+        },
+        "snapshots.SnapshotInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "permissions": {
+                "host": {
                     "type": "string"
                 },
-                "preview": {
-                    "description": "Preview of text files (first few lines)",
+                "id": {
                     "type": "string"
                 },
-                "size": {
-                    "description": "Size in bytes, only applicable for files",
-                    "type": "integer"
+                "items": {
+                    "description": "Number of items per captured section",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 },
-                "type": {
-                    "description": "\"file\", \"directory\", \"symlink\", etc.",
+                "label": {
                     "type": "string"
                 }
             }
         },
-        "server.FileSystemListing": {
+        "snapshots.SnapshotRequest": {
             "type": "object",
             "properties": {
-                "entries": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/server.FileSystemEntry"
-                    }
-                },
-                "path": {
+                "label": {
+                    "description": "Free-form label, e.g. \"before upgrade\"",
                     "type": "string"
-                },
-                "recursive": {
-                    "type": "boolean"
                 }
             }
         },
-        "server.Library": {
+        "systemd.Unit": {
             "type": "object",
             "properties": {
-                "architecture": {
+                "active_state": {
+                    "description": "e.g. \"active\", \"inactive\", \"failed\"",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "load_state": {
+                    "description": "e.g. \"loaded\", \"not-found\", \"masked\"",
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "status": {
+                "sub_state": {
+                    "description": "e.g. \"running\", \"exited\", \"dead\"",
                     "type": "string"
                 },
-                "version": {
+                "unit_file_state": {
+                    "description": "e.g. \"enabled\", \"disabled\", \"static\"",
                     "type": "string"
                 }
             }
         },
-        "server.ProcessInfo": {
+        "systemd.UnitActionResult": {
             "type": "object",
             "properties": {
-                "command": {
+                "action": {
                     "type": "string"
                 },
-                "cpu_consumption": {
+                "active_state": {
                     "type": "string"
                 },
-                "process_id": {
+                "load_state": {
                     "type": "string"
                 },
-                "rss": {
+                "output": {
+                    "description": "Output of the systemctl command, e.g. symlinks created by enable",
                     "type": "string"
                 },
-                "started": {
+                "sub_state": {
                     "type": "string"
                 },
-                "stat": {
+                "unit": {
                     "type": "string"
                 },
-                "time": {
+                "unit_file_state": {
+                    "type": "string"
+                }
+            }
+        },
+        "systemd.UnitDetail": {
+            "type": "object",
+            "properties": {
+                "active_enter_timestamp": {
                     "type": "string"
                 },
-                "tty": {
+                "active_state": {
                     "type": "string"
                 },
-                "user": {
+                "after": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
                     "type": "string"
                 },
-                "vsz": {
+                "exec_main_status": {
+                    "description": "Exit status of the main process",
+                    "type": "integer"
+                },
+                "fragment_path": {
+                    "description": "Path of the unit file",
                     "type": "string"
-                }
-            }
-        },
-        "server.ServerDetails": {
-            "type": "object",
-            "properties": {
-                "hostname": {
+                },
+                "load_state": {
                     "type": "string"
                 },
-                "kernel_version": {
+                "main_pid": {
+                    "type": "integer"
+                },
+                "memory_current": {
+                    "description": "Memory usage in bytes, if accounting is enabled",
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "os": {
+                "properties": {
+                    "description": "All properties reported by systemctl show",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "requires": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "restart": {
                     "type": "string"
                 },
-                "uptime": {
+                "sub_state": {
+                    "type": "string"
+                },
+                "unit_file_state": {
                     "type": "string"
+                },
+                "wants": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         }
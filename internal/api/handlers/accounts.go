@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/accounts"
+)
+
+// AccountsHandler handles user, group, sudoers and login history requests
+type AccountsHandler struct {
+	accountsService accounts.Service
+}
+
+// NewAccountsHandler creates a new accounts handler
+func NewAccountsHandler(accountsService accounts.Service) *AccountsHandler {
+	return &AccountsHandler{
+		accountsService: accountsService,
+	}
+}
+
+// ListUsers returns the local users
+//
+// @Summary List local users
+// @Description Retrieves the users of the passwd database with their groups, password and lock status, account expiry, sudo access, last login and number of authorized SSH keys. Shadow and sudoers details need root or passwordless sudo; without them the password status is "unknown" and sudo access is inferred from the sudo, wheel and admin groups
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param include_system query bool false "Include system accounts outside the UID_MIN..UID_MAX range of /etc/login.defs" default(false)
+// @Success 200 {array} accounts.User "Users retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid parameter"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /accounts/users [get]
+func (h *AccountsHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get filter from query parameters
+	var filter accounts.UserFilter
+	if includeSystemStr := r.URL.Query().Get("include_system"); includeSystemStr != "" {
+		var err error
+		if filter.IncludeSystem, err = strconv.ParseBool(includeSystemStr); err != nil {
+			response.Error(w, "Invalid include_system parameter: must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Get users
+	users, err := h.accountsService.ListUsers(r.Context(), sessionID, filter)
+	if err != nil {
+		writeAccountsError(w, err, "Failed to list users: ")
+		return
+	}
+
+	// Return the users
+	response.JSON(w, users, http.StatusOK)
+}
+
+// ListGroups returns the groups
+//
+// @Summary List groups
+// @Description Retrieves the groups of the group database with their supplementary members, the users having them as primary group and whether they grant sudo access
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} accounts.Group "Groups retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /accounts/groups [get]
+func (h *AccountsHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get groups
+	groups, err := h.accountsService.ListGroups(r.Context(), sessionID)
+	if err != nil {
+		writeAccountsError(w, err, "Failed to list groups: ")
+		return
+	}
+
+	// Return the groups
+	response.JSON(w, groups, http.StatusOK)
+}
+
+// GetSudoers returns the sudoers rules and the users with root privileges
+//
+// @Summary Get sudoers configuration
+// @Description Parses /etc/sudoers and the files in /etc/sudoers.d and resolves users, groups and User_Alias definitions to the users that may run commands as root. Reading the files needs root or passwordless sudo; otherwise "readable" is false and access is inferred from the sudo, wheel and admin groups
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {object} accounts.SudoersReport "Sudoers configuration retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /accounts/sudoers [get]
+func (h *AccountsHandler) GetSudoers(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get sudoers report
+	report, err := h.accountsService.GetSudoers(r.Context(), sessionID)
+	if err != nil {
+		writeAccountsError(w, err, "Failed to read sudoers: ")
+		return
+	}
+
+	// Return the sudoers report
+	response.JSON(w, report, http.StatusOK)
+}
+
+// ListAuthorizedKeys returns the SSH keys authorized for the users
+//
+// @Summary List authorized SSH keys
+// @Description Retrieves the public keys in ~/.ssh/authorized_keys and ~/.ssh/authorized_keys2 of every user, or of one user, with type, size, SHA256 fingerprint, comment and options. Lines that cannot be parsed are reported with an error. Home directories of other users need root or passwordless sudo
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param user query string false "Only list the keys of this user"
+// @Success 200 {array} accounts.AuthorizedKey "Authorized keys retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid user name"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "User not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /accounts/authorized-keys [get]
+func (h *AccountsHandler) ListAuthorizedKeys(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get authorized keys
+	keys, err := h.accountsService.ListAuthorizedKeys(r.Context(), sessionID, r.URL.Query().Get("user"))
+	if err != nil {
+		writeAccountsError(w, err, "Failed to list authorized keys: ")
+		return
+	}
+
+	// Return the authorized keys
+	response.JSON(w, keys, http.StatusOK)
+}
+
+// GetLoginHistory returns recent successful and failed logins
+//
+// @Summary Get login history
+// @Description Retrieves the most recent logins recorded in wtmp by last and the failed logins recorded in btmp by lastb. Reading btmp needs root or passwordless sudo; otherwise "failures_readable" is false
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param limit query int false "Maximum number of records of each kind (max 1000)" default(50)
+// @Success 200 {object} accounts.LoginHistory "Login history retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid limit"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /accounts/logins [get]
+func (h *AccountsHandler) GetLoginHistory(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get limit from query parameters
+	limit, err := parseIntParam(r, "limit", accounts.DefaultLoginLimit)
+	if err != nil {
+		response.Error(w, "Invalid limit parameter: must be an integer", http.StatusBadRequest)
+		return
+	}
+
+	// Get login history
+	history, err := h.accountsService.GetLoginHistory(r.Context(), sessionID, limit)
+	if err != nil {
+		writeAccountsError(w, err, "Failed to read login history: ")
+		return
+	}
+
+	// Return the login history
+	response.JSON(w, history, http.StatusOK)
+}
+
+// writeAccountsError maps account service errors to HTTP responses
+func writeAccountsError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, accounts.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, accounts.ErrInvalidQuery):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, accounts.ErrUserNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,348 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/alerts"
+)
+
+// AlertsHandler handles alert rule, alert and webhook requests
+type AlertsHandler struct {
+	alertsService alerts.Service
+}
+
+// NewAlertsHandler creates a new alerts handler
+func NewAlertsHandler(alertsService alerts.Service) *AlertsHandler {
+	return &AlertsHandler{
+		alertsService: alertsService,
+	}
+}
+
+// ListAlerts returns the pending and firing alerts of the host
+//
+// @Summary List alerts
+// @Description Lists the alerts of the host of the session: series for which the condition of a rule holds. An alert is pending until the condition has held for the duration of the rule, then firing. Rules are evaluated after every background metrics collection
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} alerts.Alert "Alerts retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /alerts [get]
+func (h *AlertsHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get alerts
+	list, err := h.alertsService.ListAlerts(r.Context(), sessionID)
+	if err != nil {
+		writeAlertsError(w, err, "Failed to list alerts: ")
+		return
+	}
+
+	// Return the alerts
+	response.JSON(w, list, http.StatusOK)
+}
+
+// ListRules returns the alert rules of the host
+//
+// @Summary List alert rules
+// @Description Lists the alert rules of the host of the session. Rules and webhooks are kept in memory and lost when the API restarts
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} alerts.Rule "Alert rules retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /alerts/rules [get]
+func (h *AlertsHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get alert rules
+	rules, err := h.alertsService.ListRules(r.Context(), sessionID)
+	if err != nil {
+		writeAlertsError(w, err, "Failed to list alert rules: ")
+		return
+	}
+
+	// Return the alert rules
+	response.JSON(w, rules, http.StatusOK)
+}
+
+// CreateRule adds an alert rule to the host
+//
+// @Summary Create an alert rule
+// @Description Adds a rule comparing a collected metric of the host of the session with a threshold, for every series matching the labels. Examples: disk.used_percent with labels {"mount": "/"} > 90; container.unexpected_exit > 0 for containers stopped after a non-zero exit or an OOM kill; load.1m > 2 with per_cpu for 5m. The alert of a series fires once the condition has held for the duration and resolves when it no longer holds or the series disappears; every webhook of the host is notified once of each
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param request body alerts.RuleSpec true "Alert rule to create"
+// @Success 201 {object} alerts.Rule "Alert rule created successfully"
+// @Failure 400 {object} response.Response "Invalid alert rule"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /alerts/rules [post]
+func (h *AlertsHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse request body
+	var spec alerts.RuleSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Create the alert rule
+	rule, err := h.alertsService.CreateRule(r.Context(), sessionID, spec)
+	if err != nil {
+		writeAlertsError(w, err, "Failed to create alert rule: ")
+		return
+	}
+
+	// Return the alert rule
+	response.JSON(w, rule, http.StatusCreated)
+}
+
+// DeleteRule removes an alert rule of the host
+//
+// @Summary Delete an alert rule
+// @Description Removes an alert rule of the host of the session together with its alerts, without sending resolve notifications
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Rule ID"
+// @Success 200 {object} alerts.Rule "Alert rule deleted successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Alert rule not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /alerts/rules/{id} [delete]
+func (h *AlertsHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Delete the alert rule
+	rule, err := h.alertsService.DeleteRule(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeAlertsError(w, err, "Failed to delete alert rule: ")
+		return
+	}
+
+	// Return the deleted alert rule
+	response.JSON(w, rule, http.StatusOK)
+}
+
+// ListWebhooks returns the webhooks of the host
+//
+// @Summary List webhooks
+// @Description Lists the webhooks receiving the alert notifications of the host of the session
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} alerts.Webhook "Webhooks retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /alerts/webhooks [get]
+func (h *AlertsHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get webhooks
+	webhooks, err := h.alertsService.ListWebhooks(r.Context(), sessionID)
+	if err != nil {
+		writeAlertsError(w, err, "Failed to list webhooks: ")
+		return
+	}
+
+	// Return the webhooks
+	response.JSON(w, webhooks, http.StatusOK)
+}
+
+// CreateWebhook adds a webhook to the host
+//
+// @Summary Create a webhook
+// @Description Adds a webhook that receives the firing and resolved notifications of all alert rules of the host of the session as a JSON POST request. Without a template the notification itself is posted; a template is a Go text/template rendering the body from the notification, where the json function encodes a value, e.g. {"text": {{json .Summary}}} for Slack or Mattermost. The template must render valid JSON. Failed requests are retried twice, unless the receiver answered with a client error
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param request body alerts.WebhookSpec true "Webhook to create"
+// @Success 201 {object} alerts.Webhook "Webhook created successfully"
+// @Failure 400 {object} response.Response "Invalid webhook"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /alerts/webhooks [post]
+func (h *AlertsHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse request body
+	var spec alerts.WebhookSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Create the webhook
+	webhook, err := h.alertsService.CreateWebhook(r.Context(), sessionID, spec)
+	if err != nil {
+		writeAlertsError(w, err, "Failed to create webhook: ")
+		return
+	}
+
+	// Return the webhook
+	response.JSON(w, webhook, http.StatusCreated)
+}
+
+// DeleteWebhook removes a webhook of the host
+//
+// @Summary Delete a webhook
+// @Description Removes a webhook of the host of the session
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} alerts.Webhook "Webhook deleted successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Webhook not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /alerts/webhooks/{id} [delete]
+func (h *AlertsHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Delete the webhook
+	webhook, err := h.alertsService.DeleteWebhook(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeAlertsError(w, err, "Failed to delete webhook: ")
+		return
+	}
+
+	// Return the deleted webhook
+	response.JSON(w, webhook, http.StatusOK)
+}
+
+// TestWebhook sends a test notification to a webhook
+//
+// @Summary Test a webhook
+// @Description Posts a notification with status test to a webhook once and returns the outcome, including the status code of the receiver. A failed delivery is reported in the response rather than as an error
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} alerts.Delivery "Test notification sent"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Webhook not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /alerts/webhooks/{id}/test [post]
+func (h *AlertsHandler) TestWebhook(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Send the test notification
+	delivery, err := h.alertsService.TestWebhook(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeAlertsError(w, err, "Failed to test webhook: ")
+		return
+	}
+
+	// Return the delivery
+	response.JSON(w, delivery, http.StatusOK)
+}
+
+// ListDeliveries returns the latest notifications sent for the host
+//
+// @Summary List notification deliveries
+// @Description Lists the latest 100 notifications posted to the webhooks of the host of the session, newest first, with the outcome of each
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} alerts.Delivery "Deliveries retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /alerts/deliveries [get]
+func (h *AlertsHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get deliveries
+	deliveries, err := h.alertsService.ListDeliveries(r.Context(), sessionID)
+	if err != nil {
+		writeAlertsError(w, err, "Failed to list deliveries: ")
+		return
+	}
+
+	// Return the deliveries
+	response.JSON(w, deliveries, http.StatusOK)
+}
+
+// writeAlertsError maps alerts service errors to HTTP responses
+func writeAlertsError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, alerts.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, alerts.ErrInvalidRule), errors.Is(err, alerts.ErrInvalidWebhook):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, alerts.ErrRuleNotFound), errors.Is(err, alerts.ErrWebhookNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/cron"
+)
+
+// CronHandler handles scheduled task and crontab requests
+type CronHandler struct {
+	cronService cron.Service
+}
+
+// NewCronHandler creates a new cron handler
+func NewCronHandler(cronService cron.Service) *CronHandler {
+	return &CronHandler{
+		cronService: cronService,
+	}
+}
+
+// ListJobs returns the scheduled jobs of the server
+//
+// @Summary List scheduled jobs
+// @Description Lists the entries of all user crontabs, /etc/crontab and /etc/cron.d, the scripts in /etc/cron.hourly, daily, weekly and monthly, and the systemd timers, with their next run and, where cron logs to the journal, their last run during the past week. User crontabs need root or passwordless sudo
+// @Tags cron
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {object} cron.JobInventory "Scheduled jobs retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /cron/jobs [get]
+func (h *CronHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get scheduled jobs
+	inventory, err := h.cronService.ListJobs(r.Context(), sessionID)
+	if err != nil {
+		writeCronError(w, err, "Failed to list scheduled jobs: ")
+		return
+	}
+
+	// Return the scheduled jobs
+	response.JSON(w, inventory, http.StatusOK)
+}
+
+// GetCrontab returns the crontab of a user
+//
+// @Summary Get a user crontab
+// @Description Retrieves the entries of a user crontab with their next run. The version, also returned in the ETag header, must be sent in the If-Match header of changes
+// @Tags cron
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param user path string true "User name"
+// @Success 200 {object} cron.Crontab "Crontab retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid user name"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Crontabs of other users need root or passwordless sudo"
+// @Failure 404 {object} response.Response "User not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "crontab is not available"
+// @Router /cron/crontabs/{user} [get]
+func (h *CronHandler) GetCrontab(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get crontab
+	crontab, err := h.cronService.GetCrontab(r.Context(), sessionID, r.PathValue("user"))
+	if err != nil {
+		writeCronError(w, err, "Failed to read crontab: ")
+		return
+	}
+
+	// Return the crontab
+	w.Header().Set("ETag", strconv.Quote(crontab.Version))
+	response.JSON(w, crontab, http.StatusOK)
+}
+
+// AddCrontabEntry appends an entry to the crontab of a user
+//
+// @Summary Add a crontab entry
+// @Description Appends an entry to a user crontab, creating the crontab if needed. The change is only applied if the crontab still has the version given in the If-Match header
+// @Tags cron
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param If-Match header string true "Crontab version as returned in the ETag header"
+// @Param user path string true "User name"
+// @Param request body cron.CrontabEntryRequest true "Schedule and command"
+// @Success 201 {object} cron.Crontab "Entry added, the crontab is returned"
+// @Failure 400 {object} response.Response "Invalid entry"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Crontabs of other users need root or passwordless sudo"
+// @Failure 404 {object} response.Response "User not found"
+// @Failure 412 {object} response.Response "Crontab was changed since it was read"
+// @Failure 428 {object} response.Response "If-Match header missing"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "crontab is not available"
+// @Router /cron/crontabs/{user}/entries [post]
+func (h *CronHandler) AddCrontabEntry(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse request body
+	var entry cron.CrontabEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Add the entry
+	crontab, err := h.cronService.AddCrontabEntry(r.Context(), sessionID, r.PathValue("user"), parseIfMatch(r), entry)
+	if err != nil {
+		writeCronError(w, err, "Failed to add crontab entry: ")
+		return
+	}
+
+	// Return the updated crontab
+	w.Header().Set("ETag", strconv.Quote(crontab.Version))
+	response.JSON(w, crontab, http.StatusCreated)
+}
+
+// UpdateCrontabEntry replaces an entry of the crontab of a user
+//
+// @Summary Update a crontab entry
+// @Description Replaces an entry of a user crontab. Entry IDs are line numbers of the version given in the If-Match header, and the change is only applied if the crontab still has that version
+// @Tags cron
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param If-Match header string true "Crontab version as returned in the ETag header"
+// @Param user path string true "User name"
+// @Param id path int true "Entry ID"
+// @Param request body cron.CrontabEntryRequest true "Schedule and command"
+// @Success 200 {object} cron.Crontab "Entry updated, the crontab is returned"
+// @Failure 400 {object} response.Response "Invalid entry"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Crontabs of other users need root or passwordless sudo"
+// @Failure 404 {object} response.Response "User or entry not found"
+// @Failure 412 {object} response.Response "Crontab was changed since it was read"
+// @Failure 428 {object} response.Response "If-Match header missing"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "crontab is not available"
+// @Router /cron/crontabs/{user}/entries/{id} [put]
+func (h *CronHandler) UpdateCrontabEntry(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get entry ID from URL
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, "Invalid entry ID: must be an integer", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var entry cron.CrontabEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Update the entry
+	crontab, err := h.cronService.UpdateCrontabEntry(r.Context(), sessionID, r.PathValue("user"), parseIfMatch(r), id, entry)
+	if err != nil {
+		writeCronError(w, err, "Failed to update crontab entry: ")
+		return
+	}
+
+	// Return the updated crontab
+	w.Header().Set("ETag", strconv.Quote(crontab.Version))
+	response.JSON(w, crontab, http.StatusOK)
+}
+
+// DeleteCrontabEntry removes an entry from the crontab of a user
+//
+// @Summary Delete a crontab entry
+// @Description Removes an entry from a user crontab. Entry IDs are line numbers of the version given in the If-Match header, and the change is only applied if the crontab still has that version
+// @Tags cron
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param If-Match header string true "Crontab version as returned in the ETag header"
+// @Param user path string true "User name"
+// @Param id path int true "Entry ID"
+// @Success 200 {object} cron.Crontab "Entry deleted, the crontab is returned"
+// @Failure 400 {object} response.Response "Invalid entry ID"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Crontabs of other users need root or passwordless sudo"
+// @Failure 404 {object} response.Response "User or entry not found"
+// @Failure 412 {object} response.Response "Crontab was changed since it was read"
+// @Failure 428 {object} response.Response "If-Match header missing"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "crontab is not available"
+// @Router /cron/crontabs/{user}/entries/{id} [delete]
+func (h *CronHandler) DeleteCrontabEntry(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get entry ID from URL
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, "Invalid entry ID: must be an integer", http.StatusBadRequest)
+		return
+	}
+
+	// Delete the entry
+	crontab, err := h.cronService.DeleteCrontabEntry(r.Context(), sessionID, r.PathValue("user"), parseIfMatch(r), id)
+	if err != nil {
+		writeCronError(w, err, "Failed to delete crontab entry: ")
+		return
+	}
+
+	// Return the updated crontab
+	w.Header().Set("ETag", strconv.Quote(crontab.Version))
+	response.JSON(w, crontab, http.StatusOK)
+}
+
+// parseIfMatch reads the entity tag of the If-Match header, accepting quoted and weak tags
+func parseIfMatch(r *http.Request) string {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	value = strings.TrimPrefix(value, "W/")
+	return strings.Trim(value, `"`)
+}
+
+// writeCronError maps cron service errors to HTTP responses
+func writeCronError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, cron.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, cron.ErrInvalidUser),
+		errors.Is(err, cron.ErrInvalidEntry):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, cron.ErrPermissionDenied):
+		response.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, cron.ErrUserNotFound),
+		errors.Is(err, cron.ErrEntryNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, cron.ErrVersionConflict):
+		response.Error(w, err.Error(), http.StatusPreconditionFailed)
+	case errors.Is(err, cron.ErrVersionRequired):
+		response.Error(w, err.Error()+": send the version in the If-Match header", http.StatusPreconditionRequired)
+	case errors.Is(err, cron.ErrCronMissing):
+		response.Error(w, err.Error(), http.StatusNotImplemented)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/docker"
+)
+
+// GetContainerStats returns resource usage of the running Docker containers
+//
+// @Summary Get Docker container resource usage
+// @Description Takes a single 'docker stats' sample of the running containers: CPU and memory usage, and cumulative network and block I/O
+// @Tags docker
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} docker.ContainerStats "Docker container resource usage retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /docker/stats [get]
+func (h *DockerHandler) GetContainerStats(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get Docker container stats
+	stats, err := h.dockerService.GetContainerStats(r.Context(), sessionID)
+	if err != nil {
+		// Handle specific errors
+		switch {
+		case errors.Is(err, docker.ErrSessionNotFound):
+			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		default:
+			response.Error(w, "Failed to get Docker container stats: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return the Docker container stats
+	response.JSON(w, stats, http.StatusOK)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/docker"
+)
+
+// GetContainerStatuses returns the state of all Docker containers
+//
+// @Summary Get Docker container states
+// @Description Retrieves the state, exit code, OOM kill flag, restart count and restart policy of all containers from 'docker inspect'
+// @Tags docker
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} docker.ContainerStatus "Docker container states retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /docker/states [get]
+func (h *DockerHandler) GetContainerStatuses(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get Docker container states
+	statuses, err := h.dockerService.GetContainerStatuses(r.Context(), sessionID)
+	if err != nil {
+		// Handle specific errors
+		switch {
+		case errors.Is(err, docker.ErrSessionNotFound):
+			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		default:
+			response.Error(w, "Failed to get Docker container states: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return the Docker container states
+	response.JSON(w, statuses, http.StatusOK)
+}
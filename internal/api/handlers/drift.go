@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/drift"
+)
+
+// maxSpecSize bounds the size of a drift spec in a request body
+const maxSpecSize = 1 << 20
+
+// DriftHandler handles desired-state drift detection requests
+type DriftHandler struct {
+	driftService drift.Service
+}
+
+// NewDriftHandler creates a new drift handler
+func NewDriftHandler(driftService drift.Service) *DriftHandler {
+	return &DriftHandler{
+		driftService: driftService,
+	}
+}
+
+// CheckSpec checks the host against a spec sent with the request
+//
+// @Summary Check for drift
+// @Description Checks the host of the session against a spec of its expected state and returns the drift report. The spec is written in YAML, or JSON, and declares packages that must be installed, optionally within version constraints such as ">= 1.18, < 1.25", or must not be; systemd services that must be enabled or disabled and optionally running or stopped; Docker containers that must be running, optionally created from an image reference and pinned by repository digest; and files that must exist with a SHA-256 checksum, mode, owner and group, or must not exist. Each item yields a result with status ok, drift or error when its actual state could not be read
+// @Tags drift
+// @Accept application/x-yaml
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param spec body drift.Spec true "Expected state"
+// @Success 200 {object} drift.Report "Host checked successfully"
+// @Failure 400 {object} response.Response "Invalid spec"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /drift/check [post]
+func (h *DriftHandler) CheckSpec(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse the spec
+	spec, err := readSpec(w, r)
+	if err != nil {
+		writeDriftError(w, err, "Invalid request body: ")
+		return
+	}
+
+	// Check the host
+	report, err := h.driftService.CheckSpec(r.Context(), sessionID, *spec)
+	if err != nil {
+		writeDriftError(w, err, "Failed to check for drift: ")
+		return
+	}
+
+	// Return the report
+	response.JSON(w, report, http.StatusOK)
+}
+
+// ListSpecs returns the stored specs of the host
+//
+// @Summary List drift specs
+// @Description Lists the specs stored for the host of the session with their schedule and latest report
+// @Tags drift
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} drift.StoredSpec "Specs retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /drift/specs [get]
+func (h *DriftHandler) ListSpecs(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get specs
+	specs, err := h.driftService.ListSpecs(r.Context(), sessionID)
+	if err != nil {
+		writeDriftError(w, err, "Failed to list drift specs: ")
+		return
+	}
+
+	// Return the specs
+	response.JSON(w, specs, http.StatusOK)
+}
+
+// CreateSpec stores a spec for the host
+//
+// @Summary Store a drift spec
+// @Description Stores a spec of the expected state of the host of the session, in the format of POST /drift/check. With an interval the host is checked on that schedule, starting right away, through any active session to it; without one it is checked on demand. The latest reports are kept with the spec. Specs are kept in memory and are lost on restart
+// @Tags drift
+// @Accept application/x-yaml
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param interval query string false "Check interval, e.g. 15m or 1h, at least 1m"
+// @Param spec body drift.Spec true "Expected state"
+// @Success 201 {object} drift.StoredSpec "Spec stored successfully"
+// @Failure 400 {object} response.Response "Invalid spec or interval"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /drift/specs [post]
+func (h *DriftHandler) CreateSpec(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse the spec
+	spec, err := readSpec(w, r)
+	if err != nil {
+		writeDriftError(w, err, "Invalid request body: ")
+		return
+	}
+
+	// Store the spec
+	stored, err := h.driftService.CreateSpec(r.Context(), sessionID, *spec, r.URL.Query().Get("interval"))
+	if err != nil {
+		writeDriftError(w, err, "Failed to store drift spec: ")
+		return
+	}
+
+	// Return the stored spec
+	response.JSON(w, stored, http.StatusCreated)
+}
+
+// GetSpec returns a stored spec
+//
+// @Summary Get a drift spec
+// @Description Retrieves a spec stored for the host of the session with its schedule and latest report
+// @Tags drift
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Spec ID"
+// @Success 200 {object} drift.StoredSpec "Spec retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Spec not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /drift/specs/{id} [get]
+func (h *DriftHandler) GetSpec(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get the spec
+	stored, err := h.driftService.GetSpec(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeDriftError(w, err, "Failed to get drift spec: ")
+		return
+	}
+
+	// Return the spec
+	response.JSON(w, stored, http.StatusOK)
+}
+
+// DeleteSpec removes a stored spec
+//
+// @Summary Delete a drift spec
+// @Description Removes a spec stored for the host of the session together with its reports
+// @Tags drift
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Spec ID"
+// @Success 200 {object} drift.StoredSpec "Spec deleted successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Spec not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /drift/specs/{id} [delete]
+func (h *DriftHandler) DeleteSpec(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Delete the spec
+	stored, err := h.driftService.DeleteSpec(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeDriftError(w, err, "Failed to delete drift spec: ")
+		return
+	}
+
+	// Return the deleted spec
+	response.JSON(w, stored, http.StatusOK)
+}
+
+// CheckStoredSpec checks the host against a stored spec
+//
+// @Summary Check a drift spec now
+// @Description Checks the host of the session against a stored spec right away, regardless of its schedule, and keeps the report with the spec
+// @Tags drift
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Spec ID"
+// @Success 200 {object} drift.Report "Host checked successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Spec not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /drift/specs/{id}/check [post]
+func (h *DriftHandler) CheckStoredSpec(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Check the host
+	report, err := h.driftService.CheckStoredSpec(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeDriftError(w, err, "Failed to check for drift: ")
+		return
+	}
+
+	// Return the report
+	response.JSON(w, report, http.StatusOK)
+}
+
+// ListReports returns the latest reports of a stored spec
+//
+// @Summary List drift reports
+// @Description Lists the latest reports of a stored spec, newest first, whether the checks were scheduled or on demand. A scheduled check that found no active session to the host yields a report with an error and no results
+// @Tags drift
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Spec ID"
+// @Success 200 {array} drift.Report "Reports retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Spec not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /drift/specs/{id}/reports [get]
+func (h *DriftHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get reports
+	reports, err := h.driftService.ListReports(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeDriftError(w, err, "Failed to list drift reports: ")
+		return
+	}
+
+	// Return the reports
+	response.JSON(w, reports, http.StatusOK)
+}
+
+// readSpec reads and parses the spec in a request body
+func readSpec(w http.ResponseWriter, r *http.Request) (*drift.Spec, error) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSpecSize))
+	if err != nil {
+		return nil, err
+	}
+	return drift.ParseSpec(data)
+}
+
+// writeDriftError maps drift service errors to HTTP responses
+func writeDriftError(w http.ResponseWriter, err error, prefix string) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, drift.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, drift.ErrInvalidSpec):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.As(err, &maxBytesErr):
+		response.Error(w, prefix+err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, drift.ErrSpecNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
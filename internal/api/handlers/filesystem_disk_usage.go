@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// AnalyzeDirectoryUsage returns a size-sorted directory tree or the largest files below a path
+//
+// @Summary Analyze directory sizes
+// @Description Runs a depth-limited du below a path and returns a size-sorted tree with byte totals and file counts per directory, or the largest files in largest-files mode. Stays on the filesystem of the path. Large trees should use the background analysis endpoints instead
+// @Tags filesystem
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param path query string true "Absolute directory to analyze"
+// @Param mode query string false "Analysis mode" Enums(tree, largest-files) default(tree)
+// @Param max_depth query int false "Tree depth below the path (max 10)" default(3)
+// @Param limit query int false "Children kept per directory in tree mode, number of files in largest-files mode (max 1000)" default(20)
+// @Success 200 {object} server.DirectoryUsageResult "Directory usage analyzed successfully"
+// @Failure 400 {object} response.Response "Invalid query"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Directory not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /filesystem/disk-usage [get]
+func (h *FileSystemHandler) AnalyzeDirectoryUsage(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get query parameters
+	query := server.DirectoryUsageQuery{
+		Path: r.URL.Query().Get("path"),
+		Mode: r.URL.Query().Get("mode"),
+	}
+
+	var err error
+	if query.MaxDepth, err = parseIntParam(r, "max_depth", server.DefaultDirectoryUsageDepth); err != nil {
+		response.Error(w, "Invalid max_depth parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if query.Limit, err = parseIntParam(r, "limit", server.DefaultDirectoryUsageLimit); err != nil {
+		response.Error(w, "Invalid limit parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Analyze the directory
+	result, err := h.serverService.AnalyzeDirectoryUsage(r.Context(), sessionID, query)
+	if err != nil {
+		writeDirectoryUsageError(w, err, "Failed to analyze directory usage: ")
+		return
+	}
+
+	// Return the analysis result
+	response.JSON(w, result, http.StatusOK)
+}
+
+// StartDirectoryAnalysis starts a directory size analysis in the background
+//
+// @Summary Start a background directory analysis
+// @Description Starts a directory size analysis that keeps running after the request returns. Poll the returned analysis for progress and the result, or cancel it. At most two analyses run per session and finished analyses are kept for an hour
+// @Tags filesystem
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param request body server.DirectoryUsageQuery true "Analysis to run"
+// @Success 202 {object} server.DirectoryAnalysis "Analysis started"
+// @Failure 400 {object} response.Response "Invalid query"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 429 {object} response.Response "Too many running analyses"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /filesystem/disk-usage/analyses [post]
+func (h *FileSystemHandler) StartDirectoryAnalysis(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse request body
+	var query server.DirectoryUsageQuery
+	if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Start the analysis
+	analysis, err := h.serverService.StartDirectoryAnalysis(r.Context(), sessionID, query)
+	if err != nil {
+		writeDirectoryUsageError(w, err, "Failed to start directory analysis: ")
+		return
+	}
+
+	// Return the running analysis
+	response.JSON(w, analysis, http.StatusAccepted)
+}
+
+// GetDirectoryAnalysis returns the state of a background directory analysis
+//
+// @Summary Get a background directory analysis
+// @Description Retrieves the status and progress of a background analysis, including the result once it has completed
+// @Tags filesystem
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Analysis ID"
+// @Success 200 {object} server.DirectoryAnalysis "Analysis retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Analysis not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /filesystem/disk-usage/analyses/{id} [get]
+func (h *FileSystemHandler) GetDirectoryAnalysis(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get the analysis
+	analysis, err := h.serverService.GetDirectoryAnalysis(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeDirectoryUsageError(w, err, "Failed to get directory analysis: ")
+		return
+	}
+
+	// Return the analysis
+	response.JSON(w, analysis, http.StatusOK)
+}
+
+// CancelDirectoryAnalysis stops a running background directory analysis
+//
+// @Summary Cancel a background directory analysis
+// @Description Cancels a running analysis and terminates its commands on the server
+// @Tags filesystem
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Analysis ID"
+// @Success 200 {object} server.DirectoryAnalysis "Analysis cancelled"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Analysis not found"
+// @Failure 409 {object} response.Response "Analysis is not running"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /filesystem/disk-usage/analyses/{id} [delete]
+func (h *FileSystemHandler) CancelDirectoryAnalysis(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Cancel the analysis
+	analysis, err := h.serverService.CancelDirectoryAnalysis(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeDirectoryUsageError(w, err, "Failed to cancel directory analysis: ")
+		return
+	}
+
+	// Return the cancelled analysis
+	response.JSON(w, analysis, http.StatusOK)
+}
+
+// writeDirectoryUsageError maps directory usage errors to HTTP responses
+func writeDirectoryUsageError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, server.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, server.ErrInvalidDirectoryQuery):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, server.ErrDirectoryNotFound),
+		errors.Is(err, server.ErrAnalysisNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, server.ErrAnalysisNotRunning):
+		response.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, server.ErrTooManyAnalyses):
+		response.Error(w, err.Error(), http.StatusTooManyRequests)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
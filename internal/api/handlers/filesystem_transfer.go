@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// DownloadFile streams the contents of a file
+//
+// @Summary Download a file
+// @Description Streams the contents of a regular file on the host without buffering it. The file is read as the SSH user unless sudo is set, which reads it through passwordless sudo and needs root or passwordless sudo. The Content-Type comes from file --mime-type, falling back to application/octet-stream, and the ETag from the modification time and size. Single and multiple byte ranges are supported for resuming large downloads, as are conditional requests with If-None-Match, If-Modified-Since and If-Range
+// @Tags filesystem
+// @Produce octet-stream
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param path query string true "Absolute path of the file"
+// @Param sudo query bool false "Read the file through passwordless sudo (default false)"
+// @Param Range header string false "Byte ranges, e.g. bytes=1048576-"
+// @Success 200 {file} file "File contents"
+// @Success 206 {file} file "Requested ranges of the file"
+// @Success 304 "File not modified"
+// @Failure 400 {object} response.Response "Invalid path or not a regular file"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "File not readable, or sudo requested without root or passwordless sudo"
+// @Failure 404 {object} response.Response "File not found"
+// @Failure 416 "Range not satisfiable"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /filesystem/download [get]
+func (h *FileSystemHandler) DownloadFile(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get query parameters
+	filePath := r.URL.Query().Get("path")
+	if filePath == "" {
+		response.Error(w, "Path is required", http.StatusBadRequest)
+		return
+	}
+	var sudo bool
+	if sudoStr := r.URL.Query().Get("sudo"); sudoStr != "" {
+		var err error
+		if sudo, err = strconv.ParseBool(sudoStr); err != nil {
+			response.Error(w, "Invalid sudo parameter: must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Open the file
+	file, err := h.serverService.OpenFile(r.Context(), sessionID, filePath, sudo)
+	if err != nil {
+		writeFileTransferError(w, err, "Failed to open file: ")
+		return
+	}
+	defer file.Close()
+
+	// Downloads of large files outlive the server write timeout, so lift the deadline for this response
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	contentType := file.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	name := path.Base(file.Path)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", file.ETag)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+
+	// Stream the file, serving ranges and conditional requests
+	http.ServeContent(w, r, name, file.ModTime, file)
+}
+
+// UploadFile writes the request body to a file
+//
+// @Summary Upload a file
+// @Description Streams the request body, raw or as the "file" part of a multipart form, to a temporary file next to the target on the host, verifies its SHA-256 against the uploaded content and, when given, the expected checksum, then renames it into place atomically, so that readers never see a partial file. A symbolic link at the path is resolved and its target replaced. Without mode, owner and group, those of the replaced file are kept; new files get mode 0644 and the SSH user. The file is written as the SSH user unless sudo is set, which writes it through passwordless sudo and needs root or passwordless sudo; writing outside the home of the SSH user or changing the owner usually needs it
+// @Tags filesystem
+// @Accept octet-stream
+// @Accept mpfd
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param path query string true "Absolute path of the file"
+// @Param mode query string false "Octal permissions, e.g. 0640"
+// @Param owner query string false "User name or ID"
+// @Param group query string false "Group name or ID"
+// @Param create_parents query bool false "Create missing parent directories" default(false)
+// @Param sha256 query string false "Expected SHA-256 of the content"
+// @Param sudo query bool false "Write the file through passwordless sudo" default(false)
+// @Param file formData file false "File content, for multipart uploads"
+// @Success 200 {object} server.FileUploadResult "File replaced successfully"
+// @Success 201 {object} server.FileUploadResult "File created successfully"
+// @Failure 400 {object} response.Response "Invalid path, attributes or body"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Permission denied, or sudo requested without root or passwordless sudo"
+// @Failure 404 {object} response.Response "Parent directory not found"
+// @Failure 422 {object} response.Response "Checksum mismatch"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /filesystem/upload [put]
+func (h *FileSystemHandler) UploadFile(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get query parameters
+	query := r.URL.Query()
+	upload := server.FileUpload{
+		Path:   query.Get("path"),
+		Mode:   query.Get("mode"),
+		Owner:  query.Get("owner"),
+		Group:  query.Get("group"),
+		SHA256: query.Get("sha256"),
+	}
+	if upload.Path == "" {
+		response.Error(w, "Path is required", http.StatusBadRequest)
+		return
+	}
+	if value := query.Get("create_parents"); value != "" {
+		createParents, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Error(w, "Invalid create_parents parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		upload.CreateParents = createParents
+	}
+	if value := query.Get("sudo"); value != "" {
+		sudo, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Error(w, "Invalid sudo parameter: must be true or false", http.StatusBadRequest)
+			return
+		}
+		upload.Sudo = sudo
+	}
+
+	// Uploads of large files outlive the server read and write timeouts, so lift the deadlines
+	controller := http.NewResponseController(w)
+	_ = controller.SetReadDeadline(time.Time{})
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	// Get the content, which is the body or the file part of a multipart form
+	content, err := uploadContent(r)
+	if err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Upload the file
+	result, err := h.serverService.UploadFile(r.Context(), sessionID, upload, content)
+	if err != nil {
+		writeFileTransferError(w, err, "Failed to upload file: ")
+		return
+	}
+
+	// Return the written file
+	status := http.StatusCreated
+	if result.Replaced {
+		status = http.StatusOK
+	}
+	response.JSON(w, result, status)
+}
+
+// uploadContent returns the content of an upload: the "file" part, or else the first file part, of a
+// multipart form, or the raw body. Parts are streamed, not buffered.
+func uploadContent(r *http.Request) (io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, nil
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("multipart form has no file part")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" || part.FileName() != "" {
+			return part, nil
+		}
+	}
+}
+
+// writeFileTransferError maps file download and upload errors to HTTP responses
+func writeFileTransferError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, server.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, server.ErrInvalidFilePath), errors.Is(err, server.ErrNotRegularFile), errors.Is(err, server.ErrInvalidUpload):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, server.ErrChecksumMismatch):
+		response.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, server.ErrPermissionDenied):
+		response.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, server.ErrFileNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/firewall"
+)
+
+// FirewallHandler handles firewall rule requests
+type FirewallHandler struct {
+	firewallService firewall.Service
+}
+
+// NewFirewallHandler creates a new firewall handler
+func NewFirewallHandler(firewallService firewall.Service) *FirewallHandler {
+	return &FirewallHandler{
+		firewallService: firewallService,
+	}
+}
+
+// GetRules returns the firewall rules of the server
+//
+// @Summary List firewall rules
+// @Description Reads the nftables ruleset, the iptables and ip6tables rules and the ufw status and normalizes them into a common rule model. Tables created by iptables-nft are reported by iptables only. Reading the rules usually needs root or passwordless sudo; backends that could not be read carry an error
+// @Tags firewall
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param backend query string false "Only rules of this backend (nftables, iptables or ufw)"
+// @Param chain query string false "Only rules of this chain, case-insensitive"
+// @Success 200 {object} firewall.Ruleset "Firewall rules retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /firewall/rules [get]
+func (h *FirewallHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	filter := firewall.RuleFilter{
+		Backend: r.URL.Query().Get("backend"),
+		Chain:   r.URL.Query().Get("chain"),
+	}
+
+	// Get firewall rules
+	ruleset, err := h.firewallService.GetRuleset(r.Context(), sessionID, filter)
+	if err != nil {
+		writeFirewallError(w, err, "Failed to read firewall rules: ")
+		return
+	}
+
+	// Return the firewall rules
+	response.JSON(w, ruleset, http.StatusOK)
+}
+
+// AddRule adds a firewall rule that is rolled back unless confirmed
+//
+// @Summary Add a firewall rule
+// @Description Adds a rule after saving the current ruleset on the server. A timer on the server restores the saved ruleset unless the change is confirmed before it expires, so a rule that cuts off the API is undone without it. Only one change per server can await confirmation. Needs root or passwordless sudo
+// @Tags firewall
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param confirm_timeout query string false "Time to confirm the change before it is rolled back, between 10s and 10m (default 1m)"
+// @Param request body firewall.RuleSpec true "Rule to add"
+// @Success 202 {object} firewall.RuleChange "Rule added, awaiting confirmation"
+// @Failure 400 {object} response.Response "Invalid rule or confirmation timeout"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Changing firewall rules needs root or passwordless sudo"
+// @Failure 409 {object} response.Response "Another change is awaiting confirmation"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "Firewall backend is not available"
+// @Router /firewall/rules [post]
+func (h *FirewallHandler) AddRule(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	confirmTimeout, err := parseDurationParam(r, "confirm_timeout", firewall.DefaultConfirmTimeout)
+	if err != nil {
+		response.Error(w, "Invalid confirm_timeout parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var spec firewall.RuleSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Add the rule
+	change, err := h.firewallService.AddRule(r.Context(), sessionID, spec, confirmTimeout)
+	if err != nil {
+		writeFirewallError(w, err, "Failed to add firewall rule: ")
+		return
+	}
+
+	// Return the pending change
+	response.JSON(w, change, http.StatusAccepted)
+}
+
+// DeleteRule deletes a firewall rule, restoring it unless confirmed
+//
+// @Summary Delete a firewall rule
+// @Description Deletes a rule by the ID returned when listing rules, after saving the current ruleset on the server. The rule is restored unless the change is confirmed before it expires. Needs root or passwordless sudo
+// @Tags firewall
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Rule ID"
+// @Param confirm_timeout query string false "Time to confirm the change before it is rolled back, between 10s and 10m (default 1m)"
+// @Success 202 {object} firewall.RuleChange "Rule deleted, awaiting confirmation"
+// @Failure 400 {object} response.Response "Invalid confirmation timeout or rule rejected by the backend"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Changing firewall rules needs root or passwordless sudo"
+// @Failure 404 {object} response.Response "Rule not found"
+// @Failure 409 {object} response.Response "Another change is awaiting confirmation"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /firewall/rules/{id} [delete]
+func (h *FirewallHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	confirmTimeout, err := parseDurationParam(r, "confirm_timeout", firewall.DefaultConfirmTimeout)
+	if err != nil {
+		response.Error(w, "Invalid confirm_timeout parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Delete the rule
+	change, err := h.firewallService.DeleteRule(r.Context(), sessionID, r.PathValue("id"), confirmTimeout)
+	if err != nil {
+		writeFirewallError(w, err, "Failed to delete firewall rule: ")
+		return
+	}
+
+	// Return the pending change
+	response.JSON(w, change, http.StatusAccepted)
+}
+
+// GetChange returns the state of a firewall rule change
+//
+// @Summary Get a firewall rule change
+// @Description Retrieves the state of a rule change made through this session: pending, confirmed, rolled_back or failed
+// @Tags firewall
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Change ID"
+// @Success 200 {object} firewall.RuleChange "Change retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Change not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /firewall/changes/{id} [get]
+func (h *FirewallHandler) GetChange(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get the change
+	change, err := h.firewallService.GetChange(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeFirewallError(w, err, "Failed to get firewall change: ")
+		return
+	}
+
+	// Return the change
+	response.JSON(w, change, http.StatusOK)
+}
+
+// ConfirmChange keeps a pending firewall rule change
+//
+// @Summary Confirm a firewall rule change
+// @Description Keeps a pending rule change and cancels its rollback timer. Confirming from a new request also proves the API can still reach the server
+// @Tags firewall
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Change ID"
+// @Success 200 {object} firewall.RuleChange "Change confirmed"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Changing firewall rules needs root or passwordless sudo"
+// @Failure 404 {object} response.Response "Change not found"
+// @Failure 409 {object} response.Response "Change is no longer pending"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /firewall/changes/{id}/confirm [post]
+func (h *FirewallHandler) ConfirmChange(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Confirm the change
+	change, err := h.firewallService.ConfirmChange(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeFirewallError(w, err, "Failed to confirm firewall change: ")
+		return
+	}
+
+	// Return the confirmed change
+	response.JSON(w, change, http.StatusOK)
+}
+
+// RollbackChange undoes a pending firewall rule change
+//
+// @Summary Roll back a firewall rule change
+// @Description Restores the ruleset saved before a pending rule change without waiting for the timer
+// @Tags firewall
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Change ID"
+// @Success 200 {object} firewall.RuleChange "Change rolled back"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Changing firewall rules needs root or passwordless sudo"
+// @Failure 404 {object} response.Response "Change not found"
+// @Failure 409 {object} response.Response "Change is no longer pending"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /firewall/changes/{id}/rollback [post]
+func (h *FirewallHandler) RollbackChange(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Roll back the change
+	change, err := h.firewallService.RollbackChange(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeFirewallError(w, err, "Failed to roll back firewall change: ")
+		return
+	}
+
+	// Return the rolled back change
+	response.JSON(w, change, http.StatusOK)
+}
+
+// writeFirewallError maps firewall service errors to HTTP responses
+func writeFirewallError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, firewall.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, firewall.ErrInvalidRule),
+		errors.Is(err, firewall.ErrInvalidConfirmation):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, firewall.ErrPermissionDenied):
+		response.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, firewall.ErrRuleNotFound),
+		errors.Is(err, firewall.ErrChangeNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, firewall.ErrChangePending),
+		errors.Is(err, firewall.ErrChangeNotPending):
+		response.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, firewall.ErrBackendUnavailable):
+		response.Error(w, err.Error(), http.StatusNotImplemented)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/kernel"
+)
+
+// KernelHandler handles kernel parameter, module and command line requests
+type KernelHandler struct {
+	kernelService kernel.Service
+}
+
+// NewKernelHandler creates a new kernel handler
+func NewKernelHandler(kernelService kernel.Service) *KernelHandler {
+	return &KernelHandler{
+		kernelService: kernelService,
+	}
+}
+
+// ListSysctl returns the kernel parameters of the server
+//
+// @Summary List kernel parameters
+// @Description Lists the kernel parameters of 'sysctl -a' as key/value pairs. Parameters set in sysctl configuration files carry the effective configured value and file, and are flagged when the runtime value differs
+// @Tags kernel
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param prefix query string false "Only parameters under this key, e.g. net.ipv4"
+// @Success 200 {object} kernel.SysctlReport "Kernel parameters retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid prefix"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /kernel/sysctl [get]
+func (h *KernelHandler) ListSysctl(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get kernel parameters
+	report, err := h.kernelService.ListSysctl(r.Context(), sessionID, r.URL.Query().Get("prefix"))
+	if err != nil {
+		writeKernelError(w, err, "Failed to read kernel parameters: ")
+		return
+	}
+
+	// Return the kernel parameters
+	response.JSON(w, report, http.StatusOK)
+}
+
+// DiffSysctl compares the kernel parameters of the server with a baseline
+//
+// @Summary Compare kernel parameters with a baseline
+// @Description Compares the kernel parameters with a baseline given as a key/value map, as the text of a sysctl.conf file or 'sysctl -a' output, or both. Keys may use the glob patterns of sysctl.d. Values are compared with whitespace normalized
+// @Tags kernel
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param request body kernel.SysctlBaseline true "Baseline"
+// @Success 200 {object} kernel.SysctlDiff "Kernel parameters compared successfully"
+// @Failure 400 {object} response.Response "Invalid baseline"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /kernel/sysctl/diff [post]
+func (h *KernelHandler) DiffSysctl(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse request body
+	var baseline kernel.SysctlBaseline
+	if err := json.NewDecoder(r.Body).Decode(&baseline); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Compare kernel parameters
+	diff, err := h.kernelService.DiffSysctl(r.Context(), sessionID, baseline)
+	if err != nil {
+		writeKernelError(w, err, "Failed to compare kernel parameters: ")
+		return
+	}
+
+	// Return the differences
+	response.JSON(w, diff, http.StatusOK)
+}
+
+// SetSysctl sets a kernel parameter
+//
+// @Summary Set a kernel parameter
+// @Description Sets a writable kernel parameter and returns the previous value and the value read back from the kernel. With persist, the value is also written to /etc/sysctl.d/99-remote-server-api.conf, replacing an earlier line for the key; if that fails, the previous runtime value is restored. A configuration file applied later at boot, such as /etc/sysctl.conf, that sets another value is reported in overridden_by. Needs root or passwordless sudo
+// @Tags kernel
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param key path string true "Parameter key, e.g. vm.swappiness"
+// @Param request body kernel.SysctlUpdate true "Value to set"
+// @Success 200 {object} kernel.SysctlUpdateResult "Kernel parameter set successfully"
+// @Failure 400 {object} response.Response "Invalid key or value"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Setting kernel parameters needs root or passwordless sudo"
+// @Failure 404 {object} response.Response "Parameter not found"
+// @Failure 409 {object} response.Response "Parameter is read-only"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /kernel/sysctl/{key} [put]
+func (h *KernelHandler) SetSysctl(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse request body
+	var update kernel.SysctlUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Set the kernel parameter
+	result, err := h.kernelService.SetSysctl(r.Context(), sessionID, r.PathValue("key"), update)
+	if err != nil {
+		writeKernelError(w, err, "Failed to set kernel parameter: ")
+		return
+	}
+
+	// Return the result
+	response.JSON(w, result, http.StatusOK)
+}
+
+// ListModules returns the loaded kernel modules
+//
+// @Summary List kernel modules
+// @Description Lists the loaded kernel modules as lsmod does, with the modules each one depends on and is used by
+// @Tags kernel
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} kernel.KernelModule "Kernel modules retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /kernel/modules [get]
+func (h *KernelHandler) ListModules(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get kernel modules
+	modules, err := h.kernelService.ListModules(r.Context(), sessionID)
+	if err != nil {
+		writeKernelError(w, err, "Failed to list kernel modules: ")
+		return
+	}
+
+	// Return the kernel modules
+	response.JSON(w, modules, http.StatusOK)
+}
+
+// GetCommandLine returns the kernel command line
+//
+// @Summary Get the kernel command line
+// @Description Retrieves the kernel release and the command line the kernel was booted with, split into parameters
+// @Tags kernel
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {object} kernel.CommandLine "Kernel command line retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /kernel/cmdline [get]
+func (h *KernelHandler) GetCommandLine(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get kernel command line
+	commandLine, err := h.kernelService.GetCommandLine(r.Context(), sessionID)
+	if err != nil {
+		writeKernelError(w, err, "Failed to read kernel command line: ")
+		return
+	}
+
+	// Return the kernel command line
+	response.JSON(w, commandLine, http.StatusOK)
+}
+
+// writeKernelError maps kernel service errors to HTTP responses
+func writeKernelError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, kernel.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, kernel.ErrInvalidKey),
+		errors.Is(err, kernel.ErrInvalidValue),
+		errors.Is(err, kernel.ErrInvalidBaseline):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, kernel.ErrPermissionDenied):
+		response.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, kernel.ErrParameterNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, kernel.ErrParameterReadOnly):
+		response.Error(w, err.Error(), http.StatusConflict)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
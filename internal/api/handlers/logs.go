@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/logs"
+)
+
+// LogsHandler handles journal and log file requests
+type LogsHandler struct {
+	logsService logs.Service
+}
+
+// NewLogsHandler creates a new logs handler
+func NewLogsHandler(logsService logs.Service) *LogsHandler {
+	return &LogsHandler{
+		logsService: logsService,
+	}
+}
+
+// GetJournal returns entries from the systemd journal
+//
+// @Summary Query the systemd journal
+// @Description Retrieves the most recent journal entries, optionally filtered by unit, priority, time range and a Go (RE2) regular expression on the message, returning the last matching entries. Uses sudo when available so system entries are visible
+// @Tags logs
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param unit query string false "Systemd unit (e.g. nginx.service)"
+// @Param priority query string false "Maximum priority as name or number, or a range (e.g. err, 4, warning..err)"
+// @Param since query string false "Start time as RFC 3339 or a duration before now (e.g. 1h)"
+// @Param until query string false "End time as RFC 3339 or a duration before now"
+// @Param grep query string false "Go (RE2) regular expression matched against the message"
+// @Param lines query int false "Number of most recent entries (max 5000)" default(100)
+// @Success 200 {array} logs.JournalEntry "Journal entries retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid query"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "journalctl is not available"
+// @Router /logs/journal [get]
+func (h *LogsHandler) GetJournal(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get journal query from query parameters
+	query, err := parseJournalQuery(r)
+	if err != nil {
+		response.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Get journal entries
+	entries, err := h.logsService.QueryJournal(r.Context(), sessionID, query)
+	if err != nil {
+		writeLogsError(w, err, "Failed to query journal: ")
+		return
+	}
+
+	// Return the journal entries
+	response.JSON(w, entries, http.StatusOK)
+}
+
+// FollowJournal streams journal entries as Server-Sent Events
+//
+// @Summary Follow the systemd journal
+// @Description Emits the most recent matching entries and then every new entry as a "journal-entry" event until the client disconnects
+// @Tags logs
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param unit query string false "Systemd unit (e.g. nginx.service)"
+// @Param priority query string false "Maximum priority as name or number, or a range (e.g. err, 4, warning..err)"
+// @Param since query string false "Start time as RFC 3339 or a duration before now (e.g. 1h)"
+// @Param grep query string false "Go (RE2) regular expression matched against the message"
+// @Param lines query int false "Number of entries to emit before following (max 5000)" default(100)
+// @Success 200 {object} logs.JournalEntry "Stream of journal entries"
+// @Failure 400 {object} response.Response "Invalid query"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /logs/journal/follow [get]
+func (h *LogsHandler) FollowJournal(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get journal query from query parameters
+	query, err := parseJournalQuery(r)
+	if err != nil {
+		response.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Open the event stream
+	stream, err := response.NewEventStream(w)
+	if err != nil {
+		response.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Stream until the client disconnects
+	err = h.logsService.FollowJournal(r.Context(), sessionID, query, func(entry *logs.JournalEntry) error {
+		return stream.Send("journal-entry", entry)
+	})
+	if err != nil && r.Context().Err() == nil {
+		_ = stream.SendError("Journal stream ended: " + err.Error())
+	}
+}
+
+// GetLogFile returns the last lines of a log file
+//
+// @Summary Tail a log file
+// @Description Retrieves the last lines of a log file, optionally filtered by a Go (RE2) regular expression, returning the last matching lines. Only files inside the permitted directories (LOG_ALLOWED_DIRS, default /var/log) can be read, after resolving symbolic links
+// @Tags logs
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param path query string true "Absolute path of the log file"
+// @Param grep query string false "Go (RE2) regular expression matched against each line"
+// @Param lines query int false "Number of most recent lines (max 5000)" default(100)
+// @Success 200 {object} logs.LogFile "Log lines retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid query"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Path not permitted or permission denied"
+// @Failure 404 {object} response.Response "Log file not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /logs/file [get]
+func (h *LogsHandler) GetLogFile(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get log file query from query parameters
+	query, err := parseLogFileQuery(r)
+	if err != nil {
+		response.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Get log lines
+	file, err := h.logsService.TailFile(r.Context(), sessionID, query)
+	if err != nil {
+		writeLogsError(w, err, "Failed to read log file: ")
+		return
+	}
+
+	// Return the log lines
+	response.JSON(w, file, http.StatusOK)
+}
+
+// FollowLogFile streams lines appended to a log file as Server-Sent Events
+//
+// @Summary Follow a log file
+// @Description Emits the last lines of a log file and then every appended line as a "log-line" event until the client disconnects. Log rotation is followed. Path errors are reported as an "error" event
+// @Tags logs
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param path query string true "Absolute path of the log file"
+// @Param grep query string false "Go (RE2) regular expression matched against each line"
+// @Param lines query int false "Number of lines to emit before following (max 5000)" default(100)
+// @Success 200 {string} string "Stream of log lines"
+// @Failure 400 {object} response.Response "Invalid query"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /logs/file/follow [get]
+func (h *LogsHandler) FollowLogFile(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get log file query from query parameters
+	query, err := parseLogFileQuery(r)
+	if err != nil {
+		response.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Open the event stream
+	stream, err := response.NewEventStream(w)
+	if err != nil {
+		response.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Stream until the client disconnects
+	err = h.logsService.FollowFile(r.Context(), sessionID, query, func(line string) error {
+		return stream.Send("log-line", line)
+	})
+	if err != nil && r.Context().Err() == nil {
+		_ = stream.SendError("Log stream ended: " + err.Error())
+	}
+}
+
+// parseJournalQuery reads the journal query parameters
+func parseJournalQuery(r *http.Request) (logs.JournalQuery, error) {
+	query := logs.JournalQuery{
+		Unit:     r.URL.Query().Get("unit"),
+		Priority: r.URL.Query().Get("priority"),
+		Grep:     r.URL.Query().Get("grep"),
+	}
+
+	var err error
+	if query.Lines, err = parseIntParam(r, "lines", logs.DefaultLines); err != nil {
+		return query, errors.New("Invalid lines parameter: " + err.Error())
+	}
+	if query.Since, err = parseTimeParam(r, "since"); err != nil {
+		return query, errors.New("Invalid since parameter: " + err.Error())
+	}
+	if query.Until, err = parseTimeParam(r, "until"); err != nil {
+		return query, errors.New("Invalid until parameter: " + err.Error())
+	}
+
+	return query, nil
+}
+
+// parseLogFileQuery reads the log file query parameters
+func parseLogFileQuery(r *http.Request) (logs.LogFileQuery, error) {
+	query := logs.LogFileQuery{
+		Path: r.URL.Query().Get("path"),
+		Grep: r.URL.Query().Get("grep"),
+	}
+	if query.Path == "" {
+		return query, errors.New("Path parameter is required")
+	}
+
+	var err error
+	if query.Lines, err = parseIntParam(r, "lines", logs.DefaultLines); err != nil {
+		return query, errors.New("Invalid lines parameter: " + err.Error())
+	}
+
+	return query, nil
+}
+
+// writeLogsError maps log retrieval errors to HTTP responses
+func writeLogsError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, logs.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, logs.ErrInvalidQuery):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, logs.ErrPathNotAllowed),
+		errors.Is(err, logs.ErrPermissionDenied):
+		response.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, logs.ErrFileNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, logs.ErrJournalMissing):
+		response.Error(w, err.Error(), http.StatusNotImplemented)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
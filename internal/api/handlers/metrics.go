@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/metrics"
+)
+
+// defaultHistoryRange is the time range of a history query without from parameter
+const defaultHistoryRange = time.Hour
+
+// MetricsHandler handles requests for collected metrics
+type MetricsHandler struct {
+	metricsService metrics.Service
+}
+
+// NewMetricsHandler creates a new metrics handler
+func NewMetricsHandler(metricsService metrics.Service) *MetricsHandler {
+	return &MetricsHandler{
+		metricsService: metricsService,
+	}
+}
+
+// GetHistory returns the collected samples of a metric
+//
+// @Summary Get metric history
+// @Description Retrieves the samples of a metric collected in the background from the host of the session, one series per label set. Samples are kept at the collection interval for two hours, in five-minute steps for two days and in hourly steps for the retention period; the finest resolution still holding the start of the range is used and averaged into steps. Metrics: cpu.usage_percent, cpu.iowait_percent, cpu.steal_percent, cpu.count, load.1m, load.5m, load.15m, memory.used_bytes, memory.available_bytes, memory.used_percent, swap.used_bytes, disk.used_bytes, disk.used_percent, disk.inodes_used_percent (label mount), network.rx_bytes_per_sec, network.tx_bytes_per_sec (label interface), container.cpu_percent, container.memory_bytes, container.memory_percent, container.running, container.unexpected_exit (label container)
+// @Tags metrics
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param metric query string true "Metric name, e.g. cpu.usage_percent"
+// @Param from query string false "Start of the range as RFC 3339 or as a duration before now (default 1h)"
+// @Param to query string false "End of the range as RFC 3339 or as a duration before now (default now)"
+// @Param step query string false "Interval between points as a duration, e.g. 5m (default: about 300 points)"
+// @Param label query []string false "Only series with this label value, as name=value (e.g. mount=/)" collectionFormat(multi)
+// @Success 200 {object} metrics.History "Metric history retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid query"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /metrics/history [get]
+func (h *MetricsHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	query := metrics.HistoryQuery{
+		Metric: r.URL.Query().Get("metric"),
+		Labels: make(map[string]string),
+	}
+
+	// Get time range and step from query parameters
+	var err error
+	if query.From, err = parseTimeParam(r, "from"); err != nil {
+		response.Error(w, "Invalid from parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if query.To, err = parseTimeParam(r, "to"); err != nil {
+		response.Error(w, "Invalid to parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if query.To.IsZero() {
+		query.To = time.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultHistoryRange)
+	}
+	if query.Step, err = parseDurationParam(r, "step", 0); err != nil {
+		response.Error(w, "Invalid step parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Get label matchers
+	for _, label := range r.URL.Query()["label"] {
+		name, value, ok := strings.Cut(label, "=")
+		if !ok || name == "" {
+			response.Error(w, "Invalid label parameter: must be name=value", http.StatusBadRequest)
+			return
+		}
+		query.Labels[name] = value
+	}
+
+	// Get metric history
+	history, err := h.metricsService.GetHistory(r.Context(), sessionID, query)
+	if err != nil {
+		writeMetricsError(w, err, "Failed to get metric history: ")
+		return
+	}
+
+	// Return the metric history
+	response.JSON(w, history, http.StatusOK)
+}
+
+// ListSeries returns the series collected from the host of the session
+//
+// @Summary List collected series
+// @Description Lists the metric series stored for the host of the session with their latest value, together with the outcome of the latest collection. Metrics are collected from the hosts of all active sessions; a host reached through several sessions is collected once
+// @Tags metrics
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {object} metrics.SeriesList "Series retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /metrics/series [get]
+func (h *MetricsHandler) ListSeries(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get series
+	series, err := h.metricsService.ListSeries(r.Context(), sessionID)
+	if err != nil {
+		writeMetricsError(w, err, "Failed to list series: ")
+		return
+	}
+
+	// Return the series
+	response.JSON(w, series, http.StatusOK)
+}
+
+// ScrapeHost returns the current metrics of a host in the Prometheus text format
+//
+// @Summary Scrape host metrics for Prometheus
+// @Description Takes the current measurements of a host over SSH and returns them in the Prometheus text exposition format, without installing anything on the host. Load, memory, filesystem and network metrics use the node_exporter names; CPU utilization, Docker container states and container resource usage are reported as cerberus_* metrics. The host is the SSH address of an active session, with the port left out for port 22. With a session token only the host of that session can be scraped; the static token configured with METRICS_TOKEN can scrape any host with an active session
+// @Tags metrics
+// @Produce plain
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <session token or metrics token>"
+// @Param host path string true "Host address, e.g. 203.0.113.7 or 203.0.113.7:2222"
+// @Success 200 {string} string "Metrics in the Prometheus text format"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "No active session to the host"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /metrics/hosts/{host} [get]
+func (h *MetricsHandler) ScrapeHost(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context, absent when authenticated with the metrics token
+	sessionID, _ := r.Context().Value(SessionIDKey).(string)
+
+	// Take the measurements
+	families, err := h.metricsService.ScrapeHost(r.Context(), sessionID, r.PathValue("host"))
+	if err != nil {
+		writeMetricsError(w, err, "Failed to scrape host: ")
+		return
+	}
+
+	// Return the metrics in the text format
+	w.Header().Set("Content-Type", metrics.ExpositionContentType)
+	w.WriteHeader(http.StatusOK)
+	metrics.WriteText(w, families)
+}
+
+// writeMetricsError maps metrics service errors to HTTP responses
+func writeMetricsError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, metrics.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, metrics.ErrInvalidQuery):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, metrics.ErrHostNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
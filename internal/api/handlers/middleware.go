@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
 	"strings"
 
@@ -57,3 +58,20 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// AuthenticateWithToken accepts a static bearer token in addition to session tokens, for clients such as
+// Prometheus that cannot log in. Requests with the static token carry no session. An empty token
+// accepts session tokens only.
+func (m *AuthMiddleware) AuthenticateWithToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authenticated := m.Authenticate(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if token != "" && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
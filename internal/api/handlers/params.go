@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// parseDurationParam reads a duration query parameter (e.g. "500ms", "2s"), falling back to a default
+func parseDurationParam(r *http.Request, name string, defaultValue time.Duration) (time.Duration, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+// parseIntParam reads an integer query parameter, falling back to a default
+func parseIntParam(r *http.Request, name string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// parseTimeParam reads a point in time given either as RFC 3339 or as a duration before now (e.g. "1h"),
+// returning the zero time when the parameter is absent
+func parseTimeParam(r *http.Request, name string) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if ago, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-ago), nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
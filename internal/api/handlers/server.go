@@ -3,6 +3,7 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"remote-server-api/internal/api/response"
 	"remote-server-api/internal/domain/server"
@@ -99,13 +100,15 @@ func (h *ServerHandler) GetCPUInfo(w http.ResponseWriter, r *http.Request) {
 // GetDiskUsage returns disk usage information
 //
 // @Summary Get disk usage information
-// @Description Retrieves disk usage information from the server
+// @Description Retrieves space and inode usage in bytes, filesystem type and mount options of the mounted filesystems. Pseudo filesystems such as tmpfs, overlay and squashfs are excluded unless requested
 // @Tags server
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param Authorization header string true "Bearer <token>"
+// @Param include_pseudo query bool false "Include pseudo filesystems such as tmpfs and overlay" default(false)
 // @Success 200 {array} server.DiskUsage "Disk usage information retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid include_pseudo parameter"
 // @Failure 401 {object} response.Response "Unauthorized"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /server-details/disk-usage [get]
@@ -117,8 +120,18 @@ func (h *ServerHandler) GetDiskUsage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get filter from query parameters
+	var filter server.DiskUsageFilter
+	if includePseudoStr := r.URL.Query().Get("include_pseudo"); includePseudoStr != "" {
+		var err error
+		if filter.IncludePseudo, err = strconv.ParseBool(includePseudoStr); err != nil {
+			response.Error(w, "Invalid include_pseudo parameter: must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Get disk usage
-	diskUsage, err := h.serverService.GetDiskUsage(r.Context(), sessionID)
+	diskUsage, err := h.serverService.GetDiskUsage(r.Context(), sessionID, filter)
 	if err != nil {
 		// Handle specific errors
 		switch {
@@ -137,13 +150,22 @@ func (h *ServerHandler) GetDiskUsage(w http.ResponseWriter, r *http.Request) {
 // GetRunningProcesses returns information about running processes
 //
 // @Summary Get running processes information
-// @Description Retrieves information about running processes on the server
+// @Description Retrieves running processes with typed CPU, memory and size fields. Supports filtering by user, command regex and state, sorting, pagination and a tree mode that nests children under their parent. The response is a paged ProcessList when page, page_size or tree is given; without them, all matching processes are returned as a plain array, as before paging was added
 // @Tags server
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param Authorization header string true "Bearer <token>"
-// @Success 200 {array} server.ProcessInfo "Running processes information retrieved successfully"
+// @Param user query string false "Only processes of this user"
+// @Param command query string false "Regular expression matched against the command line"
+// @Param state query string false "Process state letters to include, e.g. R,D"
+// @Param sort query string false "Sort key: cpu, mem, rss, start or pid" default(pid)
+// @Param order query string false "Sort order: asc or desc" default(asc)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Processes per page (max 1000)" default(100)
+// @Param tree query bool false "Nest child processes under their parent" default(false)
+// @Success 200 {object} server.ProcessList "Running processes information retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid query"
 // @Failure 401 {object} response.Response "Unauthorized"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /server-details/running-processes [get]
@@ -155,13 +177,52 @@ func (h *ServerHandler) GetRunningProcesses(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Get query parameters
+	params := r.URL.Query()
+	query := server.ProcessQuery{
+		User:    params.Get("user"),
+		Command: params.Get("command"),
+		States:  params.Get("state"),
+		SortBy:  params.Get("sort"),
+	}
+
+	switch params.Get("order") {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		response.Error(w, "Invalid order parameter: must be asc or desc", http.StatusBadRequest)
+		return
+	}
+
+	// Clients that do not ask for pages or a tree get the plain array of earlier versions
+	query.Unpaged = !params.Has("page") && !params.Has("page_size") && !params.Has("tree")
+
+	var err error
+	if query.Page, err = parseIntParam(r, "page", 1); err != nil {
+		response.Error(w, "Invalid page parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if query.PageSize, err = parseIntParam(r, "page_size", server.DefaultProcessPageSize); err != nil {
+		response.Error(w, "Invalid page_size parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if treeStr := params.Get("tree"); treeStr != "" {
+		if query.Tree, err = strconv.ParseBool(treeStr); err != nil {
+			response.Error(w, "Invalid tree parameter: must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Get running processes
-	processes, err := h.serverService.GetRunningProcesses(r.Context(), sessionID)
+	processes, err := h.serverService.GetRunningProcesses(r.Context(), sessionID, query)
 	if err != nil {
 		// Handle specific errors
 		switch {
 		case errors.Is(err, server.ErrSessionNotFound):
 			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		case errors.Is(err, server.ErrInvalidProcessQuery):
+			response.Error(w, err.Error(), http.StatusBadRequest)
 		default:
 			response.Error(w, "Failed to get running processes: "+err.Error(), http.StatusInternalServerError)
 		}
@@ -169,13 +230,17 @@ func (h *ServerHandler) GetRunningProcesses(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Return the running processes
+	if query.Unpaged {
+		response.JSON(w, processes.Processes, http.StatusOK)
+		return
+	}
 	response.JSON(w, processes, http.StatusOK)
 }
 
 // GetInstalledLibraries returns information about installed libraries
 //
 // @Summary Get installed libraries information
-// @Description Retrieves information about installed libraries and packages on the server
+// @Description Retrieves the installed packages with version, architecture and state from dpkg, rpm, pacman or apk
 // @Tags server
 // @Accept json
 // @Produce json
@@ -184,6 +249,7 @@ func (h *ServerHandler) GetRunningProcesses(w http.ResponseWriter, r *http.Reque
 // @Success 200 {array} server.Library "Installed libraries information retrieved successfully"
 // @Failure 401 {object} response.Response "Unauthorized"
 // @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "No supported package manager"
 // @Router /server-details/libraries [get]
 func (h *ServerHandler) GetInstalledLibraries(w http.ResponseWriter, r *http.Request) {
 	// Get session ID from context
@@ -196,13 +262,7 @@ func (h *ServerHandler) GetInstalledLibraries(w http.ResponseWriter, r *http.Req
 	// Get installed libraries
 	libraries, err := h.serverService.GetInstalledLibraries(r.Context(), sessionID)
 	if err != nil {
-		// Handle specific errors
-		switch {
-		case errors.Is(err, server.ErrSessionNotFound):
-			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
-		default:
-			response.Error(w, "Failed to get installed libraries: "+err.Error(), http.StatusInternalServerError)
-		}
+		writePackageError(w, err, "Failed to get installed libraries: ")
 		return
 	}
 
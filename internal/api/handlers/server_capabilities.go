@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// GetCapabilities returns the userland profile of the server
+//
+// @Summary Get server userland capabilities
+// @Description Retrieves the profile used to pick command dialects: GNU, BusyBox or BSD userland, package manager, supported stat, find, ps and df options, privileges and optional tools. The server is probed once per session; set refresh to probe it again
+// @Tags server
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param refresh query bool false "Probe the server again instead of using the cached profile" default(false)
+// @Success 200 {object} server.Capabilities "Capabilities retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid refresh parameter"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/capabilities [get]
+func (h *ServerHandler) GetCapabilities(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get refresh flag from query parameters
+	var refresh bool
+	if refreshStr := r.URL.Query().Get("refresh"); refreshStr != "" {
+		var err error
+		if refresh, err = strconv.ParseBool(refreshStr); err != nil {
+			response.Error(w, "Invalid refresh parameter: must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Get capabilities
+	capabilities, err := h.serverService.GetCapabilities(r.Context(), sessionID, refresh)
+	if err != nil {
+		// Handle specific errors
+		switch {
+		case errors.Is(err, server.ErrSessionNotFound):
+			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		default:
+			response.Error(w, "Failed to get capabilities: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return the capabilities
+	response.JSON(w, capabilities, http.StatusOK)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// Default CPU sampling intervals
+const (
+	defaultCPUUsageInterval       = time.Second
+	defaultCPUUsageStreamInterval = 2 * time.Second
+)
+
+// GetCPUUsage returns CPU utilization sampled over an interval
+//
+// @Summary Get CPU utilization
+// @Description Samples /proc/stat twice over the given interval and returns per-core and aggregate utilization percentages
+// @Tags server
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param interval query string false "Sampling interval as a duration (e.g. 500ms, 2s)" default(1s)
+// @Success 200 {object} server.CPUUsage "CPU utilization retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid interval"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/cpu-usage [get]
+func (h *ServerHandler) GetCPUUsage(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get sampling interval
+	interval, err := parseDurationParam(r, "interval", defaultCPUUsageInterval)
+	if err != nil {
+		response.Error(w, "Invalid interval parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Get CPU usage
+	usage, err := h.serverService.GetCPUUsage(r.Context(), sessionID, interval)
+	if err != nil {
+		// Handle specific errors
+		switch {
+		case errors.Is(err, server.ErrSessionNotFound):
+			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		case errors.Is(err, server.ErrInvalidInterval):
+			response.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			response.Error(w, "Failed to get CPU usage: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return the CPU usage
+	response.JSON(w, usage, http.StatusOK)
+}
+
+// StreamCPUUsage streams CPU utilization as Server-Sent Events
+//
+// @Summary Stream CPU utilization
+// @Description Continuously samples /proc/stat on the server and emits a "cpu-usage" event per interval until the client disconnects
+// @Tags server
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param interval query string false "Sampling interval as a duration (e.g. 500ms, 2s)" default(2s)
+// @Success 200 {object} server.CPUUsage "Stream of CPU utilization events"
+// @Failure 400 {object} response.Response "Invalid interval"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/cpu-usage/stream [get]
+func (h *ServerHandler) StreamCPUUsage(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get sampling interval
+	interval, err := parseDurationParam(r, "interval", defaultCPUUsageStreamInterval)
+	if err != nil {
+		response.Error(w, "Invalid interval parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if interval < server.MinSampleInterval || interval > server.MaxSampleInterval {
+		response.Error(w, "Interval must be between "+server.MinSampleInterval.String()+" and "+server.MaxSampleInterval.String(), http.StatusBadRequest)
+		return
+	}
+
+	// Open the event stream
+	stream, err := response.NewEventStream(w)
+	if err != nil {
+		response.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Stream until the client disconnects
+	err = h.serverService.StreamCPUUsage(r.Context(), sessionID, interval, func(usage *server.CPUUsage) error {
+		return stream.Send("cpu-usage", usage)
+	})
+	if err != nil && r.Context().Err() == nil {
+		_ = stream.SendError("CPU usage stream ended: " + err.Error())
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// GetHostFacts returns a consolidated inventory of the server
+//
+// @Summary Get host facts
+// @Description Retrieves operating system release, kernel, architecture, virtualization and container detection, CPU sockets, cores and threads, total memory, timezone, boot time and machine ID, gathered in a single round trip. Facts are cached per session for five minutes; set refresh to gather them again
+// @Tags server
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param refresh query bool false "Gather the facts again instead of using the cached ones" default(false)
+// @Success 200 {object} server.HostFacts "Host facts retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid refresh parameter"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/facts [get]
+func (h *ServerHandler) GetHostFacts(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get refresh flag from query parameters
+	var refresh bool
+	if refreshStr := r.URL.Query().Get("refresh"); refreshStr != "" {
+		var err error
+		if refresh, err = strconv.ParseBool(refreshStr); err != nil {
+			response.Error(w, "Invalid refresh parameter: must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Get host facts
+	facts, err := h.serverService.GetHostFacts(r.Context(), sessionID, refresh)
+	if err != nil {
+		// Handle specific errors
+		switch {
+		case errors.Is(err, server.ErrSessionNotFound):
+			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		default:
+			response.Error(w, "Failed to get host facts: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return the host facts
+	response.JSON(w, facts, http.StatusOK)
+}
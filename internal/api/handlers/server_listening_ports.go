@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// GetListeningPorts returns the listening sockets and the processes owning them
+//
+// @Summary Get listening ports
+// @Description Lists listening TCP and UDP sockets using ss, falling back to /proc/net/tcp* and /proc/net/udp* when ss is missing. Each socket includes the owning PID, process name and Docker container when available
+// @Tags server
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param protocol query string false "Filter by protocol (tcp or udp)"
+// @Param port query int false "Filter by local port"
+// @Success 200 {array} server.ListeningSocket "Listening ports retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid filter"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/listening-ports [get]
+func (h *ServerHandler) GetListeningPorts(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get query parameters
+	filter := server.ListeningSocketFilter{
+		Protocol: r.URL.Query().Get("protocol"),
+	}
+	if filter.Protocol != "" && filter.Protocol != "tcp" && filter.Protocol != "udp" {
+		response.Error(w, "Invalid protocol parameter: must be tcp or udp", http.StatusBadRequest)
+		return
+	}
+
+	if portStr := r.URL.Query().Get("port"); portStr != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			response.Error(w, "Invalid port parameter: must be between 1 and 65535", http.StatusBadRequest)
+			return
+		}
+		filter.Port = port
+	}
+
+	// Get listening sockets
+	sockets, err := h.serverService.GetListeningSockets(r.Context(), sessionID, filter)
+	if err != nil {
+		// Handle specific errors
+		switch {
+		case errors.Is(err, server.ErrSessionNotFound):
+			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		default:
+			response.Error(w, "Failed to get listening ports: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return the listening sockets
+	response.JSON(w, sockets, http.StatusOK)
+}
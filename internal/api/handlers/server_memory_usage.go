@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// GetMemoryUsage returns memory and swap usage
+//
+// @Summary Get memory usage
+// @Description Retrieves memory and swap usage from /proc/meminfo. Used memory is total minus available memory, so page cache that can be reclaimed counts as available
+// @Tags server
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {object} server.MemoryUsage "Memory usage retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/memory-usage [get]
+func (h *ServerHandler) GetMemoryUsage(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get memory usage
+	usage, err := h.serverService.GetMemoryUsage(r.Context(), sessionID)
+	if err != nil {
+		// Handle specific errors
+		switch {
+		case errors.Is(err, server.ErrSessionNotFound):
+			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		default:
+			response.Error(w, "Failed to get memory usage: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return the memory usage
+	response.JSON(w, usage, http.StatusOK)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// defaultNetworkSampleInterval is the default interval between the two traffic counter samples
+const defaultNetworkSampleInterval = time.Second
+
+// GetNetworkInfo returns network interfaces, traffic counters, routes and DNS resolvers
+//
+// @Summary Get network information
+// @Description Retrieves network interfaces with addresses, MTU, state and MAC, traffic counters and rates derived from two /proc/net/dev samples, the routing table and DNS resolvers
+// @Tags server
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param interval query string false "Interval between the two counter samples as a duration (e.g. 500ms, 2s)" default(1s)
+// @Success 200 {object} server.NetworkInfo "Network information retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid interval"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/network [get]
+func (h *ServerHandler) GetNetworkInfo(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get sampling interval
+	interval, err := parseDurationParam(r, "interval", defaultNetworkSampleInterval)
+	if err != nil {
+		response.Error(w, "Invalid interval parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Get network information
+	networkInfo, err := h.serverService.GetNetworkInfo(r.Context(), sessionID, interval)
+	if err != nil {
+		// Handle specific errors
+		switch {
+		case errors.Is(err, server.ErrSessionNotFound):
+			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		case errors.Is(err, server.ErrInvalidInterval):
+			response.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			response.Error(w, "Failed to get network information: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return the network information
+	response.JSON(w, networkInfo, http.StatusOK)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// GetPendingUpdates returns the packages with available updates
+//
+// @Summary Get pending package updates
+// @Description Lists the packages with a newer version available from apt, dnf, yum, zypper, pacman or apk. Security updates are flagged where the package manager provides advisories (apt security suites, dnf/yum updateinfo, arch-audit). Set refresh to update the package metadata first, which requires root or passwordless sudo
+// @Tags packages
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param refresh query bool false "Refresh the package metadata before checking" default(false)
+// @Success 200 {object} server.PendingUpdates "Pending updates retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid refresh parameter"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "No supported package manager"
+// @Router /server-details/packages/updates [get]
+func (h *ServerHandler) GetPendingUpdates(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get refresh flag from query parameters
+	var refresh bool
+	if refreshStr := r.URL.Query().Get("refresh"); refreshStr != "" {
+		var err error
+		if refresh, err = strconv.ParseBool(refreshStr); err != nil {
+			response.Error(w, "Invalid refresh parameter: must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Get pending updates
+	updates, err := h.serverService.GetPendingUpdates(r.Context(), sessionID, refresh)
+	if err != nil {
+		writePackageError(w, err, "Failed to get pending updates: ")
+		return
+	}
+
+	// Return the pending updates
+	response.JSON(w, updates, http.StatusOK)
+}
+
+// RunPackageOperation installs, removes or upgrades packages, streaming the package manager output
+//
+// @Summary Install, remove or upgrade packages
+// @Description Runs the package manager non-interactively with root privileges and streams every output line as an "output" event, followed by a "result" event with the exit code. Upgrading without packages upgrades the whole system. Essential packages such as the package manager, libc, sudo and the SSH server cannot be removed, directly or as dependents of the requested packages, which is checked by simulating the removal first. A transaction keeps running to completion if the client disconnects. Validation and permission errors are returned before the stream starts
+// @Tags packages
+// @Accept json
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param operation path string true "Operation" Enums(install, remove, upgrade)
+// @Param request body server.PackageOperationRequest true "Packages to act on"
+// @Success 200 {object} server.PackageOperationResult "Stream of output lines and the operation result"
+// @Failure 400 {object} response.Response "Invalid operation or package name"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Protected package or insufficient privileges"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "No supported package manager"
+// @Router /server-details/packages/{operation} [post]
+func (h *ServerHandler) RunPackageOperation(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse request body
+	var request server.PackageOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The event stream is opened with the first output line so that errors detected before the
+	// package manager starts are reported with a proper status code
+	var stream *response.EventStream
+	send := func(event string, data interface{}) error {
+		if stream == nil {
+			var err error
+			if stream, err = response.NewEventStream(w); err != nil {
+				return err
+			}
+		}
+		return stream.Send(event, data)
+	}
+
+	// Run the operation
+	result, err := h.serverService.RunPackageOperation(r.Context(), sessionID, r.PathValue("operation"), request, func(line string) error {
+		return send("output", line)
+	})
+	if err != nil {
+		if stream == nil {
+			writePackageError(w, err, "Failed to run package operation: ")
+			return
+		}
+		_ = stream.SendError("Package operation failed: " + err.Error())
+		return
+	}
+
+	// Send the result
+	_ = send("result", result)
+}
+
+// writePackageError maps package errors to HTTP responses
+func writePackageError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, server.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, server.ErrInvalidPackageOperation):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, server.ErrProtectedPackage),
+		errors.Is(err, server.ErrPermissionDenied):
+		response.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, server.ErrNoPackageManager):
+		response.Error(w, err.Error(), http.StatusNotImplemented)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
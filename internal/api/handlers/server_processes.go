@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// GetProcessDetail returns detailed information about a single process
+//
+// @Summary Get process details
+// @Description Retrieves the command line, redacted environment, open file count, cgroups, threads and parent PID of a process
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param pid path int true "Process ID"
+// @Success 200 {object} server.ProcessDetail "Process details retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid process ID"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Process not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/processes/{pid} [get]
+func (h *ServerHandler) GetProcessDetail(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get process ID from URL path parameter
+	pid, err := strconv.Atoi(r.PathValue("pid"))
+	if err != nil || pid <= 0 {
+		response.Error(w, "Invalid process ID", http.StatusBadRequest)
+		return
+	}
+
+	// Get process details
+	detail, err := h.serverService.GetProcessDetail(r.Context(), sessionID, pid)
+	if err != nil {
+		writeProcessError(w, err, "Failed to get process details: ")
+		return
+	}
+
+	// Return the process details
+	response.JSON(w, detail, http.StatusOK)
+}
+
+// SignalProcess sends a signal to a process
+//
+// @Summary Send a signal to a process
+// @Description Sends a signal (HUP, INT, QUIT, KILL, USR1, USR2, TERM, CONT, STOP) to a process. PID 1, kernel threads and the SSH session used by the API are refused, and processes of other users require passwordless sudo
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param pid path int true "Process ID"
+// @Param request body server.ProcessSignalRequest true "Signal to send"
+// @Success 200 {object} server.ProcessActionResult "Signal sent successfully"
+// @Failure 400 {object} response.Response "Invalid process ID or signal"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Protected process or permission denied"
+// @Failure 404 {object} response.Response "Process not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/processes/{pid}/signal [post]
+func (h *ServerHandler) SignalProcess(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get process ID from URL path parameter
+	pid, err := strconv.Atoi(r.PathValue("pid"))
+	if err != nil || pid <= 0 {
+		response.Error(w, "Invalid process ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var request server.ProcessSignalRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Send the signal
+	result, err := h.serverService.SignalProcess(r.Context(), sessionID, pid, request.Signal)
+	if err != nil {
+		writeProcessError(w, err, "Failed to signal process: ")
+		return
+	}
+
+	// Return the action result
+	response.JSON(w, result, http.StatusOK)
+}
+
+// KillProcess forcefully terminates a process
+//
+// @Summary Kill a process
+// @Description Sends SIGKILL to a process. PID 1, kernel threads and the SSH session used by the API are refused, and processes of other users require passwordless sudo
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param pid path int true "Process ID"
+// @Success 200 {object} server.ProcessActionResult "Process killed successfully"
+// @Failure 400 {object} response.Response "Invalid process ID"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Protected process or permission denied"
+// @Failure 404 {object} response.Response "Process not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/processes/{pid}/kill [post]
+func (h *ServerHandler) KillProcess(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get process ID from URL path parameter
+	pid, err := strconv.Atoi(r.PathValue("pid"))
+	if err != nil || pid <= 0 {
+		response.Error(w, "Invalid process ID", http.StatusBadRequest)
+		return
+	}
+
+	// Kill the process
+	result, err := h.serverService.KillProcess(r.Context(), sessionID, pid)
+	if err != nil {
+		writeProcessError(w, err, "Failed to kill process: ")
+		return
+	}
+
+	// Return the action result
+	response.JSON(w, result, http.StatusOK)
+}
+
+// ReniceProcess changes the scheduling priority of a process
+//
+// @Summary Renice a process
+// @Description Changes the nice value of a process. Raising the priority (negative values) usually requires root or passwordless sudo
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param pid path int true "Process ID"
+// @Param request body server.ProcessReniceRequest true "New priority"
+// @Success 200 {object} server.ProcessActionResult "Priority changed successfully"
+// @Failure 400 {object} response.Response "Invalid process ID or priority"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Protected process or permission denied"
+// @Failure 404 {object} response.Response "Process not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/processes/{pid}/renice [post]
+func (h *ServerHandler) ReniceProcess(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get process ID from URL path parameter
+	pid, err := strconv.Atoi(r.PathValue("pid"))
+	if err != nil || pid <= 0 {
+		response.Error(w, "Invalid process ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var request server.ProcessReniceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Change the priority
+	result, err := h.serverService.ReniceProcess(r.Context(), sessionID, pid, request.Priority)
+	if err != nil {
+		writeProcessError(w, err, "Failed to renice process: ")
+		return
+	}
+
+	// Return the action result
+	response.JSON(w, result, http.StatusOK)
+}
+
+// writeProcessError maps process management errors to HTTP responses
+func writeProcessError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, server.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, server.ErrInvalidProcessID),
+		errors.Is(err, server.ErrInvalidSignal),
+		errors.Is(err, server.ErrInvalidPriority):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, server.ErrProcessNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, server.ErrProtectedProcess),
+		errors.Is(err, server.ErrPermissionDenied):
+		response.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
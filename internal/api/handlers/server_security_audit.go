@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// GetSecurityAudit returns the scored findings of a security audit of the server
+//
+// @Summary Audit server security
+// @Description Runs read-only checks in one round trip: sshd root login, password and empty password authentication, world-writable files and directories in system paths, unexpected setuid binaries, pending security updates, failed logins of the last day, automatic updates and Docker daemon exposure on TCP. Failed checks deduct a penalty by severity (critical 30, high 15, medium 8, low 3) from a score of 100 and carry remediation hints. Some checks need root or passwordless sudo and are reported as unknown without it
+// @Tags server
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {object} server.SecurityAudit "Security audit completed successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/security-audit [get]
+func (h *ServerHandler) GetSecurityAudit(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Run the security audit
+	audit, err := h.serverService.GetSecurityAudit(r.Context(), sessionID)
+	if err != nil {
+		// Handle specific errors
+		switch {
+		case errors.Is(err, server.ErrSessionNotFound):
+			response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+		default:
+			response.Error(w, "Failed to run security audit: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Return the security audit
+	response.JSON(w, audit, http.StatusOK)
+}
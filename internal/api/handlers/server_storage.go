@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/server"
+)
+
+// GetStorageInfo returns block devices, LVM volumes and software RAID arrays
+//
+// @Summary Get storage inventory
+// @Description Retrieves block devices from lsblk, LVM physical volumes, volume groups and logical volumes, and software RAID arrays from /proc/mdstat in one call
+// @Tags storage
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {object} server.StorageInfo "Storage inventory retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/storage [get]
+func (h *ServerHandler) GetStorageInfo(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get storage inventory
+	storage, err := h.serverService.GetStorageInfo(r.Context(), sessionID)
+	if err != nil {
+		writeStorageError(w, err, "Failed to get storage information: ")
+		return
+	}
+
+	// Return the storage inventory
+	response.JSON(w, storage, http.StatusOK)
+}
+
+// GetBlockDevices returns the block devices of the server
+//
+// @Summary Get block devices
+// @Description Retrieves disks, partitions and other block devices with size, model, rotational flag, filesystem UUID and mountpoints, nested by parent device
+// @Tags storage
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {array} server.BlockDevice "Block devices retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/storage/block-devices [get]
+func (h *ServerHandler) GetBlockDevices(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get block devices
+	devices, err := h.serverService.GetBlockDevices(r.Context(), sessionID)
+	if err != nil {
+		writeStorageError(w, err, "Failed to get block devices: ")
+		return
+	}
+
+	// Return the block devices
+	response.JSON(w, devices, http.StatusOK)
+}
+
+// GetLVMInfo returns the LVM configuration of the server
+//
+// @Summary Get LVM volumes
+// @Description Retrieves LVM physical volumes, volume groups and logical volumes with sizes in bytes. Requires root or passwordless sudo
+// @Tags storage
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {object} server.LVMInfo "LVM information retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/storage/lvm [get]
+func (h *ServerHandler) GetLVMInfo(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get LVM information
+	lvm, err := h.serverService.GetLVMInfo(r.Context(), sessionID)
+	if err != nil {
+		writeStorageError(w, err, "Failed to get LVM information: ")
+		return
+	}
+
+	// Return the LVM information
+	response.JSON(w, lvm, http.StatusOK)
+}
+
+// GetRAIDInfo returns the software RAID arrays of the server
+//
+// @Summary Get software RAID status
+// @Description Retrieves md arrays from /proc/mdstat with member state and sync progress. Arrays with missing or failed members are flagged as degraded
+// @Tags storage
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Success 200 {object} server.RAIDInfo "RAID status retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /server-details/storage/raid [get]
+func (h *ServerHandler) GetRAIDInfo(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get RAID status
+	raid, err := h.serverService.GetRAIDInfo(r.Context(), sessionID)
+	if err != nil {
+		writeStorageError(w, err, "Failed to get RAID status: ")
+		return
+	}
+
+	// Return the RAID status
+	response.JSON(w, raid, http.StatusOK)
+}
+
+// writeStorageError maps storage errors to HTTP responses
+func writeStorageError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, server.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
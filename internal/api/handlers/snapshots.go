@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/snapshots"
+)
+
+// SnapshotsHandler handles server state snapshot requests
+type SnapshotsHandler struct {
+	snapshotsService snapshots.Service
+}
+
+// NewSnapshotsHandler creates a new snapshots handler
+func NewSnapshotsHandler(snapshotsService snapshots.Service) *SnapshotsHandler {
+	return &SnapshotsHandler{
+		snapshotsService: snapshotsService,
+	}
+}
+
+// CreateSnapshot captures and stores the state of the host
+//
+// @Summary Take a snapshot
+// @Description Captures the installed packages, systemd services, Docker containers and images, listening ports, users, scheduled jobs and kernel parameters of the host of the session and stores them on the API server. Values that change on their own, such as last logins, next job runs and kernel counters, are left out. A section that cannot be captured, e.g. containers on a host without Docker, carries an error instead of items
+// @Tags snapshots
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param request body snapshots.SnapshotRequest false "Snapshot label"
+// @Success 201 {object} snapshots.Snapshot "Snapshot taken successfully"
+// @Failure 400 {object} response.Response "Invalid request body"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /snapshots [post]
+func (h *SnapshotsHandler) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse request body, which is optional
+	var request snapshots.SnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Take the snapshot
+	snapshot, err := h.snapshotsService.CreateSnapshot(r.Context(), sessionID, request)
+	if err != nil {
+		writeSnapshotsError(w, err, "Failed to take snapshot: ")
+		return
+	}
+
+	// Return the snapshot
+	response.JSON(w, snapshot, http.StatusCreated)
+}
+
+// ListSnapshots returns the stored snapshots
+//
+// @Summary List snapshots
+// @Description Lists the stored snapshots of the hosts the session's user has an active session to, newest first, with the number of items per captured section, so that hosts can be compared. Snapshots of other hosts are left out
+// @Tags snapshots
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param host query string false "Only snapshots of this host, as host:port"
+// @Success 200 {array} snapshots.SnapshotInfo "Snapshots retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /snapshots [get]
+func (h *SnapshotsHandler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get snapshots
+	infos, err := h.snapshotsService.ListSnapshots(r.Context(), sessionID, r.URL.Query().Get("host"))
+	if err != nil {
+		writeSnapshotsError(w, err, "Failed to list snapshots: ")
+		return
+	}
+
+	// Return the snapshots
+	response.JSON(w, infos, http.StatusOK)
+}
+
+// GetSnapshot returns a stored snapshot
+//
+// @Summary Get a snapshot
+// @Description Retrieves a stored snapshot with all its items. Only snapshots of hosts the session's user has an active session to are found
+// @Tags snapshots
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Snapshot ID"
+// @Success 200 {object} snapshots.Snapshot "Snapshot retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Snapshot not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /snapshots/{id} [get]
+func (h *SnapshotsHandler) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get the snapshot
+	snapshot, err := h.snapshotsService.GetSnapshot(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeSnapshotsError(w, err, "Failed to get snapshot: ")
+		return
+	}
+
+	// Return the snapshot
+	response.JSON(w, snapshot, http.StatusOK)
+}
+
+// DeleteSnapshot removes a stored snapshot
+//
+// @Summary Delete a snapshot
+// @Description Removes a stored snapshot of the session's host; snapshots of other hosts are not found
+// @Tags snapshots
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param id path string true "Snapshot ID"
+// @Success 200 {object} snapshots.SnapshotInfo "Snapshot deleted successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Snapshot not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /snapshots/{id} [delete]
+func (h *SnapshotsHandler) DeleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Delete the snapshot
+	info, err := h.snapshotsService.DeleteSnapshot(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		writeSnapshotsError(w, err, "Failed to delete snapshot: ")
+		return
+	}
+
+	// Return the deleted snapshot
+	response.JSON(w, info, http.StatusOK)
+}
+
+// DiffSnapshots compares two snapshots
+//
+// @Summary Compare snapshots
+// @Description Lists the items added, removed and changed from snapshot a to snapshot b, per section. The snapshots may be of the same host, to see what changed over time, or of different hosts, to see how they differ. Items are matched by key, e.g. package name, unit name or protocol, address and port; changed items list the attributes with different values. Sections not captured in either snapshot are not compared and carry an error. Only snapshots of hosts the session's user has an active session to are found
+// @Tags snapshots
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param a query string true "ID of the older or reference snapshot"
+// @Param b query string true "ID of the newer or compared snapshot"
+// @Param section query []string false "Only these sections (packages, services, containers, images, ports, users, cron, sysctl)" collectionFormat(multi)
+// @Success 200 {object} snapshots.Diff "Snapshots compared successfully"
+// @Failure 400 {object} response.Response "Missing snapshot ID or unknown section"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Snapshot not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /snapshots/diff [get]
+func (h *SnapshotsHandler) DiffSnapshots(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+
+	// Compare the snapshots
+	diff, err := h.snapshotsService.DiffSnapshots(r.Context(), sessionID, query.Get("a"), query.Get("b"), query["section"])
+	if err != nil {
+		writeSnapshotsError(w, err, "Failed to compare snapshots: ")
+		return
+	}
+
+	// Return the differences
+	response.JSON(w, diff, http.StatusOK)
+}
+
+// writeSnapshotsError maps snapshot service errors to HTTP responses
+func writeSnapshotsError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, snapshots.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, snapshots.ErrInvalidDiff):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, snapshots.ErrSnapshotNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
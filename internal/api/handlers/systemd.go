@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"remote-server-api/internal/api/response"
+	"remote-server-api/internal/domain/systemd"
+)
+
+// SystemdHandler handles systemd service management requests
+type SystemdHandler struct {
+	systemdService systemd.Service
+}
+
+// NewSystemdHandler creates a new systemd handler
+func NewSystemdHandler(systemdService systemd.Service) *SystemdHandler {
+	return &SystemdHandler{
+		systemdService: systemdService,
+	}
+}
+
+// ListUnits returns the systemd units of the server
+//
+// @Summary List systemd units
+// @Description Lists systemd units with their load, active, sub and unit file state. Only services are listed unless another type is requested
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param type query string false "Unit type (service, socket, target, timer, mount, ...) or 'all'" default(service)
+// @Param state query string false "Only units whose load, active or sub state matches (e.g. running, failed, inactive)"
+// @Success 200 {array} systemd.Unit "Units retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid unit type"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "systemd is not available"
+// @Router /services [get]
+func (h *SystemdHandler) ListUnits(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get filter from query parameters
+	filter := systemd.UnitFilter{
+		Type:  r.URL.Query().Get("type"),
+		State: r.URL.Query().Get("state"),
+	}
+	switch filter.Type {
+	case "":
+		filter.Type = "service"
+	case "all":
+		filter.Type = ""
+	}
+
+	// Get units
+	units, err := h.systemdService.ListUnits(r.Context(), sessionID, filter)
+	if err != nil {
+		writeSystemdError(w, err, "Failed to list units: ")
+		return
+	}
+
+	// Return the units
+	response.JSON(w, units, http.StatusOK)
+}
+
+// GetUnit returns the properties of a systemd unit
+//
+// @Summary Get systemd unit details
+// @Description Retrieves the state, unit file, main PID, dependencies and all properties reported by systemctl show. Names without a suffix are treated as services
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param unit path string true "Unit name (e.g. nginx or nginx.service)"
+// @Success 200 {object} systemd.UnitDetail "Unit details retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid unit name"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Unit not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "systemd is not available"
+// @Router /services/{unit} [get]
+func (h *SystemdHandler) GetUnit(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Get unit details
+	detail, err := h.systemdService.GetUnit(r.Context(), sessionID, r.PathValue("unit"))
+	if err != nil {
+		writeSystemdError(w, err, "Failed to get unit: ")
+		return
+	}
+
+	// Return the unit details
+	response.JSON(w, detail, http.StatusOK)
+}
+
+// PerformUnitAction starts, stops, restarts, reloads, enables or disables a systemd unit
+//
+// @Summary Perform an action on a systemd unit
+// @Description Runs systemctl start, stop, restart, reload, enable or disable for a unit and returns the resulting state. Requires root or passwordless sudo
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer <token>"
+// @Param unit path string true "Unit name (e.g. nginx or nginx.service)"
+// @Param action path string true "Action to perform" Enums(start, stop, restart, reload, enable, disable)
+// @Success 200 {object} systemd.UnitActionResult "Action performed successfully"
+// @Failure 400 {object} response.Response "Invalid unit name or action"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Permission denied"
+// @Failure 404 {object} response.Response "Unit not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Failure 501 {object} response.Response "systemd is not available"
+// @Router /services/{unit}/{action} [post]
+func (h *SystemdHandler) PerformUnitAction(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from context
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	if !ok {
+		response.Error(w, "Session not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Perform the action
+	result, err := h.systemdService.PerformAction(r.Context(), sessionID, r.PathValue("unit"), r.PathValue("action"))
+	if err != nil {
+		writeSystemdError(w, err, "Failed to perform unit action: ")
+		return
+	}
+
+	// Return the action result
+	response.JSON(w, result, http.StatusOK)
+}
+
+// writeSystemdError maps systemd errors to HTTP responses
+func writeSystemdError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, systemd.ErrSessionNotFound):
+		response.Error(w, "Session expired or not found", http.StatusUnauthorized)
+	case errors.Is(err, systemd.ErrInvalidUnitName),
+		errors.Is(err, systemd.ErrInvalidUnitType),
+		errors.Is(err, systemd.ErrInvalidAction):
+		response.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, systemd.ErrUnitNotFound):
+		response.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, systemd.ErrPermissionDenied):
+		response.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, systemd.ErrSystemdMissing):
+		response.Error(w, err.Error(), http.StatusNotImplemented)
+	default:
+		response.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,58 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrStreamingUnsupported is returned when the response writer cannot be flushed
+var ErrStreamingUnsupported = errors.New("streaming is not supported by the response writer")
+
+// EventStream writes Server-Sent Events to the client
+type EventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// NewEventStream prepares the response for Server-Sent Events and sends the headers
+func NewEventStream(w http.ResponseWriter) (*EventStream, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, ErrStreamingUnsupported
+	}
+
+	// Streams outlive the server write timeout, so lift the deadline for this response
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &EventStream{w: w, flusher: flusher}, nil
+}
+
+// Send writes a single event with a JSON encoded payload and flushes it to the client
+func (s *EventStream) Send(event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+
+	s.flusher.Flush()
+	return nil
+}
+
+// SendError writes an error event to the client
+func (s *EventStream) SendError(message string) error {
+	return s.Send("error", Response{Success: false, Error: message})
+}
@@ -3,6 +3,7 @@ package router
 import (
 	"net/http"
 	"remote-server-api/internal/domain/docker"
+	"remote-server-api/internal/domain/drift"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -12,8 +13,17 @@ import (
 
 	_ "remote-server-api/docs" // Import for swagger docs
 	"remote-server-api/internal/api/handlers"
+	"remote-server-api/internal/domain/accounts"
+	"remote-server-api/internal/domain/alerts"
 	"remote-server-api/internal/domain/auth"
+	"remote-server-api/internal/domain/cron"
+	"remote-server-api/internal/domain/firewall"
+	"remote-server-api/internal/domain/kernel"
+	"remote-server-api/internal/domain/logs"
+	"remote-server-api/internal/domain/metrics"
 	"remote-server-api/internal/domain/server"
+	"remote-server-api/internal/domain/snapshots"
+	"remote-server-api/internal/domain/systemd"
 )
 
 // New creates and configures a router with all application routes
@@ -21,6 +31,17 @@ func New(
 	authService auth.Service,
 	serverService server.Service,
 	dockerService docker.Service,
+	systemdService systemd.Service,
+	logsService logs.Service,
+	accountsService accounts.Service,
+	cronService cron.Service,
+	firewallService firewall.Service,
+	kernelService kernel.Service,
+	metricsService metrics.Service,
+	metricsToken string,
+	alertsService alerts.Service,
+	snapshotsService snapshots.Service,
+	driftService drift.Service,
 ) http.Handler {
 	r := chi.NewRouter()
 
@@ -29,14 +50,16 @@ func New(
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+
+	// Request timeout for regular endpoints; streaming endpoints are registered outside of it
+	timeout := middleware.Timeout(60 * time.Second)
 
 	// CORS configuration
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match", "If-None-Match", "If-Range", "Range"},
+		ExposedHeaders:   []string{"Link", "ETag", "Accept-Ranges", "Content-Disposition", "Content-Range"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -46,16 +69,27 @@ func New(
 	serverHandler := handlers.NewServerHandler(serverService)
 	dockerHandler := handlers.NewDockerHandler(dockerService)
 	fileSystemHandler := handlers.NewFileSystemHandler(serverService)
+	systemdHandler := handlers.NewSystemdHandler(systemdService)
+	logsHandler := handlers.NewLogsHandler(logsService)
+	accountsHandler := handlers.NewAccountsHandler(accountsService)
+	cronHandler := handlers.NewCronHandler(cronService)
+	firewallHandler := handlers.NewFirewallHandler(firewallService)
+	kernelHandler := handlers.NewKernelHandler(kernelService)
+	metricsHandler := handlers.NewMetricsHandler(metricsService)
+	alertsHandler := handlers.NewAlertsHandler(alertsService)
+	snapshotsHandler := handlers.NewSnapshotsHandler(snapshotsService)
+	driftHandler := handlers.NewDriftHandler(driftService)
 
 	// Authentication middleware
 	authMiddleware := handlers.NewAuthMiddleware(authService)
 
 	// Swagger documentation
 	// This serves the Swagger UI at /swagger/index.html
-	r.Get("/swagger/*", httpSwagger.WrapHandler)
+	r.With(timeout).Get("/swagger/*", httpSwagger.WrapHandler)
 
 	// Public routes
 	r.Group(func(r chi.Router) {
+		r.Use(timeout)
 		r.Post("/login", authHandler.Login)
 	})
 
@@ -65,29 +99,177 @@ func New(
 
 		// Server details routes
 		r.Route("/server-details", func(r chi.Router) {
-			r.Get("/", serverHandler.GetBasicDetails)
-			r.Get("/cpu-info", serverHandler.GetCPUInfo)
-			r.Get("/disk-usage", serverHandler.GetDiskUsage)
-			r.Get("/running-processes", serverHandler.GetRunningProcesses)
-			r.Get("/libraries", serverHandler.GetInstalledLibraries)
+			r.Group(func(r chi.Router) {
+				r.Use(timeout)
+				r.Get("/", serverHandler.GetBasicDetails)
+				r.Get("/facts", serverHandler.GetHostFacts)
+				r.Get("/capabilities", serverHandler.GetCapabilities)
+				r.Get("/cpu-info", serverHandler.GetCPUInfo)
+				r.Get("/cpu-usage", serverHandler.GetCPUUsage)
+				r.Get("/memory-usage", serverHandler.GetMemoryUsage)
+				r.Get("/disk-usage", serverHandler.GetDiskUsage)
+				r.Get("/storage", serverHandler.GetStorageInfo)
+				r.Get("/storage/block-devices", serverHandler.GetBlockDevices)
+				r.Get("/storage/lvm", serverHandler.GetLVMInfo)
+				r.Get("/storage/raid", serverHandler.GetRAIDInfo)
+				r.Get("/network", serverHandler.GetNetworkInfo)
+				r.Get("/listening-ports", serverHandler.GetListeningPorts)
+				r.Get("/running-processes", serverHandler.GetRunningProcesses)
+				r.Get("/processes/{pid}", serverHandler.GetProcessDetail)
+				r.Post("/processes/{pid}/signal", serverHandler.SignalProcess)
+				r.Post("/processes/{pid}/kill", serverHandler.KillProcess)
+				r.Post("/processes/{pid}/renice", serverHandler.ReniceProcess)
+				r.Get("/libraries", serverHandler.GetInstalledLibraries)
+				r.Get("/packages/updates", serverHandler.GetPendingUpdates)
+				r.Get("/security-audit", serverHandler.GetSecurityAudit)
+			})
+
+			// Streaming routes
+			r.Get("/cpu-usage/stream", serverHandler.StreamCPUUsage)
+			r.Post("/packages/{operation}", serverHandler.RunPackageOperation)
 		})
 
 		// Docker routes
 		r.Route("/docker", func(r chi.Router) {
+			r.Use(timeout)
 			r.Get("/containers", dockerHandler.GetContainerInfo)
 			r.Get("/container/{container_id}", dockerHandler.GetContainerDetail)
+			r.Get("/stats", dockerHandler.GetContainerStats)
+			r.Get("/states", dockerHandler.GetContainerStatuses)
 			r.Get("/images", dockerHandler.GetImages)
 			r.Get("/image/{image_id}", dockerHandler.GetImageDetail)
 			r.Post("/image/run", dockerHandler.RunContainer)
 			r.Delete("/image/{image_id}", dockerHandler.DeleteImage)
 		})
 
-		// Docker routes
+		// Systemd service routes
+		r.Route("/services", func(r chi.Router) {
+			r.Use(timeout)
+			r.Get("/", systemdHandler.ListUnits)
+			r.Get("/{unit}", systemdHandler.GetUnit)
+			r.Post("/{unit}/{action}", systemdHandler.PerformUnitAction)
+		})
+
+		// Log routes
+		r.Route("/logs", func(r chi.Router) {
+			r.Group(func(r chi.Router) {
+				r.Use(timeout)
+				r.Get("/journal", logsHandler.GetJournal)
+				r.Get("/file", logsHandler.GetLogFile)
+			})
+
+			// Streaming routes
+			r.Get("/journal/follow", logsHandler.FollowJournal)
+			r.Get("/file/follow", logsHandler.FollowLogFile)
+		})
+
+		// Account routes
+		r.Route("/accounts", func(r chi.Router) {
+			r.Use(timeout)
+			r.Get("/users", accountsHandler.ListUsers)
+			r.Get("/groups", accountsHandler.ListGroups)
+			r.Get("/sudoers", accountsHandler.GetSudoers)
+			r.Get("/authorized-keys", accountsHandler.ListAuthorizedKeys)
+			r.Get("/logins", accountsHandler.GetLoginHistory)
+		})
+
+		// Scheduled task routes
+		r.Route("/cron", func(r chi.Router) {
+			r.Use(timeout)
+			r.Get("/jobs", cronHandler.ListJobs)
+			r.Get("/crontabs/{user}", cronHandler.GetCrontab)
+			r.Post("/crontabs/{user}/entries", cronHandler.AddCrontabEntry)
+			r.Put("/crontabs/{user}/entries/{id}", cronHandler.UpdateCrontabEntry)
+			r.Delete("/crontabs/{user}/entries/{id}", cronHandler.DeleteCrontabEntry)
+		})
+
+		// Firewall routes
+		r.Route("/firewall", func(r chi.Router) {
+			r.Use(timeout)
+			r.Get("/rules", firewallHandler.GetRules)
+			r.Post("/rules", firewallHandler.AddRule)
+			r.Delete("/rules/{id}", firewallHandler.DeleteRule)
+			r.Get("/changes/{id}", firewallHandler.GetChange)
+			r.Post("/changes/{id}/confirm", firewallHandler.ConfirmChange)
+			r.Post("/changes/{id}/rollback", firewallHandler.RollbackChange)
+		})
+
+		// Kernel routes
+		r.Route("/kernel", func(r chi.Router) {
+			r.Use(timeout)
+			r.Get("/sysctl", kernelHandler.ListSysctl)
+			r.Post("/sysctl/diff", kernelHandler.DiffSysctl)
+			r.Put("/sysctl/{key}", kernelHandler.SetSysctl)
+			r.Get("/modules", kernelHandler.ListModules)
+			r.Get("/cmdline", kernelHandler.GetCommandLine)
+		})
+
+		// Alert routes
+		r.Route("/alerts", func(r chi.Router) {
+			r.Use(timeout)
+			r.Get("/", alertsHandler.ListAlerts)
+			r.Get("/rules", alertsHandler.ListRules)
+			r.Post("/rules", alertsHandler.CreateRule)
+			r.Delete("/rules/{id}", alertsHandler.DeleteRule)
+			r.Get("/webhooks", alertsHandler.ListWebhooks)
+			r.Post("/webhooks", alertsHandler.CreateWebhook)
+			r.Delete("/webhooks/{id}", alertsHandler.DeleteWebhook)
+			r.Post("/webhooks/{id}/test", alertsHandler.TestWebhook)
+			r.Get("/deliveries", alertsHandler.ListDeliveries)
+		})
+
+		// Snapshot routes
+		r.Route("/snapshots", func(r chi.Router) {
+			r.Use(timeout)
+			r.Get("/", snapshotsHandler.ListSnapshots)
+			r.Post("/", snapshotsHandler.CreateSnapshot)
+			r.Get("/diff", snapshotsHandler.DiffSnapshots)
+			r.Get("/{id}", snapshotsHandler.GetSnapshot)
+			r.Delete("/{id}", snapshotsHandler.DeleteSnapshot)
+		})
+
+		// Drift detection routes
+		r.Route("/drift", func(r chi.Router) {
+			r.Use(timeout)
+			r.Post("/check", driftHandler.CheckSpec)
+			r.Get("/specs", driftHandler.ListSpecs)
+			r.Post("/specs", driftHandler.CreateSpec)
+			r.Get("/specs/{id}", driftHandler.GetSpec)
+			r.Delete("/specs/{id}", driftHandler.DeleteSpec)
+			r.Post("/specs/{id}/check", driftHandler.CheckStoredSpec)
+			r.Get("/specs/{id}/reports", driftHandler.ListReports)
+		})
+
+		// File system routes
 		r.Route("/filesystem", func(r chi.Router) {
-			r.Get("/list", fileSystemHandler.ListFileSystem)
-			r.Get("/details", fileSystemHandler.GetFileDetails)
-			r.Get("/search", fileSystemHandler.SearchFiles)
+			r.Group(func(r chi.Router) {
+				r.Use(timeout)
+				r.Get("/list", fileSystemHandler.ListFileSystem)
+				r.Get("/details", fileSystemHandler.GetFileDetails)
+				r.Get("/search", fileSystemHandler.SearchFiles)
+				r.Get("/disk-usage", fileSystemHandler.AnalyzeDirectoryUsage)
+				r.Post("/disk-usage/analyses", fileSystemHandler.StartDirectoryAnalysis)
+				r.Get("/disk-usage/analyses/{id}", fileSystemHandler.GetDirectoryAnalysis)
+				r.Delete("/disk-usage/analyses/{id}", fileSystemHandler.CancelDirectoryAnalysis)
+			})
+
+			// Streaming routes
+			r.Get("/download", fileSystemHandler.DownloadFile)
+			r.Put("/upload", fileSystemHandler.UploadFile)
+		})
+	})
+
+	// Metrics routes
+	r.Route("/metrics", func(r chi.Router) {
+		r.Use(timeout)
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Get("/history", metricsHandler.GetHistory)
+			r.Get("/series", metricsHandler.ListSeries)
 		})
+
+		// Prometheus exposition, which also accepts the static metrics token
+		r.With(authMiddleware.AuthenticateWithToken(metricsToken)).Get("/hosts/{host}", metricsHandler.ScrapeHost)
 	})
 
 	return r
@@ -0,0 +1,100 @@
+package accounts
+
+import "time"
+
+// Password states derived from the shadow password field
+const (
+	PasswordSet      = "set"      // A password hash is set
+	PasswordLocked   = "locked"   // The hash is prefixed with "!", so password logins are disabled
+	PasswordDisabled = "disabled" // No hash ("*"), password logins were never possible
+	PasswordEmpty    = "empty"    // No password at all, logins need none
+	PasswordUnknown  = "unknown"  // /etc/shadow could not be read
+)
+
+// User represents a local account from the passwd database
+type User struct {
+	Name           string     `json:"name"`
+	UID            int        `json:"uid"`
+	GID            int        `json:"gid"`
+	Comment        string     `json:"comment"` // GECOS field
+	Home           string     `json:"home"`
+	Shell          string     `json:"shell"`
+	LoginShell     bool       `json:"login_shell"` // Whether the shell allows interactive logins
+	System         bool       `json:"system"`      // UID below UID_MIN of /etc/login.defs
+	PasswordStatus string     `json:"password_status"`
+	Locked         bool       `json:"locked"`
+	Expired        bool       `json:"expired"` // Account expiry date has passed
+	Sudo           bool       `json:"sudo"`    // User may run commands as root through sudo
+	Groups         []string   `json:"groups"`  // Primary group first
+	LastLogin      *time.Time `json:"last_login,omitempty"`
+	LastLoginFrom  string     `json:"last_login_from,omitempty"`
+	LastLoginTTY   string     `json:"last_login_tty,omitempty"`
+	AuthorizedKeys int        `json:"authorized_keys"` // Number of keys in ~/.ssh/authorized_keys
+}
+
+// UserFilter narrows down the user list
+type UserFilter struct {
+	IncludeSystem bool // Include system accounts below UID_MIN
+}
+
+// Group represents a group from the group database
+type Group struct {
+	Name         string   `json:"name"`
+	GID          int      `json:"gid"`
+	Members      []string `json:"members"`       // Supplementary members listed in the group entry
+	PrimaryUsers []string `json:"primary_users"` // Users with this group as their primary group
+	Sudo         bool     `json:"sudo"`          // Members may run commands as root through sudo
+}
+
+// SudoRule is a user specification from the sudoers files
+type SudoRule struct {
+	Source     string   `json:"source"`     // File the rule was read from
+	Principals []string `json:"principals"` // Users, %groups or aliases the rule applies to
+	Spec       string   `json:"spec"`       // Host, runas and command specification
+	NoPassword bool     `json:"no_password"`
+}
+
+// SudoAccess lists how a user obtains root privileges
+type SudoAccess struct {
+	User       string   `json:"user"`
+	Via        []string `json:"via"` // e.g. "user", "%sudo", "User_Alias ADMINS", "uid 0"
+	NoPassword bool     `json:"no_password"`
+}
+
+// SudoersReport summarizes the sudoers configuration
+type SudoersReport struct {
+	Readable bool         `json:"readable"` // False when the sudoers files could not be read and access was inferred from group membership
+	Rules    []SudoRule   `json:"rules"`
+	Users    []SudoAccess `json:"users"`
+}
+
+// AuthorizedKey is a public key that grants SSH access to a user
+type AuthorizedKey struct {
+	User        string   `json:"user"`
+	File        string   `json:"file"`
+	Line        int      `json:"line"`
+	Type        string   `json:"type"`
+	Bits        int      `json:"bits,omitempty"`
+	Fingerprint string   `json:"fingerprint"` // SHA256 fingerprint as printed by ssh-keygen -l
+	Comment     string   `json:"comment"`
+	Options     []string `json:"options"`
+	Error       string   `json:"error,omitempty"` // Set when the line could not be parsed
+}
+
+// LoginRecord is an entry of the login history
+type LoginRecord struct {
+	User     string     `json:"user"`
+	Terminal string     `json:"terminal"`
+	Host     string     `json:"host"`
+	LoginAt  time.Time  `json:"login_at"`
+	LogoutAt *time.Time `json:"logout_at,omitempty"`
+	Duration string     `json:"duration,omitempty"`
+	Status   string     `json:"status"` // "logged out", "still logged in", "crash", "down" or "gone - no logout"
+}
+
+// LoginHistory holds recent successful and failed logins
+type LoginHistory struct {
+	Logins           []LoginRecord `json:"logins"`
+	Failures         []LoginRecord `json:"failures"`
+	FailuresReadable bool          `json:"failures_readable"` // False when lastb could not read /var/log/btmp
+}
@@ -0,0 +1,73 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound = errors.New("session not found or expired")
+	ErrCommandFailed   = errors.New("command execution failed")
+	ErrInvalidQuery    = errors.New("invalid account query")
+	ErrUserNotFound    = errors.New("user not found")
+)
+
+// Bounds for the number of login records returned
+const (
+	DefaultLoginLimit = 50
+	MaxLoginLimit     = 1000
+)
+
+// userNamePattern matches the user names accepted by useradd and most directory services
+var userNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.][a-zA-Z0-9_.@-]*\$?$`)
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// RunCommand executes a command on the SSH session
+	RunCommand(ctx context.Context, sessionID string, command string) (string, error)
+}
+
+// Service defines the account inventory service
+type Service interface {
+	// ListUsers retrieves the local users with lock status, sudo access and last login
+	ListUsers(ctx context.Context, sessionID string, filter UserFilter) ([]User, error)
+
+	// ListGroups retrieves the groups with their supplementary and primary members
+	ListGroups(ctx context.Context, sessionID string) ([]Group, error)
+
+	// GetSudoers retrieves the sudoers rules and the users they grant root privileges to
+	GetSudoers(ctx context.Context, sessionID string) (*SudoersReport, error)
+
+	// ListAuthorizedKeys retrieves the SSH public keys authorized for all users, or for one user
+	ListAuthorizedKeys(ctx context.Context, sessionID string, user string) ([]AuthorizedKey, error)
+
+	// GetLoginHistory retrieves recent successful logins from last and failed logins from lastb
+	GetLoginHistory(ctx context.Context, sessionID string, limit int) (*LoginHistory, error)
+}
+
+type service struct {
+	sessionRepo SessionRepository
+}
+
+// NewService creates a new account inventory service
+func NewService(sessionRepo SessionRepository) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// IsValidUserName reports whether a name can belong to a local user
+func IsValidUserName(name string) bool {
+	return userNamePattern.MatchString(name) && len(name) <= 256
+}
+
+// validateUserName rejects names that cannot belong to a local user
+func validateUserName(name string) error {
+	if !IsValidUserName(name) {
+		return fmt.Errorf("%w: invalid user name %q", ErrInvalidQuery, name)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package accounts
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"remote-server-api/pkg/shell"
+)
+
+// authorizedKeysScript prints the authorized_keys files of every user, or of the user given as first
+// argument, in sections named "keys <user> <path>". It is run through passwordless sudo when available
+// so that the files of other users can be read. Key lines are prefixed with "|" so that lines of the
+// user-writable files cannot be taken for section headers.
+const authorizedKeysScript = `{ getent passwd 2>/dev/null || cat /etc/passwd; } | while IFS=: read -r n _ _ _ _ h _; do
+  [ -z "$1" ] || [ "$n" = "$1" ] || continue
+  echo "### user $n"
+  for k in "$h/.ssh/authorized_keys" "$h/.ssh/authorized_keys2"; do
+    [ -f "$k" ] && { echo "### keys $n $k"; sed 's/^/|/' "$k"; }
+  done
+done
+true`
+
+// ListAuthorizedKeys implements the Service interface
+func (s *service) ListAuthorizedKeys(ctx context.Context, sessionID string, user string) ([]AuthorizedKey, error) {
+	if user != "" {
+		if err := validateUserName(user); err != nil {
+			return nil, err
+		}
+	}
+
+	command := fmt.Sprintf("%s sh -c %s sh %s", shell.SudoIfAvailable, shell.Quote(authorizedKeysScript), shell.Quote(user))
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, command)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	if _, ok := sections["user "+user]; user != "" && !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, user)
+	}
+
+	keys := []AuthorizedKey{}
+	for _, name := range shell.SortedSectionNames(sections, "keys ") {
+		owner, file, _ := strings.Cut(strings.TrimPrefix(name, "keys "), " ")
+		keys = append(keys, parseAuthorizedKeys(shell.TrimLinePrefix(sections[name]), owner, file)...)
+	}
+
+	return keys, nil
+}
+
+// parseAuthorizedKeys parses an authorized_keys file, reporting lines that are not valid keys with
+// an error instead of dropping them
+func parseAuthorizedKeys(content string, user string, file string) []AuthorizedKey {
+	var keys []AuthorizedKey
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key := AuthorizedKey{
+			User:    user,
+			File:    file,
+			Line:    i + 1,
+			Options: []string{},
+		}
+
+		publicKey, comment, options, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			key.Error = err.Error()
+			keys = append(keys, key)
+			continue
+		}
+
+		key.Type = publicKey.Type()
+		key.Fingerprint = ssh.FingerprintSHA256(publicKey)
+		key.Comment = comment
+		if options != nil {
+			key.Options = options
+		}
+		if cryptoKey, ok := publicKey.(ssh.CryptoPublicKey); ok {
+			if rsaKey, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey); ok {
+				key.Bits = rsaKey.N.BitLen()
+			}
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,104 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// loginHistoryScript prints the most recent logins from wtmp and failed logins from btmp with ISO
+// timestamps and numeric addresses. btmp is only readable by root, so lastb runs through passwordless
+// sudo when available. The placeholder receives the number of records.
+const loginHistoryScript = `echo '### last'
+last --time-format iso -w -i -n %[1]d 2>/dev/null
+echo '### lastb'
+` + shell.SudoIfAvailable + ` lastb --time-format iso -w -i -n %[1]d 2>/dev/null || echo '### lastb-denied'
+true`
+
+// GetLoginHistory implements the Service interface
+func (s *service) GetLoginHistory(ctx context.Context, sessionID string, limit int) (*LoginHistory, error) {
+	if limit == 0 {
+		limit = DefaultLoginLimit
+	}
+	if limit < 1 || limit > MaxLoginLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxLoginLimit)
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(loginHistoryScript, limit))
+	if err != nil {
+		return nil, err
+	}
+	sections := shell.ParseSections(output)
+
+	history := &LoginHistory{
+		Logins:   parseLastOutput(sections["last"]),
+		Failures: parseLastOutput(sections["lastb"]),
+	}
+	_, denied := sections["lastb-denied"]
+	history.FailuresReadable = !denied
+
+	return history, nil
+}
+
+// parseLastOutput parses last and lastb lines in ISO time format, e.g.
+// "alice pts/0 10.0.0.5 2024-10-14T10:00:00+00:00 - 2024-10-14T11:02:00+00:00 (01:02)".
+// Terminals may contain spaces ("system boot"), so the columns are located from the login time.
+func parseLastOutput(output string) []LoginRecord {
+	records := []LoginRecord{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 1 && fields[1] == "begins" {
+			// "wtmp begins <time>" trailer
+			continue
+		}
+
+		start := -1
+		var loginAt time.Time
+		for i := 2; i < len(fields); i++ {
+			if t, err := time.Parse(time.RFC3339, fields[i]); err == nil {
+				start, loginAt = i, t
+				break
+			}
+		}
+		if start < 0 {
+			continue
+		}
+
+		record := LoginRecord{
+			User:     fields[0],
+			Terminal: strings.Join(fields[1:start-1], " "),
+			Host:     fields[start-1],
+			LoginAt:  loginAt.UTC(),
+		}
+		if record.Host == "0.0.0.0" {
+			record.Host = ""
+		}
+
+		rest := fields[start+1:]
+		if len(rest) > 0 && strings.HasPrefix(rest[len(rest)-1], "(") {
+			record.Duration = strings.Trim(rest[len(rest)-1], "()")
+			rest = rest[:len(rest)-1]
+		}
+
+		switch {
+		case len(rest) >= 2 && rest[0] == "-":
+			if logoutAt, err := time.Parse(time.RFC3339, rest[1]); err == nil {
+				logoutAt = logoutAt.UTC()
+				record.LogoutAt = &logoutAt
+				record.Status = "logged out"
+			} else {
+				record.Status = strings.Join(rest[1:], " ")
+			}
+		case len(rest) > 0:
+			record.Status = strings.Join(rest, " ")
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
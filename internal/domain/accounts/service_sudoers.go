@@ -0,0 +1,237 @@
+package accounts
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// defaultSudoGroups are the groups distributions grant sudo access to by default. They are used to
+// infer sudo access when the sudoers files cannot be read.
+var defaultSudoGroups = map[string]bool{
+	"sudo":  true,
+	"wheel": true,
+	"admin": true,
+}
+
+// sudoersSectionPrefix prefixes the inventory sections holding a sudoers file
+const sudoersSectionPrefix = "sudoers:"
+
+// buildSudoersReport parses the sudoers files and resolves the users the rules apply to. Users with
+// UID 0 are root regardless of sudoers, and without /etc/sudoers sudo grants nothing. When the files
+// cannot be read, members of the default sudo groups are reported instead.
+func buildSudoersReport(sections map[string]string, users []User, groups []Group) *SudoersReport {
+	report := &SudoersReport{
+		Rules: []SudoRule{},
+		Users: []SudoAccess{},
+	}
+
+	sources := shell.SortedSectionNames(sections, sudoersSectionPrefix)
+	_, missing := sections["sudoers-missing"]
+	report.Readable = len(sources) > 0 || missing
+
+	aliases := make(map[string][]string)
+	for _, source := range sources {
+		rules := parseSudoers(sections[source], strings.TrimPrefix(source, sudoersSectionPrefix), aliases)
+		report.Rules = append(report.Rules, rules...)
+	}
+
+	access := make(map[string]*SudoAccess)
+	grant := func(user string, via string, noPassword bool) {
+		entry, ok := access[user]
+		if !ok {
+			entry = &SudoAccess{User: user, Via: []string{}}
+			access[user] = entry
+		}
+		if !containsString(entry.Via, via) {
+			entry.Via = append(entry.Via, via)
+		}
+		entry.NoPassword = entry.NoPassword || noPassword
+	}
+
+	for _, user := range users {
+		if user.UID == 0 {
+			grant(user.Name, "uid 0", true)
+		}
+	}
+
+	if report.Readable {
+		for _, rule := range report.Rules {
+			for _, principal := range rule.Principals {
+				for _, user := range resolveSudoPrincipal(principal, aliases, users, groups, nil) {
+					grant(user, principal, rule.NoPassword)
+				}
+			}
+		}
+	} else {
+		for _, group := range groups {
+			if !defaultSudoGroups[group.Name] {
+				continue
+			}
+			for _, user := range resolveSudoPrincipal("%"+group.Name, aliases, users, groups, nil) {
+				grant(user, "%"+group.Name, false)
+			}
+		}
+	}
+
+	for _, entry := range access {
+		report.Users = append(report.Users, *entry)
+	}
+	sort.Slice(report.Users, func(i, j int) bool { return report.Users[i].User < report.Users[j].User })
+
+	return report
+}
+
+// parseSudoers extracts the user specifications of a sudoers file and records its User_Alias
+// definitions. Defaults, other aliases and include directives are skipped; the files in
+// /etc/sudoers.d are read separately.
+func parseSudoers(content string, source string, aliases map[string][]string) []SudoRule {
+	var rules []SudoRule
+
+	for _, line := range joinContinuationLines(content) {
+		line = stripSudoersComment(line)
+		if line == "" {
+			continue
+		}
+
+		keyword := strings.Fields(line)[0]
+		switch {
+		case keyword == "User_Alias":
+			// User_Alias NAME = user, %group : OTHER = user
+			for _, definition := range strings.Split(strings.TrimSpace(strings.TrimPrefix(line, keyword)), ":") {
+				name, members, found := strings.Cut(definition, "=")
+				if !found {
+					continue
+				}
+				aliases[strings.TrimSpace(name)] = splitSudoList(members)
+			}
+			continue
+		case strings.HasPrefix(keyword, "Defaults"),
+			keyword == "Cmnd_Alias", keyword == "Cmd_Alias", keyword == "Host_Alias", keyword == "Runas_Alias",
+			strings.HasPrefix(keyword, "#include"), strings.HasPrefix(keyword, "@include"):
+			continue
+		}
+
+		// user_list host_list = (runas) [tags:] command_list
+		equals := strings.Index(line, "=")
+		if equals < 0 {
+			continue
+		}
+		fields := strings.Fields(line[:equals])
+		if len(fields) < 2 {
+			continue
+		}
+		hosts := fields[len(fields)-1]
+		principals := splitSudoList(strings.Join(fields[:len(fields)-1], " "))
+		spec := hosts + "=" + strings.Join(strings.Fields(line[equals+1:]), " ")
+
+		rules = append(rules, SudoRule{
+			Source:     source,
+			Principals: principals,
+			Spec:       spec,
+			NoPassword: strings.Contains(spec, "NOPASSWD:"),
+		})
+	}
+
+	return rules
+}
+
+// resolveSudoPrincipal expands a user, %group, #uid, User_Alias or ALL into user names. Negated
+// entries grant nothing and are ignored.
+func resolveSudoPrincipal(principal string, aliases map[string][]string, users []User, groups []Group, visited map[string]bool) []string {
+	var names []string
+
+	switch {
+	case strings.HasPrefix(principal, "!"):
+	case principal == "ALL":
+		for _, user := range users {
+			names = append(names, user.Name)
+		}
+	case strings.HasPrefix(principal, "%"):
+		name := strings.TrimPrefix(strings.TrimPrefix(principal, "%"), ":")
+		for _, group := range groups {
+			if group.Name != name && "#"+strconv.Itoa(group.GID) != name {
+				continue
+			}
+			names = append(names, group.Members...)
+			names = append(names, group.PrimaryUsers...)
+		}
+	case strings.HasPrefix(principal, "#"):
+		for _, user := range users {
+			if "#"+strconv.Itoa(user.UID) == principal {
+				names = append(names, user.Name)
+			}
+		}
+	case aliases[principal] != nil:
+		if visited == nil {
+			visited = make(map[string]bool)
+		}
+		if visited[principal] {
+			return nil
+		}
+		visited[principal] = true
+		for _, member := range aliases[principal] {
+			names = append(names, resolveSudoPrincipal(member, aliases, users, groups, visited)...)
+		}
+	default:
+		names = append(names, principal)
+	}
+
+	return names
+}
+
+// joinContinuationLines splits a sudoers file into logical lines, joining lines ending with a backslash
+func joinContinuationLines(content string) []string {
+	var lines []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(content, "\n") {
+		if trimmed := strings.TrimRight(line, " \t"); strings.HasSuffix(trimmed, "\\") {
+			current.WriteString(strings.TrimSuffix(trimmed, "\\"))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(line)
+		lines = append(lines, current.String())
+		current.Reset()
+	}
+	if current.Len() > 0 {
+		lines = append(lines, current.String())
+	}
+
+	return lines
+}
+
+// stripSudoersComment removes comments from a sudoers line. A "#" starts a comment unless it begins
+// an include directive or a numeric user or group ID.
+func stripSudoersComment(line string) string {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#include") {
+		return line
+	}
+
+	for i := 0; i < len(line); i++ {
+		if line[i] != '#' {
+			continue
+		}
+		if i+1 < len(line) && line[i+1] >= '0' && line[i+1] <= '9' {
+			continue
+		}
+		return strings.TrimSpace(line[:i])
+	}
+
+	return line
+}
+
+// splitSudoList splits a comma-separated sudoers list
+func splitSudoList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
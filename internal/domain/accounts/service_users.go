@@ -0,0 +1,375 @@
+package accounts
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// Default UID range of regular users when /etc/login.defs does not set one
+const (
+	defaultUIDMin = 1000
+	defaultUIDMax = 60000
+)
+
+// privilegedInventoryScript prints the files only root can read: the shadow database, the sudoers
+// files that sudo would load and the number of authorized SSH keys of every user. Files in
+// /etc/sudoers.d whose names contain a dot or end with "~" are skipped, as sudo does.
+const privilegedInventoryScript = `if [ -r /etc/shadow ]; then echo '### shadow'; cat /etc/shadow; fi
+[ -e /etc/sudoers ] || echo '### sudoers-missing'
+if [ -r /etc/sudoers ]; then
+  for f in /etc/sudoers /etc/sudoers.d/*; do
+    case "${f#/etc/sudoers.d/}" in *.*|*~) [ "$f" = /etc/sudoers ] || continue ;; esac
+    [ -f "$f" ] && { echo "### sudoers:$f"; cat "$f"; }
+  done
+fi
+echo '### key-counts'
+{ getent passwd 2>/dev/null || cat /etc/passwd; } | while IFS=: read -r n _ _ _ _ h _; do
+  for k in "$h/.ssh/authorized_keys" "$h/.ssh/authorized_keys2"; do
+    [ -f "$k" ] && printf '%s\t%s\n' "$n" "$(grep -cvE '^[[:space:]]*(#|$)' "$k")"
+  done
+done
+true`
+
+// inventoryScript prints the passwd and group databases, the UID range of regular users and the
+// last logins, followed by the output of privilegedInventoryScript run through passwordless sudo
+// when available
+var inventoryScript = `echo '### passwd'
+getent passwd 2>/dev/null || cat /etc/passwd
+echo '### group'
+getent group 2>/dev/null || cat /etc/group
+echo '### login-defs'
+grep -E '^[[:space:]]*UID_(MIN|MAX)[[:space:]]' /etc/login.defs 2>/dev/null
+echo '### lastlog'
+lastlog 2>/dev/null || lastlog2 2>/dev/null
+` + shell.SudoIfAvailable + ` sh -c ` + shell.Quote(privilegedInventoryScript) + `
+true`
+
+// nonLoginShells are shells that refuse interactive logins
+var nonLoginShells = []string{"nologin", "false", "sync", "shutdown", "halt"}
+
+// inventory holds the parsed account databases of a server
+type inventory struct {
+	users    []User
+	groups   []Group
+	sections map[string]string
+}
+
+// ListUsers implements the Service interface
+func (s *service) ListUsers(ctx context.Context, sessionID string, filter UserFilter) ([]User, error) {
+	inv, err := s.loadInventory(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []User{}
+	for _, user := range inv.users {
+		if user.System && !filter.IncludeSystem {
+			continue
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// ListGroups implements the Service interface
+func (s *service) ListGroups(ctx context.Context, sessionID string) ([]Group, error) {
+	inv, err := s.loadInventory(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return inv.groups, nil
+}
+
+// GetSudoers implements the Service interface
+func (s *service) GetSudoers(ctx context.Context, sessionID string) (*SudoersReport, error) {
+	inv, err := s.loadInventory(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildSudoersReport(inv.sections, inv.users, inv.groups), nil
+}
+
+// loadInventory reads the account databases and links users, groups, sudo access and last logins
+func (s *service) loadInventory(ctx context.Context, sessionID string) (*inventory, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, inventoryScript)
+	if err != nil {
+		return nil, err
+	}
+	sections := shell.ParseSections(output)
+
+	uidMin, uidMax := parseUIDRange(sections["login-defs"])
+	users := parsePasswd(sections["passwd"], uidMin, uidMax)
+	groups := parseGroups(sections["group"])
+
+	// Group memberships, primary group first
+	groupNames := make(map[int]string)
+	for i := range groups {
+		groupNames[groups[i].GID] = groups[i].Name
+	}
+	for i := range users {
+		if name, ok := groupNames[users[i].GID]; ok {
+			users[i].Groups = append(users[i].Groups, name)
+		}
+	}
+	for i := range groups {
+		for j := range users {
+			if users[j].GID == groups[i].GID {
+				groups[i].PrimaryUsers = append(groups[i].PrimaryUsers, users[j].Name)
+			} else if containsString(groups[i].Members, users[j].Name) {
+				users[j].Groups = append(users[j].Groups, groups[i].Name)
+			}
+		}
+	}
+
+	// Password and expiry state
+	shadow, shadowReadable := sections["shadow"]
+	applyShadow(users, shadow, shadowReadable)
+
+	// Sudo access
+	report := buildSudoersReport(sections, users, groups)
+	for _, access := range report.Users {
+		for i := range users {
+			if users[i].Name == access.User {
+				users[i].Sudo = true
+			}
+		}
+	}
+	for _, rule := range report.Rules {
+		for _, principal := range rule.Principals {
+			for i := range groups {
+				if principal == "%"+groups[i].Name {
+					groups[i].Sudo = true
+				}
+			}
+		}
+	}
+	if !report.Readable {
+		for i := range groups {
+			groups[i].Sudo = defaultSudoGroups[groups[i].Name]
+		}
+	}
+
+	// Last logins and authorized key counts
+	lastLogins := parseLastlog(sections["lastlog"])
+	keyCounts := make(map[string]int)
+	for _, line := range strings.Split(sections["key-counts"], "\n") {
+		name, count, found := strings.Cut(line, "\t")
+		if n, err := strconv.Atoi(strings.TrimSpace(count)); found && err == nil {
+			keyCounts[name] += n
+		}
+	}
+	for i := range users {
+		if login, ok := lastLogins[users[i].Name]; ok {
+			loginAt := login.LoginAt
+			users[i].LastLogin = &loginAt
+			users[i].LastLoginFrom = login.Host
+			users[i].LastLoginTTY = login.Terminal
+		}
+		users[i].AuthorizedKeys = keyCounts[users[i].Name]
+	}
+
+	return &inventory{users: users, groups: groups, sections: sections}, nil
+}
+
+// parseUIDRange reads UID_MIN and UID_MAX from /etc/login.defs lines
+func parseUIDRange(output string) (int, int) {
+	uidMin, uidMax := defaultUIDMin, defaultUIDMax
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		value, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "UID_MIN":
+			uidMin = value
+		case "UID_MAX":
+			uidMax = value
+		}
+	}
+	return uidMin, uidMax
+}
+
+// parsePasswd parses passwd entries "name:x:uid:gid:gecos:home:shell". Accounts outside the regular
+// UID range, except root, are system accounts.
+func parsePasswd(output string, uidMin int, uidMax int) []User {
+	users := []User{}
+	seen := make(map[string]bool)
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) < 7 || fields[0] == "" || seen[fields[0]] {
+			continue
+		}
+		uid, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+		seen[fields[0]] = true
+
+		user := User{
+			Name:           fields[0],
+			UID:            uid,
+			Comment:        fields[4],
+			Home:           fields[5],
+			Shell:          fields[6],
+			LoginShell:     true,
+			System:         uid != 0 && (uid < uidMin || uid > uidMax),
+			PasswordStatus: PasswordUnknown,
+			Groups:         []string{},
+		}
+		user.GID, _ = strconv.Atoi(fields[3])
+		for _, name := range nonLoginShells {
+			if strings.HasSuffix(user.Shell, "/"+name) {
+				user.LoginShell = false
+			}
+		}
+
+		users = append(users, user)
+	}
+
+	return users
+}
+
+// parseGroups parses group entries "name:x:gid:member,member"
+func parseGroups(output string) []Group {
+	groups := []Group{}
+	seen := make(map[string]bool)
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) < 4 || fields[0] == "" || seen[fields[0]] {
+			continue
+		}
+		gid, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+		seen[fields[0]] = true
+
+		group := Group{
+			Name:         fields[0],
+			GID:          gid,
+			Members:      []string{},
+			PrimaryUsers: []string{},
+		}
+		for _, member := range strings.Split(fields[3], ",") {
+			if member = strings.TrimSpace(member); member != "" {
+				group.Members = append(group.Members, member)
+			}
+		}
+
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].GID < groups[j].GID })
+	return groups
+}
+
+// applyShadow sets the password state and expiry from shadow entries
+// "name:hash:lastchange:min:max:warn:inactive:expire:reserved"
+func applyShadow(users []User, shadow string, readable bool) {
+	if !readable {
+		return
+	}
+
+	entries := make(map[string][]string)
+	for _, line := range strings.Split(shadow, "\n") {
+		if fields := strings.Split(line, ":"); len(fields) >= 8 {
+			entries[fields[0]] = fields
+		}
+	}
+
+	today := time.Now().Unix() / 86400
+	for i := range users {
+		fields, ok := entries[users[i].Name]
+		if !ok {
+			continue
+		}
+
+		hash := fields[1]
+		switch {
+		case hash == "":
+			users[i].PasswordStatus = PasswordEmpty
+		case strings.HasPrefix(hash, "!"):
+			users[i].PasswordStatus = PasswordLocked
+			users[i].Locked = true
+		case strings.HasPrefix(hash, "*"):
+			users[i].PasswordStatus = PasswordDisabled
+		default:
+			users[i].PasswordStatus = PasswordSet
+		}
+
+		if expire, err := strconv.ParseInt(fields[7], 10, 64); err == nil && expire <= today {
+			users[i].Expired = true
+		}
+	}
+}
+
+// parseLastlog parses lastlog output. The columns are padded to the width of the header, and users
+// who never logged in are reported as "**Never logged in**".
+func parseLastlog(output string) map[string]LoginRecord {
+	logins := make(map[string]LoginRecord)
+
+	lines := strings.Split(output, "\n")
+	if len(lines) == 0 {
+		return logins
+	}
+	header := lines[0]
+	portColumn := strings.Index(header, "Port")
+	fromColumn := strings.Index(header, "From")
+	latestColumn := strings.Index(header, "Latest")
+	if portColumn < 0 || fromColumn < portColumn || latestColumn < fromColumn {
+		return logins
+	}
+
+	for _, line := range lines[1:] {
+		if len(line) <= latestColumn || strings.Contains(line, "**Never logged in**") {
+			continue
+		}
+
+		// Long user names push the remaining columns to the right
+		name := strings.Fields(line)[0]
+		shift := max(len(name)-portColumn+1, 0)
+		if len(line) <= latestColumn+shift {
+			continue
+		}
+
+		latest := strings.Join(strings.Fields(line[latestColumn+shift:]), " ")
+		loginAt, err := time.Parse("Mon Jan 2 15:04:05 -0700 2006", latest)
+		if err != nil {
+			continue
+		}
+
+		loginAt = loginAt.UTC()
+		logins[name] = LoginRecord{
+			User:     name,
+			Terminal: strings.TrimSpace(line[portColumn+shift : fromColumn+shift]),
+			Host:     strings.TrimSpace(line[fromColumn+shift : latestColumn+shift]),
+			LoginAt:  loginAt,
+		}
+	}
+
+	return logins
+}
+
+// containsString reports whether the list contains the value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
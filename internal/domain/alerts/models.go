@@ -0,0 +1,109 @@
+package alerts
+
+import "time"
+
+// Alert severities
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Alert states
+const (
+	StatePending = "pending" // The condition holds, for less than the duration of the rule
+	StateFiring  = "firing"
+)
+
+// Notification statuses
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+	StatusTest     = "test" // Sent when testing a webhook
+)
+
+// RuleSpec describes an alert rule to create
+type RuleSpec struct {
+	Name      string            `json:"name"`
+	Metric    string            `json:"metric"`             // Collected metric, e.g. disk.used_percent
+	Labels    map[string]string `json:"labels,omitempty"`   // Only series with these label values, e.g. {"mount": "/"}
+	Operator  string            `json:"operator"`           // >, >=, <, <=, == or !=
+	Threshold float64           `json:"threshold"`          // Value the metric is compared with
+	PerCPU    bool              `json:"per_cpu,omitempty"`  // Multiply the threshold by the number of CPUs of the host
+	For       string            `json:"for,omitempty"`      // How long the condition must hold before the alert fires, e.g. 5m (default 0)
+	Severity  string            `json:"severity,omitempty"` // info, warning or critical (default warning)
+}
+
+// Rule is an alert rule evaluated against the metrics collected from a host
+type Rule struct {
+	ID string `json:"id"`
+	RuleSpec
+	Host      string    `json:"host"`
+	User      string    `json:"user"` // SSH user whose collected metrics the rule is evaluated against
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Alert is a series of a host for which the condition of a rule holds
+type Alert struct {
+	RuleID    string            `json:"rule_id"`
+	RuleName  string            `json:"rule_name"`
+	Severity  string            `json:"severity"`
+	Host      string            `json:"host"`
+	Metric    string            `json:"metric"`
+	Labels    map[string]string `json:"labels,omitempty"` // Labels of the series
+	State     string            `json:"state"`            // pending or firing
+	Value     float64           `json:"value"`            // Latest value of the series
+	Operator  string            `json:"operator"`
+	Threshold float64           `json:"threshold"` // Threshold in effect, multiplied by the CPUs for per-CPU rules
+	ActiveAt  time.Time         `json:"active_at"` // Collection at which the condition started to hold
+	FiredAt   *time.Time        `json:"fired_at,omitempty"`
+}
+
+// Notification is sent to the webhooks of a host when an alert fires or resolves. It is the default
+// request body and the data of webhook templates.
+type Notification struct {
+	Status    string            `json:"status"` // firing, resolved or test
+	RuleID    string            `json:"rule_id"`
+	RuleName  string            `json:"rule_name"`
+	Severity  string            `json:"severity"`
+	Host      string            `json:"host"`
+	Metric    string            `json:"metric"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Value     float64           `json:"value"` // Latest value of the series
+	Operator  string            `json:"operator"`
+	Threshold float64           `json:"threshold"`
+	Summary   string            `json:"summary"` // One-line description, e.g. for chat messages
+	StartsAt  time.Time         `json:"starts_at"`
+	EndsAt    *time.Time        `json:"ends_at,omitempty"` // Resolved notifications only
+}
+
+// WebhookSpec describes a webhook to create
+type WebhookSpec struct {
+	Name    string            `json:"name"`
+	URL     string            `json:"url"`               // http or https URL the notifications are posted to
+	Headers map[string]string `json:"headers,omitempty"` // Extra request headers, e.g. Authorization
+	// Template is a Go text/template rendering the JSON request body from the notification, e.g.
+	// {"text": {{json .Summary}}}. The json function encodes a value as JSON. Empty to post the
+	// notification as JSON.
+	Template string `json:"template,omitempty"`
+}
+
+// Webhook receives the notifications of the alerts of a host
+type Webhook struct {
+	ID string `json:"id"`
+	WebhookSpec
+	Host      string    `json:"host"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Delivery is the outcome of posting a notification to a webhook
+type Delivery struct {
+	WebhookID    string       `json:"webhook_id"`
+	WebhookName  string       `json:"webhook_name"`
+	Notification Notification `json:"notification"`
+	Delivered    bool         `json:"delivered"`
+	Attempts     int          `json:"attempts"`
+	StatusCode   int          `json:"status_code,omitempty"` // Status of the last response
+	Error        string       `json:"error,omitempty"`
+	SentAt       time.Time    `json:"sent_at"` // Time of the last attempt
+}
@@ -0,0 +1,320 @@
+package alerts
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"remote-server-api/internal/domain/auth"
+	"remote-server-api/internal/domain/metrics"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound = errors.New("session not found or expired")
+	ErrInvalidRule     = errors.New("invalid alert rule")
+	ErrRuleNotFound    = errors.New("alert rule not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+// operators lists the comparisons a rule may use
+var operators = []string{">", ">=", "<", "<=", "==", "!="}
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// GetSession retrieves an SSH session by ID
+	GetSession(ctx context.Context, sessionID string) (*auth.Session, error)
+}
+
+// HTTPClient sends webhook requests, e.g. an *http.Client
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Service defines the alerting service. Rules and webhooks belong to the host of the session that
+// created them and are kept in memory. A rule is evaluated against the metrics collected as the SSH
+// user that created it.
+type Service interface {
+	metrics.Observer
+
+	// ListRules lists the alert rules of the session's host
+	ListRules(ctx context.Context, sessionID string) ([]Rule, error)
+
+	// CreateRule adds an alert rule to the session's host
+	CreateRule(ctx context.Context, sessionID string, spec RuleSpec) (*Rule, error)
+
+	// DeleteRule removes an alert rule of the session's host together with its alerts
+	DeleteRule(ctx context.Context, sessionID string, id string) (*Rule, error)
+
+	// ListWebhooks lists the webhooks of the session's host
+	ListWebhooks(ctx context.Context, sessionID string) ([]Webhook, error)
+
+	// CreateWebhook adds a webhook receiving the notifications of the session's host
+	CreateWebhook(ctx context.Context, sessionID string, spec WebhookSpec) (*Webhook, error)
+
+	// DeleteWebhook removes a webhook of the session's host
+	DeleteWebhook(ctx context.Context, sessionID string, id string) (*Webhook, error)
+
+	// TestWebhook posts a test notification to a webhook and returns the outcome
+	TestWebhook(ctx context.Context, sessionID string, id string) (*Delivery, error)
+
+	// ListAlerts lists the pending and firing alerts of the session's host
+	ListAlerts(ctx context.Context, sessionID string) ([]Alert, error)
+
+	// ListDeliveries lists the latest notifications sent for the session's host, newest first
+	ListDeliveries(ctx context.Context, sessionID string) ([]Delivery, error)
+}
+
+type service struct {
+	sessionRepo SessionRepository
+	client      HTTPClient
+
+	mu         sync.Mutex
+	rules      map[string]*Rule
+	webhooks   map[string]*Webhook
+	alerts     map[string]*Alert     // Rule ID and series labels
+	deliveries map[string][]Delivery // Host, newest first
+
+	// delivering serializes the deliveries of a host, so that receivers get the notifications in order
+	delivering sync.Map
+}
+
+// NewService creates a new alerting service posting notifications with the given client
+func NewService(sessionRepo SessionRepository, client HTTPClient) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+		client:      client,
+		rules:       make(map[string]*Rule),
+		webhooks:    make(map[string]*Webhook),
+		alerts:      make(map[string]*Alert),
+		deliveries:  make(map[string][]Delivery),
+	}
+}
+
+// ListRules implements the Service interface
+func (s *service) ListRules(ctx context.Context, sessionID string) ([]Rule, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules := []Rule{}
+	for _, rule := range s.rules {
+		if rule.Host == host {
+			rules = append(rules, *rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].CreatedAt.Before(rules[j].CreatedAt) })
+	return rules, nil
+}
+
+// CreateRule implements the Service interface
+func (s *service) CreateRule(ctx context.Context, sessionID string, spec RuleSpec) (*Rule, error) {
+	session, err := s.session(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRule(&spec); err != nil {
+		return nil, err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	rule := &Rule{ID: id, RuleSpec: spec, Host: session.Host, User: session.Username, CreatedAt: time.Now().UTC()}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules[id] = rule
+	return rule, nil
+}
+
+// DeleteRule implements the Service interface
+func (s *service) DeleteRule(ctx context.Context, sessionID string, id string) (*Rule, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.rules[id]
+	if !ok || rule.Host != host {
+		return nil, fmt.Errorf("%w: %s", ErrRuleNotFound, id)
+	}
+	delete(s.rules, id)
+	for key, alert := range s.alerts {
+		if alert.RuleID == id {
+			delete(s.alerts, key)
+		}
+	}
+	return rule, nil
+}
+
+// ListWebhooks implements the Service interface
+func (s *service) ListWebhooks(ctx context.Context, sessionID string) ([]Webhook, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hostWebhooksLocked(host), nil
+}
+
+// CreateWebhook implements the Service interface
+func (s *service) CreateWebhook(ctx context.Context, sessionID string, spec WebhookSpec) (*Webhook, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateWebhook(spec); err != nil {
+		return nil, err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	webhook := &Webhook{ID: id, WebhookSpec: spec, Host: host, CreatedAt: time.Now().UTC()}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.webhooks[id] = webhook
+	return webhook, nil
+}
+
+// DeleteWebhook implements the Service interface
+func (s *service) DeleteWebhook(ctx context.Context, sessionID string, id string) (*Webhook, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook, ok := s.webhooks[id]
+	if !ok || webhook.Host != host {
+		return nil, fmt.Errorf("%w: %s", ErrWebhookNotFound, id)
+	}
+	delete(s.webhooks, id)
+	return webhook, nil
+}
+
+// ListAlerts implements the Service interface
+func (s *service) ListAlerts(ctx context.Context, sessionID string) ([]Alert, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alerts := []Alert{}
+	for _, alert := range s.alerts {
+		if alert.Host == host {
+			alerts = append(alerts, *alert)
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if !alerts[i].ActiveAt.Equal(alerts[j].ActiveAt) {
+			return alerts[i].ActiveAt.Before(alerts[j].ActiveAt)
+		}
+		return labelKey(alerts[i].RuleID, alerts[i].Labels) < labelKey(alerts[j].RuleID, alerts[j].Labels)
+	})
+	return alerts, nil
+}
+
+// ListDeliveries implements the Service interface
+func (s *service) ListDeliveries(ctx context.Context, sessionID string) ([]Delivery, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Delivery{}, s.deliveries[host]...), nil
+}
+
+// session retrieves an active session
+func (s *service) session(ctx context.Context, sessionID string) (*auth.Session, error) {
+	session, err := s.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+	return session, nil
+}
+
+// sessionHost returns the address of the SSH server of a session
+func (s *service) sessionHost(ctx context.Context, sessionID string) (string, error) {
+	session, err := s.session(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+	return session.Host, nil
+}
+
+// hostWebhooksLocked returns the webhooks of a host, oldest first. The caller must hold the lock.
+func (s *service) hostWebhooksLocked(host string) []Webhook {
+	webhooks := []Webhook{}
+	for _, webhook := range s.webhooks {
+		if webhook.Host == host {
+			webhooks = append(webhooks, *webhook)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt) })
+	return webhooks
+}
+
+// validateRule checks a rule and fills in its defaults
+func validateRule(spec *RuleSpec) error {
+	if spec.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRule)
+	}
+	if !slices.Contains(metrics.MetricNames, spec.Metric) {
+		return fmt.Errorf("%w: unknown metric %q", ErrInvalidRule, spec.Metric)
+	}
+	if !slices.Contains(operators, spec.Operator) {
+		return fmt.Errorf("%w: operator must be one of %v", ErrInvalidRule, operators)
+	}
+	if spec.For != "" {
+		duration, err := time.ParseDuration(spec.For)
+		if err != nil || duration < 0 {
+			return fmt.Errorf("%w: invalid for duration %q", ErrInvalidRule, spec.For)
+		}
+	}
+	switch spec.Severity {
+	case "":
+		spec.Severity = SeverityWarning
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+	default:
+		return fmt.Errorf("%w: severity must be info, warning or critical", ErrInvalidRule)
+	}
+	return nil
+}
+
+// newID generates a random rule or webhook identifier
+func newID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,212 @@
+package alerts
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/internal/domain/metrics"
+)
+
+// ObserveCollection evaluates the rules of the host created by the user the collection was taken as
+// against its samples. An alert fires once its condition has held for the duration of the rule and
+// resolves when the condition no longer holds or its series disappears, sending one notification each
+// time.
+func (s *service) ObserveCollection(collection metrics.Collection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var notifications []Notification
+	for _, rule := range s.rules {
+		if rule.Host == collection.Host && rule.User == collection.User {
+			notifications = append(notifications, s.evaluateLocked(rule, collection)...)
+		}
+	}
+	if len(notifications) == 0 {
+		return
+	}
+
+	webhooks := s.hostWebhooksLocked(collection.Host)
+	if len(webhooks) > 0 {
+		go s.deliverAll(collection.Host, webhooks, notifications)
+	}
+}
+
+// evaluateLocked updates the alerts of a rule and returns the notifications to send. The caller must
+// hold the lock.
+func (s *service) evaluateLocked(rule *Rule, collection metrics.Collection) []Notification {
+	threshold := rule.Threshold
+	if rule.PerCPU {
+		cpus, ok := findSample(collection.Samples, metrics.MetricCPUCount)
+		if !ok || cpus == 0 {
+			// Leave the alerts as they are until the CPUs are known again
+			return nil
+		}
+		threshold *= cpus
+	}
+	forDuration, _ := time.ParseDuration(rule.For)
+
+	var notifications []Notification
+	seen := make(map[string]bool)
+	for _, sample := range collection.Samples {
+		if sample.Metric != rule.Metric || !matchLabels(sample.Labels, rule.Labels) {
+			continue
+		}
+		key := labelKey(rule.ID, sample.Labels)
+		seen[key] = true
+		alert, active := s.alerts[key]
+
+		if !compare(sample.Value, rule.Operator, threshold) {
+			if active {
+				alert.Value = sample.Value
+				if alert.State == StateFiring {
+					notifications = append(notifications, newNotification(StatusResolved, alert, collection.At))
+				}
+				delete(s.alerts, key)
+			}
+			continue
+		}
+
+		if !active {
+			alert = &Alert{
+				RuleID:   rule.ID,
+				RuleName: rule.Name,
+				Severity: rule.Severity,
+				Host:     rule.Host,
+				Metric:   rule.Metric,
+				Labels:   sample.Labels,
+				State:    StatePending,
+				Operator: rule.Operator,
+				ActiveAt: collection.At,
+			}
+			s.alerts[key] = alert
+		}
+		alert.Value = sample.Value
+		alert.Threshold = threshold
+
+		if alert.State == StatePending && collection.At.Sub(alert.ActiveAt) >= forDuration {
+			firedAt := collection.At
+			alert.State = StateFiring
+			alert.FiredAt = &firedAt
+			notifications = append(notifications, newNotification(StatusFiring, alert, collection.At))
+		}
+	}
+
+	// A series missing from a complete collection is gone, e.g. a removed container or an unmounted
+	// filesystem. When a source failed, e.g. docker-stats for the container CPU and memory series, its
+	// series are missing for a different reason.
+	if len(collection.Failed) > 0 {
+		return notifications
+	}
+	for key, alert := range s.alerts {
+		if alert.RuleID != rule.ID || seen[key] {
+			continue
+		}
+		if alert.State == StateFiring {
+			notifications = append(notifications, newNotification(StatusResolved, alert, collection.At))
+		}
+		delete(s.alerts, key)
+	}
+
+	return notifications
+}
+
+// newNotification creates the notification of an alert changing state
+func newNotification(status string, alert *Alert, at time.Time) Notification {
+	notification := Notification{
+		Status:    status,
+		RuleID:    alert.RuleID,
+		RuleName:  alert.RuleName,
+		Severity:  alert.Severity,
+		Host:      alert.Host,
+		Metric:    alert.Metric,
+		Labels:    alert.Labels,
+		Value:     alert.Value,
+		Operator:  alert.Operator,
+		Threshold: alert.Threshold,
+		StartsAt:  alert.ActiveAt,
+	}
+	if status == StatusResolved {
+		endsAt := at
+		notification.EndsAt = &endsAt
+	}
+	notification.Summary = summarize(notification)
+	return notification
+}
+
+// summarize describes a notification in one line, e.g.
+// [FIRING] Root disk full on 203.0.113.7:22: disk.used_percent{mount="/"} = 93.1 (> 90)
+func summarize(n Notification) string {
+	return fmt.Sprintf("[%s] %s on %s: %s = %s (%s %s)", strings.ToUpper(n.Status), n.RuleName, n.Host,
+		seriesName(n.Metric, n.Labels), formatValue(n.Value), n.Operator, formatValue(n.Threshold))
+}
+
+// seriesName formats a metric with its labels, e.g. disk.used_percent{mount="/"}
+func seriesName(metric string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return metric
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + strconv.Quote(labels[name])
+	}
+	return metric + "{" + strings.Join(parts, ",") + "}"
+}
+
+// formatValue formats a value rounded to two decimals
+func formatValue(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// compare applies the operator of a rule to a value and the threshold
+func compare(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	case "==":
+		return value == threshold
+	case "!=":
+		return value != threshold
+	}
+	return false
+}
+
+// findSample returns the value of an unlabelled metric in the samples
+func findSample(samples []metrics.Sample, metric string) (float64, bool) {
+	for _, sample := range samples {
+		if sample.Metric == metric && len(sample.Labels) == 0 {
+			return sample.Value, true
+		}
+	}
+	return 0, false
+}
+
+// matchLabels reports whether the labels contain all the wanted label values
+func matchLabels(labels map[string]string, want map[string]string) bool {
+	for name, value := range want {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// labelKey identifies the alert of a rule for a series
+func labelKey(ruleID string, labels map[string]string) string {
+	return ruleID + "\x00" + seriesName("", labels)
+}
@@ -0,0 +1,236 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"remote-server-api/internal/domain/metrics"
+)
+
+// WebhookTimeout bounds a single webhook request
+const WebhookTimeout = 10 * time.Second
+
+// maxDeliveries is the number of deliveries kept per host
+const maxDeliveries = 100
+
+// deliveryAttempts is the number of times a notification is posted before giving up, and
+// retryBackoff the wait before the first retry, doubled for every further retry
+const (
+	deliveryAttempts = 3
+	retryBackoff     = 2 * time.Second
+)
+
+// maxResponseError bounds the part of an error response kept with a delivery
+const maxResponseError = 200
+
+// templateFuncs are the functions available to webhook templates
+var templateFuncs = template.FuncMap{
+	"json": func(value any) (string, error) {
+		data, err := marshalJSON(value)
+		return string(data), err
+	},
+}
+
+// TestWebhook implements the Service interface
+func (s *service) TestWebhook(ctx context.Context, sessionID string, id string) (*Delivery, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	webhook, ok := s.webhooks[id]
+	s.mu.Unlock()
+	if !ok || webhook.Host != host {
+		return nil, fmt.Errorf("%w: %s", ErrWebhookNotFound, id)
+	}
+
+	notification := testNotification(host)
+	delivery := s.deliver(ctx, *webhook, notification, 1)
+	s.recordDelivery(host, delivery)
+	return &delivery, nil
+}
+
+// deliverAll posts the notifications to every webhook of a host and records the outcomes
+func (s *service) deliverAll(host string, webhooks []Webhook, notifications []Notification) {
+	value, _ := s.delivering.LoadOrStore(host, &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for _, notification := range notifications {
+		for _, webhook := range webhooks {
+			s.recordDelivery(host, s.deliver(context.Background(), webhook, notification, deliveryAttempts))
+		}
+	}
+}
+
+// deliver posts a notification to a webhook, retrying failed attempts that may succeed later
+func (s *service) deliver(ctx context.Context, webhook Webhook, notification Notification, attempts int) Delivery {
+	delivery := Delivery{
+		WebhookID:    webhook.ID,
+		WebhookName:  webhook.Name,
+		Notification: notification,
+	}
+
+	body, err := renderBody(webhook.Template, notification)
+	if err != nil {
+		delivery.Error = err.Error()
+		delivery.SentAt = time.Now().UTC()
+		return delivery
+	}
+
+	backoff := retryBackoff
+	for delivery.Attempts < attempts {
+		if delivery.Attempts > 0 {
+			select {
+			case <-ctx.Done():
+				return delivery
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		delivery.Attempts++
+		delivery.SentAt = time.Now().UTC()
+		statusCode, err := s.post(ctx, webhook, body)
+		delivery.StatusCode = statusCode
+		if err == nil {
+			delivery.Delivered = true
+			delivery.Error = ""
+			return delivery
+		}
+		delivery.Error = err.Error()
+
+		// Client errors other than rate limiting fail again the same way
+		if statusCode >= 400 && statusCode < 500 && statusCode != http.StatusTooManyRequests {
+			return delivery
+		}
+	}
+
+	return delivery
+}
+
+// post sends a request body to a webhook and returns the response status
+func (s *service) post(ctx context.Context, webhook Webhook, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, WebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Cerberus-Alerts")
+	for name, value := range webhook.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseError))
+		return resp.StatusCode, fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	return resp.StatusCode, nil
+}
+
+// recordDelivery keeps the outcome of a delivery, dropping the oldest beyond maxDeliveries
+func (s *service) recordDelivery(host string, delivery Delivery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deliveries := append([]Delivery{delivery}, s.deliveries[host]...)
+	if len(deliveries) > maxDeliveries {
+		deliveries = deliveries[:maxDeliveries]
+	}
+	s.deliveries[host] = deliveries
+}
+
+// renderBody renders the request body of a notification with the template of a webhook, or encodes
+// the notification as JSON without one
+func renderBody(text string, notification Notification) ([]byte, error) {
+	if text == "" {
+		return marshalJSON(notification)
+	}
+
+	tmpl, err := template.New("webhook").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, notification); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	if !json.Valid(body.Bytes()) {
+		return nil, fmt.Errorf("template does not render valid JSON: %s", body.String())
+	}
+	return body.Bytes(), nil
+}
+
+// marshalJSON encodes a value as JSON, leaving the characters <, > and & of summaries unescaped
+func marshalJSON(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// validateWebhook checks the URL of a webhook and renders its template with a test notification
+func validateWebhook(spec WebhookSpec) error {
+	if spec.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWebhook)
+	}
+
+	u, err := url.Parse(spec.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an http or https URL", ErrInvalidWebhook)
+	}
+
+	for name := range spec.Headers {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("%w: invalid header name %q", ErrInvalidWebhook, name)
+		}
+	}
+
+	if _, err := renderBody(spec.Template, testNotification("203.0.113.7:22")); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	return nil
+}
+
+// testNotification creates the notification sent when testing a webhook
+func testNotification(host string) Notification {
+	notification := Notification{
+		Status:    StatusTest,
+		RuleID:    "test",
+		RuleName:  "Test notification",
+		Severity:  SeverityInfo,
+		Host:      host,
+		Metric:    metrics.MetricDiskUsage,
+		Labels:    map[string]string{"mount": "/"},
+		Value:     93.1,
+		Operator:  ">",
+		Threshold: 90,
+		StartsAt:  time.Now().UTC(),
+	}
+	notification.Summary = summarize(notification)
+	return notification
+}
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v4"
 	"golang.org/x/crypto/ssh"
 )
@@ -27,7 +29,20 @@ type Claims struct {
 
 // Session represents an active SSH session
 type Session struct {
-	ID       string
-	Username string
-	Client   *ssh.Client
+	ID        string
+	Username  string
+	Host      string // Address of the SSH server, e.g. "203.0.113.7:22"
+	Client    *ssh.Client
+	ExpiresAt time.Time // Expiry of the token issued for the session, after which it is removed
+}
+
+// Owner identifies the user and host of a session, e.g. "deploy@203.0.113.7:22". Data collected in
+// the background through a session belongs to its owner and is only served to sessions of that owner.
+func (s *Session) Owner() string {
+	return OwnerOf(s.Username, s.Host)
+}
+
+// OwnerOf returns the owner of the sessions of a user to a host
+func OwnerOf(username string, host string) string {
+	return username + "@" + host
 }
@@ -2,13 +2,15 @@ package auth
 
 import (
 	"context"
+	"time"
+
 	"golang.org/x/crypto/ssh"
 )
 
 // Repository defines the interface for session persistence
 type Repository interface {
-	// StoreSession stores a new SSH session
-	StoreSession(ctx context.Context, sessionID string, username string, client *ssh.Client) error
+	// StoreSession stores a new SSH session, which expires at the given time
+	StoreSession(ctx context.Context, sessionID string, username string, client *ssh.Client, expiresAt time.Time) error
 
 	// GetSession retrieves an SSH session by ID
 	GetSession(ctx context.Context, sessionID string) (*Session, error)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
 	"golang.org/x/crypto/ssh"
 )
 
@@ -18,6 +20,7 @@ var (
 type TokenService interface {
 	GenerateToken(username, sessionID string) (string, error)
 	ValidateToken(tokenString string) (*Claims, error)
+	ExpiresIn() time.Duration
 }
 
 // SSHClient defines methods for SSH operations
@@ -63,8 +66,9 @@ func (s *service) Login(ctx context.Context, req LoginRequest) (*LoginResponse,
 	// Generate a unique session ID (in production, use a proper UUID library)
 	sessionID := fmt.Sprintf("session_%s_%s", req.Username, req.IP)
 
-	// Store the session
-	if err := s.repo.StoreSession(ctx, sessionID, req.Username, client); err != nil {
+	// Store the session, which expires together with its token
+	expiresAt := time.Now().Add(s.tokenService.ExpiresIn())
+	if err := s.repo.StoreSession(ctx, sessionID, req.Username, client, expiresAt); err != nil {
 		return nil, fmt.Errorf("failed to store session: %w", err)
 	}
 
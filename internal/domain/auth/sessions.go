@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+	"sort"
+)
+
+// SessionLister lists the active SSH sessions, e.g. for work done in the background
+type SessionLister interface {
+	ListSessions(ctx context.Context) ([]*Session, error)
+}
+
+// OwnerSessions returns one active session per owner, the one with the lowest ID when an owner has
+// several, so that background work on behalf of a user on a host runs with that user's credentials
+func OwnerSessions(ctx context.Context, lister SessionLister) (map[string]*Session, error) {
+	sessions, err := lister.ListSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID < sessions[j].ID })
+
+	owners := make(map[string]*Session)
+	for _, session := range sessions {
+		if session.Host == "" {
+			continue
+		}
+		if _, ok := owners[session.Owner()]; !ok {
+			owners[session.Owner()] = session
+		}
+	}
+	return owners, nil
+}
@@ -0,0 +1,58 @@
+package cron
+
+import "time"
+
+// Kinds of scheduled jobs
+const (
+	JobKindUser     = "user"     // Entry of a user crontab
+	JobKindSystem   = "system"   // Entry of /etc/crontab or /etc/cron.d, which name the user to run as
+	JobKindPeriodic = "periodic" // Script in /etc/cron.hourly, /etc/cron.daily, /etc/cron.weekly or /etc/cron.monthly
+	JobKindTimer    = "timer"    // systemd timer
+)
+
+// Job is a scheduled task found on the server
+type Job struct {
+	Kind     string     `json:"kind"`
+	Source   string     `json:"source"`         // File the job was read from, or the timer unit
+	Line     int        `json:"line,omitempty"` // Line number in the source file
+	User     string     `json:"user,omitempty"`
+	Schedule string     `json:"schedule"` // Cron expression, @keyword or timer specification
+	Command  string     `json:"command,omitempty"`
+	Unit     string     `json:"unit,omitempty"` // Unit activated by a timer
+	Enabled  bool       `json:"enabled"`
+	NextRun  *time.Time `json:"next_run,omitempty"`
+	LastRun  *time.Time `json:"last_run,omitempty"`    // From the cron entries of the journal, or the last trigger of a timer
+	Result   string     `json:"last_result,omitempty"` // Result of the last run of a timer
+}
+
+// JobInventory lists the scheduled jobs of a server
+type JobInventory struct {
+	Timezone             string    `json:"timezone"` // Time zone cron schedules are evaluated in
+	UserCrontabsReadable bool      `json:"user_crontabs_readable"`
+	Jobs                 []Job     `json:"jobs"`
+	CollectedAt          time.Time `json:"collected_at"`
+}
+
+// Crontab is the crontab of a user. Version identifies its content and must be passed back when
+// changing it, so that concurrent edits are detected.
+type Crontab struct {
+	User     string         `json:"user"`
+	Exists   bool           `json:"exists"`
+	Version  string         `json:"version"` // SHA-256 of the crontab content
+	Timezone string         `json:"timezone"`
+	Entries  []CrontabEntry `json:"entries"`
+}
+
+// CrontabEntry is a job line of a crontab
+type CrontabEntry struct {
+	ID       int        `json:"id"` // Line number, valid for the version it was read with
+	Schedule string     `json:"schedule"`
+	Command  string     `json:"command"`
+	NextRun  *time.Time `json:"next_run,omitempty"`
+}
+
+// CrontabEntryRequest is the content of a crontab entry to add or replace
+type CrontabEntryRequest struct {
+	Schedule string `json:"schedule"` // Five cron fields or @reboot, @hourly, @daily, @weekly, @monthly, @yearly
+	Command  string `json:"command"`
+}
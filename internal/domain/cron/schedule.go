@@ -0,0 +1,251 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleKeywords maps the @keywords of cron to the equivalent expressions
+var scheduleKeywords = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	weekdayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// scheduleSearchYears bounds the search for the next run of schedules that rarely match, such as
+// February 30th
+const scheduleSearchYears = 5
+
+// schedule is a parsed cron schedule. Every field is a bit set of the values it matches.
+type schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	// A day field starting with "*" does not restrict the day. When both are restricted a day
+	// matches if either field matches, as in Vixie cron.
+	dayOfMonthStar, dayOfWeekStar bool
+
+	// reboot schedules run once at startup and have no next run
+	reboot bool
+}
+
+// parseSchedule parses five cron fields or an @keyword
+func parseSchedule(spec string) (*schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "@reboot" {
+		return &schedule{reboot: true}, nil
+	}
+	if strings.HasPrefix(spec, "@") {
+		expression, ok := scheduleKeywords[spec]
+		if !ok {
+			return nil, fmt.Errorf("unknown schedule %q", spec)
+		}
+		spec = expression
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q must have five fields", spec)
+	}
+
+	var s schedule
+	var err error
+	if s.minute, err = parseScheduleField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if s.hour, err = parseScheduleField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if s.dayOfMonth, err = parseScheduleField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if s.month, err = parseScheduleField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if s.dayOfWeek, err = parseScheduleField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+
+	// Sunday may be written as 0 or 7
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1
+	}
+	s.dayOfMonthStar = strings.HasPrefix(fields[2], "*")
+	s.dayOfWeekStar = strings.HasPrefix(fields[4], "*")
+
+	return &s, nil
+}
+
+// parseScheduleField parses a comma-separated list of values, ranges and steps ("*/5", "1-10/2",
+// "mon-fri") into a bit set
+func parseScheduleField(field string, min int, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*":
+			low, high = min, max
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseScheduleValue(lowPart, min, max, names); err != nil {
+				return 0, err
+			}
+			if high, err = parseScheduleValue(highPart, min, max, names); err != nil {
+				return 0, err
+			}
+			if high < low {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := parseScheduleValue(rangePart, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			low, high = value, value
+			if hasStep {
+				// "5/15" means every 15 starting at 5
+				high = max
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+// parseScheduleValue parses a number or a month or weekday name within the bounds of a field
+func parseScheduleValue(value string, min int, max int, names map[string]int) (int, error) {
+	if number, ok := names[strings.ToLower(value)]; ok {
+		return number, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < min || number > max {
+		return 0, fmt.Errorf("invalid value %q (%d-%d)", value, min, max)
+	}
+	return number, nil
+}
+
+// next returns the first time after the given time the schedule matches, in the given location
+func (s *schedule) next(after time.Time, loc *time.Location) (time.Time, bool) {
+	if s.reboot {
+		return time.Time{}, false
+	}
+
+	after = after.In(loc)
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute()+1, 0, 0, loc)
+	limit := t.AddDate(scheduleSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// matchesDay reports whether the day of month and day of week fields match the day of t
+func (s *schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// nextRun computes the next run of a schedule in UTC, or nil when it cannot be determined
+func nextRun(spec string, now time.Time, loc *time.Location) *time.Time {
+	s, err := parseSchedule(spec)
+	if err != nil {
+		return nil
+	}
+
+	next, ok := s.next(now, loc)
+	if !ok {
+		return nil
+	}
+	next = next.UTC()
+	return &next
+}
+
+// splitCronLine splits a crontab line into its schedule, the user field of system crontabs and the
+// command, keeping the spacing of the command intact
+func splitCronLine(line string, withUser bool) (spec string, user string, command string, ok bool) {
+	count := 5
+	if strings.HasPrefix(line, "@") {
+		count = 1
+	}
+	if withUser {
+		count++
+	}
+
+	fields, rest := cutFields(line, count)
+	if len(fields) < count || rest == "" {
+		return "", "", "", false
+	}
+	if withUser {
+		user = fields[count-1]
+		fields = fields[:count-1]
+	}
+
+	return strings.Join(fields, " "), user, rest, true
+}
+
+// cutFields splits off the first n whitespace-separated fields and returns them with the remainder
+func cutFields(line string, n int) ([]string, string) {
+	var fields []string
+	rest := strings.TrimLeft(line, " \t")
+
+	for len(fields) < n && rest != "" {
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			end = len(rest)
+		}
+		fields = append(fields, rest[:end])
+		rest = strings.TrimLeft(rest[end:], " \t")
+	}
+
+	return fields, strings.TrimRight(rest, " \t\r")
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"remote-server-api/internal/domain/accounts"
+	"remote-server-api/pkg/keylock"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound  = errors.New("session not found or expired")
+	ErrCommandFailed    = errors.New("command execution failed")
+	ErrInvalidUser      = errors.New("invalid user name")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrInvalidEntry     = errors.New("invalid crontab entry")
+	ErrEntryNotFound    = errors.New("crontab entry not found")
+	ErrVersionRequired  = errors.New("crontab version required")
+	ErrVersionConflict  = errors.New("crontab was changed since it was read")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrCronMissing      = errors.New("crontab is not available on this server")
+)
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// RunCommand executes a command on the SSH session
+	RunCommand(ctx context.Context, sessionID string, command string) (string, error)
+}
+
+// Service defines the scheduled task service
+type Service interface {
+	// ListJobs retrieves the jobs of all user crontabs, the system crontabs, the periodic job
+	// directories and the systemd timers with their next and last run
+	ListJobs(ctx context.Context, sessionID string) (*JobInventory, error)
+
+	// GetCrontab retrieves the crontab of a user with the version needed to change it
+	GetCrontab(ctx context.Context, sessionID string, user string) (*Crontab, error)
+
+	// AddCrontabEntry appends an entry to the crontab of a user, if it still has the given version
+	AddCrontabEntry(ctx context.Context, sessionID string, user string, version string, entry CrontabEntryRequest) (*Crontab, error)
+
+	// UpdateCrontabEntry replaces an entry of the crontab of a user, if it still has the given version
+	UpdateCrontabEntry(ctx context.Context, sessionID string, user string, version string, id int, entry CrontabEntryRequest) (*Crontab, error)
+
+	// DeleteCrontabEntry removes an entry from the crontab of a user, if it still has the given version
+	DeleteCrontabEntry(ctx context.Context, sessionID string, user string, version string, id int) (*Crontab, error)
+}
+
+type service struct {
+	sessionRepo SessionRepository
+
+	// locks serializes crontab changes made through this API per session and user
+	locks keylock.Locks
+}
+
+// NewService creates a new scheduled task service
+func NewService(sessionRepo SessionRepository) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// validateUserName rejects names that cannot belong to a local user
+func validateUserName(name string) error {
+	if !accounts.IsValidUserName(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidUser, name)
+	}
+	return nil
+}
+
+// lock acquires the crontab lock of a user and returns the function releasing it
+func (s *service) lock(sessionID string, user string) func() {
+	return s.locks.Lock(sessionID + "\x00" + user)
+}
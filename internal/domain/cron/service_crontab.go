@@ -0,0 +1,265 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// Limits for crontab changes
+const (
+	maxCommandLength = 4096
+	maxCrontabSize   = 64 * 1024
+)
+
+// crontabPreamble selects the crontab command for a user and a SHA-256 tool. The SSH user manages its
+// own crontab directly; other users' crontabs need root or passwordless sudo. The placeholder receives
+// the quoted user name.
+const crontabPreamble = `u=%[1]s
+command -v crontab >/dev/null 2>&1 || { echo '### missing'; exit 0; }
+id -u "$u" >/dev/null 2>&1 || { echo '### no-user'; exit 0; }
+if [ "$(id -un)" = "$u" ]; then c=crontab; else c="` + shell.SudoPrefix + ` crontab -u $u"; fi
+if command -v sha256sum >/dev/null 2>&1; then h=sha256sum; else h='openssl dgst -sha256 -r'; fi
+`
+
+// crontabReadScript follows crontabPreamble and clockScript and prints the exit status and error of
+// 'crontab -l', the hash of the crontab and then its content, with lines prefixed with "|" so that
+// comments cannot be taken for section headers. The hash is taken first so that a change in between
+// makes the returned version stale rather than the content.
+const crontabReadScript = `e=$($c -l 2>&1 >/dev/null); s=$?
+echo '### status'
+echo "$s"
+echo "$e"
+echo '### version'
+$c -l 2>/dev/null | $h | cut -d' ' -f1
+echo '### crontab'
+$c -l 2>/dev/null | sed 's/^/|/'
+true`
+
+// crontabWriteScript installs a new crontab only if the current one still has the expected hash. The
+// check and the installation run in one shell invocation. The placeholders receive the quoted user
+// name, the expected hash and the new content.
+const crontabWriteScript = crontabPreamble + `v=$($c -l 2>/dev/null | $h | cut -d' ' -f1)
+[ "$v" = %[2]s ] || { echo '### conflict'; exit 0; }
+echo '### install'
+printf '%%s' %[3]s | $c - 2>&1
+echo "exit=$?"
+true`
+
+// noCrontabMessages are fragments of 'crontab -l' errors for users without a crontab
+var noCrontabMessages = []string{"no crontab for", "no such file"}
+
+// crontabDeniedMessages are fragments of crontab output, besides the common ones, that indicate
+// missing privileges, e.g. for users missing from cron.allow
+var crontabDeniedMessages = []string{"not allowed"}
+
+// crontabState is the content and version of a crontab as read from the server
+type crontabState struct {
+	crontab *Crontab
+	lines   []string
+}
+
+// GetCrontab implements the Service interface
+func (s *service) GetCrontab(ctx context.Context, sessionID string, user string) (*Crontab, error) {
+	state, err := s.readCrontab(ctx, sessionID, user)
+	if err != nil {
+		return nil, err
+	}
+	return state.crontab, nil
+}
+
+// AddCrontabEntry implements the Service interface
+func (s *service) AddCrontabEntry(ctx context.Context, sessionID string, user string, version string, entry CrontabEntryRequest) (*Crontab, error) {
+	line, err := formatCrontabEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.changeCrontab(ctx, sessionID, user, version, func(lines []string) ([]string, error) {
+		return append(lines, line), nil
+	})
+}
+
+// UpdateCrontabEntry implements the Service interface
+func (s *service) UpdateCrontabEntry(ctx context.Context, sessionID string, user string, version string, id int, entry CrontabEntryRequest) (*Crontab, error) {
+	line, err := formatCrontabEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.changeCrontab(ctx, sessionID, user, version, func(lines []string) ([]string, error) {
+		if err := checkEntryID(lines, id); err != nil {
+			return nil, err
+		}
+		lines[id-1] = line
+		return lines, nil
+	})
+}
+
+// DeleteCrontabEntry implements the Service interface
+func (s *service) DeleteCrontabEntry(ctx context.Context, sessionID string, user string, version string, id int) (*Crontab, error) {
+	return s.changeCrontab(ctx, sessionID, user, version, func(lines []string) ([]string, error) {
+		if err := checkEntryID(lines, id); err != nil {
+			return nil, err
+		}
+		return append(lines[:id-1], lines[id:]...), nil
+	})
+}
+
+// changeCrontab applies a change to the lines of a crontab and installs the result, provided the
+// crontab still has the version the change was based on
+func (s *service) changeCrontab(ctx context.Context, sessionID string, user string, version string, change func(lines []string) ([]string, error)) (*Crontab, error) {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if version == "" {
+		return nil, ErrVersionRequired
+	}
+
+	unlock := s.lock(sessionID, user)
+	defer unlock()
+
+	state, err := s.readCrontab(ctx, sessionID, user)
+	if err != nil {
+		return nil, err
+	}
+	if state.crontab.Version != version {
+		return nil, fmt.Errorf("%w: current version is %s", ErrVersionConflict, state.crontab.Version)
+	}
+
+	lines, err := change(state.lines)
+	if err != nil {
+		return nil, err
+	}
+	content := ""
+	if len(lines) > 0 {
+		content = strings.Join(lines, "\n") + "\n"
+	}
+	if len(content) > maxCrontabSize {
+		return nil, fmt.Errorf("%w: crontab would exceed %d bytes", ErrInvalidEntry, maxCrontabSize)
+	}
+
+	command := fmt.Sprintf(crontabWriteScript, shell.Quote(user), shell.Quote(version), shell.Quote(content))
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, command)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	if err := checkCrontabSections(sections, user); err != nil {
+		return nil, err
+	}
+	if _, conflict := sections["conflict"]; conflict {
+		return nil, ErrVersionConflict
+	}
+
+	// Split the crontab output from the trailing exit status line
+	installOutput, exitStatus := shell.SplitExitStatus(sections["install"])
+	if exitStatus != "0" {
+		lower := strings.ToLower(installOutput)
+		switch {
+		case shell.IsPermissionDenied(lower, crontabDeniedMessages...):
+			return nil, fmt.Errorf("%w: %s", ErrPermissionDenied, installOutput)
+		case strings.Contains(lower, "errors in crontab"):
+			return nil, fmt.Errorf("%w: %s", ErrInvalidEntry, installOutput)
+		default:
+			return nil, fmt.Errorf("%w: crontab: %s", ErrCommandFailed, installOutput)
+		}
+	}
+
+	return s.GetCrontab(ctx, sessionID, user)
+}
+
+// readCrontab reads the content and version of the crontab of a user
+func (s *service) readCrontab(ctx context.Context, sessionID string, user string) (*crontabState, error) {
+	if err := validateUserName(user); err != nil {
+		return nil, err
+	}
+
+	command := fmt.Sprintf(crontabPreamble, shell.Quote(user)) + clockScript + crontabReadScript
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, command)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	if err := checkCrontabSections(sections, user); err != nil {
+		return nil, err
+	}
+
+	// status: exit status of 'crontab -l' followed by its error output
+	status, message, _ := strings.Cut(strings.TrimSpace(sections["status"]), "\n")
+	message = strings.TrimSpace(message)
+	exists := status == "0"
+	if !exists && !shell.ContainsAny(strings.ToLower(message), noCrontabMessages) {
+		if shell.IsPermissionDenied(message, crontabDeniedMessages...) {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionDenied, message)
+		}
+		return nil, fmt.Errorf("%w: crontab -l: %s", ErrCommandFailed, message)
+	}
+
+	var lines []string
+	if content := strings.TrimRight(shell.TrimLinePrefix(sections["crontab"]), "\n"); content != "" {
+		lines = strings.Split(content, "\n")
+	}
+
+	clk := parseClock(sections)
+	crontab := &Crontab{
+		User:     user,
+		Exists:   exists,
+		Version:  strings.ToLower(strings.TrimSpace(sections["version"])),
+		Timezone: clk.name,
+		Entries:  []CrontabEntry{},
+	}
+	for _, line := range parseCrontab(strings.Join(lines, "\n"), false) {
+		crontab.Entries = append(crontab.Entries, CrontabEntry{
+			ID:       line.line,
+			Schedule: line.schedule,
+			Command:  line.command,
+			NextRun:  nextRun(line.schedule, clk.now, clk.lineLocation(line)),
+		})
+	}
+
+	return &crontabState{crontab: crontab, lines: lines}, nil
+}
+
+// checkCrontabSections maps the failures reported by crontabPreamble to errors
+func checkCrontabSections(sections map[string]string, user string) error {
+	if _, missing := sections["missing"]; missing {
+		return ErrCronMissing
+	}
+	if _, noUser := sections["no-user"]; noUser {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, user)
+	}
+	return nil
+}
+
+// formatCrontabEntry validates an entry and formats it as a crontab line
+func formatCrontabEntry(entry CrontabEntryRequest) (string, error) {
+	schedule := strings.Join(strings.Fields(entry.Schedule), " ")
+	if _, err := parseSchedule(schedule); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+	}
+
+	command := strings.TrimSpace(entry.Command)
+	switch {
+	case command == "":
+		return "", fmt.Errorf("%w: command is required", ErrInvalidEntry)
+	case len(command) > maxCommandLength:
+		return "", fmt.Errorf("%w: command exceeds %d bytes", ErrInvalidEntry, maxCommandLength)
+	case strings.ContainsAny(command, "\n\r\x00"):
+		return "", fmt.Errorf("%w: command must be a single line", ErrInvalidEntry)
+	}
+
+	return schedule + " " + command, nil
+}
+
+// checkEntryID verifies that the ID refers to a job line of the crontab
+func checkEntryID(lines []string, id int) error {
+	for _, line := range parseCrontab(strings.Join(lines, "\n"), false) {
+		if line.line == id {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %d", ErrEntryNotFound, id)
+}
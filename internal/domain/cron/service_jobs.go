@@ -0,0 +1,474 @@
+package cron
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// clockScript prints the time zone cron evaluates schedules in, its current UTC offset and the current
+// time of the server, so that next runs do not depend on the clock of the API host
+const clockScript = `echo '### timezone'
+tz=$(timedatectl show -p Timezone --value 2>/dev/null)
+[ -n "$tz" ] || tz=$(cat /etc/timezone 2>/dev/null)
+[ -n "$tz" ] || tz=$(readlink /etc/localtime 2>/dev/null | sed 's|.*zoneinfo/||')
+echo "$tz"
+echo '### offset'
+date +%z
+echo '### now'
+date +%s
+`
+
+// privilegedJobsScript prints the files only root can usually read: the user crontabs of the spool
+// directories used by Debian, RHEL, SUSE and Alpine, the system crontabs, the scripts of the periodic
+// job directories and the commands cron logged to the journal during the last week. File contents are
+// prefixed with "|" so that their lines cannot be taken for section headers.
+const privilegedJobsScript = `for d in /var/spool/cron/crontabs /var/spool/cron/tabs /var/spool/cron /etc/crontabs; do
+  [ -d "$d" ] || continue
+  if [ ! -r "$d" ] || [ ! -x "$d" ]; then echo '### spool-unreadable'; continue; fi
+  for f in "$d"/*; do
+    [ -f "$f" ] && [ -r "$f" ] && { echo "### user:$f"; sed 's/^/|/' "$f"; echo; }
+  done
+done
+for f in /etc/crontab /etc/cron.d/*; do
+  case "$f" in *~|*.dpkg-*|*.rpmsave|*.rpmnew|*.swp) continue ;; esac
+  [ -f "$f" ] && [ -r "$f" ] && { echo "### system:$f"; sed 's/^/|/' "$f"; echo; }
+done
+for d in /etc/cron.hourly /etc/cron.daily /etc/cron.weekly /etc/cron.monthly; do
+  [ -d "$d" ] || continue
+  echo "### periodic:$d"
+  for f in "$d"/*; do [ -f "$f" ] && [ -x "$f" ] && echo "${f##*/}"; done
+done
+[ -r /etc/anacrontab ] && { echo '### anacrontab'; sed 's/^/|/' /etc/anacrontab; }
+if command -v journalctl >/dev/null 2>&1; then
+  echo '### runs'
+  journalctl -q --no-pager -o short-unix --since -7d -t CRON -t cron -t crond -t CROND 2>/dev/null | grep ' CMD (' | tail -n 5000
+fi
+true`
+
+// jobsScript gathers the cron jobs and systemd timers in one round trip. The timer properties are
+// printed as Unix timestamps where systemctl supports it.
+var jobsScript = clockScript + `echo '### btime'
+awk '/^btime/ { print $2 }' /proc/stat 2>/dev/null
+` + shell.SudoIfAvailable + ` sh -c ` + shell.Quote(privilegedJobsScript) + `
+if command -v systemctl >/dev/null 2>&1; then
+  t=$({ systemctl list-units --all --type=timer --plain --no-legend --no-pager; systemctl list-unit-files --type=timer --no-legend --no-pager; } 2>/dev/null | awk '$1 ~ /[^@]\.timer$/ { print $1 }' | sort -u)
+  if [ -n "$t" ]; then
+    echo '### timers'
+    p=Id,Triggers,ActiveState,UnitFileState,TimersCalendar,TimersMonotonic,NextElapseUSecRealtime,NextElapseUSecMonotonic,LastTriggerUSec,Result
+    systemctl show --timestamp=unix --no-pager -p $p -- $t 2>/dev/null || systemctl show --no-pager -p $p -- $t 2>/dev/null
+  fi
+fi
+true`
+
+// Section name prefixes of jobsScript
+const (
+	userSectionPrefix     = "user:"
+	systemSectionPrefix   = "system:"
+	periodicSectionPrefix = "periodic:"
+)
+
+var (
+	// environmentPattern matches the variable assignments of crontabs
+	environmentPattern = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+	// cronRunPattern matches the journal entries cron writes when running a command, e.g.
+	// "1760745601.123456 host CRON[123]: (root) CMD (command)"
+	cronRunPattern = regexp.MustCompile(`^(\d+)(?:\.\d+)? \S+ \S+: \(([^)]+)\) CMD \((.*)\)$`)
+
+	// timerSpecPattern matches an entry of the TimersCalendar and TimersMonotonic properties, e.g.
+	// "{ OnCalendar=*-*-* 00:00:00 ; next_elapse=... }"
+	timerSpecPattern = regexp.MustCompile(`\{ (\w+)=([^;]*?) ;`)
+)
+
+// anacronPeriods maps the periods of /etc/anacrontab to the equivalent schedules
+var anacronPeriods = map[string]string{
+	"1":        "@daily",
+	"@daily":   "@daily",
+	"7":        "@weekly",
+	"@weekly":  "@weekly",
+	"@monthly": "@monthly",
+	"30":       "@monthly",
+	"@yearly":  "@yearly",
+	"365":      "@yearly",
+}
+
+// cronLine is a job line of a crontab
+type cronLine struct {
+	line     int
+	schedule string
+	user     string
+	command  string
+	timezone string // CRON_TZ in effect for the line
+}
+
+// clock holds the time zone and current time of a server
+type clock struct {
+	name     string
+	location *time.Location
+	now      time.Time
+}
+
+// ListJobs implements the Service interface
+func (s *service) ListJobs(ctx context.Context, sessionID string) (*JobInventory, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, jobsScript)
+	if err != nil {
+		return nil, err
+	}
+	sections := shell.ParseSections(output)
+	clk := parseClock(sections)
+	runs := parseCronRuns(sections["runs"])
+
+	_, unreadable := sections["spool-unreadable"]
+	inventory := &JobInventory{
+		Timezone:             clk.name,
+		UserCrontabsReadable: !unreadable,
+		Jobs:                 []Job{},
+		CollectedAt:          time.Now().UTC(),
+	}
+
+	addCronJob := func(kind string, source string, user string, line cronLine) {
+		job := Job{
+			Kind:     kind,
+			Source:   source,
+			Line:     line.line,
+			User:     user,
+			Schedule: line.schedule,
+			Command:  line.command,
+			Enabled:  true,
+			NextRun:  nextRun(line.schedule, clk.now, clk.lineLocation(line)),
+		}
+		if lastRun, ok := runs[user+"\x00"+line.command]; ok {
+			job.LastRun = &lastRun
+		}
+		inventory.Jobs = append(inventory.Jobs, job)
+	}
+
+	// User crontabs, named after their owner
+	seen := make(map[string]bool)
+	for _, name := range shell.SortedSectionNames(sections, userSectionPrefix) {
+		path := strings.TrimPrefix(name, userSectionPrefix)
+		user := path[strings.LastIndex(path, "/")+1:]
+		if seen[user] {
+			continue
+		}
+		seen[user] = true
+		for _, line := range parseCrontab(shell.TrimLinePrefix(sections[name]), false) {
+			addCronJob(JobKindUser, path, user, line)
+		}
+	}
+
+	// System crontabs, remembering the entries that run the periodic job directories
+	periodicSchedules := make(map[string]Job)
+	for _, name := range shell.SortedSectionNames(sections, systemSectionPrefix) {
+		path := strings.TrimPrefix(name, systemSectionPrefix)
+		for _, line := range parseCrontab(shell.TrimLinePrefix(sections[name]), true) {
+			addCronJob(JobKindSystem, path, line.user, line)
+			for _, dir := range shell.SortedSectionNames(sections, periodicSectionPrefix) {
+				dir = strings.TrimPrefix(dir, periodicSectionPrefix)
+				if _, found := periodicSchedules[dir]; !found && strings.Contains(line.command, dir) {
+					periodicSchedules[dir] = inventory.Jobs[len(inventory.Jobs)-1]
+				}
+			}
+		}
+	}
+	for dir, schedule := range parseAnacrontab(shell.TrimLinePrefix(sections["anacrontab"])) {
+		if _, found := periodicSchedules[dir]; !found {
+			periodicSchedules[dir] = Job{Schedule: schedule, User: "root"}
+		}
+	}
+
+	// Periodic job directories, run by run-parts from cron or anacron
+	for _, name := range shell.SortedSectionNames(sections, periodicSectionPrefix) {
+		dir := strings.TrimPrefix(name, periodicSectionPrefix)
+		parent := periodicSchedules[dir]
+		for _, script := range strings.Split(sections[name], "\n") {
+			script = strings.TrimSpace(script)
+			if script == "" || strings.HasPrefix(script, ".") {
+				continue
+			}
+			inventory.Jobs = append(inventory.Jobs, Job{
+				Kind:     JobKindPeriodic,
+				Source:   dir,
+				User:     shell.FirstNonEmpty(parent.User, "root"),
+				Schedule: shell.FirstNonEmpty(parent.Schedule, "@"+strings.TrimPrefix(dir, "/etc/cron.")),
+				Command:  dir + "/" + script,
+				Enabled:  true,
+				NextRun:  parent.NextRun,
+				LastRun:  parent.LastRun,
+			})
+		}
+	}
+
+	// systemd timers
+	inventory.Jobs = append(inventory.Jobs, parseTimers(sections["timers"], sections["btime"], clk)...)
+
+	return inventory, nil
+}
+
+// parseClock reads the time zone and current time of the server. The zone database of the API host
+// is used to follow daylight saving time; when it does not know the zone, the current offset is used.
+func parseClock(sections map[string]string) clock {
+	clk := clock{
+		name:     strings.TrimSpace(sections["timezone"]),
+		location: time.UTC,
+		now:      time.Now(),
+	}
+
+	if seconds, err := strconv.ParseInt(strings.TrimSpace(sections["now"]), 10, 64); err == nil {
+		clk.now = time.Unix(seconds, 0)
+	}
+
+	offset := strings.TrimSpace(sections["offset"])
+	if location, err := time.LoadLocation(clk.name); err == nil && clk.name != "" {
+		clk.location = location
+	} else if parsed, err := time.Parse("-0700", offset); err == nil {
+		_, seconds := parsed.Zone()
+		clk.location = time.FixedZone(offset, seconds)
+		if clk.name == "" {
+			clk.name = offset
+		}
+	}
+	if clk.name == "" {
+		clk.name = "UTC"
+	}
+
+	clk.now = clk.now.In(clk.location)
+	return clk
+}
+
+// lineLocation returns the location the schedule of a crontab line is evaluated in
+func (c clock) lineLocation(line cronLine) *time.Location {
+	if line.timezone != "" {
+		if location, err := time.LoadLocation(line.timezone); err == nil {
+			return location
+		}
+	}
+	return c.location
+}
+
+// parseCrontab extracts the job lines of a crontab. System crontabs name the user to run as after
+// the schedule.
+func parseCrontab(content string, withUser bool) []cronLine {
+	var lines []cronLine
+	timezone := ""
+
+	for i, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if match := environmentPattern.FindStringSubmatch(line); match != nil {
+			if match[1] == "CRON_TZ" {
+				timezone = strings.Trim(match[2], `"'`)
+			}
+			continue
+		}
+
+		schedule, user, command, ok := splitCronLine(line, withUser)
+		if !ok {
+			continue
+		}
+		lines = append(lines, cronLine{
+			line:     i + 1,
+			schedule: schedule,
+			user:     user,
+			command:  command,
+			timezone: timezone,
+		})
+	}
+
+	return lines
+}
+
+// parseAnacrontab maps the directories run by anacron to their schedule. Entries have the form
+// "period delay job-id command".
+func parseAnacrontab(content string) map[string]string {
+	schedules := make(map[string]string)
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || environmentPattern.MatchString(line) {
+			continue
+		}
+		fields, command := cutFields(line, 3)
+		if len(fields) < 3 {
+			continue
+		}
+		schedule, ok := anacronPeriods[fields[0]]
+		if !ok {
+			continue
+		}
+		for _, word := range strings.Fields(command) {
+			if strings.HasPrefix(word, "/etc/cron.") {
+				schedules[word] = schedule
+			}
+		}
+	}
+
+	return schedules
+}
+
+// parseCronRuns returns the last time each user ran each command according to the journal
+func parseCronRuns(output string) map[string]time.Time {
+	runs := make(map[string]time.Time)
+
+	for _, line := range strings.Split(output, "\n") {
+		match := cronRunPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		seconds, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		at := time.Unix(seconds, 0).UTC()
+		key := match[2] + "\x00" + match[3]
+		if at.After(runs[key]) {
+			runs[key] = at
+		}
+	}
+
+	return runs
+}
+
+// parseTimers parses the 'systemctl show' blocks of the timer units
+func parseTimers(output string, btime string, clk clock) []Job {
+	var jobs []Job
+	seen := make(map[string]bool)
+
+	var boot time.Time
+	if seconds, err := strconv.ParseInt(strings.TrimSpace(btime), 10, 64); err == nil {
+		boot = time.Unix(seconds, 0).UTC()
+	}
+
+	for _, block := range strings.Split(output, "\n\n") {
+		properties := make(map[string][]string)
+		for _, line := range strings.Split(block, "\n") {
+			if key, value, found := strings.Cut(line, "="); found {
+				properties[key] = append(properties[key], value)
+			}
+		}
+		property := func(key string) string {
+			if values := properties[key]; len(values) > 0 {
+				return strings.TrimSpace(values[0])
+			}
+			return ""
+		}
+
+		id := property("Id")
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		var specs []string
+		for _, key := range []string{"TimersCalendar", "TimersMonotonic"} {
+			for _, value := range properties[key] {
+				for _, match := range timerSpecPattern.FindAllStringSubmatch(value, -1) {
+					specs = append(specs, match[1]+"="+strings.TrimSpace(match[2]))
+				}
+			}
+		}
+
+		job := Job{
+			Kind:     JobKindTimer,
+			Source:   id,
+			Schedule: strings.Join(specs, "; "),
+			Enabled:  property("ActiveState") == "active",
+			Result:   property("Result"),
+		}
+		if triggers := strings.Fields(property("Triggers")); len(triggers) > 0 {
+			job.Unit = triggers[0]
+		}
+		if job.Enabled {
+			next := parseSystemdTimestamp(property("NextElapseUSecRealtime"), clk.location)
+			if span, ok := parseSystemdTimespan(property("NextElapseUSecMonotonic")); ok && !boot.IsZero() {
+				if monotonic := boot.Add(span); next == nil || monotonic.Before(*next) {
+					next = &monotonic
+				}
+			}
+			job.NextRun = next
+		}
+		job.LastRun = parseSystemdTimestamp(property("LastTriggerUSec"), clk.location)
+
+		jobs = append(jobs, job)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Source < jobs[j].Source })
+	return jobs
+}
+
+// systemdTimespanUnits maps the units of systemd time spans to durations
+var systemdTimespanUnits = map[string]time.Duration{
+	"us":     time.Microsecond,
+	"ms":     time.Millisecond,
+	"s":      time.Second,
+	"min":    time.Minute,
+	"h":      time.Hour,
+	"d":      24 * time.Hour,
+	"w":      7 * 24 * time.Hour,
+	"month":  2629800 * time.Second,
+	"months": 2629800 * time.Second,
+	"y":      31557600 * time.Second,
+}
+
+// systemdTimespanPattern matches a component of a systemd time span, e.g. "3min" or "1.5s"
+var systemdTimespanPattern = regexp.MustCompile(`^([0-9.]+)([a-z]+)$`)
+
+// parseSystemdTimestamp parses a timestamp printed by systemctl show, either as "@<unix seconds>" or
+// as "Sat 2026-10-17 00:00:01 UTC". Unset timestamps yield nil.
+func parseSystemdTimestamp(value string, location *time.Location) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "n/a" || value == "0" {
+		return nil
+	}
+
+	if seconds, found := strings.CutPrefix(value, "@"); found {
+		unix, err := strconv.ParseInt(seconds, 10, 64)
+		if err != nil || unix == 0 {
+			return nil
+		}
+		t := time.Unix(unix, 0).UTC()
+		return &t
+	}
+
+	t, err := time.ParseInLocation("Mon 2006-01-02 15:04:05 MST", value, location)
+	if err != nil {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// parseSystemdTimespan parses a time span printed by systemctl show, e.g. "1d 2h 3min 4.5s"
+func parseSystemdTimespan(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" || value == "infinity" {
+		return 0, false
+	}
+
+	var total time.Duration
+	for _, part := range strings.Fields(value) {
+		match := systemdTimespanPattern.FindStringSubmatch(part)
+		if match == nil {
+			return 0, false
+		}
+		unit, ok := systemdTimespanUnits[match[2]]
+		if !ok {
+			return 0, false
+		}
+		number, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, false
+		}
+		total += time.Duration(number * float64(unit))
+	}
+
+	return total, total > 0
+}
@@ -0,0 +1,16 @@
+package docker
+
+// ContainerStats contains a resource usage sample of a running container from 'docker stats'
+type ContainerStats struct {
+	ContainerID       string  `json:"container_id"`
+	Name              string  `json:"name"`
+	CPUPercentage     float64 `json:"cpu_percentage"` // Share of one CPU, so it can exceed 100 on multi-core hosts
+	MemoryUsageBytes  uint64  `json:"memory_usage_bytes"`
+	MemoryLimitBytes  uint64  `json:"memory_limit_bytes"`
+	MemoryPercentage  float64 `json:"memory_percentage"`
+	NetworkRxBytes    uint64  `json:"network_rx_bytes"` // Cumulative since the container started
+	NetworkTxBytes    uint64  `json:"network_tx_bytes"`
+	BlockReadBytes    uint64  `json:"block_read_bytes"` // Cumulative since the container started
+	BlockWrittenBytes uint64  `json:"block_written_bytes"`
+	PIDs              int     `json:"pids"`
+}
@@ -0,0 +1,18 @@
+package docker
+
+import "time"
+
+// ContainerStatus contains the state of a container from 'docker inspect'
+type ContainerStatus struct {
+	ContainerID   string     `json:"container_id"`
+	Name          string     `json:"name"`
+	Image         string     `json:"image"`    // Image reference the container was created from
+	ImageID       string     `json:"image_id"` // ID of the image, e.g. "sha256:..."
+	State         string     `json:"state"`    // created, running, paused, restarting, removing, exited or dead
+	ExitCode      int        `json:"exit_code"`
+	OOMKilled     bool       `json:"oom_killed"`
+	RestartCount  int        `json:"restart_count"`
+	RestartPolicy string     `json:"restart_policy"` // no, always, unless-stopped or on-failure
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
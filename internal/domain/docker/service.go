@@ -26,6 +26,12 @@ type Service interface {
 	// GetContainerDetail retrieves detailed information about a specific Docker container
 	GetContainerDetail(ctx context.Context, sessionID string, containerID string) (*ContainerDetail, error)
 
+	// GetContainerStatuses retrieves the state, exit code and restart count of all containers
+	GetContainerStatuses(ctx context.Context, sessionID string) ([]ContainerStatus, error)
+
+	// GetContainerStats samples CPU, memory, network and block I/O usage of the running containers
+	GetContainerStats(ctx context.Context, sessionID string) ([]ContainerStats, error)
+
 	// GetImages retrieves information about Docker images
 	GetImages(ctx context.Context, sessionID string) ([]Image, error)
 
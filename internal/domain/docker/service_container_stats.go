@@ -0,0 +1,117 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// dockerStatsLine is a line of 'docker stats --format "{{json .}}"' output
+type dockerStatsLine struct {
+	ID       string `json:"ID"`
+	Name     string `json:"Name"`
+	CPUPerc  string `json:"CPUPerc"`
+	MemUsage string `json:"MemUsage"`
+	MemPerc  string `json:"MemPerc"`
+	NetIO    string `json:"NetIO"`
+	BlockIO  string `json:"BlockIO"`
+	PIDs     string `json:"PIDs"`
+}
+
+// dockerSizeUnits are the unit suffixes docker uses in stats output, binary for memory and decimal for I/O
+var dockerSizeUnits = map[string]float64{
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// GetContainerStats implements the Service interface
+func (s *service) GetContainerStats(ctx context.Context, sessionID string) ([]ContainerStats, error) {
+	// Take a single sample of the running containers
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, "docker stats --no-stream --no-trunc --format '{{json .}}'")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseContainerStats(output)
+}
+
+// parseContainerStats parses JSON lines of 'docker stats'
+func parseContainerStats(output string) ([]ContainerStats, error) {
+	stats := []ContainerStats{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var raw dockerStatsLine
+		if err := json.Unmarshal([]byte(line), &raw); err != nil {
+			return nil, fmt.Errorf("%w: unexpected docker stats output: %v", ErrCommandFailed, err)
+		}
+
+		memoryUsage, memoryLimit := splitDockerSizes(raw.MemUsage)
+		networkRx, networkTx := splitDockerSizes(raw.NetIO)
+		blockRead, blockWritten := splitDockerSizes(raw.BlockIO)
+		pids, _ := strconv.Atoi(raw.PIDs)
+
+		stats = append(stats, ContainerStats{
+			ContainerID:       raw.ID,
+			Name:              raw.Name,
+			CPUPercentage:     parseDockerPercentage(raw.CPUPerc),
+			MemoryUsageBytes:  memoryUsage,
+			MemoryLimitBytes:  memoryLimit,
+			MemoryPercentage:  parseDockerPercentage(raw.MemPerc),
+			NetworkRxBytes:    networkRx,
+			NetworkTxBytes:    networkTx,
+			BlockReadBytes:    blockRead,
+			BlockWrittenBytes: blockWritten,
+			PIDs:              pids,
+		})
+	}
+
+	return stats, nil
+}
+
+// parseDockerPercentage parses a percentage such as "12.34%", returning 0 for "--"
+func parseDockerPercentage(value string) float64 {
+	percentage, _ := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+	return percentage
+}
+
+// splitDockerSizes parses a pair of sizes such as "1.5MiB / 7.6GiB"
+func splitDockerSizes(value string) (uint64, uint64) {
+	first, second, _ := strings.Cut(value, "/")
+	return parseDockerSize(first), parseDockerSize(second)
+}
+
+// parseDockerSize parses a size such as "12.3kB" or "1.5GiB" into bytes
+func parseDockerSize(value string) uint64 {
+	value = strings.TrimSpace(value)
+	index := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if index <= 0 {
+		return 0
+	}
+
+	number, err := strconv.ParseFloat(value[:index], 64)
+	if err != nil {
+		return 0
+	}
+	unit, ok := dockerSizeUnits[strings.ToLower(strings.TrimSpace(value[index:]))]
+	if !ok {
+		return 0
+	}
+
+	return uint64(math.Round(number * unit))
+}
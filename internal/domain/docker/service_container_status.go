@@ -0,0 +1,67 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// containerStatusScript inspects all containers, printing one line of pipe-separated state fields each
+const containerStatusScript = `ids=$(docker ps -aq --no-trunc) || exit 1
+[ -z "$ids" ] || docker inspect --format '{{.Id}}|{{.Name}}|{{.Config.Image}}|{{.Image}}|{{.State.Status}}|{{.State.ExitCode}}|{{.State.OOMKilled}}|{{.RestartCount}}|{{.HostConfig.RestartPolicy.Name}}|{{.State.StartedAt}}|{{.State.FinishedAt}}' $ids`
+
+// GetContainerStatuses implements the Service interface
+func (s *service) GetContainerStatuses(ctx context.Context, sessionID string) ([]ContainerStatus, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, containerStatusScript)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseContainerStatuses(output)
+}
+
+// parseContainerStatuses parses the output of containerStatusScript
+func parseContainerStatuses(output string) ([]ContainerStatus, error) {
+	statuses := []ContainerStatus{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "|")
+		if len(fields) != 11 {
+			return nil, fmt.Errorf("%w: unexpected docker inspect output: %s", ErrCommandFailed, line)
+		}
+
+		exitCode, _ := strconv.Atoi(fields[5])
+		restartCount, _ := strconv.Atoi(fields[7])
+		statuses = append(statuses, ContainerStatus{
+			ContainerID:   fields[0],
+			Name:          strings.TrimPrefix(fields[1], "/"),
+			Image:         fields[2],
+			ImageID:       fields[3],
+			State:         fields[4],
+			ExitCode:      exitCode,
+			OOMKilled:     fields[6] == "true",
+			RestartCount:  restartCount,
+			RestartPolicy: fields[8],
+			StartedAt:     parseDockerTime(fields[9]),
+			FinishedAt:    parseDockerTime(fields[10]),
+		})
+	}
+
+	return statuses, nil
+}
+
+// parseDockerTime parses a timestamp of 'docker inspect', which reports "0001-01-01T00:00:00Z" for
+// events that did not happen yet
+func parseDockerTime(value string) *time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil || parsed.Year() <= 1 {
+		return nil
+	}
+	return &parsed
+}
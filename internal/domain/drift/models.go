@@ -0,0 +1,125 @@
+package drift
+
+import "time"
+
+// Kinds of checks
+const (
+	KindPackage   = "package"
+	KindService   = "service"
+	KindContainer = "container"
+	KindFile      = "file"
+)
+
+// Check statuses
+const (
+	StatusOK    = "ok"
+	StatusDrift = "drift"
+	StatusError = "error" // The actual state could not be read
+)
+
+// Desired presence of a package or file
+const (
+	StatePresent = "present"
+	StateAbsent  = "absent"
+)
+
+// Spec declares the expected state of a host. It is written in YAML, or JSON, e.g.
+//
+//	name: web
+//	packages:
+//	  - name: nginx
+//	    version: ">= 1.18, < 1.25"
+//	  - name: telnetd
+//	    state: absent
+//	services:
+//	  - name: nginx
+//	    running: true
+//	containers:
+//	  - name: app
+//	    digest: sha256:4c1e...
+//	files:
+//	  - path: /etc/nginx/nginx.conf
+//	    sha256: 9f86d081...
+//	    mode: "0644"
+//	    owner: root
+type Spec struct {
+	Name       string          `yaml:"name,omitempty" json:"name,omitempty"`
+	Packages   []PackageSpec   `yaml:"packages,omitempty" json:"packages,omitempty"`
+	Services   []ServiceSpec   `yaml:"services,omitempty" json:"services,omitempty"`
+	Containers []ContainerSpec `yaml:"containers,omitempty" json:"containers,omitempty"`
+	Files      []FileSpec      `yaml:"files,omitempty" json:"files,omitempty"`
+}
+
+// PackageSpec is a package that must be installed, optionally in a version range, or must not be
+type PackageSpec struct {
+	Name    string `yaml:"name" json:"name"`
+	Version string `yaml:"version,omitempty" json:"version,omitempty"` // Comma-separated constraints, e.g. ">= 1.18, < 1.25" or "1.24.0-2ubuntu7"
+	State   string `yaml:"state,omitempty" json:"state,omitempty"`     // present (default) or absent
+}
+
+// ServiceSpec is a systemd service that must be enabled, or disabled, and optionally running or not
+type ServiceSpec struct {
+	Name    string `yaml:"name" json:"name"`                           // Unit name; .service is appended when there is no suffix
+	Enabled *bool  `yaml:"enabled,omitempty" json:"enabled,omitempty"` // Default true
+	Running *bool  `yaml:"running,omitempty" json:"running,omitempty"` // Not checked by default
+}
+
+// ContainerSpec is a Docker container that must exist, optionally created from an image pinned by digest
+type ContainerSpec struct {
+	Name    string `yaml:"name" json:"name"`
+	Image   string `yaml:"image,omitempty" json:"image,omitempty"`     // Image reference the container was created from, e.g. nginx:1.25
+	Digest  string `yaml:"digest,omitempty" json:"digest,omitempty"`   // Repository digest of its image, e.g. sha256:4c1e...
+	Running *bool  `yaml:"running,omitempty" json:"running,omitempty"` // Default true
+}
+
+// FileSpec is a file that must exist with a checksum, mode and ownership, or must not exist
+type FileSpec struct {
+	Path   string `yaml:"path" json:"path"`
+	SHA256 string `yaml:"sha256,omitempty" json:"sha256,omitempty"`
+	Mode   string `yaml:"mode,omitempty" json:"mode,omitempty"` // Octal permissions, e.g. "0644"
+	Owner  string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Group  string `yaml:"group,omitempty" json:"group,omitempty"`
+	State  string `yaml:"state,omitempty" json:"state,omitempty"` // present (default) or absent
+}
+
+// CheckResult is the outcome of checking one item of a spec
+type CheckResult struct {
+	Kind     string `json:"kind"`   // package, service, container or file
+	Name     string `json:"name"`   // Package, unit or container name, or file path
+	Status   string `json:"status"` // ok, drift or error
+	Expected string `json:"expected"`
+	Actual   string `json:"actual,omitempty"`
+	Message  string `json:"message,omitempty"` // What drifted, or why the state could not be read
+}
+
+// ReportSummary counts the results of a drift report by status
+type ReportSummary struct {
+	OK     int `json:"ok"`
+	Drift  int `json:"drift"`
+	Errors int `json:"errors"`
+}
+
+// Report is the outcome of checking a host against a spec
+type Report struct {
+	SpecID     string        `json:"spec_id,omitempty"` // Stored spec the check ran for
+	SpecName   string        `json:"spec_name,omitempty"`
+	Host       string        `json:"host"`
+	CheckedAt  time.Time     `json:"checked_at"`
+	DurationMS int64         `json:"duration_ms"`
+	Compliant  bool          `json:"compliant"`       // Every item matches the spec
+	Error      string        `json:"error,omitempty"` // Why a scheduled check could not run, e.g. no active session
+	Summary    ReportSummary `json:"summary"`
+	Results    []CheckResult `json:"results"`
+}
+
+// StoredSpec is a spec kept for a host and checked on demand or every interval
+type StoredSpec struct {
+	ID          string     `json:"id"`
+	Host        string     `json:"host"`
+	User        string     `json:"user"`               // SSH user that stored the spec, whose sessions run the scheduled checks
+	Interval    string     `json:"interval,omitempty"` // Empty when checked on demand only
+	Spec        Spec       `json:"spec"`
+	CreatedAt   time.Time  `json:"created_at"`
+	NextCheckAt *time.Time `json:"next_check_at,omitempty"`
+	LastReport  *Report    `json:"last_report,omitempty"`
+}
@@ -0,0 +1,305 @@
+package drift
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"remote-server-api/internal/domain/auth"
+	"remote-server-api/internal/domain/docker"
+	"remote-server-api/internal/domain/server"
+	"remote-server-api/internal/domain/systemd"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound = errors.New("session not found or expired")
+	ErrInvalidSpec     = errors.New("invalid drift spec")
+	ErrSpecNotFound    = errors.New("drift spec not found")
+)
+
+// maxReports is the number of reports kept per stored spec
+const maxReports = 20
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// GetSession retrieves an SSH session by ID
+	GetSession(ctx context.Context, sessionID string) (*auth.Session, error)
+
+	// ListSessions returns all stored SSH sessions
+	ListSessions(ctx context.Context) ([]*auth.Session, error)
+
+	// RunCommand executes a command on the SSH session
+	RunCommand(ctx context.Context, sessionID string, command string) (string, error)
+}
+
+// ServerSource provides the installed packages of the server details service
+type ServerSource interface {
+	GetInstalledLibraries(ctx context.Context, sessionID string) ([]server.Library, error)
+}
+
+// SystemdSource provides the units of the systemd service
+type SystemdSource interface {
+	GetUnit(ctx context.Context, sessionID string, name string) (*systemd.UnitDetail, error)
+}
+
+// DockerSource provides the containers and image digests of the Docker service
+type DockerSource interface {
+	GetContainerStatuses(ctx context.Context, sessionID string) ([]docker.ContainerStatus, error)
+	GetImageDetail(ctx context.Context, sessionID string, imageID string) (*docker.ImageDetail, error)
+}
+
+// Sources are the services the actual state of a host is read from
+type Sources struct {
+	Server  ServerSource
+	Systemd SystemdSource
+	Docker  DockerSource
+}
+
+// Service defines the drift detection service. Stored specs belong to the host of the session that
+// created them and are kept in memory.
+type Service interface {
+	// Run checks the stored specs with an interval whenever they are due, through an active session of
+	// the user that stored them, until the context is cancelled
+	Run(ctx context.Context)
+
+	// CheckSpec checks the session's host against a spec without storing it
+	CheckSpec(ctx context.Context, sessionID string, spec Spec) (*Report, error)
+
+	// ListSpecs lists the stored specs of the session's host
+	ListSpecs(ctx context.Context, sessionID string) ([]StoredSpec, error)
+
+	// CreateSpec stores a spec for the session's host, checked every interval unless it is empty
+	CreateSpec(ctx context.Context, sessionID string, spec Spec, interval string) (*StoredSpec, error)
+
+	// GetSpec retrieves a stored spec of the session's host
+	GetSpec(ctx context.Context, sessionID string, id string) (*StoredSpec, error)
+
+	// DeleteSpec removes a stored spec of the session's host together with its reports
+	DeleteSpec(ctx context.Context, sessionID string, id string) (*StoredSpec, error)
+
+	// CheckStoredSpec checks the session's host against a stored spec now and keeps the report
+	CheckStoredSpec(ctx context.Context, sessionID string, id string) (*Report, error)
+
+	// ListReports lists the latest reports of a stored spec, newest first
+	ListReports(ctx context.Context, sessionID string, id string) ([]Report, error)
+}
+
+type service struct {
+	sessionRepo SessionRepository
+	sources     Sources
+
+	mu      sync.Mutex
+	specs   map[string]*StoredSpec
+	reports map[string][]Report // Spec ID, newest first
+
+	// checking marks the stored specs with a check in progress
+	checking sync.Map
+}
+
+// NewService creates a new drift detection service
+func NewService(sessionRepo SessionRepository, sources Sources) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+		sources:     sources,
+		specs:       make(map[string]*StoredSpec),
+		reports:     make(map[string][]Report),
+	}
+}
+
+// CheckSpec implements the Service interface
+func (s *service) CheckSpec(ctx context.Context, sessionID string, spec Spec) (*Report, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSpec(&spec); err != nil {
+		return nil, err
+	}
+
+	return s.check(ctx, sessionID, host, &spec), nil
+}
+
+// ListSpecs implements the Service interface
+func (s *service) ListSpecs(ctx context.Context, sessionID string) ([]StoredSpec, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	specs := []StoredSpec{}
+	for _, stored := range s.specs {
+		if stored.Host == host {
+			specs = append(specs, *stored)
+		}
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].CreatedAt.Before(specs[j].CreatedAt) })
+	return specs, nil
+}
+
+// CreateSpec implements the Service interface
+func (s *service) CreateSpec(ctx context.Context, sessionID string, spec Spec, interval string) (*StoredSpec, error) {
+	session, err := s.session(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSpec(&spec); err != nil {
+		return nil, err
+	}
+	every, err := parseInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	stored := &StoredSpec{ID: id, Host: session.Host, User: session.Username, Spec: spec, CreatedAt: time.Now().UTC()}
+	if every > 0 {
+		stored.Interval = every.String()
+		next := stored.CreatedAt
+		stored.NextCheckAt = &next
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.specs[id] = stored
+	return stored, nil
+}
+
+// GetSpec implements the Service interface
+func (s *service) GetSpec(ctx context.Context, sessionID string, id string) (*StoredSpec, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.findSpecLocked(host, id)
+	if err != nil {
+		return nil, err
+	}
+	result := *stored
+	return &result, nil
+}
+
+// DeleteSpec implements the Service interface
+func (s *service) DeleteSpec(ctx context.Context, sessionID string, id string) (*StoredSpec, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.findSpecLocked(host, id)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.specs, id)
+	delete(s.reports, id)
+	return stored, nil
+}
+
+// CheckStoredSpec implements the Service interface
+func (s *service) CheckStoredSpec(ctx context.Context, sessionID string, id string) (*Report, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	stored, err := s.findSpecLocked(host, id)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	report := s.check(ctx, sessionID, host, &stored.Spec)
+	report.SpecID = id
+	s.record(id, *report)
+	return report, nil
+}
+
+// ListReports implements the Service interface
+func (s *service) ListReports(ctx context.Context, sessionID string, id string) ([]Report, error) {
+	host, err := s.sessionHost(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.findSpecLocked(host, id); err != nil {
+		return nil, err
+	}
+	reports := append([]Report{}, s.reports[id]...)
+	return reports, nil
+}
+
+// record keeps the report of a stored spec, unless the spec was deleted during the check
+func (s *service) record(id string, report Report) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.specs[id]
+	if !ok {
+		return
+	}
+	stored.LastReport = &report
+
+	reports := append([]Report{report}, s.reports[id]...)
+	if len(reports) > maxReports {
+		reports = reports[:maxReports]
+	}
+	s.reports[id] = reports
+}
+
+// findSpecLocked returns a stored spec of a host. The caller must hold the lock.
+func (s *service) findSpecLocked(host string, id string) (*StoredSpec, error) {
+	stored, ok := s.specs[id]
+	if !ok || stored.Host != host {
+		return nil, fmt.Errorf("%w: %s", ErrSpecNotFound, id)
+	}
+	return stored, nil
+}
+
+// session retrieves an active session
+func (s *service) session(ctx context.Context, sessionID string) (*auth.Session, error) {
+	session, err := s.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+	return session, nil
+}
+
+// sessionHost returns the address of the SSH server of a session
+func (s *service) sessionHost(ctx context.Context, sessionID string) (string, error) {
+	session, err := s.session(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+	return session.Host, nil
+}
+
+// newID generates a random identifier for a stored spec
+func newID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
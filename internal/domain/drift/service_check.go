@@ -0,0 +1,500 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"remote-server-api/internal/domain/docker"
+	"remote-server-api/internal/domain/server"
+	"remote-server-api/internal/domain/systemd"
+	"remote-server-api/pkg/shell"
+)
+
+// fileScript prints, for each path given as a positional parameter, a "### file <n>" section holding
+// "missing", or the file type, octal mode, owner and group on one line followed by the SHA-256 of
+// regular files. Symbolic links are followed. Files are read with sudo when it is available, so that
+// files readable only by root can be checked.
+const fileScript = `S="` + shell.SudoIfAvailable + `"
+i=0
+for p in "$@"; do
+  echo "### file $i"
+  i=$((i+1))
+  if ! $S test -e "$p"; then
+    echo missing
+    continue
+  fi
+  $S stat -L -c '%F|%a|%U|%G' -- "$p" 2>&1
+  if $S test -f "$p"; then
+    if command -v sha256sum >/dev/null 2>&1; then
+      $S sha256sum -- "$p" 2>&1
+    else
+      $S openssl dgst -sha256 -r "$p" 2>&1
+    fi
+  fi
+done
+true`
+
+// check reads the actual state of a host for the items of a spec and compares them. The sources of
+// the kinds the spec declares are read concurrently; a source that fails marks its items as errors.
+func (s *service) check(ctx context.Context, sessionID string, host string, spec *Spec) *Report {
+	started := time.Now()
+
+	var packages, services, containers, files []CheckResult
+	var wg sync.WaitGroup
+	run := func(declared int, checkKind func()) {
+		if declared == 0 {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkKind()
+		}()
+	}
+	run(len(spec.Packages), func() { packages = s.checkPackages(ctx, sessionID, spec.Packages) })
+	run(len(spec.Services), func() { services = s.checkServices(ctx, sessionID, spec.Services) })
+	run(len(spec.Containers), func() { containers = s.checkContainers(ctx, sessionID, spec.Containers) })
+	run(len(spec.Files), func() { files = s.checkFiles(ctx, sessionID, spec.Files) })
+	wg.Wait()
+
+	report := &Report{
+		SpecName:  spec.Name,
+		Host:      host,
+		CheckedAt: started.UTC(),
+		Results:   make([]CheckResult, 0, len(packages)+len(services)+len(containers)+len(files)),
+	}
+	for _, results := range [][]CheckResult{packages, services, containers, files} {
+		report.Results = append(report.Results, results...)
+	}
+	summarize(report)
+	report.DurationMS = time.Since(started).Milliseconds()
+	return report
+}
+
+// summarize counts the results of a report by status
+func summarize(report *Report) {
+	report.Summary = ReportSummary{}
+	for _, result := range report.Results {
+		switch result.Status {
+		case StatusOK:
+			report.Summary.OK++
+		case StatusDrift:
+			report.Summary.Drift++
+		default:
+			report.Summary.Errors++
+		}
+	}
+	report.Compliant = report.Error == "" && report.Summary.Drift == 0 && report.Summary.Errors == 0
+}
+
+// checkPackages compares the installed packages with the declared ones
+func (s *service) checkPackages(ctx context.Context, sessionID string, specs []PackageSpec) []CheckResult {
+	results := make([]CheckResult, 0, len(specs))
+
+	libraries, err := s.sources.Server.GetInstalledLibraries(ctx, sessionID)
+	if err != nil {
+		for _, pkg := range specs {
+			results = append(results, errorResult(KindPackage, pkg.Name, packageExpectation(pkg), "failed to list installed packages: "+err.Error()))
+		}
+		return results
+	}
+
+	installed := make(map[string][]server.Library)
+	for _, library := range libraries {
+		installed[library.Name] = append(installed[library.Name], library)
+	}
+
+	for _, pkg := range specs {
+		result := CheckResult{Kind: KindPackage, Name: pkg.Name, Expected: packageExpectation(pkg), Status: StatusOK}
+		versions := packageVersions(installed[pkg.Name])
+		if len(versions) > 0 {
+			result.Actual = strings.Join(versions, ", ")
+		} else {
+			result.Actual = "not installed"
+		}
+
+		switch {
+		case pkg.State == StateAbsent && len(versions) > 0:
+			result.Status = StatusDrift
+			result.Message = "package is installed"
+		case pkg.State == StatePresent && len(versions) == 0:
+			result.Status = StatusDrift
+			result.Message = "package is not installed"
+		case pkg.Version != "":
+			constraints, _ := parseConstraints(pkg.Version)
+			for _, version := range versions {
+				if !satisfies(version, constraints) {
+					result.Status = StatusDrift
+					result.Message = fmt.Sprintf("version %s does not satisfy %s", version, pkg.Version)
+					break
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// packageVersions returns the distinct versions of the installed instances of a package, e.g. one per
+// architecture
+func packageVersions(libraries []server.Library) []string {
+	var versions []string
+	seen := make(map[string]bool)
+	for _, library := range libraries {
+		if !seen[library.Version] {
+			seen[library.Version] = true
+			versions = append(versions, library.Version)
+		}
+	}
+	return versions
+}
+
+// packageExpectation describes a declared package, e.g. "installed, >= 1.18, < 1.25"
+func packageExpectation(pkg PackageSpec) string {
+	if pkg.State == StateAbsent {
+		return "not installed"
+	}
+	if pkg.Version != "" {
+		return "installed, " + pkg.Version
+	}
+	return "installed"
+}
+
+// checkServices compares the systemd services with the declared ones. Units are looked up one by one,
+// since only loaded units are listed and a disabled, stopped service may not be loaded.
+func (s *service) checkServices(ctx context.Context, sessionID string, specs []ServiceSpec) []CheckResult {
+	results := make([]CheckResult, 0, len(specs))
+
+	for _, svc := range specs {
+		result := CheckResult{Kind: KindService, Name: svc.Name, Expected: serviceExpectation(svc), Status: StatusOK}
+		unit, err := s.sources.Systemd.GetUnit(ctx, sessionID, svc.Name)
+		if errors.Is(err, systemd.ErrUnitNotFound) {
+			result.Actual = "not found"
+			if *svc.Enabled || (svc.Running != nil && *svc.Running) {
+				result.Status = StatusDrift
+				result.Message = "unit does not exist"
+			}
+			results = append(results, result)
+			continue
+		}
+		if err != nil {
+			results = append(results, errorResult(KindService, svc.Name, result.Expected, "failed to get unit: "+err.Error()))
+			continue
+		}
+
+		fileState := unit.UnitFileState
+		if fileState == "" {
+			fileState = unit.LoadState
+		}
+		result.Actual = fileState + ", " + unit.ActiveState
+
+		var problems []string
+		enabled := fileState == "enabled" || fileState == "enabled-runtime" || fileState == "static" || fileState == "alias"
+		if *svc.Enabled && !enabled {
+			problems = append(problems, "unit is "+fileState+", not enabled")
+		}
+		if !*svc.Enabled && (fileState == "enabled" || fileState == "enabled-runtime") {
+			problems = append(problems, "unit is enabled")
+		}
+		if svc.Running != nil {
+			running := unit.ActiveState == "active" || unit.ActiveState == "reloading" || unit.ActiveState == "activating"
+			if *svc.Running && !running {
+				problems = append(problems, "unit is "+unit.ActiveState+", not running")
+			}
+			if !*svc.Running && running {
+				problems = append(problems, "unit is running")
+			}
+		}
+		if len(problems) > 0 {
+			result.Status = StatusDrift
+			result.Message = strings.Join(problems, "; ")
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// serviceExpectation describes a declared service, e.g. "enabled, running"
+func serviceExpectation(svc ServiceSpec) string {
+	expected := "enabled"
+	if svc.Enabled != nil && !*svc.Enabled {
+		expected = "disabled"
+	}
+	if svc.Running != nil {
+		if *svc.Running {
+			expected += ", running"
+		} else {
+			expected += ", stopped"
+		}
+	}
+	return expected
+}
+
+// checkContainers compares the Docker containers with the declared ones. The repository digests of
+// the images of containers pinned by digest are looked up once per image.
+func (s *service) checkContainers(ctx context.Context, sessionID string, specs []ContainerSpec) []CheckResult {
+	results := make([]CheckResult, 0, len(specs))
+
+	statuses, err := s.sources.Docker.GetContainerStatuses(ctx, sessionID)
+	if err != nil {
+		for _, container := range specs {
+			results = append(results, errorResult(KindContainer, container.Name, containerExpectation(container), "failed to list containers: "+err.Error()))
+		}
+		return results
+	}
+
+	byName := make(map[string]docker.ContainerStatus, len(statuses))
+	for _, status := range statuses {
+		byName[strings.TrimPrefix(status.Name, "/")] = status
+	}
+
+	images := make(map[string]*docker.ImageDetail)
+	imageErrs := make(map[string]error)
+
+	for _, container := range specs {
+		result := CheckResult{Kind: KindContainer, Name: container.Name, Expected: containerExpectation(container), Status: StatusOK}
+		status, ok := byName[container.Name]
+		if !ok {
+			result.Actual = "not found"
+			result.Status = StatusDrift
+			result.Message = "container does not exist"
+			results = append(results, result)
+			continue
+		}
+		result.Actual = status.State + ", " + status.Image
+
+		var problems []string
+		running := status.State == "running"
+		if *container.Running && !running {
+			problems = append(problems, "container is "+status.State+", not running")
+		}
+		if !*container.Running && running {
+			problems = append(problems, "container is running")
+		}
+		if container.Image != "" && !sameImage(status.Image, container.Image) {
+			problems = append(problems, "container was created from "+status.Image)
+		}
+
+		if container.Digest != "" {
+			if _, fetched := images[status.ImageID]; !fetched && imageErrs[status.ImageID] == nil {
+				detail, err := s.sources.Docker.GetImageDetail(ctx, sessionID, status.ImageID)
+				if err != nil {
+					imageErrs[status.ImageID] = err
+				} else {
+					images[status.ImageID] = detail
+				}
+			}
+
+			if err := imageErrs[status.ImageID]; err != nil {
+				results = append(results, errorResult(KindContainer, container.Name, result.Expected, "failed to inspect image: "+err.Error()))
+				continue
+			}
+			digests := imageDigests(status.Image, images[status.ImageID])
+			if !hasDigest(digests, container.Digest) {
+				if len(digests) == 0 {
+					problems = append(problems, "image has no repository digest, e.g. it was built locally")
+				} else {
+					problems = append(problems, "image digest is "+strings.Join(digests, ", "))
+				}
+			}
+		}
+
+		if len(problems) > 0 {
+			result.Status = StatusDrift
+			result.Message = strings.Join(problems, "; ")
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// containerExpectation describes a declared container, e.g. "running, nginx:1.25@sha256:4c1e..."
+func containerExpectation(container ContainerSpec) string {
+	expected := "running"
+	if container.Running != nil && !*container.Running {
+		expected = "not running"
+	}
+	if container.Image != "" || container.Digest != "" {
+		expected += ", " + container.Image
+		if container.Digest != "" {
+			expected += "@" + container.Digest
+		}
+	}
+	return expected
+}
+
+// sameImage reports whether the image reference of a container is the declared one. A reference
+// without a tag stands for "latest", and Docker Hub references may leave out "docker.io/library/".
+func sameImage(actual string, expected string) bool {
+	return normalizeImage(actual) == normalizeImage(expected)
+}
+
+// normalizeImage expands an image reference to its registry, repository and tag, leaving out the digest
+func normalizeImage(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	name, tag := image, "latest"
+	if i := strings.LastIndexByte(image, ':'); i > strings.LastIndexByte(image, '/') {
+		name, tag = image[:i], image[i+1:]
+	}
+	if !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	if first, _, _ := strings.Cut(name, "/"); !strings.ContainsAny(first, ".:") && first != "localhost" {
+		name = "docker.io/" + name
+	}
+	return name + ":" + tag
+}
+
+// imageDigests returns the digests a container's image is known by: the digest of its reference, when
+// it was created from one pinned by digest, and the repository digests of the image
+func imageDigests(reference string, detail *docker.ImageDetail) []string {
+	var digests []string
+	if _, digest, ok := strings.Cut(reference, "@"); ok {
+		digests = append(digests, digest)
+	}
+	if detail != nil {
+		for _, repoDigest := range detail.RepoDigests {
+			if _, digest, ok := strings.Cut(repoDigest, "@"); ok {
+				digests = append(digests, digest)
+			}
+		}
+	}
+	return digests
+}
+
+// hasDigest reports whether a digest is among the digests of an image
+func hasDigest(digests []string, digest string) bool {
+	for _, d := range digests {
+		if d == digest {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFiles compares the files of the host with the declared ones
+func (s *service) checkFiles(ctx context.Context, sessionID string, specs []FileSpec) []CheckResult {
+	results := make([]CheckResult, 0, len(specs))
+
+	args := make([]string, len(specs))
+	for i, file := range specs {
+		args[i] = shell.Quote(file.Path)
+	}
+	command := "sh -c " + shell.Quote(fileScript) + " sh " + strings.Join(args, " ")
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, command)
+	if err != nil {
+		for _, file := range specs {
+			results = append(results, errorResult(KindFile, file.Path, fileExpectation(file), "failed to read files: "+err.Error()))
+		}
+		return results
+	}
+	sections := shell.ParseSections(output)
+
+	for i, file := range specs {
+		result := CheckResult{Kind: KindFile, Name: file.Path, Expected: fileExpectation(file), Status: StatusOK}
+		body, ok := sections["file "+strconv.Itoa(i)]
+		if !ok {
+			results = append(results, errorResult(KindFile, file.Path, result.Expected, "file was not read"))
+			continue
+		}
+		lines := strings.Split(strings.TrimSpace(body), "\n")
+
+		if lines[0] == "missing" {
+			result.Actual = "missing"
+			if file.State == StatePresent {
+				result.Status = StatusDrift
+				result.Message = "file does not exist"
+			}
+			results = append(results, result)
+			continue
+		}
+
+		fields := strings.Split(lines[0], "|")
+		if len(fields) != 4 {
+			results = append(results, errorResult(KindFile, file.Path, result.Expected, strings.TrimSpace(body)))
+			continue
+		}
+		fileType, mode, owner, group := fields[0], normalizeMode(fields[1]), fields[2], fields[3]
+
+		var checksum string
+		if len(lines) > 1 {
+			checksum, _, _ = strings.Cut(strings.TrimPrefix(lines[1], "\\"), " ")
+			if !checksumPattern.MatchString(checksum) {
+				if file.SHA256 != "" {
+					results = append(results, errorResult(KindFile, file.Path, result.Expected, "failed to checksum file: "+strings.TrimSpace(lines[1])))
+					continue
+				}
+				checksum = ""
+			}
+		}
+
+		actual := []string{fileType, mode, owner + ":" + group}
+		if checksum != "" {
+			actual = append(actual, "sha256 "+checksum)
+		}
+		result.Actual = strings.Join(actual, ", ")
+
+		var problems []string
+		if file.State == StateAbsent {
+			problems = append(problems, "file exists")
+		}
+		if file.SHA256 != "" {
+			if fileType != "regular file" && fileType != "regular empty file" {
+				problems = append(problems, "not a regular file")
+			} else if checksum != file.SHA256 {
+				problems = append(problems, "checksum differs")
+			}
+		}
+		if file.Mode != "" && mode != file.Mode {
+			problems = append(problems, "mode is "+mode)
+		}
+		if file.Owner != "" && owner != file.Owner {
+			problems = append(problems, "owner is "+owner)
+		}
+		if file.Group != "" && group != file.Group {
+			problems = append(problems, "group is "+group)
+		}
+		if len(problems) > 0 {
+			result.Status = StatusDrift
+			result.Message = strings.Join(problems, "; ")
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// fileExpectation describes a declared file, e.g. "present, 0644, root:root, sha256 9f86..."
+func fileExpectation(file FileSpec) string {
+	if file.State == StateAbsent {
+		return "missing"
+	}
+	expected := []string{"present"}
+	if file.Mode != "" {
+		expected = append(expected, file.Mode)
+	}
+	if file.Owner != "" || file.Group != "" {
+		owner, group := file.Owner, file.Group
+		if owner == "" {
+			owner = "*"
+		}
+		if group == "" {
+			group = "*"
+		}
+		expected = append(expected, owner+":"+group)
+	}
+	if file.SHA256 != "" {
+		expected = append(expected, "sha256 "+file.SHA256)
+	}
+	return strings.Join(expected, ", ")
+}
+
+// errorResult is the result of an item whose actual state could not be read
+func errorResult(kind string, name string, expected string, message string) CheckResult {
+	return CheckResult{Kind: kind, Name: name, Status: StatusError, Expected: expected, Message: message}
+}
@@ -0,0 +1,86 @@
+package drift
+
+import (
+	"context"
+	"time"
+
+	"remote-server-api/internal/domain/auth"
+)
+
+// schedulerTick is how often the scheduler looks for stored specs that are due
+const schedulerTick = 15 * time.Second
+
+// checkTimeout bounds the time a scheduled check of a host may take
+const checkTimeout = 2 * time.Minute
+
+// Run implements the Service interface
+func (s *service) Run(ctx context.Context) {
+	ticker := time.NewTicker(schedulerTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkDue(ctx, time.Now())
+		}
+	}
+}
+
+// checkDue starts a check of every stored spec that is due, through an active session of the user
+// that stored it, so that the check runs with that user's credentials. The next check is scheduled
+// before the check starts, and a spec still being checked is skipped.
+func (s *service) checkDue(ctx context.Context, now time.Time) {
+	owners, err := auth.OwnerSessions(ctx, s.sessionRepo)
+	if err != nil {
+		return
+	}
+
+	type dueSpec struct {
+		id    string
+		host  string
+		owner string
+		spec  Spec
+	}
+	var due []dueSpec
+
+	s.mu.Lock()
+	for id, stored := range s.specs {
+		if stored.NextCheckAt == nil || stored.NextCheckAt.After(now) {
+			continue
+		}
+		interval, err := time.ParseDuration(stored.Interval)
+		if err != nil {
+			continue
+		}
+		next := now.Add(interval).UTC()
+		stored.NextCheckAt = &next
+		due = append(due, dueSpec{id: id, host: stored.Host, owner: auth.OwnerOf(stored.User, stored.Host), spec: stored.Spec})
+	}
+	s.mu.Unlock()
+
+	for _, d := range due {
+		session, ok := owners[d.owner]
+		if !ok {
+			report := Report{SpecID: d.id, SpecName: d.spec.Name, Host: d.host, CheckedAt: now.UTC(), Results: []CheckResult{}, Error: "no active session of the user to host"}
+			summarize(&report)
+			s.record(d.id, report)
+			continue
+		}
+		if _, busy := s.checking.LoadOrStore(d.id, true); busy {
+			continue
+		}
+
+		go func() {
+			defer s.checking.Delete(d.id)
+
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			report := s.check(checkCtx, session.ID, d.host, &d.spec)
+			report.SpecID = d.id
+			s.record(d.id, *report)
+		}()
+	}
+}
@@ -0,0 +1,341 @@
+package drift
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bounds of a spec
+const (
+	maxSpecItems = 500
+	maxFiles     = 200
+	minInterval  = time.Minute
+)
+
+var (
+	// digestPattern matches image digests, e.g. sha256:4c1e...
+	digestPattern = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+	// checksumPattern matches SHA-256 checksums
+	checksumPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+	// modePattern matches octal permissions, e.g. 644 or 0644
+	modePattern = regexp.MustCompile(`^0?[0-7]{3,4}$`)
+)
+
+// constraintOperators lists the version comparisons of a package constraint, longest first so that
+// ">=" is not taken for ">"
+var constraintOperators = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+// constraint is one comparison of a package version constraint, e.g. ">= 1.18"
+type constraint struct {
+	operator string
+	version  string
+}
+
+// ParseSpec decodes a spec written in YAML, or JSON, rejecting unknown fields so that typos do not
+// silently disable checks
+func ParseSpec(data []byte) (*Spec, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var spec Spec
+	if err := decoder.Decode(&spec); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: spec is empty", ErrInvalidSpec)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	return &spec, nil
+}
+
+// validateSpec checks a spec and fills in its defaults
+func validateSpec(spec *Spec) error {
+	total := len(spec.Packages) + len(spec.Services) + len(spec.Containers) + len(spec.Files)
+	if total == 0 {
+		return fmt.Errorf("%w: spec declares no packages, services, containers or files", ErrInvalidSpec)
+	}
+	if total > maxSpecItems {
+		return fmt.Errorf("%w: spec declares more than %d items", ErrInvalidSpec, maxSpecItems)
+	}
+	if len(spec.Files) > maxFiles {
+		return fmt.Errorf("%w: spec declares more than %d files", ErrInvalidSpec, maxFiles)
+	}
+
+	for i := range spec.Packages {
+		pkg := &spec.Packages[i]
+		pkg.Name = strings.TrimSpace(pkg.Name)
+		if pkg.Name == "" {
+			return fmt.Errorf("%w: package %d has no name", ErrInvalidSpec, i+1)
+		}
+		state, err := validateState(pkg.State)
+		if err != nil {
+			return fmt.Errorf("%w: package %s: %v", ErrInvalidSpec, pkg.Name, err)
+		}
+		pkg.State = state
+		if pkg.Version != "" {
+			if state == StateAbsent {
+				return fmt.Errorf("%w: package %s: version cannot be required of an absent package", ErrInvalidSpec, pkg.Name)
+			}
+			if _, err := parseConstraints(pkg.Version); err != nil {
+				return fmt.Errorf("%w: package %s: %v", ErrInvalidSpec, pkg.Name, err)
+			}
+		}
+	}
+
+	for i := range spec.Services {
+		svc := &spec.Services[i]
+		svc.Name = strings.TrimSpace(svc.Name)
+		if svc.Name == "" || strings.ContainsAny(svc.Name, " \t/") {
+			return fmt.Errorf("%w: service %d has an invalid unit name %q", ErrInvalidSpec, i+1, svc.Name)
+		}
+		if !strings.Contains(svc.Name, ".") {
+			svc.Name += ".service"
+		}
+		if svc.Enabled == nil {
+			enabled := true
+			svc.Enabled = &enabled
+		}
+	}
+
+	for i := range spec.Containers {
+		container := &spec.Containers[i]
+		container.Name = strings.TrimPrefix(strings.TrimSpace(container.Name), "/")
+		if container.Name == "" {
+			return fmt.Errorf("%w: container %d has no name", ErrInvalidSpec, i+1)
+		}
+		if container.Digest != "" && !digestPattern.MatchString(container.Digest) {
+			return fmt.Errorf("%w: container %s: digest must be sha256: followed by 64 hex digits", ErrInvalidSpec, container.Name)
+		}
+		if container.Running == nil {
+			running := true
+			container.Running = &running
+		}
+	}
+
+	for i := range spec.Files {
+		file := &spec.Files[i]
+		if !path.IsAbs(file.Path) || path.Clean(file.Path) != file.Path || strings.ContainsAny(file.Path, "\n\r") {
+			return fmt.Errorf("%w: file %d: path %q must be absolute and clean", ErrInvalidSpec, i+1, file.Path)
+		}
+		state, err := validateState(file.State)
+		if err != nil {
+			return fmt.Errorf("%w: file %s: %v", ErrInvalidSpec, file.Path, err)
+		}
+		file.State = state
+		file.SHA256 = strings.ToLower(file.SHA256)
+		if file.SHA256 != "" && !checksumPattern.MatchString(file.SHA256) {
+			return fmt.Errorf("%w: file %s: sha256 must be 64 hex digits", ErrInvalidSpec, file.Path)
+		}
+		if file.Mode != "" {
+			if !modePattern.MatchString(file.Mode) {
+				return fmt.Errorf("%w: file %s: mode must be octal, e.g. 0644", ErrInvalidSpec, file.Path)
+			}
+			file.Mode = normalizeMode(file.Mode)
+		}
+		if state == StateAbsent && (file.SHA256 != "" || file.Mode != "" || file.Owner != "" || file.Group != "") {
+			return fmt.Errorf("%w: file %s: attributes cannot be required of an absent file", ErrInvalidSpec, file.Path)
+		}
+	}
+
+	return nil
+}
+
+// validateState checks the desired presence of a package or file, which defaults to present
+func validateState(state string) (string, error) {
+	switch state {
+	case "":
+		return StatePresent, nil
+	case StatePresent, StateAbsent:
+		return state, nil
+	default:
+		return "", fmt.Errorf("state must be %s or %s", StatePresent, StateAbsent)
+	}
+}
+
+// parseInterval parses the check interval of a stored spec. Empty means on demand only.
+func parseInterval(interval string) (time.Duration, error) {
+	if interval == "" {
+		return 0, nil
+	}
+	every, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid interval %q", ErrInvalidSpec, interval)
+	}
+	if every < minInterval {
+		return 0, fmt.Errorf("%w: interval must be at least %s", ErrInvalidSpec, minInterval)
+	}
+	return every, nil
+}
+
+// normalizeMode writes octal permissions with four digits, e.g. 644 as 0644
+func normalizeMode(mode string) string {
+	mode = strings.TrimLeft(mode, "0")
+	for len(mode) < 4 {
+		mode = "0" + mode
+	}
+	return mode
+}
+
+// parseConstraints parses comma-separated version constraints. A bare version must match exactly.
+func parseConstraints(value string) ([]constraint, error) {
+	var constraints []constraint
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		c := constraint{operator: "="}
+		for _, operator := range constraintOperators {
+			if rest, ok := strings.CutPrefix(part, operator); ok {
+				c.operator = operator
+				part = rest
+				break
+			}
+		}
+		c.version = strings.TrimSpace(part)
+		if c.version == "" || strings.ContainsAny(c.version, " \t") {
+			return nil, fmt.Errorf("invalid version constraint %q", value)
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+// satisfies reports whether a version meets every constraint
+func satisfies(version string, constraints []constraint) bool {
+	for _, c := range constraints {
+		cmp := compareVersions(version, c.version)
+		var ok bool
+		switch c.operator {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		case "!=":
+			ok = cmp != 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// compareVersions orders package versions the way dpkg does, which also suits rpm versions for the
+// usual forms: an optional numeric epoch ("1:"), the upstream version and an optional revision after
+// the last "-". A constraint without a revision, e.g. "1.24", leaves the revision out of the
+// comparison, so that "1.24.0-2ubuntu7" matches "= 1.24.0".
+func compareVersions(a string, b string) int {
+	epochA, upstreamA, revisionA := splitVersion(a)
+	epochB, upstreamB, revisionB := splitVersion(b)
+
+	if cmp := compareVersionPart(epochA, epochB); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareVersionPart(upstreamA, upstreamB); cmp != 0 {
+		return cmp
+	}
+	if revisionB == "" {
+		return 0
+	}
+	return compareVersionPart(revisionA, revisionB)
+}
+
+// splitVersion splits a version into its epoch, upstream version and revision
+func splitVersion(version string) (string, string, string) {
+	epoch := "0"
+	if i := strings.IndexByte(version, ':'); i > 0 && isDigits(version[:i]) {
+		epoch, version = version[:i], version[i+1:]
+	}
+	revision := ""
+	if i := strings.LastIndexByte(version, '-'); i >= 0 {
+		version, revision = version[:i], version[i+1:]
+	}
+	return epoch, version, revision
+}
+
+// compareVersionPart compares alternating runs of non-digits, by character with letters before other
+// characters and "~" before everything including the end, and runs of digits, numerically
+func compareVersionPart(a string, b string) int {
+	for a != "" || b != "" {
+		for (a != "" && !isDigit(a[0])) || (b != "" && !isDigit(b[0])) {
+			orderA, orderB := charOrder(a), charOrder(b)
+			if orderA != orderB {
+				return sign(orderA - orderB)
+			}
+			a, b = a[1:], b[1:]
+		}
+
+		a = strings.TrimLeft(a, "0")
+		b = strings.TrimLeft(b, "0")
+		firstDiff := 0
+		for a != "" && isDigit(a[0]) && b != "" && isDigit(b[0]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[0]) - int(b[0])
+			}
+			a, b = a[1:], b[1:]
+		}
+		if a != "" && isDigit(a[0]) {
+			return 1
+		}
+		if b != "" && isDigit(b[0]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return sign(firstDiff)
+		}
+	}
+	return 0
+}
+
+// charOrder ranks the first character of a non-digit run. The end of the run ranks with digits.
+func charOrder(s string) int {
+	if s == "" || isDigit(s[0]) {
+		return 0
+	}
+	c := s[0]
+	switch {
+	case c == '~':
+		return -1
+	case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		return int(c)
+	default:
+		return int(c) + 256
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
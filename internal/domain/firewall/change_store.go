@@ -0,0 +1,96 @@
+package firewall
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// changeRetention is how long changes are kept in memory after their rollback time
+const changeRetention = time.Hour
+
+// changeEntry tracks a rule change made through a session
+type changeEntry struct {
+	sessionID string
+	change    RuleChange
+}
+
+// changeStore keeps rule changes in memory. The state of a pending change is owned by the server,
+// where the rollback timer runs; the store holds what the API needs to query and settle it.
+type changeStore struct {
+	mu      sync.Mutex
+	entries map[string]*changeEntry
+}
+
+// newChangeStore creates an empty change store
+func newChangeStore() *changeStore {
+	return &changeStore{
+		entries: make(map[string]*changeEntry),
+	}
+}
+
+// add registers a new change
+func (st *changeStore) add(sessionID string, change RuleChange) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.pruneLocked()
+	st.entries[change.ID] = &changeEntry{sessionID: sessionID, change: change}
+}
+
+// get returns a copy of the change with the given ID if it belongs to the session
+func (st *changeStore) get(sessionID string, id string) (*RuleChange, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.pruneLocked()
+
+	entry, ok := st.entries[id]
+	if !ok || entry.sessionID != sessionID {
+		return nil, fmt.Errorf("%w: %s", ErrChangeNotFound, id)
+	}
+	change := entry.change
+	return &change, nil
+}
+
+// update records the state of a change reported by the server and returns a copy of the change
+func (st *changeStore) update(id string, status string, message string) *RuleChange {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	entry, ok := st.entries[id]
+	if !ok {
+		return nil
+	}
+	if entry.change.Status != status && status == ChangeStatusConfirmed {
+		now := time.Now().UTC()
+		entry.change.ConfirmedAt = &now
+	}
+	entry.change.Status = status
+	entry.change.Error = message
+
+	change := entry.change
+	return &change
+}
+
+// pruneLocked drops changes whose rollback time is past the retention, by which time the server has
+// settled them. The caller must hold the lock.
+func (st *changeStore) pruneLocked() {
+	cutoff := time.Now().Add(-changeRetention)
+	for id, entry := range st.entries {
+		if entry.change.ExpiresAt.Before(cutoff) {
+			delete(st.entries, id)
+		}
+	}
+}
+
+// newChangeID generates a random change identifier
+func newChangeID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate change ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
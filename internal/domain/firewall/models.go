@@ -0,0 +1,119 @@
+package firewall
+
+import "time"
+
+// Firewall backends
+const (
+	BackendNftables = "nftables"
+	BackendIptables = "iptables"
+	BackendUFW      = "ufw"
+)
+
+// Rule actions of the common rule model
+const (
+	ActionAccept = "accept"
+	ActionDrop   = "drop"
+	ActionReject = "reject"
+)
+
+// Rule change operations
+const (
+	OperationAdd    = "add"
+	OperationDelete = "delete"
+)
+
+// Rule change states
+const (
+	ChangeStatusPending    = "pending"     // Applied, rolled back unless confirmed before expires_at
+	ChangeStatusConfirmed  = "confirmed"   // Kept
+	ChangeStatusRolledBack = "rolled_back" // Ruleset restored, on request or by the timer
+	ChangeStatusFailed     = "failed"      // The change or its rollback failed
+)
+
+// BackendStatus describes a firewall backend found on the server
+type BackendStatus struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"` // The tool is installed
+	Active    bool   `json:"active"`    // The backend has rules or, for ufw, is enabled
+	Variant   string `json:"variant,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Chain is a chain of a firewall table
+type Chain struct {
+	Backend  string `json:"backend"`
+	Family   string `json:"family"` // ip, ip6, inet, arp, bridge or netdev
+	Table    string `json:"table"`
+	Name     string `json:"name"`
+	Hook     string `json:"hook,omitempty"` // Base chains only
+	Priority *int   `json:"priority,omitempty"`
+	Policy   string `json:"policy,omitempty"`
+}
+
+// Rule is a firewall rule in the common model. Matches that have no field of their own are listed in
+// Extra in the syntax of the backend.
+type Rule struct {
+	ID               string   `json:"id"` // Stable identifier used to delete the rule
+	Backend          string   `json:"backend"`
+	Family           string   `json:"family"`
+	Table            string   `json:"table"`
+	Chain            string   `json:"chain"`
+	Position         int      `json:"position"` // 1-based position in the chain
+	Action           string   `json:"action"`   // accept, drop, reject, jump <chain>, goto <chain>, return, limit, ...
+	Protocol         string   `json:"protocol,omitempty"`
+	Source           string   `json:"source,omitempty"` // Address or prefix, "!" prefixed when negated
+	Destination      string   `json:"destination,omitempty"`
+	SourcePorts      []string `json:"source_ports,omitempty"`
+	DestinationPorts []string `json:"destination_ports,omitempty"`
+	InInterface      string   `json:"in_interface,omitempty"`
+	OutInterface     string   `json:"out_interface,omitempty"`
+	States           []string `json:"states,omitempty"` // Connection tracking states
+	Comment          string   `json:"comment,omitempty"`
+	Extra            []string `json:"extra,omitempty"`
+}
+
+// Ruleset is the normalized firewall configuration of a server
+type Ruleset struct {
+	Backends    []BackendStatus `json:"backends"`
+	Chains      []Chain         `json:"chains"`
+	Rules       []Rule          `json:"rules"`
+	CollectedAt time.Time       `json:"collected_at"`
+}
+
+// RuleFilter narrows down the rules of a ruleset
+type RuleFilter struct {
+	Backend string
+	Chain   string // Chain name, case-insensitive
+}
+
+// RuleSpec describes a rule to add
+type RuleSpec struct {
+	Backend          string   `json:"backend"`          // nftables, iptables or ufw
+	Family           string   `json:"family,omitempty"` // nftables: table family (default inet); iptables: ip or ip6 (default ip)
+	Table            string   `json:"table,omitempty"`  // nftables: required; iptables: default filter; ufw: unused
+	Chain            string   `json:"chain"`            // ufw: input or output
+	Action           string   `json:"action"`           // accept, drop or reject
+	Protocol         string   `json:"protocol,omitempty"`
+	Source           string   `json:"source,omitempty"`
+	Destination      string   `json:"destination,omitempty"`
+	DestinationPorts []string `json:"destination_ports,omitempty"` // Ports or ranges ("8000-8080"), requires tcp or udp
+	InInterface      string   `json:"in_interface,omitempty"`
+	Comment          string   `json:"comment,omitempty"`
+	Insert           bool     `json:"insert,omitempty"` // Insert at the top of the chain instead of appending
+}
+
+// RuleChange is a guarded change of the ruleset. The previous ruleset is saved on the server before
+// the change and restored by a timer on the server unless the change is confirmed in time, so that a
+// rule locking out the API is undone even when the API can no longer reach the server.
+type RuleChange struct {
+	ID          string     `json:"id"`
+	Operation   string     `json:"operation"`
+	Backend     string     `json:"backend"`
+	Rule        Rule       `json:"rule"`    // Rule added or deleted
+	Command     string     `json:"command"` // Command that applied the change
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"` // Rollback time of a pending change
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+}
@@ -0,0 +1,288 @@
+package firewall
+
+import (
+	"fmt"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// iptablesMatchModules are match modules whose options map onto fields of the common rule model
+var iptablesMatchModules = map[string]bool{
+	"tcp":       true,
+	"udp":       true,
+	"sctp":      true,
+	"multiport": true,
+	"conntrack": true,
+	"state":     true,
+	"comment":   true,
+}
+
+// iptablesTargets are the targets reported as actions of their own rather than as jumps
+var iptablesTargets = map[string]bool{
+	"ACCEPT": true, "DROP": true, "REJECT": true, "RETURN": true, "LOG": true, "NFLOG": true,
+	"MASQUERADE": true, "SNAT": true, "DNAT": true, "REDIRECT": true, "MARK": true, "CONNMARK": true,
+	"TCPMSS": true, "NOTRACK": true, "CT": true, "QUEUE": true, "NFQUEUE": true,
+}
+
+// parseIptablesVariant extracts the variant from 'iptables -V', e.g. "iptables v1.8.9 (nf_tables)"
+func parseIptablesVariant(output string) string {
+	start := strings.Index(output, "(")
+	end := strings.Index(output, ")")
+	if start < 0 || end < start {
+		return "legacy"
+	}
+	return output[start+1 : end]
+}
+
+// parseIptablesSave parses iptables-save output. Lines other than tables, chains and rules are
+// returned as the problem, e.g. a permission error.
+func parseIptablesSave(output string, family string) ([]Chain, []parsedRule, string) {
+	var chains []Chain
+	var rules []parsedRule
+	problem := ""
+
+	table := ""
+	positions := make(map[string]int)
+	ids := make(ruleIDs)
+	userChains := make(map[string]bool)
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || line == "COMMIT":
+		case strings.HasPrefix(line, "*"):
+			table = strings.TrimPrefix(line, "*")
+		case strings.HasPrefix(line, ":"):
+			// :INPUT ACCEPT [0:0]
+			fields := strings.Fields(strings.TrimPrefix(line, ":"))
+			if len(fields) < 2 {
+				continue
+			}
+			chain := Chain{Backend: BackendIptables, Family: family, Table: table, Name: fields[0]}
+			if fields[1] != "-" {
+				chain.Hook = strings.ToLower(fields[0])
+				chain.Policy = strings.ToLower(fields[1])
+			} else {
+				userChains[table+" "+fields[0]] = true
+			}
+			chains = append(chains, chain)
+		case strings.HasPrefix(line, "-A "):
+			args := splitIptablesArgs(strings.TrimPrefix(line, "-A "))
+			if len(args) == 0 {
+				continue
+			}
+			chain := args[0]
+			args = args[1:]
+			positions[table+" "+chain]++
+
+			rule := parsedRule{
+				Rule: Rule{
+					ID:       ids.next(fmt.Sprintf("%s:%s:%s:%s", BackendIptables, family, table, chain), strings.Join(args, " ")),
+					Backend:  BackendIptables,
+					Family:   family,
+					Table:    table,
+					Chain:    chain,
+					Position: positions[table+" "+chain],
+				},
+				args: args,
+			}
+			applyIptablesArgs(&rule.Rule, args, func(target string) bool { return userChains[table+" "+target] })
+			rules = append(rules, rule)
+		default:
+			if problem == "" {
+				problem = line
+			}
+		}
+	}
+
+	return chains, rules, problem
+}
+
+// applyIptablesArgs maps the options of a rule onto the common rule model. Options of other match
+// modules and of targets are added to Extra.
+func applyIptablesArgs(rule *Rule, args []string, isUserChain func(target string) bool) {
+	negate := ""
+	var extra []string
+
+	for i := 0; i < len(args); i++ {
+		option := args[i]
+		value := ""
+		if i+1 < len(args) {
+			value = args[i+1]
+		}
+
+		switch option {
+		case "!":
+			negate = "!"
+			continue
+		case "-p", "--protocol":
+			rule.Protocol = negate + value
+		case "-s", "--source":
+			rule.Source = negate + value
+		case "-d", "--destination":
+			rule.Destination = negate + value
+		case "-i", "--in-interface":
+			rule.InInterface = negate + value
+		case "-o", "--out-interface":
+			rule.OutInterface = negate + value
+		case "--dport", "--destination-port", "--dports", "--destination-ports":
+			rule.DestinationPorts = iptablesPorts(negate, value)
+		case "--sport", "--source-port", "--sports", "--source-ports":
+			rule.SourcePorts = iptablesPorts(negate, value)
+		case "--ctstate", "--state":
+			rule.States = strings.Split(strings.ToLower(value), ",")
+			if negate != "" {
+				rule.States = []string{negate + strings.ToLower(value)}
+			}
+		case "--comment":
+			rule.Comment = value
+		case "-m", "--match":
+			if !iptablesMatchModules[value] {
+				extra = append(extra, "-m "+value)
+			}
+		case "-j", "--jump", "-g", "--goto":
+			switch {
+			case option == "-g" || option == "--goto":
+				rule.Action = "goto " + value
+			case iptablesTargets[value] || !isUserChain(value):
+				rule.Action = strings.ToLower(value)
+			default:
+				rule.Action = "jump " + value
+			}
+			// The remaining options belong to the target
+			if i+2 < len(args) {
+				extra = append(extra, strings.Join(args[i+2:], " "))
+			}
+			i = len(args)
+			continue
+		default:
+			// Option of another match module, with its values up to the next option
+			words := []string{negate + option}
+			for i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") && args[i+1] != "!" {
+				i++
+				words = append(words, args[i])
+			}
+			extra = append(extra, strings.Join(words, " "))
+			negate = ""
+			continue
+		}
+
+		negate = ""
+		i++
+	}
+
+	rule.Extra = extra
+}
+
+// iptablesPorts converts a port list ("22,80", "1000:2000") to the common notation
+func iptablesPorts(negate string, value string) []string {
+	ports := strings.Split(strings.ReplaceAll(value, ":", "-"), ",")
+	if negate != "" {
+		return []string{negate + strings.Join(ports, ",")}
+	}
+	return ports
+}
+
+// splitIptablesArgs splits a rule line of iptables-save into words. Values containing spaces, such
+// as comments, are double-quoted with backslash escapes.
+func splitIptablesArgs(line string) []string {
+	var args []string
+	var current strings.Builder
+	inWord, quoted := false, false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quoted && c == '\\' && i+1 < len(line):
+			i++
+			current.WriteByte(line[i])
+		case c == '"':
+			quoted = !quoted
+			inWord = true
+		case !quoted && (c == ' ' || c == '\t'):
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		args = append(args, current.String())
+	}
+
+	return args
+}
+
+// iptablesCommand returns the iptables binary for a family
+func iptablesCommand(family string) string {
+	if family == "ip6" {
+		return "ip6tables -w"
+	}
+	return "iptables -w"
+}
+
+// iptablesAddCommand builds the iptables command adding a rule
+func iptablesAddCommand(spec RuleSpec) string {
+	args := []string{"-t", spec.Table}
+	if spec.Insert {
+		args = append(args, "-I", spec.Chain, "1")
+	} else {
+		args = append(args, "-A", spec.Chain)
+	}
+	if spec.InInterface != "" {
+		args = append(args, "-i", spec.InInterface)
+	}
+	if spec.Source != "" {
+		args = append(args, "-s", spec.Source)
+	}
+	if spec.Destination != "" {
+		args = append(args, "-d", spec.Destination)
+	}
+	if spec.Protocol != "" {
+		protocol := spec.Protocol
+		if protocol == "icmpv6" {
+			protocol = "ipv6-icmp"
+		}
+		args = append(args, "-p", protocol)
+	}
+	switch ports := strings.ReplaceAll(strings.Join(spec.DestinationPorts, ","), "-", ":"); {
+	case len(spec.DestinationPorts) == 1:
+		args = append(args, "--dport", ports)
+	case len(spec.DestinationPorts) > 1:
+		args = append(args, "-m", "multiport", "--dports", ports)
+	}
+	if spec.Comment != "" {
+		args = append(args, "-m", "comment", "--comment", spec.Comment)
+	}
+	args = append(args, "-j", strings.ToUpper(spec.Action))
+
+	return iptablesCommand(spec.Family) + " " + quoteArgs(args)
+}
+
+// iptablesDeleteCommand builds the iptables command deleting the first rule of the chain with the
+// same specification
+func iptablesDeleteCommand(rule parsedRule) string {
+	args := append([]string{"-t", rule.Table, "-D", rule.Chain}, rule.args...)
+	return iptablesCommand(rule.Family) + " " + quoteArgs(args)
+}
+
+// quoteArgs quotes every argument for the remote shell
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shell.Quote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// iptablesSnapshotCommand saves the IPv4 and IPv6 rules to the change directory,
+// iptablesRestoreCommand loads them again
+const (
+	iptablesSnapshotCommand = `iptables-save > "$d/rules.v4" && { ! command -v ip6tables-save >/dev/null 2>&1 || ip6tables-save > "$d/rules.v6"; }`
+	iptablesRestoreCommand  = `iptables-restore < "$d/rules.v4" && { [ ! -f "$d/rules.v6" ] || ip6tables-restore < "$d/rules.v6"; }`
+)
@@ -0,0 +1,309 @@
+package firewall
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// nftDocument is the output of 'nft -j list ruleset'. Every object has a single key naming its type.
+type nftDocument struct {
+	Nftables []map[string]json.RawMessage `json:"nftables"`
+}
+
+// nftChain is a "chain" object of the JSON ruleset
+type nftChain struct {
+	Family string          `json:"family"`
+	Table  string          `json:"table"`
+	Name   string          `json:"name"`
+	Hook   string          `json:"hook"`
+	Prio   json.RawMessage `json:"prio"`
+	Policy string          `json:"policy"`
+}
+
+// nftRule is a "rule" object of the JSON ruleset
+type nftRule struct {
+	Family  string                       `json:"family"`
+	Table   string                       `json:"table"`
+	Chain   string                       `json:"chain"`
+	Handle  int                          `json:"handle"`
+	Comment string                       `json:"comment"`
+	Expr    []map[string]json.RawMessage `json:"expr"`
+}
+
+// nftMatch is a "match" expression comparing a packet field with a value
+type nftMatch struct {
+	Op    string          `json:"op"`
+	Left  json.RawMessage `json:"left"`
+	Right json.RawMessage `json:"right"`
+}
+
+// parseNftRuleset parses the JSON ruleset. Tables for which skip returns true are left out. When the
+// output is not JSON, it is returned as the problem, e.g. a permission error.
+func parseNftRuleset(output string, skip func(family string, table string) bool) ([]Chain, []parsedRule, string) {
+	var chains []Chain
+	var rules []parsedRule
+
+	start := strings.Index(output, "{")
+	var document nftDocument
+	if start < 0 || json.Unmarshal([]byte(output[start:]), &document) != nil {
+		return nil, nil, firstLine(output)
+	}
+
+	positions := make(map[string]int)
+	for _, object := range document.Nftables {
+		if raw, ok := object["chain"]; ok {
+			var chain nftChain
+			if json.Unmarshal(raw, &chain) != nil || skip(chain.Family, chain.Table) {
+				continue
+			}
+			result := Chain{
+				Backend: BackendNftables,
+				Family:  chain.Family,
+				Table:   chain.Table,
+				Name:    chain.Name,
+				Hook:    chain.Hook,
+				Policy:  chain.Policy,
+			}
+			var priority int
+			if json.Unmarshal(chain.Prio, &priority) == nil {
+				result.Priority = &priority
+			}
+			chains = append(chains, result)
+			continue
+		}
+
+		raw, ok := object["rule"]
+		if !ok {
+			continue
+		}
+		var rule nftRule
+		if json.Unmarshal(raw, &rule) != nil || skip(rule.Family, rule.Table) {
+			continue
+		}
+
+		key := rule.Family + " " + rule.Table + " " + rule.Chain
+		positions[key]++
+		parsed := parsedRule{
+			Rule: Rule{
+				ID:       fmt.Sprintf("%s:%s:%s:%s:%d", BackendNftables, rule.Family, rule.Table, rule.Chain, rule.Handle),
+				Backend:  BackendNftables,
+				Family:   rule.Family,
+				Table:    rule.Table,
+				Chain:    rule.Chain,
+				Position: positions[key],
+				Comment:  rule.Comment,
+			},
+			handle: rule.Handle,
+		}
+		for _, expression := range rule.Expr {
+			applyNftExpression(&parsed.Rule, expression)
+		}
+		rules = append(rules, parsed)
+	}
+
+	return chains, rules, ""
+}
+
+// applyNftExpression maps an expression of a rule onto the common rule model
+func applyNftExpression(rule *Rule, expression map[string]json.RawMessage) {
+	for name, raw := range expression {
+		switch name {
+		case "match":
+			var match nftMatch
+			if json.Unmarshal(raw, &match) == nil {
+				applyNftMatch(rule, match)
+			}
+		case "accept", "drop", "reject", "return", "masquerade", "continue", "queue":
+			rule.Action = name
+		case "jump", "goto":
+			var target struct {
+				Target string `json:"target"`
+			}
+			_ = json.Unmarshal(raw, &target)
+			rule.Action = name + " " + target.Target
+		case "snat", "dnat", "redirect":
+			rule.Action = name + " " + compactJSON(raw)
+		case "counter":
+		default:
+			// log, limit, mark, xt and other statements
+			rule.Extra = append(rule.Extra, name+" "+compactJSON(raw))
+		}
+	}
+}
+
+// applyNftMatch maps a match expression onto the common rule model. Matches without a field of their
+// own are added to Extra.
+func applyNftMatch(rule *Rule, match nftMatch) {
+	var left struct {
+		Payload *struct {
+			Protocol string `json:"protocol"`
+			Field    string `json:"field"`
+		} `json:"payload"`
+		Meta *struct {
+			Key string `json:"key"`
+		} `json:"meta"`
+		Ct *struct {
+			Key string `json:"key"`
+		} `json:"ct"`
+	}
+	_ = json.Unmarshal(match.Left, &left)
+
+	values := nftValues(match.Right)
+	negate := ""
+	if match.Op == "!=" {
+		negate = "!"
+	}
+	joined := negate + strings.Join(values, ",")
+
+	switch {
+	case left.Payload != nil && (left.Payload.Field == "saddr" || left.Payload.Field == "daddr"):
+		if left.Payload.Field == "saddr" {
+			rule.Source = joined
+		} else {
+			rule.Destination = joined
+		}
+	case left.Payload != nil && (left.Payload.Field == "sport" || left.Payload.Field == "dport"):
+		if rule.Protocol == "" {
+			rule.Protocol = left.Payload.Protocol
+		}
+		ports := values
+		if negate != "" {
+			ports = []string{joined}
+		}
+		if left.Payload.Field == "sport" {
+			rule.SourcePorts = ports
+		} else {
+			rule.DestinationPorts = ports
+		}
+	case left.Meta != nil && (left.Meta.Key == "l4proto" || left.Meta.Key == "protocol"):
+		rule.Protocol = joined
+	case left.Payload != nil && (left.Payload.Field == "protocol" || left.Payload.Field == "nexthdr"):
+		rule.Protocol = joined
+	case left.Meta != nil && (left.Meta.Key == "iifname" || left.Meta.Key == "iif"):
+		rule.InInterface = joined
+	case left.Meta != nil && (left.Meta.Key == "oifname" || left.Meta.Key == "oif"):
+		rule.OutInterface = joined
+	case left.Ct != nil && left.Ct.Key == "state":
+		rule.States = values
+	default:
+		op := match.Op
+		if op == "" {
+			op = "=="
+		}
+		rule.Extra = append(rule.Extra, compactJSON(match.Left)+" "+op+" "+joined)
+	}
+}
+
+// nftValues formats the right-hand side of a match: numbers, strings, lists, sets, ranges and
+// prefixes
+func nftValues(raw json.RawMessage) []string {
+	var number json.Number
+	if json.Unmarshal(raw, &number) == nil {
+		return []string{number.String()}
+	}
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return []string{text}
+	}
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		var values []string
+		for _, item := range list {
+			values = append(values, nftValues(item)...)
+		}
+		return values
+	}
+
+	var object struct {
+		Set    []json.RawMessage `json:"set"`
+		Range  []json.RawMessage `json:"range"`
+		Prefix *struct {
+			Addr string `json:"addr"`
+			Len  int    `json:"len"`
+		} `json:"prefix"`
+	}
+	if json.Unmarshal(raw, &object) == nil {
+		switch {
+		case object.Set != nil:
+			var values []string
+			for _, item := range object.Set {
+				values = append(values, nftValues(item)...)
+			}
+			return values
+		case len(object.Range) == 2:
+			return []string{strings.Join(nftValues(object.Range[0]), "") + "-" + strings.Join(nftValues(object.Range[1]), "")}
+		case object.Prefix != nil:
+			return []string{object.Prefix.Addr + "/" + strconv.Itoa(object.Prefix.Len)}
+		}
+	}
+
+	return []string{compactJSON(raw)}
+}
+
+// compactJSON renders a JSON value on a single line
+func compactJSON(raw json.RawMessage) string {
+	var value interface{}
+	if json.Unmarshal(raw, &value) != nil {
+		return string(raw)
+	}
+	compact, err := json.Marshal(value)
+	if err != nil {
+		return string(raw)
+	}
+	return string(compact)
+}
+
+// nftAddCommand builds the nft command adding a rule
+func nftAddCommand(spec RuleSpec) string {
+	var parts []string
+	if spec.InInterface != "" {
+		parts = append(parts, fmt.Sprintf("iifname %q", spec.InInterface))
+	}
+	for _, address := range []struct{ value, field string }{{spec.Source, "saddr"}, {spec.Destination, "daddr"}} {
+		if address.value == "" {
+			continue
+		}
+		family := "ip"
+		if isIPv6(address.value) {
+			family = "ip6"
+		}
+		parts = append(parts, family+" "+address.field+" "+address.value)
+	}
+	switch {
+	case len(spec.DestinationPorts) == 1:
+		parts = append(parts, spec.Protocol+" dport "+spec.DestinationPorts[0])
+	case len(spec.DestinationPorts) > 1:
+		parts = append(parts, spec.Protocol+" dport { "+strings.Join(spec.DestinationPorts, ", ")+" }")
+	case spec.Protocol == "icmpv6":
+		parts = append(parts, "meta l4proto ipv6-icmp")
+	case spec.Protocol != "":
+		parts = append(parts, "meta l4proto "+spec.Protocol)
+	}
+	parts = append(parts, "counter", spec.Action)
+	if spec.Comment != "" {
+		parts = append(parts, fmt.Sprintf("comment %q", spec.Comment))
+	}
+
+	verb := "add"
+	if spec.Insert {
+		verb = "insert"
+	}
+	statement := fmt.Sprintf("%s rule %s %s %s %s", verb, spec.Family, spec.Table, spec.Chain, strings.Join(parts, " "))
+	return "nft " + shell.Quote(statement)
+}
+
+// nftDeleteCommand builds the nft command deleting a rule by its handle
+func nftDeleteCommand(rule parsedRule) string {
+	return fmt.Sprintf("nft delete rule %s %s %s handle %d", shell.Quote(rule.Family), shell.Quote(rule.Table), shell.Quote(rule.Chain), rule.handle)
+}
+
+// nftSnapshotCommand saves the ruleset to the change directory, nftRestoreCommand loads it again
+// atomically
+const (
+	nftSnapshotCommand = `nft list ruleset > "$d/ruleset"`
+	nftRestoreCommand  = `{ echo 'flush ruleset'; cat "$d/ruleset"; } | nft -f -`
+)
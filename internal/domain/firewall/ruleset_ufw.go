@@ -0,0 +1,240 @@
+package firewall
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// ufwRulePattern matches a line of 'ufw status numbered':
+// "[ 2] 80,443/tcp                 ALLOW IN    192.168.1.0/24             # web"
+var ufwRulePattern = regexp.MustCompile(`^\[\s*(\d+)\]\s+(.*?)\s{2,}((?:ALLOW|DENY|REJECT|LIMIT)(?: (?:IN|OUT|FWD))?)\s+(.*?)(?:\s+#\s*(.*))?$`)
+
+// ufwDefaultPattern matches the default policies of 'ufw status verbose':
+// "Default: deny (incoming), allow (outgoing), disabled (routed)"
+var ufwDefaultPattern = regexp.MustCompile(`(\w+) \((incoming|outgoing|routed)\)`)
+
+// ufwChains maps the directions of ufw rules and default policies to chains
+var ufwChains = map[string]string{
+	"IN":       "input",
+	"OUT":      "output",
+	"FWD":      "forward",
+	"incoming": "input",
+	"outgoing": "output",
+	"routed":   "forward",
+}
+
+// ufwActions maps ufw actions to the common rule model
+var ufwActions = map[string]string{
+	"ALLOW":  ActionAccept,
+	"DENY":   ActionDrop,
+	"REJECT": ActionReject,
+	"LIMIT":  "limit",
+}
+
+// ufwEndpoint is the "To" or "From" column of a ufw rule
+type ufwEndpoint struct {
+	address  string
+	ports    []string
+	protocol string
+	iface    string
+	app      string
+	ipv6     bool
+}
+
+// parseUFWStatus parses 'ufw status numbered' and the default policies of 'ufw status verbose'.
+// Output without a status line is returned as the problem, e.g. a permission error.
+func parseUFWStatus(numbered string, verbose string) (bool, []Chain, []parsedRule, string) {
+	status := ""
+	for _, line := range strings.Split(numbered, "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "Status:"); ok {
+			status = strings.TrimSpace(value)
+			break
+		}
+	}
+	if status == "" {
+		return false, nil, nil, firstLine(numbered)
+	}
+
+	var chains []Chain
+	for _, line := range strings.Split(verbose, "\n") {
+		defaults, ok := strings.CutPrefix(strings.TrimSpace(line), "Default:")
+		if !ok {
+			continue
+		}
+		for _, match := range ufwDefaultPattern.FindAllStringSubmatch(defaults, -1) {
+			name := ufwChains[match[2]]
+			chains = append(chains, Chain{
+				Backend: BackendUFW,
+				Family:  "inet",
+				Table:   "ufw",
+				Name:    name,
+				Hook:    name,
+				Policy:  match[1],
+			})
+		}
+	}
+
+	var rules []parsedRule
+	positions := make(map[string]int)
+	ids := make(ruleIDs)
+	for _, line := range strings.Split(numbered, "\n") {
+		match := ufwRulePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(match[1])
+		to := parseUFWEndpoint(match[2])
+		from := parseUFWEndpoint(match[4])
+
+		action, direction, _ := strings.Cut(match[3], " ")
+		if direction == "" {
+			direction = "IN"
+		}
+		chain := ufwChains[direction]
+		positions[chain]++
+
+		family := "ip"
+		if to.ipv6 || from.ipv6 {
+			family = "ip6"
+		}
+
+		rule := Rule{
+			ID:               ids.next(BackendUFW+":"+chain, strings.Join(match[2:], "|")),
+			Backend:          BackendUFW,
+			Family:           family,
+			Table:            "ufw",
+			Chain:            chain,
+			Position:         positions[chain],
+			Action:           ufwActions[action],
+			Protocol:         shell.FirstNonEmpty(to.protocol, from.protocol),
+			Source:           from.address,
+			Destination:      to.address,
+			SourcePorts:      from.ports,
+			DestinationPorts: to.ports,
+			Comment:          strings.TrimSpace(match[5]),
+		}
+		if chain == "output" {
+			rule.OutInterface = to.iface
+		} else {
+			rule.InInterface = to.iface
+		}
+		for _, app := range []string{to.app, from.app} {
+			if app != "" {
+				rule.Extra = append(rule.Extra, "app "+app)
+			}
+		}
+
+		rules = append(rules, parsedRule{Rule: rule, number: number})
+	}
+
+	return status == "active", chains, rules, ""
+}
+
+// parseUFWEndpoint parses a column such as "Anywhere", "22/tcp (v6)", "10.0.0.5 8080/tcp",
+// "Anywhere on eth0" or "OpenSSH"
+func parseUFWEndpoint(text string) ufwEndpoint {
+	var endpoint ufwEndpoint
+
+	fields := strings.Fields(text)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		switch {
+		case field == "(v6)":
+			endpoint.ipv6 = true
+		case field == "(out)":
+		case field == "on" && i+1 < len(fields):
+			i++
+			endpoint.iface = fields[i]
+		case field == "Anywhere":
+		case isAddress(field):
+			endpoint.address = field
+			endpoint.ipv6 = endpoint.ipv6 || strings.Contains(field, ":")
+		case isUFWPorts(field):
+			ports, protocol, _ := strings.Cut(field, "/")
+			endpoint.protocol = protocol
+			endpoint.ports = strings.Split(strings.ReplaceAll(ports, ":", "-"), ",")
+		default:
+			// Application profile, whose name may contain spaces
+			if endpoint.app != "" {
+				endpoint.app += " "
+			}
+			endpoint.app += field
+		}
+	}
+
+	return endpoint
+}
+
+// isUFWPorts reports whether a field is a port list with an optional protocol, e.g. "80,443/tcp"
+func isUFWPorts(field string) bool {
+	ports, _, _ := strings.Cut(field, "/")
+	if ports == "" {
+		return false
+	}
+	for _, c := range ports {
+		if (c < '0' || c > '9') && c != ',' && c != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAddress reports whether a value is an IP address or network
+func isAddress(value string) bool {
+	if net.ParseIP(value) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
+
+// isIPv6 reports whether an address or network is IPv6
+func isIPv6(value string) bool {
+	return strings.Contains(value, ":")
+}
+
+// ufwAddCommand builds the ufw command adding a rule. Rules can only be inserted when the ruleset
+// is not empty.
+func ufwAddCommand(spec RuleSpec, hasRules bool) string {
+	args := []string{"ufw"}
+	if spec.Insert && hasRules {
+		args = append(args, "insert", "1")
+	}
+	action := map[string]string{ActionAccept: "allow", ActionDrop: "deny", ActionReject: "reject"}[spec.Action]
+	direction := "in"
+	if spec.Chain == "output" {
+		direction = "out"
+	}
+	args = append(args, action, direction)
+	if spec.InInterface != "" {
+		args = append(args, "on", spec.InInterface)
+	}
+	if spec.Protocol != "" {
+		args = append(args, "proto", spec.Protocol)
+	}
+	args = append(args, "from", shell.FirstNonEmpty(spec.Source, "any"), "to", shell.FirstNonEmpty(spec.Destination, "any"))
+	if len(spec.DestinationPorts) > 0 {
+		args = append(args, "port", strings.ReplaceAll(strings.Join(spec.DestinationPorts, ","), "-", ":"))
+	}
+	if spec.Comment != "" {
+		args = append(args, "comment", spec.Comment)
+	}
+
+	return quoteArgs(args)
+}
+
+// ufwDeleteCommand builds the ufw command deleting a rule by its number
+func ufwDeleteCommand(rule parsedRule) string {
+	return "ufw --force delete " + strconv.Itoa(rule.number)
+}
+
+// ufwSnapshotCommand saves the ufw rule files to the change directory, ufwRestoreCommand copies them
+// back and reloads ufw
+const (
+	ufwSnapshotCommand = `cp -p /etc/ufw/user.rules /etc/ufw/user6.rules "$d/"`
+	ufwRestoreCommand  = `cp -p "$d/user.rules" "$d/user6.rules" /etc/ufw/ && ufw reload`
+)
@@ -0,0 +1,78 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"remote-server-api/pkg/keylock"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound     = errors.New("session not found or expired")
+	ErrCommandFailed       = errors.New("command execution failed")
+	ErrInvalidRule         = errors.New("invalid firewall rule")
+	ErrBackendUnavailable  = errors.New("firewall backend is not available")
+	ErrRuleNotFound        = errors.New("firewall rule not found")
+	ErrChangeNotFound      = errors.New("firewall change not found")
+	ErrChangePending       = errors.New("another firewall change is awaiting confirmation")
+	ErrChangeNotPending    = errors.New("firewall change is no longer pending")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrInvalidConfirmation = errors.New("invalid confirmation timeout")
+)
+
+// Bounds for the time a change may wait for confirmation before it is rolled back
+const (
+	DefaultConfirmTimeout = time.Minute
+	MinConfirmTimeout     = 10 * time.Second
+	MaxConfirmTimeout     = 10 * time.Minute
+)
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// RunCommand executes a command on the SSH session
+	RunCommand(ctx context.Context, sessionID string, command string) (string, error)
+}
+
+// Service defines the firewall service
+type Service interface {
+	// GetRuleset retrieves the nftables, iptables and ufw rules in the common rule model
+	GetRuleset(ctx context.Context, sessionID string, filter RuleFilter) (*Ruleset, error)
+
+	// AddRule adds a rule and rolls it back unless the change is confirmed within the timeout
+	AddRule(ctx context.Context, sessionID string, spec RuleSpec, confirmTimeout time.Duration) (*RuleChange, error)
+
+	// DeleteRule deletes a rule and restores it unless the change is confirmed within the timeout
+	DeleteRule(ctx context.Context, sessionID string, ruleID string, confirmTimeout time.Duration) (*RuleChange, error)
+
+	// GetChange retrieves the state of a rule change
+	GetChange(ctx context.Context, sessionID string, id string) (*RuleChange, error)
+
+	// ConfirmChange keeps a pending rule change and cancels its rollback
+	ConfirmChange(ctx context.Context, sessionID string, id string) (*RuleChange, error)
+
+	// RollbackChange restores the ruleset saved before a pending rule change
+	RollbackChange(ctx context.Context, sessionID string, id string) (*RuleChange, error)
+}
+
+type service struct {
+	sessionRepo SessionRepository
+	changes     *changeStore
+
+	// locks serializes rule changes made through this API per session
+	locks keylock.Locks
+}
+
+// NewService creates a new firewall service
+func NewService(sessionRepo SessionRepository) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+		changes:     newChangeStore(),
+	}
+}
+
+// lock acquires the change lock of a session and returns the unlock function
+func (s *service) lock(sessionID string) func() {
+	return s.locks.Lock(sessionID)
+}
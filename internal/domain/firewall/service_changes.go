@@ -0,0 +1,476 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// applyScript applies a guarded change. It saves the ruleset to a change directory, starts a timer on
+// the server that restores it unless the change is confirmed first, and then runs the change. The
+// timer survives the SSH session, so a rule that cuts off the API is still undone. Positional
+// parameters: change ID, timeout in seconds, snapshot command, restore command and change command.
+const applyScript = `b=` + changeBaseDir + `
+d="$b/$1"
+umask 077
+mkdir -p "$b" || { echo '### failed'; exit 0; }
+for p in "$b"/*/pending; do
+  [ -e "$p" ] || continue
+  if kill -0 "$(cat "${p%/pending}/timer" 2>/dev/null)" 2>/dev/null; then
+    echo '### busy'
+    basename "${p%/pending}"
+    exit 0
+  fi
+  rm -rf "${p%/pending}"
+done
+find "$b" -mindepth 1 -maxdepth 1 -type d -mtime +0 -exec rm -rf {} + 2>/dev/null
+mkdir "$d" || { echo '### failed'; exit 0; }
+echo '### snapshot'
+if ! eval "$3" 2>&1; then
+  echo '### failed'
+  rm -rf "$d"
+  exit 0
+fi
+printf 'd=%s\n%s\n' "$d" "$4" > "$d/restore"
+touch "$d/pending"
+t=
+command -v setsid >/dev/null 2>&1 && t=setsid
+$t nohup sh -c 'sleep "$1"; mv "$0/pending" "$0/rolling" 2>/dev/null || exit 0; if sh "$0/restore" > "$0/rollback.log" 2>&1; then mv "$0/rolling" "$0/rolled_back"; else mv "$0/rolling" "$0/failed"; fi' "$d" "$2" </dev/null >/dev/null 2>&1 &
+echo $! > "$d/timer"
+echo '### apply'
+eval "$5" 2>&1
+s=$?
+echo "exit=$s"
+if [ "$s" -ne 0 ]; then
+  kill "$(cat "$d/timer")" 2>/dev/null
+  rm -rf "$d"
+fi
+true`
+
+// settleScript confirms or rolls back a pending change and prints its state. Moving the pending marker
+// is atomic, so exactly one of the caller and the timer settles the change. Positional parameters:
+// change ID and confirm, rollback or status.
+const settleScript = `d="` + changeBaseDir + `/$1"
+case "$2" in
+confirm)
+  if mv "$d/pending" "$d/confirmed" 2>/dev/null; then
+    kill "$(cat "$d/timer")" 2>/dev/null
+    echo '### settled'
+  fi ;;
+rollback)
+  if mv "$d/pending" "$d/rolling" 2>/dev/null; then
+    kill "$(cat "$d/timer")" 2>/dev/null
+    echo '### settled'
+    if sh "$d/restore" > "$d/rollback.log" 2>&1; then mv "$d/rolling" "$d/rolled_back"; else mv "$d/rolling" "$d/failed"; fi
+  fi ;;
+esac
+echo '### status'
+for s in pending rolling rolled_back failed confirmed; do
+  [ -e "$d/$s" ] && echo "$s"
+done
+echo '### log'
+cat "$d/rollback.log" 2>/dev/null
+true`
+
+// changeBaseDir holds a directory per change with the saved ruleset, the restore script, the timer PID
+// and a marker file named after the state. Directories are removed after a day.
+const changeBaseDir = "/var/tmp/remote-server-api-firewall"
+
+// Limits of rule specifications
+const (
+	maxCommentLength = 128
+	maxPorts         = 15 // iptables multiport limit
+)
+
+// interfacePattern matches network interface names
+var interfacePattern = regexp.MustCompile(`^[a-zA-Z0-9_.:@-]{1,15}$`)
+
+// portPattern matches a port or a port range such as "8000-8080"
+var portPattern = regexp.MustCompile(`^(\d{1,5})(?:-(\d{1,5}))?$`)
+
+// AddRule implements the Service interface
+func (s *service) AddRule(ctx context.Context, sessionID string, spec RuleSpec, confirmTimeout time.Duration) (*RuleChange, error) {
+	if err := validateConfirmTimeout(confirmTimeout); err != nil {
+		return nil, err
+	}
+
+	unlock := s.lock(sessionID)
+	defer unlock()
+
+	parsed, err := s.readRuleset(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizeRuleSpec(&spec, parsed); err != nil {
+		return nil, err
+	}
+
+	var command, snapshot, restore string
+	switch spec.Backend {
+	case BackendNftables:
+		command, snapshot, restore = nftAddCommand(spec), nftSnapshotCommand, nftRestoreCommand
+	case BackendIptables:
+		command, snapshot, restore = iptablesAddCommand(spec), iptablesSnapshotCommand, iptablesRestoreCommand
+	case BackendUFW:
+		hasRules := false
+		for _, rule := range parsed.rules {
+			hasRules = hasRules || rule.Backend == BackendUFW
+		}
+		command, snapshot, restore = ufwAddCommand(spec, hasRules), ufwSnapshotCommand, ufwRestoreCommand
+	}
+
+	rule := Rule{
+		Backend:          spec.Backend,
+		Family:           spec.Family,
+		Table:            spec.Table,
+		Chain:            spec.Chain,
+		Action:           spec.Action,
+		Protocol:         spec.Protocol,
+		Source:           spec.Source,
+		Destination:      spec.Destination,
+		DestinationPorts: spec.DestinationPorts,
+		InInterface:      spec.InInterface,
+		Comment:          spec.Comment,
+	}
+	if spec.Insert {
+		rule.Position = 1
+	}
+
+	return s.applyChange(ctx, sessionID, OperationAdd, rule, confirmTimeout, command, snapshot, restore)
+}
+
+// DeleteRule implements the Service interface
+func (s *service) DeleteRule(ctx context.Context, sessionID string, ruleID string, confirmTimeout time.Duration) (*RuleChange, error) {
+	if err := validateConfirmTimeout(confirmTimeout); err != nil {
+		return nil, err
+	}
+
+	unlock := s.lock(sessionID)
+	defer unlock()
+
+	// Rules are looked up again right before deleting, as ufw rule numbers shift with every change
+	parsed, err := s.readRuleset(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	var target *parsedRule
+	for i := range parsed.rules {
+		if parsed.rules[i].ID == ruleID {
+			target = &parsed.rules[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("%w: %s", ErrRuleNotFound, ruleID)
+	}
+
+	var command, snapshot, restore string
+	switch target.Backend {
+	case BackendNftables:
+		command, snapshot, restore = nftDeleteCommand(*target), nftSnapshotCommand, nftRestoreCommand
+	case BackendIptables:
+		command, snapshot, restore = iptablesDeleteCommand(*target), iptablesSnapshotCommand, iptablesRestoreCommand
+	case BackendUFW:
+		command, snapshot, restore = ufwDeleteCommand(*target), ufwSnapshotCommand, ufwRestoreCommand
+	}
+
+	return s.applyChange(ctx, sessionID, OperationDelete, target.Rule, confirmTimeout, command, snapshot, restore)
+}
+
+// GetChange implements the Service interface
+func (s *service) GetChange(ctx context.Context, sessionID string, id string) (*RuleChange, error) {
+	change, err := s.changes.get(sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+	if change.Status != ChangeStatusPending {
+		return change, nil
+	}
+
+	change, _, err = s.settleChange(ctx, sessionID, change, "status")
+	return change, err
+}
+
+// ConfirmChange implements the Service interface
+func (s *service) ConfirmChange(ctx context.Context, sessionID string, id string) (*RuleChange, error) {
+	return s.settle(ctx, sessionID, id, "confirm")
+}
+
+// RollbackChange implements the Service interface
+func (s *service) RollbackChange(ctx context.Context, sessionID string, id string) (*RuleChange, error) {
+	return s.settle(ctx, sessionID, id, "rollback")
+}
+
+// settle confirms or rolls back a pending change
+func (s *service) settle(ctx context.Context, sessionID string, id string, action string) (*RuleChange, error) {
+	change, err := s.changes.get(sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+	if change.Status != ChangeStatusPending {
+		return nil, fmt.Errorf("%w: %s is %s", ErrChangeNotPending, id, change.Status)
+	}
+
+	unlock := s.lock(sessionID)
+	defer unlock()
+
+	change, settled, err := s.settleChange(ctx, sessionID, change, action)
+	if err != nil {
+		return nil, err
+	}
+	if !settled {
+		return nil, fmt.Errorf("%w: %s is %s", ErrChangeNotPending, id, change.Status)
+	}
+	return change, nil
+}
+
+// applyChange saves the ruleset, starts the rollback timer and runs the change command on the server
+func (s *service) applyChange(ctx context.Context, sessionID string, operation string, rule Rule, confirmTimeout time.Duration, command string, snapshot string, restore string) (*RuleChange, error) {
+	id, err := newChangeID()
+	if err != nil {
+		return nil, err
+	}
+
+	seconds := strconv.Itoa(int(confirmTimeout.Round(time.Second) / time.Second))
+	sections, err := s.runPrivileged(ctx, sessionID, applyScript, id, seconds, snapshot, restore, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if busy, ok := sections["busy"]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrChangePending, strings.TrimSpace(busy))
+	}
+	if _, ok := sections["failed"]; ok {
+		message := strings.TrimSpace(sections["snapshot"])
+		if shell.IsPermissionDenied(message) {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionDenied, message)
+		}
+		return nil, fmt.Errorf("%w: failed to save the ruleset: %s", ErrCommandFailed, message)
+	}
+
+	// Split the command output from the trailing exit status line
+	output, exitStatus := shell.SplitExitStatus(sections["apply"])
+	if exitStatus != "0" {
+		if shell.IsPermissionDenied(output) {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionDenied, output)
+		}
+		return nil, fmt.Errorf("%w: %s rejected the change: %s", ErrInvalidRule, rule.Backend, output)
+	}
+
+	now := time.Now().UTC()
+	change := RuleChange{
+		ID:        id,
+		Operation: operation,
+		Backend:   rule.Backend,
+		Rule:      rule,
+		Command:   command,
+		Status:    ChangeStatusPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(confirmTimeout),
+	}
+	s.changes.add(sessionID, change)
+
+	return &change, nil
+}
+
+// settleChange runs the settle script with the action and records the reported state. It also
+// reports whether this call settled the change.
+func (s *service) settleChange(ctx context.Context, sessionID string, change *RuleChange, action string) (*RuleChange, bool, error) {
+	sections, err := s.runPrivileged(ctx, sessionID, settleScript, change.ID, action)
+	if err != nil {
+		return nil, false, err
+	}
+	_, settled := sections["settled"]
+
+	status, message := ChangeStatusFailed, "change state is no longer available on the server"
+	switch strings.TrimSpace(sections["status"]) {
+	case "pending", "rolling":
+		status, message = ChangeStatusPending, ""
+	case "confirmed":
+		status, message = ChangeStatusConfirmed, ""
+	case "rolled_back":
+		status, message = ChangeStatusRolledBack, ""
+	case "failed":
+		message = "rollback failed: " + strings.TrimSpace(sections["log"])
+	}
+
+	updated := s.changes.update(change.ID, status, message)
+	if updated == nil {
+		return nil, false, fmt.Errorf("%w: %s", ErrChangeNotFound, change.ID)
+	}
+	return updated, settled, nil
+}
+
+// runPrivileged runs a change script as root through passwordless sudo with positional parameters
+// and parses its sections
+func (s *service) runPrivileged(ctx context.Context, sessionID string, script string, args ...string) (map[string]string, error) {
+	command := "{ " + shell.SudoPrefix + " sh -c " + shell.Quote(script) + " sh " + quoteArgs(args) + "; } 2>&1; true"
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, command)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	if len(sections) == 0 {
+		message := strings.TrimSpace(output)
+		if shell.IsPermissionDenied(message) {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionDenied, message)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, firstLine(message))
+	}
+	return sections, nil
+}
+
+// validateConfirmTimeout checks that a confirmation timeout is within bounds
+func validateConfirmTimeout(timeout time.Duration) error {
+	if timeout < MinConfirmTimeout || timeout > MaxConfirmTimeout {
+		return fmt.Errorf("%w: must be between %s and %s", ErrInvalidConfirmation, MinConfirmTimeout, MaxConfirmTimeout)
+	}
+	return nil
+}
+
+// normalizeRuleSpec validates a rule specification against the ruleset and fills in defaults
+func normalizeRuleSpec(spec *RuleSpec, parsed *parsedRuleset) error {
+	spec.Backend = strings.ToLower(strings.TrimSpace(spec.Backend))
+	spec.Action = strings.ToLower(strings.TrimSpace(spec.Action))
+	spec.Protocol = strings.ToLower(strings.TrimSpace(spec.Protocol))
+
+	var status *BackendStatus
+	for i := range parsed.backends {
+		if parsed.backends[i].Name == spec.Backend {
+			status = &parsed.backends[i]
+		}
+	}
+	if status == nil {
+		return fmt.Errorf("%w: unknown backend %q (use nftables, iptables or ufw)", ErrInvalidRule, spec.Backend)
+	}
+	if !status.Available {
+		return fmt.Errorf("%w: %s", ErrBackendUnavailable, spec.Backend)
+	}
+
+	switch spec.Action {
+	case ActionAccept, ActionDrop, ActionReject:
+	default:
+		return fmt.Errorf("%w: action must be accept, drop or reject", ErrInvalidRule)
+	}
+
+	// Addresses decide the family where the backend distinguishes them
+	addressFamily := ""
+	for _, address := range []string{spec.Source, spec.Destination} {
+		if address == "" {
+			continue
+		}
+		if !isAddress(address) {
+			return fmt.Errorf("%w: %q is not an IP address or network", ErrInvalidRule, address)
+		}
+		family := "ip"
+		if isIPv6(address) {
+			family = "ip6"
+		}
+		if addressFamily != "" && addressFamily != family {
+			return fmt.Errorf("%w: source and destination must be of the same IP version", ErrInvalidRule)
+		}
+		addressFamily = family
+	}
+
+	if err := validateProtocolAndPorts(spec); err != nil {
+		return err
+	}
+	if spec.InInterface != "" && !interfacePattern.MatchString(spec.InInterface) {
+		return fmt.Errorf("%w: invalid interface name %q", ErrInvalidRule, spec.InInterface)
+	}
+	if len(spec.Comment) > maxCommentLength {
+		return fmt.Errorf("%w: comment must be at most %d characters", ErrInvalidRule, maxCommentLength)
+	}
+	for _, c := range spec.Comment {
+		if c < ' ' || c == 0x7f || c == '"' || c == '\\' {
+			return fmt.Errorf("%w: comment must not contain quotes, backslashes or control characters", ErrInvalidRule)
+		}
+	}
+
+	switch spec.Backend {
+	case BackendNftables:
+		if spec.Family == "" {
+			spec.Family = "inet"
+		}
+		if spec.Protocol == "icmp" && spec.Family == "ip6" || spec.Protocol == "icmpv6" && spec.Family == "ip" {
+			return fmt.Errorf("%w: %s does not match family %s", ErrInvalidRule, spec.Protocol, spec.Family)
+		}
+		if addressFamily != "" && spec.Family != "inet" && spec.Family != addressFamily {
+			return fmt.Errorf("%w: addresses do not match family %s", ErrInvalidRule, spec.Family)
+		}
+	case BackendIptables:
+		if spec.Family == "" {
+			spec.Family = shell.FirstNonEmpty(addressFamily, "ip")
+		}
+		if spec.Table == "" {
+			spec.Table = "filter"
+		}
+		if spec.Family != "ip" && spec.Family != "ip6" {
+			return fmt.Errorf("%w: iptables family must be ip or ip6", ErrInvalidRule)
+		}
+		if addressFamily != "" && spec.Family != addressFamily {
+			return fmt.Errorf("%w: addresses do not match family %s", ErrInvalidRule, spec.Family)
+		}
+	case BackendUFW:
+		spec.Chain = strings.ToLower(spec.Chain)
+		spec.Family, spec.Table = shell.FirstNonEmpty(addressFamily, "inet"), "ufw"
+		if spec.Chain != "input" && spec.Chain != "output" {
+			return fmt.Errorf("%w: ufw chain must be input or output", ErrInvalidRule)
+		}
+		if spec.Protocol != "" && spec.Protocol != "tcp" && spec.Protocol != "udp" {
+			return fmt.Errorf("%w: ufw rules support tcp and udp only", ErrInvalidRule)
+		}
+		if spec.InInterface != "" && spec.Chain == "output" {
+			return fmt.Errorf("%w: in_interface cannot be used with the output chain", ErrInvalidRule)
+		}
+		return nil
+	}
+
+	// The chain must exist in the table
+	for _, chain := range parsed.chains {
+		if chain.Backend == spec.Backend && chain.Family == spec.Family && chain.Table == spec.Table && chain.Name == spec.Chain {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: chain %s %s %s not found", ErrInvalidRule, spec.Family, spec.Table, spec.Chain)
+}
+
+// validateProtocolAndPorts checks the protocol and the destination ports of a rule specification
+func validateProtocolAndPorts(spec *RuleSpec) error {
+	switch spec.Protocol {
+	case "", "tcp", "udp", "sctp", "icmp", "icmpv6":
+	default:
+		return fmt.Errorf("%w: protocol must be tcp, udp, sctp, icmp or icmpv6", ErrInvalidRule)
+	}
+
+	if len(spec.DestinationPorts) == 0 {
+		return nil
+	}
+	if spec.Protocol != "tcp" && spec.Protocol != "udp" && spec.Protocol != "sctp" {
+		return fmt.Errorf("%w: destination ports require protocol tcp, udp or sctp", ErrInvalidRule)
+	}
+	if len(spec.DestinationPorts) > maxPorts {
+		return fmt.Errorf("%w: at most %d destination ports", ErrInvalidRule, maxPorts)
+	}
+	for _, port := range spec.DestinationPorts {
+		match := portPattern.FindStringSubmatch(port)
+		if match == nil {
+			return fmt.Errorf("%w: invalid port %q", ErrInvalidRule, port)
+		}
+		first, _ := strconv.Atoi(match[1])
+		last := first
+		if match[2] != "" {
+			last, _ = strconv.Atoi(match[2])
+		}
+		if first < 1 || last > 65535 || first > last {
+			return fmt.Errorf("%w: invalid port %q", ErrInvalidRule, port)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package firewall
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// rulesetScript prints the nftables ruleset as JSON, the iptables and ip6tables rules and the ufw
+// status, using passwordless sudo when available. Errors are printed into the sections so that
+// missing privileges can be reported per backend.
+const rulesetScript = `S="` + shell.SudoIfAvailable + `"
+if command -v nft >/dev/null 2>&1; then
+  echo '### nft'
+  $S nft -j list ruleset 2>&1
+fi
+if command -v iptables-save >/dev/null 2>&1; then
+  echo '### iptables-version'
+  iptables -V 2>/dev/null
+  echo '### iptables'
+  $S iptables-save 2>&1
+  if command -v ip6tables-save >/dev/null 2>&1; then
+    echo '### ip6tables'
+    $S ip6tables-save 2>&1
+  fi
+fi
+if command -v ufw >/dev/null 2>&1; then
+  echo '### ufw'
+  $S ufw status numbered 2>&1
+  echo '### ufw-verbose'
+  $S ufw status verbose 2>&1
+fi
+true`
+
+// iptablesNftTables are the tables iptables-nft creates in nftables. Their rules are reported from
+// iptables-save, which shows them in the syntax they were written in.
+var iptablesNftTables = map[string]bool{
+	"filter":   true,
+	"nat":      true,
+	"mangle":   true,
+	"raw":      true,
+	"security": true,
+}
+
+// parsedRule is a rule together with the details needed to delete it
+type parsedRule struct {
+	Rule
+
+	// handle of an nftables rule
+	handle int
+
+	// args of an iptables rule after "-A <chain>", as tokens
+	args []string
+
+	// number of a ufw rule in 'ufw status numbered'
+	number int
+}
+
+// parsedRuleset is the result of reading all backends
+type parsedRuleset struct {
+	backends []BackendStatus
+	chains   []Chain
+	rules    []parsedRule
+}
+
+// GetRuleset implements the Service interface
+func (s *service) GetRuleset(ctx context.Context, sessionID string, filter RuleFilter) (*Ruleset, error) {
+	parsed, err := s.readRuleset(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset := &Ruleset{
+		Backends:    parsed.backends,
+		Chains:      []Chain{},
+		Rules:       []Rule{},
+		CollectedAt: time.Now().UTC(),
+	}
+	for _, chain := range parsed.chains {
+		if matchesFilter(filter, chain.Backend, chain.Name) {
+			ruleset.Chains = append(ruleset.Chains, chain)
+		}
+	}
+	for _, rule := range parsed.rules {
+		if matchesFilter(filter, rule.Backend, rule.Chain) {
+			ruleset.Rules = append(ruleset.Rules, rule.Rule)
+		}
+	}
+
+	return ruleset, nil
+}
+
+// readRuleset reads and normalizes the rules of all backends
+func (s *service) readRuleset(ctx context.Context, sessionID string) (*parsedRuleset, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, rulesetScript)
+	if err != nil {
+		return nil, err
+	}
+	sections := shell.ParseSections(output)
+
+	parsed := &parsedRuleset{}
+
+	// iptables first, so that tables managed by iptables-nft can be skipped in the nftables ruleset
+	iptablesStatus := BackendStatus{Name: BackendIptables}
+	iptablesTables := make(map[string]bool)
+	if _, ok := sections["iptables"]; ok {
+		iptablesStatus.Available = true
+		iptablesStatus.Variant = parseIptablesVariant(sections["iptables-version"])
+		for _, family := range []string{"ip", "ip6"} {
+			section := "iptables"
+			if family == "ip6" {
+				section = "ip6tables"
+			}
+			chains, rules, problem := parseIptablesSave(sections[section], family)
+			if problem != "" && iptablesStatus.Error == "" {
+				iptablesStatus.Error = problem
+			}
+			parsed.chains = append(parsed.chains, chains...)
+			parsed.rules = append(parsed.rules, rules...)
+			for _, chain := range chains {
+				iptablesTables[chain.Family+" "+chain.Table] = true
+			}
+			iptablesStatus.Active = iptablesStatus.Active || len(rules) > 0
+		}
+	}
+
+	nftablesStatus := BackendStatus{Name: BackendNftables}
+	if output, ok := sections["nft"]; ok {
+		nftablesStatus.Available = true
+		skip := func(family string, table string) bool {
+			return iptablesStatus.Variant == "nf_tables" && iptablesNftTables[table] && iptablesTables[family+" "+table]
+		}
+		chains, rules, problem := parseNftRuleset(output, skip)
+		nftablesStatus.Error = problem
+		nftablesStatus.Active = len(rules) > 0
+		parsed.chains = append(parsed.chains, chains...)
+		parsed.rules = append(parsed.rules, rules...)
+	}
+
+	ufwStatus := BackendStatus{Name: BackendUFW}
+	if output, ok := sections["ufw"]; ok {
+		ufwStatus.Available = true
+		active, chains, rules, problem := parseUFWStatus(output, sections["ufw-verbose"])
+		ufwStatus.Active = active
+		ufwStatus.Error = problem
+		parsed.chains = append(parsed.chains, chains...)
+		parsed.rules = append(parsed.rules, rules...)
+	}
+
+	parsed.backends = []BackendStatus{nftablesStatus, iptablesStatus, ufwStatus}
+	return parsed, nil
+}
+
+// matchesFilter reports whether a chain or rule of a backend passes the filter
+func matchesFilter(filter RuleFilter, backend string, chain string) bool {
+	if filter.Backend != "" && filter.Backend != backend {
+		return false
+	}
+	return filter.Chain == "" || strings.EqualFold(filter.Chain, chain)
+}
+
+// ruleIDs assigns identifiers derived from the content of rules, so that they stay valid when other
+// rules are added or removed. Identical rules in the same chain are numbered.
+type ruleIDs map[string]int
+
+// next returns the identifier of a rule with the given prefix and content
+func (ids ruleIDs) next(prefix string, content string) string {
+	sum := sha256.Sum256([]byte(content))
+	id := prefix + ":" + hex.EncodeToString(sum[:4])
+
+	ids[id]++
+	if count := ids[id]; count > 1 {
+		id += ":" + strconv.Itoa(count)
+	}
+	return id
+}
+
+// firstLine returns the first non-empty line of command output
+func firstLine(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
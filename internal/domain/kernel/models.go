@@ -0,0 +1,90 @@
+package kernel
+
+import "time"
+
+// Sysctl difference states
+const (
+	DifferenceChanged = "changed" // The server has another value than the baseline
+	DifferenceMissing = "missing" // The parameter does not exist on the server
+)
+
+// SysctlParameter is a kernel parameter with its runtime value and, when set in a sysctl
+// configuration file, the value applied at boot
+type SysctlParameter struct {
+	Key             string `json:"key"`
+	Value           string `json:"value"` // Runtime value, whitespace-separated fields joined by single spaces
+	ConfiguredValue string `json:"configured_value,omitempty"`
+	ConfiguredIn    string `json:"configured_in,omitempty"` // Configuration file with the effective setting
+	Drift           bool   `json:"drift,omitempty"`         // The runtime value differs from the configured one
+}
+
+// SysctlReport lists the kernel parameters of a server
+type SysctlReport struct {
+	Parameters  []SysctlParameter `json:"parameters"`
+	CollectedAt time.Time         `json:"collected_at"`
+}
+
+// SysctlBaseline is a set of expected kernel parameter values, given as a map or as the text of a
+// sysctl.conf file or of 'sysctl -a' output. Both may be combined; values of the map take precedence.
+type SysctlBaseline struct {
+	Values  map[string]string `json:"values,omitempty"`
+	Content string            `json:"content,omitempty"`
+}
+
+// SysctlDifference is a baseline parameter whose value differs on the server
+type SysctlDifference struct {
+	Key      string `json:"key"`
+	Status   string `json:"status"` // changed or missing
+	Baseline string `json:"baseline"`
+	Current  string `json:"current,omitempty"`
+}
+
+// SysctlDiff compares the kernel parameters of a server with a baseline
+type SysctlDiff struct {
+	Compared    int                `json:"compared"` // Number of baseline parameters
+	Matching    int                `json:"matching"`
+	Differences []SysctlDifference `json:"differences"`
+	CollectedAt time.Time          `json:"collected_at"`
+}
+
+// SysctlUpdate is a request to set a kernel parameter
+type SysctlUpdate struct {
+	Value   string `json:"value"`
+	Persist bool   `json:"persist,omitempty"` // Also write the value to /etc/sysctl.d so that it survives reboots
+}
+
+// SysctlUpdateResult describes an applied kernel parameter change
+type SysctlUpdateResult struct {
+	Key           string `json:"key"`
+	PreviousValue string `json:"previous_value"`
+	Value         string `json:"value"` // Value read back from the kernel
+	Persisted     bool   `json:"persisted"`
+	PersistFile   string `json:"persist_file,omitempty"`
+	// OverriddenBy is a configuration file applied after PersistFile, e.g. /etc/sysctl.conf, that sets
+	// the parameter to another value, which is the one applied at boot
+	OverriddenBy string `json:"overridden_by,omitempty"`
+}
+
+// KernelModule is a loaded kernel module from /proc/modules, the source of lsmod
+type KernelModule struct {
+	Name      string   `json:"name"`
+	Size      int64    `json:"size"`      // Memory size in bytes
+	UseCount  int      `json:"use_count"` // References held by other modules and processes
+	UsedBy    []string `json:"used_by"`   // Modules depending on this module
+	DependsOn []string `json:"depends_on"`
+	State     string   `json:"state"` // Live, Loading or Unloading
+}
+
+// CommandLineParameter is a parameter of the kernel command line. Flags have no value.
+type CommandLineParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+// CommandLine is the command line the running kernel was booted with
+type CommandLine struct {
+	Release    string                 `json:"release"` // uname -r
+	Raw        string                 `json:"raw"`
+	Parameters []CommandLineParameter `json:"parameters"`
+	InitArgs   []string               `json:"init_args,omitempty"` // Arguments after "--", passed to init
+}
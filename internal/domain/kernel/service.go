@@ -0,0 +1,99 @@
+package kernel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound   = errors.New("session not found or expired")
+	ErrCommandFailed     = errors.New("command execution failed")
+	ErrInvalidKey        = errors.New("invalid sysctl key")
+	ErrInvalidValue      = errors.New("invalid sysctl value")
+	ErrInvalidBaseline   = errors.New("invalid sysctl baseline")
+	ErrParameterNotFound = errors.New("sysctl parameter not found")
+	ErrParameterReadOnly = errors.New("sysctl parameter is read-only")
+	ErrPermissionDenied  = errors.New("permission denied")
+)
+
+// Limits of sysctl keys and values
+const (
+	maxKeyLength   = 256
+	maxValueLength = 4096
+)
+
+// sysctlKeyPattern matches sysctl keys in dotted or slash-separated notation, and the glob patterns
+// of sysctl.d
+var sysctlKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9_*?][a-zA-Z0-9_.:/@*?-]*$`)
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// RunCommand executes a command on the SSH session
+	RunCommand(ctx context.Context, sessionID string, command string) (string, error)
+}
+
+// Service defines the kernel parameter and module service
+type Service interface {
+	// ListSysctl retrieves the kernel parameters, optionally those under a key prefix, with the values
+	// set in sysctl configuration files
+	ListSysctl(ctx context.Context, sessionID string, prefix string) (*SysctlReport, error)
+
+	// DiffSysctl compares the kernel parameters with a baseline
+	DiffSysctl(ctx context.Context, sessionID string, baseline SysctlBaseline) (*SysctlDiff, error)
+
+	// SetSysctl sets a kernel parameter and optionally persists it to /etc/sysctl.d
+	SetSysctl(ctx context.Context, sessionID string, key string, update SysctlUpdate) (*SysctlUpdateResult, error)
+
+	// ListModules retrieves the loaded kernel modules with their dependencies
+	ListModules(ctx context.Context, sessionID string) ([]KernelModule, error)
+
+	// GetCommandLine retrieves the kernel command line
+	GetCommandLine(ctx context.Context, sessionID string) (*CommandLine, error)
+}
+
+type service struct {
+	sessionRepo SessionRepository
+}
+
+// NewService creates a new kernel service
+func NewService(sessionRepo SessionRepository) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// normalizeKey validates a sysctl key and converts it to the dotted notation. In the slash-separated
+// notation dots belong to names, such as VLAN interfaces, and are shown as slashes in dotted keys.
+func normalizeKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if len(key) > maxKeyLength || !sysctlKeyPattern.MatchString(key) || strings.Contains(key, "..") || strings.Contains(key, "//") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	if strings.Contains(key, "/") && !strings.Contains(key, ".") {
+		key = strings.ReplaceAll(key, "/", ".")
+	}
+	return strings.TrimSuffix(key, "."), nil
+}
+
+// procPath returns the path of a dotted sysctl key below /proc/sys
+func procPath(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.':
+			return '/'
+		case '/':
+			return '.'
+		}
+		return r
+	}, key)
+}
+
+// normalizeValue joins the whitespace-separated fields of a value with single spaces, as sysctl
+// separates the fields of vector values with tabs
+func normalizeValue(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
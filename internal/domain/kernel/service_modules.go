@@ -0,0 +1,145 @@
+package kernel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// modulesScript prints the loaded modules in the format of /proc/modules, which lsmod formats
+const modulesScript = `cat /proc/modules 2>/dev/null
+true`
+
+// commandLineScript prints the kernel release and command line
+const commandLineScript = `echo '### release'
+uname -r
+echo '### cmdline'
+cat /proc/cmdline 2>/dev/null
+true`
+
+// ListModules implements the Service interface
+func (s *service) ListModules(ctx context.Context, sessionID string) ([]KernelModule, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, modulesScript)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseModules(output), nil
+}
+
+// GetCommandLine implements the Service interface
+func (s *service) GetCommandLine(ctx context.Context, sessionID string) (*CommandLine, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, commandLineScript)
+	if err != nil {
+		return nil, err
+	}
+	sections := shell.ParseSections(output)
+
+	raw := strings.TrimSpace(sections["cmdline"])
+	if raw == "" {
+		return nil, fmt.Errorf("%w: /proc/cmdline could not be read", ErrCommandFailed)
+	}
+
+	parameters, initArgs := parseCommandLine(raw)
+	return &CommandLine{
+		Release:    strings.TrimSpace(sections["release"]),
+		Raw:        raw,
+		Parameters: parameters,
+		InitArgs:   initArgs,
+	}, nil
+}
+
+// parseModules parses /proc/modules lines such as
+// "nf_conntrack 172032 2 nf_nat,nft_ct, Live 0x0000000000000000"
+// and derives the dependencies of each module from the modules using it
+func parseModules(output string) []KernelModule {
+	modules := []KernelModule{}
+	index := make(map[string]int)
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[1], 10, 64)
+		useCount, _ := strconv.Atoi(fields[2])
+
+		module := KernelModule{
+			Name:      fields[0],
+			Size:      size,
+			UseCount:  useCount,
+			UsedBy:    []string{},
+			DependsOn: []string{},
+			State:     fields[4],
+		}
+		for _, user := range strings.Split(fields[3], ",") {
+			if user != "" && user != "-" && user != "[permanent]" {
+				module.UsedBy = append(module.UsedBy, user)
+			}
+		}
+		sort.Strings(module.UsedBy)
+
+		index[module.Name] = len(modules)
+		modules = append(modules, module)
+	}
+
+	for _, module := range modules {
+		for _, user := range module.UsedBy {
+			if i, ok := index[user]; ok {
+				modules[i].DependsOn = append(modules[i].DependsOn, module.Name)
+			}
+		}
+	}
+
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Name < modules[j].Name
+	})
+	for i := range modules {
+		sort.Strings(modules[i].DependsOn)
+	}
+
+	return modules
+}
+
+// parseCommandLine splits a kernel command line into parameters and the arguments after "--", which
+// the kernel passes to init. Values may be double-quoted to contain spaces.
+func parseCommandLine(raw string) ([]CommandLineParameter, []string) {
+	parameters := []CommandLineParameter{}
+	var initArgs []string
+
+	var word strings.Builder
+	quoted, toInit := false, false
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		switch {
+		case toInit:
+			initArgs = append(initArgs, word.String())
+		case word.String() == "--":
+			toInit = true
+		default:
+			name, value, _ := strings.Cut(word.String(), "=")
+			parameters = append(parameters, CommandLineParameter{Name: name, Value: value})
+		}
+		word.Reset()
+	}
+
+	for _, c := range raw {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case !quoted && (c == ' ' || c == '\t' || c == '\n'):
+			flush()
+		default:
+			word.WriteRune(c)
+		}
+	}
+	flush()
+
+	return parameters, initArgs
+}
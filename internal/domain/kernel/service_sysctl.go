@@ -0,0 +1,371 @@
+package kernel
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// sysctlScript prints the kernel parameters, falling back to reading /proc/sys without sysctl, and the
+// sysctl configuration files in the order systemd-sysctl reads their directories. Configuration lines
+// are prefixed with "|" so that comments cannot be taken for section headers.
+const sysctlScript = `S="` + shell.SudoIfAvailable + `"
+if command -v sysctl >/dev/null 2>&1; then
+  echo '### sysctl'
+  $S sysctl -a 2>/dev/null
+else
+  echo '### proc'
+  find /proc/sys -type f 2>/dev/null | while read -r f; do v=$(cat "$f" 2>/dev/null) && printf '%s = %s\n' "${f#/proc/sys/}" "$v"; done
+fi
+for f in /etc/sysctl.d/*.conf /run/sysctl.d/*.conf /usr/local/lib/sysctl.d/*.conf /usr/lib/sysctl.d/*.conf /lib/sysctl.d/*.conf /etc/sysctl.conf; do
+  [ -f "$f" ] || continue
+  echo "### conf $f"
+  sed 's/^/|/' "$f" 2>/dev/null
+done
+true`
+
+// setSysctlScript writes a kernel parameter through /proc/sys and, on request, replaces its line in
+// the persistence file, which is written to a temporary file and renamed. When persisting fails, the
+// previous runtime value is restored. Positional parameters: dotted key, path below /proc/sys, value,
+// 1 to persist and the persistence file.
+const setSysctlScript = `p="/proc/sys/$2"
+[ -f "$p" ] || { echo '### missing'; exit 0; }
+[ -n "$(find "$p" -perm -200 2>/dev/null)" ] || { echo '### readonly'; exit 0; }
+prev=$(cat "$p" 2>/dev/null)
+echo '### previous'
+printf '%s\n' "$prev"
+echo '### write'
+printf '%s\n' "$3" | tee "$p" 2>&1 >/dev/null
+w=$?
+echo "exit=$w"
+echo '### current'
+cat "$p" 2>&1
+if [ "$w" -eq 0 ] && [ "$4" = 1 ]; then
+  echo '### persist'
+  f="$5"
+  t="$f.tmp.$$"
+  mkdir -p "$(dirname "$f")" && {
+    if [ -f "$f" ]; then
+      awk -v k="$1" '{ x = $0; sub(/^[ \t-]+/, "", x); sub(/[ \t]*=.*/, "", x); if (x != k) print }' "$f"
+    else
+      echo '# Kernel parameters set through remote-server-api'
+    fi
+    printf '%s = %s\n' "$1" "$3"
+  } > "$t" && chmod 644 "$t" && mv "$t" "$f"
+  s=$?
+  echo "exit=$s"
+  if [ "$s" -ne 0 ]; then
+    rm -f "$t"
+    printf '%s\n' "$prev" > "$p"
+  fi
+fi
+true`
+
+// PersistFile is the sysctl.d file kernel parameters set through the API are persisted to. The
+// prefix orders it after the distribution defaults, but files sorting after it and /etc/sysctl.conf
+// are still applied later.
+const PersistFile = "/etc/sysctl.d/99-remote-server-api.conf"
+
+// sysctlConfigDirs are the sysctl.d directories in order of precedence. Files with the same name in a
+// later directory are shadowed.
+var sysctlConfigDirs = []string{"/etc/sysctl.d", "/run/sysctl.d", "/usr/local/lib/sysctl.d", "/usr/lib/sysctl.d", "/lib/sysctl.d"}
+
+// readOnlyMessage is the error of writes to /proc/sys where it is mounted read-only, e.g. in
+// containers, which is reported as missing privileges
+const readOnlyMessage = "read-only file system"
+
+// sysctlAssignment is a "key = value" line of a sysctl configuration file. Keys may be glob patterns.
+type sysctlAssignment struct {
+	key   string
+	value string
+	file  string
+}
+
+// ListSysctl implements the Service interface
+func (s *service) ListSysctl(ctx context.Context, sessionID string, prefix string) (*SysctlReport, error) {
+	if prefix != "" {
+		normalized, err := normalizeKey(prefix)
+		if err != nil {
+			return nil, err
+		}
+		prefix = normalized
+	}
+
+	values, configured, err := s.readSysctl(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SysctlReport{
+		Parameters:  []SysctlParameter{},
+		CollectedAt: time.Now().UTC(),
+	}
+	for key, value := range values {
+		if prefix != "" && key != prefix && !strings.HasPrefix(key, prefix+".") {
+			continue
+		}
+		parameter := SysctlParameter{Key: key, Value: value}
+		if assignment, ok := configured[key]; ok {
+			parameter.ConfiguredValue = assignment.value
+			parameter.ConfiguredIn = assignment.file
+			parameter.Drift = assignment.value != value
+		}
+		report.Parameters = append(report.Parameters, parameter)
+	}
+	sort.Slice(report.Parameters, func(i, j int) bool {
+		return report.Parameters[i].Key < report.Parameters[j].Key
+	})
+
+	return report, nil
+}
+
+// DiffSysctl implements the Service interface
+func (s *service) DiffSysctl(ctx context.Context, sessionID string, baseline SysctlBaseline) (*SysctlDiff, error) {
+	assignments := parseSysctlAssignments(baseline.Content, "")
+	keys := make([]string, 0, len(baseline.Values))
+	for key := range baseline.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		normalized, err := normalizeKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBaseline, err)
+		}
+		assignments = append(assignments, sysctlAssignment{key: normalized, value: normalizeValue(baseline.Values[key])})
+	}
+	if len(assignments) == 0 {
+		return nil, fmt.Errorf("%w: no parameters given", ErrInvalidBaseline)
+	}
+
+	values, _, err := s.readSysctl(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Later assignments of a key take precedence, as in sysctl.conf
+	expected := make(map[string]string)
+	for _, assignment := range assignments {
+		if !isPattern(assignment.key) {
+			expected[assignment.key] = assignment.value
+			continue
+		}
+		for key := range values {
+			if matched, _ := path.Match(assignment.key, key); matched {
+				expected[key] = assignment.value
+			}
+		}
+	}
+
+	diff := &SysctlDiff{
+		Compared:    len(expected),
+		Differences: []SysctlDifference{},
+		CollectedAt: time.Now().UTC(),
+	}
+	for key, want := range expected {
+		current, ok := values[key]
+		switch {
+		case !ok:
+			diff.Differences = append(diff.Differences, SysctlDifference{Key: key, Status: DifferenceMissing, Baseline: want})
+		case current != want:
+			diff.Differences = append(diff.Differences, SysctlDifference{Key: key, Status: DifferenceChanged, Baseline: want, Current: current})
+		default:
+			diff.Matching++
+		}
+	}
+	sort.Slice(diff.Differences, func(i, j int) bool {
+		return diff.Differences[i].Key < diff.Differences[j].Key
+	})
+
+	return diff, nil
+}
+
+// SetSysctl implements the Service interface
+func (s *service) SetSysctl(ctx context.Context, sessionID string, key string, update SysctlUpdate) (*SysctlUpdateResult, error) {
+	key, err := normalizeKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if isPattern(key) {
+		return nil, fmt.Errorf("%w: %q (patterns cannot be set)", ErrInvalidKey, key)
+	}
+	value := normalizeValue(update.Value)
+	if value == "" || len(value) > maxValueLength {
+		return nil, fmt.Errorf("%w: must be between 1 and %d characters", ErrInvalidValue, maxValueLength)
+	}
+	for _, c := range value {
+		if c < ' ' || c == 0x7f {
+			return nil, fmt.Errorf("%w: must not contain control characters", ErrInvalidValue)
+		}
+	}
+
+	persist := "0"
+	if update.Persist {
+		persist = "1"
+	}
+	args := []string{key, procPath(key), value, persist, PersistFile}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shell.Quote(arg)
+	}
+	command := "{ " + shell.SudoPrefix + " sh -c " + shell.Quote(setSysctlScript) + " sh " + strings.Join(quoted, " ") + "; } 2>&1; true"
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, command)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	if _, ok := sections["missing"]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrParameterNotFound, key)
+	}
+	if _, ok := sections["readonly"]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrParameterReadOnly, key)
+	}
+	if _, ok := sections["write"]; !ok {
+		message := strings.TrimSpace(output)
+		if shell.IsPermissionDenied(message, readOnlyMessage) {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionDenied, message)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, message)
+	}
+
+	if writeOutput, exitStatus := shell.SplitExitStatus(sections["write"]); exitStatus != "0" {
+		lower := strings.ToLower(writeOutput)
+		switch {
+		case shell.IsPermissionDenied(lower, readOnlyMessage):
+			return nil, fmt.Errorf("%w: %s", ErrPermissionDenied, writeOutput)
+		case strings.Contains(lower, "invalid argument") || strings.Contains(lower, "numerical result out of range"):
+			return nil, fmt.Errorf("%w: the kernel rejected %q for %s", ErrInvalidValue, value, key)
+		default:
+			return nil, fmt.Errorf("%w: failed to write %s: %s", ErrCommandFailed, key, writeOutput)
+		}
+	}
+
+	result := &SysctlUpdateResult{
+		Key:           key,
+		PreviousValue: normalizeValue(sections["previous"]),
+		Value:         normalizeValue(sections["current"]),
+	}
+
+	if update.Persist {
+		persistOutput, exitStatus := shell.SplitExitStatus(sections["persist"])
+		if exitStatus != "0" {
+			// The runtime value was restored by the script
+			if shell.IsPermissionDenied(persistOutput, readOnlyMessage) {
+				return nil, fmt.Errorf("%w: %s", ErrPermissionDenied, persistOutput)
+			}
+			return nil, fmt.Errorf("%w: failed to persist %s to %s, the previous value was restored: %s", ErrCommandFailed, key, PersistFile, persistOutput)
+		}
+		result.Persisted = true
+		result.PersistFile = PersistFile
+
+		// Report a file applied later at boot that sets another value. The change itself succeeded,
+		// so a failure to read the configuration back is not an error.
+		if _, configured, err := s.readSysctl(ctx, sessionID); err == nil {
+			if assignment, ok := configured[key]; ok && assignment.file != PersistFile && normalizeValue(assignment.value) != value {
+				result.OverriddenBy = assignment.file
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// readSysctl reads the runtime values of the kernel parameters and the effective settings of the
+// sysctl configuration files
+func (s *service) readSysctl(ctx context.Context, sessionID string) (map[string]string, map[string]sysctlAssignment, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, sysctlScript)
+	if err != nil {
+		return nil, nil, err
+	}
+	sections := shell.ParseSections(output)
+
+	values := make(map[string]string)
+	proc, fromProc := sections["proc"]
+	for _, assignment := range parseSysctlAssignments(sections["sysctl"]+proc, "") {
+		// Paths below /proc/sys whose names contain dots keep their slashes when normalized
+		if fromProc && strings.Contains(assignment.key, "/") {
+			assignment.key = procPath(assignment.key)
+		}
+		values[assignment.key] = assignment.value
+	}
+	if len(values) == 0 {
+		return nil, nil, fmt.Errorf("%w: no kernel parameters could be read", ErrCommandFailed)
+	}
+
+	// Files of the same name shadow each other by directory; the rest are applied in name order, and
+	// /etc/sysctl.conf last
+	files := make(map[string]string)
+	var names []string
+	for _, dir := range sysctlConfigDirs {
+		for name := range sections {
+			file, ok := strings.CutPrefix(name, "conf ")
+			if !ok || path.Dir(file) != dir {
+				continue
+			}
+			if _, shadowed := files[path.Base(file)]; !shadowed {
+				files[path.Base(file)] = file
+				names = append(names, path.Base(file))
+			}
+		}
+	}
+	sort.Strings(names)
+	ordered := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		ordered = append(ordered, files[name])
+	}
+	if _, ok := sections["conf /etc/sysctl.conf"]; ok {
+		ordered = append(ordered, "/etc/sysctl.conf")
+	}
+
+	configured := make(map[string]sysctlAssignment)
+	for _, file := range ordered {
+		for _, assignment := range parseSysctlAssignments(shell.TrimLinePrefix(sections["conf "+file]), file) {
+			if !isPattern(assignment.key) {
+				configured[assignment.key] = assignment
+				continue
+			}
+			for key := range values {
+				if matched, _ := path.Match(assignment.key, key); matched {
+					configured[key] = sysctlAssignment{key: key, value: assignment.value, file: file}
+				}
+			}
+		}
+	}
+
+	return values, configured, nil
+}
+
+// parseSysctlAssignments parses "key = value" lines of sysctl configuration files and of 'sysctl -a'
+// output. Comments, invalid keys and lines without "=" are skipped.
+func parseSysctlAssignments(content string, file string) []sysctlAssignment {
+	var assignments []sysctlAssignment
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		// A leading "-" makes systemd-sysctl ignore failures to set the key
+		key, err := normalizeKey(strings.TrimPrefix(strings.TrimSpace(key), "-"))
+		if err != nil {
+			continue
+		}
+		assignments = append(assignments, sysctlAssignment{key: key, value: normalizeValue(value), file: file})
+	}
+	return assignments
+}
+
+// isPattern reports whether a key is a glob pattern, as allowed in sysctl.d
+func isPattern(key string) bool {
+	return strings.ContainsAny(key, "*?")
+}
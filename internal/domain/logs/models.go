@@ -0,0 +1,40 @@
+package logs
+
+import "time"
+
+// JournalEntry represents a single structured entry from the systemd journal
+type JournalEntry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Unit         string    `json:"unit,omitempty"`       // Systemd unit that logged the entry
+	Identifier   string    `json:"identifier,omitempty"` // Syslog identifier, usually the program name
+	PID          int       `json:"pid,omitempty"`
+	Priority     int       `json:"priority"`      // 0 (emerg) to 7 (debug)
+	PriorityName string    `json:"priority_name"` // e.g. "err", "warning", "info"
+	Hostname     string    `json:"hostname,omitempty"`
+	Message      string    `json:"message"`
+	Cursor       string    `json:"cursor,omitempty"` // Journal cursor, usable to resume reading after this entry
+}
+
+// JournalQuery narrows down the journal entries to retrieve
+type JournalQuery struct {
+	Unit     string    // Systemd unit name; empty for all units
+	Priority string    // Maximum priority as a name or number (e.g. "err", "3"), or a range such as "warning..err"
+	Since    time.Time // Only entries at or after this time; zero for no lower bound
+	Until    time.Time // Only entries at or before this time; zero for no upper bound
+	Grep     string    // Go regular expression matched against the message
+	Lines    int       // Number of most recent matching entries to return
+}
+
+// LogFileQuery selects the lines to read from a log file
+type LogFileQuery struct {
+	Path  string // Absolute path of the log file, which must be inside a permitted directory
+	Grep  string // Go regular expression matched against each line
+	Lines int    // Number of most recent matching lines to return
+}
+
+// LogFile contains the last lines of a log file
+type LogFile struct {
+	Path  string   `json:"path"` // Resolved path of the log file
+	Size  int64    `json:"size"` // File size in bytes
+	Lines []string `json:"lines"`
+}
@@ -0,0 +1,129 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound  = errors.New("session not found or expired")
+	ErrCommandFailed    = errors.New("command execution failed")
+	ErrInvalidQuery     = errors.New("invalid log query")
+	ErrJournalMissing   = errors.New("journalctl is not available on this server")
+	ErrPathNotAllowed   = errors.New("path is outside the permitted log directories")
+	ErrFileNotFound     = errors.New("log file not found")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Bounds for the number of lines returned by a query
+const (
+	DefaultLines = 100
+	MaxLines     = 5000
+)
+
+// DefaultAllowedDirs are the directories log files may be read from unless configured otherwise
+var DefaultAllowedDirs = []string{"/var/log"}
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// RunCommand executes a command on the SSH session
+	RunCommand(ctx context.Context, sessionID string, command string) (string, error)
+
+	// StreamCommand executes a long-running command, writing its output until the context is cancelled
+	StreamCommand(ctx context.Context, sessionID string, command string, stdout io.Writer) error
+}
+
+// Service defines the log retrieval service
+type Service interface {
+	// QueryJournal retrieves the most recent journal entries matching the query
+	QueryJournal(ctx context.Context, sessionID string, query JournalQuery) ([]JournalEntry, error)
+
+	// FollowJournal streams journal entries matching the query until the context is cancelled
+	FollowJournal(ctx context.Context, sessionID string, query JournalQuery, onEntry func(*JournalEntry) error) error
+
+	// TailFile retrieves the last lines of a log file inside a permitted directory
+	TailFile(ctx context.Context, sessionID string, query LogFileQuery) (*LogFile, error)
+
+	// FollowFile streams lines appended to a log file until the context is cancelled
+	FollowFile(ctx context.Context, sessionID string, query LogFileQuery, onLine func(string) error) error
+}
+
+type service struct {
+	sessionRepo SessionRepository
+	allowedDirs []string
+}
+
+// NewService creates a new log service. Log files can only be read from the allowed directories;
+// DefaultAllowedDirs is used when none are given.
+func NewService(sessionRepo SessionRepository, allowedDirs []string) Service {
+	dirs := make([]string, 0, len(allowedDirs))
+	for _, dir := range allowedDirs {
+		if dir = strings.TrimSpace(dir); path.IsAbs(dir) {
+			dirs = append(dirs, path.Clean(dir))
+		}
+	}
+	if len(dirs) == 0 {
+		dirs = DefaultAllowedDirs
+	}
+
+	return &service{
+		sessionRepo: sessionRepo,
+		allowedDirs: dirs,
+	}
+}
+
+// compileGrep compiles a grep pattern. Patterns are Go regular expressions (RE2) and are always
+// matched on the API server, so that they behave the same for files, the journal and follow mode
+// whatever grep or journalctl the server has. An empty pattern matches everything and yields nil.
+func compileGrep(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	grep, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid grep pattern: %v", ErrInvalidQuery, err)
+	}
+	return grep, nil
+}
+
+// lastMatches keeps the last lines added to it, up to a limit
+type lastMatches struct {
+	lines []string
+	limit int
+	next  int // Index of the oldest line once the limit is reached
+}
+
+// add keeps a line, dropping the oldest one when the limit is reached
+func (m *lastMatches) add(line string) {
+	if len(m.lines) < m.limit {
+		m.lines = append(m.lines, line)
+		return
+	}
+	m.lines[m.next] = line
+	m.next = (m.next + 1) % m.limit
+}
+
+// result returns the kept lines, oldest first
+func (m *lastMatches) result() []string {
+	lines := make([]string, 0, len(m.lines))
+	lines = append(lines, m.lines[m.next:]...)
+	return append(lines, m.lines[:m.next]...)
+}
+
+// normalizeLines applies the default and upper bound to a requested line count
+func normalizeLines(lines int) (int, error) {
+	switch {
+	case lines == 0:
+		return DefaultLines, nil
+	case lines < 0 || lines > MaxLines:
+		return 0, fmt.Errorf("%w: lines must be between 1 and %d", ErrInvalidQuery, MaxLines)
+	default:
+		return lines, nil
+	}
+}
@@ -0,0 +1,323 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// journalCommand runs journalctl with JSON output, through sudo when available so that entries of
+// other users and system services are visible. The %s placeholder receives the journalctl arguments.
+const journalCommand = `command -v journalctl >/dev/null 2>&1 || { echo '### missing'; exit 0; }
+` + shell.SudoIfAvailable + ` journalctl --no-pager -o json %s 2>&1
+echo "exit=$?"`
+
+// journalReverseCommand is journalCommand reading the journal newest first, so that matching entries
+// can be collected until there are enough of them
+const journalReverseCommand = `command -v journalctl >/dev/null 2>&1 || { echo '### missing'; exit 0; }
+` + shell.SudoIfAvailable + ` journalctl --no-pager -o json --reverse %s 2>&1
+echo "exit=$?"`
+
+// journalFollowCommand streams journal entries as they are written
+const journalFollowCommand = shell.SudoIfAvailable + " journalctl --no-pager -o json --follow %s 2>&1"
+
+// errEnoughEntries stops reading the journal once enough matching entries were found
+var errEnoughEntries = errors.New("enough journal entries")
+
+// priorityNames maps syslog priority names to their numeric level
+var priorityNames = map[string]int{
+	"emerg":   0,
+	"alert":   1,
+	"crit":    2,
+	"err":     3,
+	"warning": 4,
+	"notice":  5,
+	"info":    6,
+	"debug":   7,
+}
+
+// priorityLabels are the syslog priority names indexed by level
+var priorityLabels = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// journalUnitPattern matches valid unit names and globs accepted by journalctl --unit
+var journalUnitPattern = regexp.MustCompile(`^[a-zA-Z0-9:_.@\\*\-]+$`)
+
+// journalRecord mirrors the fields of 'journalctl -o json' used to build entries. MESSAGE is kept raw
+// because journald encodes non-UTF-8 messages as an array of bytes.
+type journalRecord struct {
+	Cursor           string          `json:"__CURSOR"`
+	RealtimeUsec     string          `json:"__REALTIME_TIMESTAMP"`
+	Priority         string          `json:"PRIORITY"`
+	Message          json.RawMessage `json:"MESSAGE"`
+	SyslogIdentifier string          `json:"SYSLOG_IDENTIFIER"`
+	Comm             string          `json:"_COMM"`
+	PID              string          `json:"_PID"`
+	SystemdUnit      string          `json:"_SYSTEMD_UNIT"`
+	Hostname         string          `json:"_HOSTNAME"`
+}
+
+// QueryJournal implements the Service interface
+func (s *service) QueryJournal(ctx context.Context, sessionID string, query JournalQuery) ([]JournalEntry, error) {
+	lines, err := normalizeLines(query.Lines)
+	if err != nil {
+		return nil, err
+	}
+	query.Lines = lines
+
+	grep, err := compileGrep(query.Grep)
+	if err != nil {
+		return nil, err
+	}
+	if grep != nil {
+		return s.grepJournal(ctx, sessionID, query, grep)
+	}
+
+	args, err := buildJournalArgs(query, true)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(journalCommand, args))
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(output, "### missing") {
+		return nil, ErrJournalMissing
+	}
+
+	entries, diagnostics, exitStatus := parseJournalOutput(output)
+	if exitStatus != "0" && len(entries) == 0 && len(diagnostics) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, strings.Join(diagnostics, "; "))
+	}
+
+	return entries, nil
+}
+
+// grepJournal reads the journal newest first and keeps the entries whose message matches the
+// pattern, until it has the requested number of them. The entries are returned oldest first.
+func (s *service) grepJournal(ctx context.Context, sessionID string, query JournalQuery, grep *regexp.Regexp) ([]JournalEntry, error) {
+	args, err := buildJournalArgs(query, false)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []JournalEntry{}
+	var diagnostics []string
+	exitStatus := ""
+	missing := false
+	err = shell.StreamLines(ctx, s.sessionRepo, sessionID, fmt.Sprintf(journalReverseCommand, args), func(line string) error {
+		line = strings.TrimSpace(line)
+		if entry, ok := parseJournalLine(line); ok {
+			if grep.MatchString(entry.Message) {
+				entries = append(entries, *entry)
+			}
+			if len(entries) == query.Lines {
+				return errEnoughEntries
+			}
+			return nil
+		}
+		switch status, found := strings.CutPrefix(line, shell.ExitStatusMarker); {
+		case found:
+			exitStatus = status
+		case line == "### missing":
+			missing = true
+		case line != "":
+			diagnostics = append(diagnostics, line)
+		}
+		return nil
+	})
+	if errors.Is(err, errEnoughEntries) {
+		exitStatus, err = "0", nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if missing {
+		return nil, ErrJournalMissing
+	}
+	if exitStatus != "0" && len(entries) == 0 && len(diagnostics) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, strings.Join(diagnostics, "; "))
+	}
+
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+// FollowJournal implements the Service interface
+func (s *service) FollowJournal(ctx context.Context, sessionID string, query JournalQuery, onEntry func(*JournalEntry) error) error {
+	lines, err := normalizeLines(query.Lines)
+	if err != nil {
+		return err
+	}
+	query.Lines = lines
+
+	grep, err := compileGrep(query.Grep)
+	if err != nil {
+		return err
+	}
+
+	args, err := buildJournalArgs(query, true)
+	if err != nil {
+		return err
+	}
+
+	return shell.StreamLines(ctx, s.sessionRepo, sessionID, fmt.Sprintf(journalFollowCommand, args), func(line string) error {
+		entry, ok := parseJournalLine(line)
+		if !ok {
+			if strings.Contains(line, "journalctl: not found") || strings.Contains(line, "journalctl: command not found") {
+				return ErrJournalMissing
+			}
+			return nil
+		}
+		if grep != nil && !grep.MatchString(entry.Message) {
+			return nil
+		}
+		return onEntry(entry)
+	})
+}
+
+// buildJournalArgs validates the query and converts it to quoted journalctl arguments, limited to the
+// requested number of lines on request. The grep pattern is matched locally and not passed on.
+func buildJournalArgs(query JournalQuery, limit bool) (string, error) {
+	var args []string
+	if limit {
+		args = append(args, "--lines="+strconv.Itoa(query.Lines))
+	}
+
+	if query.Unit != "" {
+		if !journalUnitPattern.MatchString(query.Unit) || strings.HasPrefix(query.Unit, "-") {
+			return "", fmt.Errorf("%w: invalid unit name %q", ErrInvalidQuery, query.Unit)
+		}
+		args = append(args, "--unit="+shell.Quote(query.Unit))
+	}
+
+	if query.Priority != "" {
+		priority, err := normalizePriority(query.Priority)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, "--priority="+priority)
+	}
+
+	// Timestamps are passed as seconds since the epoch so the remote time zone does not matter
+	if !query.Since.IsZero() {
+		args = append(args, "--since=@"+strconv.FormatInt(query.Since.Unix(), 10))
+	}
+	if !query.Until.IsZero() {
+		if !query.Since.IsZero() && query.Until.Before(query.Since) {
+			return "", fmt.Errorf("%w: until must not be before since", ErrInvalidQuery)
+		}
+		args = append(args, "--until=@"+strconv.FormatInt(query.Until.Unix(), 10))
+	}
+
+	return strings.Join(args, " "), nil
+}
+
+// normalizePriority validates a priority name, number or "from..to" range
+func normalizePriority(value string) (string, error) {
+	parts := strings.Split(strings.ToLower(value), "..")
+	if len(parts) > 2 {
+		return "", fmt.Errorf("%w: invalid priority %q", ErrInvalidQuery, value)
+	}
+
+	for i, part := range parts {
+		if level, ok := priorityNames[part]; ok {
+			parts[i] = strconv.Itoa(level)
+			continue
+		}
+		if level, err := strconv.Atoi(part); err != nil || level < 0 || level > 7 {
+			return "", fmt.Errorf("%w: invalid priority %q (use 0-7 or emerg, alert, crit, err, warning, notice, info, debug)", ErrInvalidQuery, value)
+		}
+	}
+
+	return strings.Join(parts, ".."), nil
+}
+
+// parseJournalOutput splits journalctl output into entries, diagnostic messages and the exit status
+func parseJournalOutput(output string) ([]JournalEntry, []string, string) {
+	entries := []JournalEntry{}
+	var diagnostics []string
+	exitStatus := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if status, found := strings.CutPrefix(line, shell.ExitStatusMarker); found {
+			exitStatus = status
+			continue
+		}
+		if entry, ok := parseJournalLine(line); ok {
+			entries = append(entries, *entry)
+			continue
+		}
+		diagnostics = append(diagnostics, line)
+	}
+
+	return entries, diagnostics, exitStatus
+}
+
+// parseJournalLine parses a single line of 'journalctl -o json' output
+func parseJournalLine(line string) (*JournalEntry, bool) {
+	if !strings.HasPrefix(line, "{") {
+		return nil, false
+	}
+
+	var record journalRecord
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		return nil, false
+	}
+
+	entry := &JournalEntry{
+		Unit:       record.SystemdUnit,
+		Identifier: record.SyslogIdentifier,
+		Hostname:   record.Hostname,
+		Message:    decodeJournalMessage(record.Message),
+		Cursor:     record.Cursor,
+		Priority:   6,
+	}
+	if entry.Identifier == "" {
+		entry.Identifier = record.Comm
+	}
+	entry.PID, _ = strconv.Atoi(record.PID)
+
+	if usec, err := strconv.ParseInt(record.RealtimeUsec, 10, 64); err == nil {
+		entry.Timestamp = time.UnixMicro(usec).UTC()
+	}
+
+	// Entries without a priority are logged at info level
+	if priority, err := strconv.Atoi(record.Priority); err == nil && priority >= 0 && priority <= 7 {
+		entry.Priority = priority
+	}
+	entry.PriorityName = priorityLabels[entry.Priority]
+
+	return entry, true
+}
+
+// decodeJournalMessage decodes MESSAGE, which is a string, an array of bytes or null
+func decodeJournalMessage(raw json.RawMessage) string {
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return message
+	}
+
+	var data []byte
+	var values []int
+	if err := json.Unmarshal(raw, &values); err == nil {
+		for _, value := range values {
+			data = append(data, byte(value))
+		}
+		return strings.ToValidUTF8(string(data), "�")
+	}
+
+	return ""
+}
@@ -0,0 +1,190 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// resolvePathCommand resolves symbolic links and relative components of a path. The %s placeholder
+// receives the quoted path.
+const resolvePathCommand = "readlink -f -- %s 2>/dev/null; true"
+
+// tailFileScript prints the size and then lines of a file. Files the SSH user cannot read are read
+// through passwordless sudo if available. The placeholders receive the quoted path and the command
+// printing the lines, which reads "$p" using "$r".
+const tailFileScript = `p=%[1]s
+[ -e "$p" ] || { echo '### missing'; exit 0; }
+[ -f "$p" ] || { echo '### not-regular'; exit 0; }
+r=""
+[ -r "$p" ] || r="` + shell.SudoIfAvailable + `"
+$r test -r "$p" || { echo '### denied'; exit 0; }
+echo '### size'
+$r stat -c %%s -- "$p"
+echo '### lines'
+%[2]s`
+
+// followFileCommand prints the last lines of a file and then every appended line, following
+// rotations. The placeholders receive the quoted path and the line count.
+const followFileCommand = `p=%[1]s; r=""; [ -r "$p" ] || r="` + shell.SudoIfAvailable + `"; exec $r tail -n %[2]d -F -- "$p" 2>&1`
+
+// TailFile implements the Service interface
+func (s *service) TailFile(ctx context.Context, sessionID string, query LogFileQuery) (*LogFile, error) {
+	lines, err := normalizeLines(query.Lines)
+	if err != nil {
+		return nil, err
+	}
+	grep, err := compileGrep(query.Grep)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.resolveLogPath(ctx, sessionID, query.Path)
+	if err != nil {
+		return nil, err
+	}
+	if grep != nil {
+		return s.grepFile(ctx, sessionID, resolved, grep, lines)
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(tailFileScript, shell.Quote(resolved), fmt.Sprintf(`$r tail -n %d -- "$p"`, lines)))
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	if err := checkFileSections(sections, resolved); err != nil {
+		return nil, err
+	}
+
+	file := &LogFile{
+		Path:  resolved,
+		Lines: []string{},
+	}
+	file.Size, _ = strconv.ParseInt(strings.TrimSpace(sections["size"]), 10, 64)
+
+	if content := strings.TrimRight(sections["lines"], "\n"); content != "" {
+		file.Lines = strings.Split(content, "\n")
+	}
+
+	return file, nil
+}
+
+// grepFile streams a whole file and keeps its last lines matching the pattern. Lines after the lines
+// header are content, so that lines of the file looking like headers are not taken for one.
+func (s *service) grepFile(ctx context.Context, sessionID string, resolved string, grep *regexp.Regexp, lines int) (*LogFile, error) {
+	file := &LogFile{Path: resolved}
+	sections := make(map[string]string)
+	section := ""
+	matches := &lastMatches{limit: lines}
+
+	command := fmt.Sprintf(tailFileScript, shell.Quote(resolved), `$r cat -- "$p"`)
+	err := shell.StreamLines(ctx, s.sessionRepo, sessionID, command, func(line string) error {
+		if section == "lines" {
+			if grep.MatchString(line) {
+				matches.add(line)
+			}
+			return nil
+		}
+		if name, ok := strings.CutPrefix(line, shell.SectionMarker); ok {
+			section = name
+			sections[section] = ""
+			return nil
+		}
+		sections[section] += line + "\n"
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkFileSections(sections, resolved); err != nil {
+		return nil, err
+	}
+	file.Size, _ = strconv.ParseInt(strings.TrimSpace(sections["size"]), 10, 64)
+	file.Lines = matches.result()
+	return file, nil
+}
+
+// checkFileSections maps the failures reported by tailFileScript to errors
+func checkFileSections(sections map[string]string, resolved string) error {
+	if _, ok := sections["missing"]; ok {
+		return fmt.Errorf("%w: %s", ErrFileNotFound, resolved)
+	}
+	if _, ok := sections["not-regular"]; ok {
+		return fmt.Errorf("%w: %s is not a regular file", ErrInvalidQuery, resolved)
+	}
+	if _, ok := sections["denied"]; ok {
+		return fmt.Errorf("%w: cannot read %s", ErrPermissionDenied, resolved)
+	}
+	return nil
+}
+
+// FollowFile implements the Service interface
+func (s *service) FollowFile(ctx context.Context, sessionID string, query LogFileQuery, onLine func(string) error) error {
+	lines, err := normalizeLines(query.Lines)
+	if err != nil {
+		return err
+	}
+
+	grep, err := compileGrep(query.Grep)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := s.resolveLogPath(ctx, sessionID, query.Path)
+	if err != nil {
+		return err
+	}
+
+	command := fmt.Sprintf(followFileCommand, shell.Quote(resolved), lines)
+	return shell.StreamLines(ctx, s.sessionRepo, sessionID, command, func(line string) error {
+		if grep != nil && !grep.MatchString(line) {
+			return nil
+		}
+		return onLine(line)
+	})
+}
+
+// resolveLogPath resolves a path on the server and checks that the result lies in a permitted directory,
+// so that neither ".." components nor symbolic links can escape it
+func (s *service) resolveLogPath(ctx context.Context, sessionID string, filePath string) (string, error) {
+	if filePath == "" || !path.IsAbs(filePath) {
+		return "", fmt.Errorf("%w: path must be absolute", ErrInvalidQuery)
+	}
+
+	// Reject obvious escapes before touching the server
+	if !s.isAllowedPath(path.Clean(filePath)) {
+		return "", fmt.Errorf("%w: %s", ErrPathNotAllowed, filePath)
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(resolvePathCommand, shell.Quote(filePath)))
+	if err != nil {
+		return "", err
+	}
+
+	resolved := strings.TrimSpace(output)
+	if resolved == "" {
+		return "", fmt.Errorf("%w: %s", ErrFileNotFound, filePath)
+	}
+	if !s.isAllowedPath(resolved) {
+		return "", fmt.Errorf("%w: %s resolves to %s", ErrPathNotAllowed, filePath, resolved)
+	}
+
+	return resolved, nil
+}
+
+// isAllowedPath reports whether a clean absolute path is inside one of the permitted directories
+func (s *service) isAllowedPath(filePath string) bool {
+	for _, dir := range s.allowedDirs {
+		if dir == "/" || strings.HasPrefix(filePath, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
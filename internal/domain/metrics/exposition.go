@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExpositionContentType is the content type of the Prometheus text exposition format
+const ExpositionContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// helpEscaper escapes help texts of the text exposition format
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// labelEscaper escapes label values of the text exposition format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// WriteText writes metric families in the Prometheus text exposition format. Families without samples
+// are left out.
+func WriteText(w io.Writer, families []MetricFamily) error {
+	bw := bufio.NewWriter(w)
+	for _, family := range families {
+		if len(family.Samples) == 0 {
+			continue
+		}
+
+		bw.WriteString("# HELP " + family.Name + " " + helpEscaper.Replace(family.Help) + "\n")
+		bw.WriteString("# TYPE " + family.Name + " " + family.Type + "\n")
+		for _, sample := range family.Samples {
+			bw.WriteString(family.Name)
+			if len(sample.Labels) > 0 {
+				bw.WriteString("{")
+				for i, label := range sample.Labels {
+					if i > 0 {
+						bw.WriteString(",")
+					}
+					bw.WriteString(label.Name + `="` + labelEscaper.Replace(label.Value) + `"`)
+				}
+				bw.WriteString("}")
+			}
+			bw.WriteString(" " + strconv.FormatFloat(sample.Value, 'g', -1, 64) + "\n")
+		}
+	}
+	return bw.Flush()
+}
+
+// familySet builds metric families in the order they are first added
+type familySet struct {
+	families []MetricFamily
+	index    map[string]int
+}
+
+// newFamilySet creates an empty family set
+func newFamilySet() *familySet {
+	return &familySet{index: make(map[string]int)}
+}
+
+// add appends a sample to a family, creating the family on first use. Labels are given as name/value pairs.
+func (fs *familySet) add(name string, help string, metricType string, value float64, labels ...string) {
+	i, ok := fs.index[name]
+	if !ok {
+		i = len(fs.families)
+		fs.index[name] = i
+		fs.families = append(fs.families, MetricFamily{Name: name, Help: help, Type: metricType})
+	}
+
+	sample := MetricSample{Value: value}
+	for j := 0; j+1 < len(labels); j += 2 {
+		sample.Labels = append(sample.Labels, Label{Name: labels[j], Value: labels[j+1]})
+	}
+	fs.families[i].Samples = append(fs.families[i].Samples, sample)
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import "time"
+
+// Metric names. Series of disk metrics carry a "mount" label, network metrics an "interface" label
+// and container metrics a "container" label.
+const (
+	MetricCPUUsage          = "cpu.usage_percent"
+	MetricCPUIOWait         = "cpu.iowait_percent"
+	MetricCPUSteal          = "cpu.steal_percent"
+	MetricCPUCount          = "cpu.count"
+	MetricLoad1             = "load.1m"
+	MetricLoad5             = "load.5m"
+	MetricLoad15            = "load.15m"
+	MetricMemoryUsed        = "memory.used_bytes"
+	MetricMemoryAvailable   = "memory.available_bytes"
+	MetricMemoryUsage       = "memory.used_percent"
+	MetricSwapUsed          = "swap.used_bytes"
+	MetricDiskUsed          = "disk.used_bytes"
+	MetricDiskUsage         = "disk.used_percent"
+	MetricDiskInodeUsage    = "disk.inodes_used_percent"
+	MetricNetworkRx         = "network.rx_bytes_per_sec"
+	MetricNetworkTx         = "network.tx_bytes_per_sec"
+	MetricContainerCPU      = "container.cpu_percent"
+	MetricContainerMemory   = "container.memory_bytes"
+	MetricContainerMemUsage = "container.memory_percent"
+	MetricContainerRunning  = "container.running"         // 1 while the container runs
+	MetricContainerExited   = "container.unexpected_exit" // 1 while the container is stopped after failing
+)
+
+// MetricNames lists the metrics taken by the collector
+var MetricNames = []string{
+	MetricCPUUsage, MetricCPUIOWait, MetricCPUSteal, MetricCPUCount, MetricLoad1, MetricLoad5, MetricLoad15,
+	MetricMemoryUsed, MetricMemoryAvailable, MetricMemoryUsage, MetricSwapUsed,
+	MetricDiskUsed, MetricDiskUsage, MetricDiskInodeUsage, MetricNetworkRx, MetricNetworkTx,
+	MetricContainerCPU, MetricContainerMemory, MetricContainerMemUsage, MetricContainerRunning, MetricContainerExited,
+}
+
+// Sample is a single measurement taken by the collector
+type Sample struct {
+	Metric string
+	Labels map[string]string
+	Value  float64
+}
+
+// Collection holds the samples taken from a host in one collection
+type Collection struct {
+	Host    string
+	User    string // SSH user the samples were collected as
+	At      time.Time
+	Samples []Sample
+	Failed  []string // Sources whose samples are missing, e.g. docker or docker-stats
+}
+
+// Point is the value of a series at a point in time. Downsampled points hold the mean of the samples
+// in the step starting at the timestamp.
+type Point struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// Series is a metric of a host with a set of labels, e.g. disk.used_percent{mount="/"}
+type Series struct {
+	Metric string            `json:"metric"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Points []Point           `json:"points"`
+}
+
+// HistoryQuery selects the series and time range of a history query
+type HistoryQuery struct {
+	Metric string
+	Labels map[string]string // Only series with these label values
+	From   time.Time
+	To     time.Time
+	Step   time.Duration // Zero to pick a step that yields at most MaxPoints points per series
+}
+
+// History contains the stored samples of a metric of a host
+type History struct {
+	Host         string    `json:"host"`
+	User         string    `json:"user"` // SSH user the samples were collected as
+	Metric       string    `json:"metric"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	StepMS       int64     `json:"step_ms"`       // Interval between points in milliseconds
+	ResolutionMS int64     `json:"resolution_ms"` // Resolution of the stored samples the points were computed from
+	Series       []Series  `json:"series"`
+}
+
+// SeriesInfo describes a stored series
+type SeriesInfo struct {
+	Metric    string            `json:"metric"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	FirstSeen time.Time         `json:"first_seen"` // Start of the oldest retained step, in the coarsest resolution
+	LastSeen  time.Time         `json:"last_seen"`
+	LastValue float64           `json:"last_value"`
+}
+
+// CollectionStatus describes the latest collection from a host
+type CollectionStatus struct {
+	LastCollectedAt time.Time `json:"last_collected_at,omitempty"`
+	DurationMS      int64     `json:"duration_ms"`
+	Errors          []string  `json:"errors,omitempty"` // Sources that failed, e.g. Docker on hosts without it
+}
+
+// SeriesList lists the series stored for a host
+type SeriesList struct {
+	Host       string           `json:"host"`
+	User       string           `json:"user"`        // SSH user the series were collected as
+	IntervalMS int64            `json:"interval_ms"` // Collection interval in milliseconds
+	Collection CollectionStatus `json:"collection"`
+	Series     []SeriesInfo     `json:"series"`
+}
+
+// Prometheus metric types
+const (
+	TypeGauge   = "gauge"
+	TypeCounter = "counter"
+)
+
+// Label is a Prometheus label of a sample
+type Label struct {
+	Name  string
+	Value string
+}
+
+// MetricSample is a sample of a Prometheus metric family
+type MetricSample struct {
+	Labels []Label
+	Value  float64
+}
+
+// MetricFamily is a Prometheus metric with its help text, type and samples
+type MetricFamily struct {
+	Name    string
+	Help    string
+	Type    string // gauge or counter
+	Samples []MetricSample
+}
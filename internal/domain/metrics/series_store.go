@@ -0,0 +1,265 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxPoints bounds the number of points per series returned by a history query
+const MaxPoints = 11000
+
+// defaultQueryPoints is the number of points per series aimed for when a query has no step
+const defaultQueryPoints = 300
+
+// tier keeps samples at a resolution for a retention period. Samples falling into the same
+// resolution step are merged into their mean.
+type tier struct {
+	resolution time.Duration
+	retention  time.Duration
+}
+
+// bucket accumulates the samples of one resolution step
+type bucket struct {
+	start time.Time
+	sum   float64
+	count int
+}
+
+// mean returns the mean of the samples in the bucket
+func (b bucket) mean() float64 {
+	return b.sum / float64(b.count)
+}
+
+// series holds the buckets of a metric with a set of labels, one slice per tier, oldest first
+type series struct {
+	metric  string
+	labels  map[string]string
+	buckets [][]bucket
+}
+
+// seriesStore is an in-memory time-series store. Every sample is added to each tier, so recent data is
+// available at the collection interval and older data in coarser steps that are kept for longer.
+type seriesStore struct {
+	mu     sync.RWMutex
+	tiers  []tier
+	series map[string]map[string]*series // Owner, then series key
+}
+
+// newSeriesStore creates a store keeping samples at the collection interval for two hours, in
+// five-minute steps for two days and in hourly steps for the retention period
+func newSeriesStore(interval time.Duration, retention time.Duration) *seriesStore {
+	tiers := []tier{
+		{resolution: interval, retention: 2 * time.Hour},
+		{resolution: 5 * time.Minute, retention: 48 * time.Hour},
+		{resolution: time.Hour, retention: retention},
+	}
+
+	// Drop tiers that are not coarser than the previous one or outlive the retention period
+	st := &seriesStore{series: make(map[string]map[string]*series)}
+	for _, t := range tiers {
+		t.retention = min(t.retention, retention)
+		if len(st.tiers) > 0 {
+			previous := st.tiers[len(st.tiers)-1]
+			if t.resolution <= previous.resolution || previous.retention >= retention {
+				continue
+			}
+		}
+		st.tiers = append(st.tiers, t)
+	}
+
+	return st
+}
+
+// add stores the samples of an owner taken at the given time
+func (st *seriesStore) add(owner string, at time.Time, samples []Sample) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	ownerSeries, ok := st.series[owner]
+	if !ok {
+		ownerSeries = make(map[string]*series)
+		st.series[owner] = ownerSeries
+	}
+
+	for _, smp := range samples {
+		key := seriesKey(smp.Metric, smp.Labels)
+		sr, ok := ownerSeries[key]
+		if !ok {
+			sr = &series{metric: smp.Metric, labels: smp.Labels, buckets: make([][]bucket, len(st.tiers))}
+			ownerSeries[key] = sr
+		}
+
+		for i, t := range st.tiers {
+			start := at.Truncate(t.resolution)
+			buckets := sr.buckets[i]
+			if n := len(buckets); n > 0 && buckets[n-1].start.Equal(start) {
+				buckets[n-1].sum += smp.Value
+				buckets[n-1].count++
+				continue
+			}
+			sr.buckets[i] = append(buckets, bucket{start: start, sum: smp.Value, count: 1})
+		}
+	}
+}
+
+// prune drops buckets that are past the retention of their tier, and series and owners left empty
+func (st *seriesStore) prune(now time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for owner, ownerSeries := range st.series {
+		for key, sr := range ownerSeries {
+			empty := true
+			for i, t := range st.tiers {
+				cutoff := now.Add(-t.retention)
+				buckets := sr.buckets[i]
+				drop := sort.Search(len(buckets), func(j int) bool { return !buckets[j].start.Before(cutoff) })
+				sr.buckets[i] = buckets[drop:]
+				if len(sr.buckets[i]) > 0 {
+					empty = false
+				}
+			}
+			if empty {
+				delete(ownerSeries, key)
+			}
+		}
+		if len(ownerSeries) == 0 {
+			delete(st.series, owner)
+		}
+	}
+}
+
+// query returns the points of the series of an owner matching the query. The finest tier still holding
+// the start of the range is used, and its buckets are merged into steps aligned to the step size.
+func (st *seriesStore) query(owner string, query HistoryQuery, now time.Time) (*History, error) {
+	if !query.From.Before(query.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+	if query.Step < 0 {
+		return nil, fmt.Errorf("%w: step must be positive", ErrInvalidQuery)
+	}
+
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	tierIndex := len(st.tiers) - 1
+	for i, t := range st.tiers {
+		if !query.From.Before(now.Add(-t.retention)) {
+			tierIndex = i
+			break
+		}
+	}
+	resolution := st.tiers[tierIndex].resolution
+
+	step := query.Step
+	if step == 0 {
+		step = query.To.Sub(query.From) / defaultQueryPoints
+	}
+	step = max(step, resolution).Truncate(resolution)
+	if points := query.To.Sub(query.From) / step; points > MaxPoints {
+		return nil, fmt.Errorf("%w: %d points per series exceed the maximum of %d, use a larger step", ErrInvalidQuery, points, MaxPoints)
+	}
+
+	history := &History{
+		Metric:       query.Metric,
+		From:         query.From,
+		To:           query.To,
+		StepMS:       step.Milliseconds(),
+		ResolutionMS: resolution.Milliseconds(),
+		Series:       []Series{},
+	}
+
+	for _, sr := range st.series[owner] {
+		if sr.metric != query.Metric || !matchLabels(sr.labels, query.Labels) {
+			continue
+		}
+
+		points := []Point{}
+		var current bucket
+		flush := func() {
+			if current.count > 0 {
+				points = append(points, Point{Timestamp: current.start, Value: current.mean()})
+			}
+		}
+		for _, b := range sr.buckets[tierIndex] {
+			if b.start.Before(query.From) || !b.start.Before(query.To) {
+				continue
+			}
+			start := b.start.Truncate(step)
+			if !start.Equal(current.start) {
+				flush()
+				current = bucket{start: start}
+			}
+			current.sum += b.sum
+			current.count += b.count
+		}
+		flush()
+
+		history.Series = append(history.Series, Series{Metric: sr.metric, Labels: sr.labels, Points: points})
+	}
+
+	sort.Slice(history.Series, func(i, j int) bool {
+		return seriesKey("", history.Series[i].Labels) < seriesKey("", history.Series[j].Labels)
+	})
+
+	return history, nil
+}
+
+// list describes the series stored for an owner, using the finest tier for the latest value and the
+// coarsest one for the oldest retained sample
+func (st *seriesStore) list(owner string) []SeriesInfo {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	infos := []SeriesInfo{}
+	for _, sr := range st.series[owner] {
+		info := SeriesInfo{Metric: sr.metric, Labels: sr.labels}
+		for _, buckets := range sr.buckets {
+			if len(buckets) == 0 {
+				continue
+			}
+			if info.FirstSeen.IsZero() || buckets[0].start.Before(info.FirstSeen) {
+				info.FirstSeen = buckets[0].start
+			}
+		}
+		if latest := sr.buckets[0]; len(latest) > 0 {
+			info.LastSeen = latest[len(latest)-1].start
+			info.LastValue = latest[len(latest)-1].mean()
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return seriesKey(infos[i].Metric, infos[i].Labels) < seriesKey(infos[j].Metric, infos[j].Labels)
+	})
+
+	return infos
+}
+
+// seriesKey identifies a series by its metric and sorted labels, e.g. `disk.used_percent{mount="/"}`
+func seriesKey(metric string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", key, labels[key]))
+	}
+	return metric + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// matchLabels reports whether the labels contain all wanted label values
+func matchLabels(labels map[string]string, wanted map[string]string) bool {
+	for key, value := range wanted {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"remote-server-api/internal/domain/auth"
+	"remote-server-api/internal/domain/docker"
+	"remote-server-api/internal/domain/server"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound = errors.New("session not found or expired")
+	ErrInvalidQuery    = errors.New("invalid metrics query")
+	ErrHostNotFound    = errors.New("no active session to host")
+)
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// GetSession retrieves an SSH session by ID
+	GetSession(ctx context.Context, sessionID string) (*auth.Session, error)
+
+	// ListSessions returns all stored SSH sessions
+	ListSessions(ctx context.Context) ([]*auth.Session, error)
+}
+
+// ServerSource provides the host measurements of the server details service
+type ServerSource interface {
+	GetCPUUsage(ctx context.Context, sessionID string, interval time.Duration) (*server.CPUUsage, error)
+	GetMemoryUsage(ctx context.Context, sessionID string) (*server.MemoryUsage, error)
+	GetDiskUsage(ctx context.Context, sessionID string, filter server.DiskUsageFilter) ([]server.DiskUsage, error)
+	GetNetworkInfo(ctx context.Context, sessionID string, interval time.Duration) (*server.NetworkInfo, error)
+}
+
+// DockerSource provides the container measurements of the Docker service
+type DockerSource interface {
+	GetContainerStatuses(ctx context.Context, sessionID string) ([]docker.ContainerStatus, error)
+	GetContainerStats(ctx context.Context, sessionID string) ([]docker.ContainerStats, error)
+}
+
+// Observer is notified of every collection, e.g. to evaluate alert rules against the samples
+type Observer interface {
+	ObserveCollection(collection Collection)
+}
+
+// Service defines the metrics service
+type Service interface {
+	// Run collects metrics through the active sessions until the context is cancelled. Each owner of a
+	// session, a user on a host, has a history of its own, collected with that user's credentials.
+	Run(ctx context.Context)
+
+	// AddObserver registers an observer of the collections. Observers are called from the collector
+	// and must not block.
+	AddObserver(observer Observer)
+
+	// GetHistory retrieves the stored samples of a metric collected for the session's owner
+	GetHistory(ctx context.Context, sessionID string, query HistoryQuery) (*History, error)
+
+	// ListSeries lists the series stored for the session's owner
+	ListSeries(ctx context.Context, sessionID string) (*SeriesList, error)
+
+	// ScrapeHost takes the current measurements of a host as Prometheus metric families. Without a
+	// session, any active session to the host is used.
+	ScrapeHost(ctx context.Context, sessionID string, host string) ([]MetricFamily, error)
+}
+
+type service struct {
+	sessionRepo SessionRepository
+	server      ServerSource
+	docker      DockerSource
+	interval    time.Duration
+	store       *seriesStore
+
+	// collecting marks the owners with a collection in progress
+	collecting sync.Map
+
+	mu        sync.Mutex
+	status    map[string]CollectionStatus // Owner
+	observers []Observer
+}
+
+// NewService creates a new metrics service collecting every interval and keeping samples for the
+// retention period. A zero interval disables collection.
+func NewService(sessionRepo SessionRepository, serverSource ServerSource, dockerSource DockerSource, interval time.Duration, retention time.Duration) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+		server:      serverSource,
+		docker:      dockerSource,
+		interval:    interval,
+		store:       newSeriesStore(max(interval, time.Second), retention),
+		status:      make(map[string]CollectionStatus),
+	}
+}
+
+// AddObserver implements the Service interface
+func (s *service) AddObserver(observer Observer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observers = append(s.observers, observer)
+}
+
+// GetHistory implements the Service interface
+func (s *service) GetHistory(ctx context.Context, sessionID string, query HistoryQuery) (*History, error) {
+	session, err := s.session(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if query.Metric == "" {
+		return nil, fmt.Errorf("%w: metric is required", ErrInvalidQuery)
+	}
+
+	history, err := s.store.query(session.Owner(), query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	history.Host, history.User = session.Host, session.Username
+	return history, nil
+}
+
+// ListSeries implements the Service interface
+func (s *service) ListSeries(ctx context.Context, sessionID string) (*SeriesList, error) {
+	session, err := s.session(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	status := s.status[session.Owner()]
+	s.mu.Unlock()
+
+	return &SeriesList{
+		Host:       session.Host,
+		User:       session.Username,
+		IntervalMS: s.interval.Milliseconds(),
+		Collection: status,
+		Series:     s.store.list(session.Owner()),
+	}, nil
+}
+
+// session retrieves an active session
+func (s *service) session(ctx context.Context, sessionID string) (*auth.Session, error) {
+	session, err := s.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+	return session, nil
+}
+
+// sessionHost returns the address of the SSH server of a session
+func (s *service) sessionHost(ctx context.Context, sessionID string) (string, error) {
+	session, err := s.session(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+	return session.Host, nil
+}
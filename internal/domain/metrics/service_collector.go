@@ -0,0 +1,226 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"remote-server-api/internal/domain/auth"
+	"remote-server-api/internal/domain/docker"
+	"remote-server-api/internal/domain/server"
+)
+
+// sampleInterval is the interval over which CPU and network rates are measured
+const sampleInterval = time.Second
+
+// maxErrorLength bounds the error messages kept in the collection status, which may quote whole scripts
+const maxErrorLength = 200
+
+// sigtermExitCode is the exit code of a process terminated by SIGTERM
+const sigtermExitCode = 128 + 15
+
+// minCollectTimeout bounds the time a collection from a host may take when the interval is short
+const minCollectTimeout = 20 * time.Second
+
+// Run implements the Service interface
+func (s *service) Run(ctx context.Context) {
+	if s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.collectAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// collectAll starts a collection for the owner of every active session, a user on a host, through a
+// session of that owner. An owner still being collected for is skipped, and the collection status of
+// owners without a session is dropped.
+func (s *service) collectAll(ctx context.Context) {
+	s.store.prune(time.Now())
+
+	owners, err := auth.OwnerSessions(ctx, s.sessionRepo)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	for owner := range s.status {
+		if _, ok := owners[owner]; !ok {
+			delete(s.status, owner)
+		}
+	}
+	s.mu.Unlock()
+
+	for owner, session := range owners {
+		if _, busy := s.collecting.LoadOrStore(owner, true); busy {
+			continue
+		}
+
+		go func() {
+			defer s.collecting.Delete(owner)
+
+			collectCtx, cancel := context.WithTimeout(ctx, max(s.interval, minCollectTimeout))
+			defer cancel()
+
+			s.collect(collectCtx, session)
+		}()
+	}
+}
+
+// collect takes the samples of a session's owner and records the outcome of the collection
+func (s *service) collect(ctx context.Context, session *auth.Session) {
+	sessionID, owner := session.ID, session.Owner()
+	started := time.Now()
+	var samples []Sample
+	var errs, failed []string
+
+	if usage, err := s.server.GetCPUUsage(ctx, sessionID, sampleInterval); err == nil {
+		samples = append(samples,
+			Sample{Metric: MetricCPUUsage, Value: usage.Total.Usage},
+			Sample{Metric: MetricCPUIOWait, Value: usage.Total.IOWait},
+			Sample{Metric: MetricCPUSteal, Value: usage.Total.Steal},
+			Sample{Metric: MetricLoad1, Value: usage.LoadAverage.Load1},
+			Sample{Metric: MetricLoad5, Value: usage.LoadAverage.Load5},
+			Sample{Metric: MetricLoad15, Value: usage.LoadAverage.Load15},
+			Sample{Metric: MetricCPUCount, Value: float64(len(usage.Cores))},
+		)
+	} else {
+		errs = append(errs, collectError("cpu", err))
+		failed = append(failed, "cpu")
+	}
+
+	if usage, err := s.server.GetMemoryUsage(ctx, sessionID); err == nil {
+		samples = append(samples,
+			Sample{Metric: MetricMemoryUsed, Value: float64(usage.UsedBytes)},
+			Sample{Metric: MetricMemoryAvailable, Value: float64(usage.AvailableBytes)},
+			Sample{Metric: MetricMemoryUsage, Value: usage.UsePercentage},
+			Sample{Metric: MetricSwapUsed, Value: float64(usage.SwapUsedBytes)},
+		)
+	} else {
+		errs = append(errs, collectError("memory", err))
+		failed = append(failed, "memory")
+	}
+
+	if usages, err := s.server.GetDiskUsage(ctx, sessionID, server.DiskUsageFilter{}); err == nil {
+		for _, usage := range usages {
+			labels := map[string]string{"mount": usage.MountedOn}
+			samples = append(samples,
+				Sample{Metric: MetricDiskUsed, Labels: labels, Value: float64(usage.UsedBytes)},
+				Sample{Metric: MetricDiskUsage, Labels: labels, Value: usage.UsePercentage},
+			)
+			if usage.Inodes > 0 {
+				samples = append(samples, Sample{Metric: MetricDiskInodeUsage, Labels: labels, Value: usage.InodesUsePercent})
+			}
+		}
+	} else {
+		errs = append(errs, collectError("disk", err))
+		failed = append(failed, "disk")
+	}
+
+	if network, err := s.server.GetNetworkInfo(ctx, sessionID, sampleInterval); err == nil {
+		for _, iface := range network.Interfaces {
+			// Loopback and the host side of container interfaces come and go with no value of their own
+			if iface.Name == "lo" || strings.HasPrefix(iface.Name, "veth") {
+				continue
+			}
+			labels := map[string]string{"interface": iface.Name}
+			samples = append(samples,
+				Sample{Metric: MetricNetworkRx, Labels: labels, Value: iface.Rates.RxBytesPerSec},
+				Sample{Metric: MetricNetworkTx, Labels: labels, Value: iface.Rates.TxBytesPerSec},
+			)
+		}
+	} else {
+		errs = append(errs, collectError("network", err))
+		failed = append(failed, "network")
+	}
+
+	if containers, err := s.docker.GetContainerStatuses(ctx, sessionID); err == nil {
+		running := false
+		for _, container := range containers {
+			running = running || container.State == "running"
+			labels := map[string]string{"container": container.Name}
+			samples = append(samples,
+				Sample{Metric: MetricContainerRunning, Labels: labels, Value: boolValue(container.State == "running")},
+				Sample{Metric: MetricContainerExited, Labels: labels, Value: boolValue(exitedUnexpectedly(container))},
+			)
+		}
+
+		// Stats fail when no container is running on some Docker versions; there are no stats to miss then
+		if stats, err := s.docker.GetContainerStats(ctx, sessionID); err == nil {
+			for _, container := range stats {
+				labels := map[string]string{"container": container.Name}
+				samples = append(samples,
+					Sample{Metric: MetricContainerCPU, Labels: labels, Value: container.CPUPercentage},
+					Sample{Metric: MetricContainerMemory, Labels: labels, Value: float64(container.MemoryUsageBytes)},
+					Sample{Metric: MetricContainerMemUsage, Labels: labels, Value: container.MemoryPercentage},
+				)
+			}
+		} else if running {
+			errs = append(errs, collectError("docker-stats", err))
+			failed = append(failed, "docker-stats")
+		}
+	} else {
+		errs = append(errs, collectError("docker", err))
+		failed = append(failed, "docker")
+	}
+
+	s.store.add(owner, started, samples)
+
+	s.mu.Lock()
+	observers := s.observers
+	status := CollectionStatus{
+		LastCollectedAt: s.status[owner].LastCollectedAt,
+		DurationMS:      time.Since(started).Milliseconds(),
+		Errors:          errs,
+	}
+	if len(samples) > 0 {
+		status.LastCollectedAt = started
+	}
+	s.status[owner] = status
+	s.mu.Unlock()
+
+	collection := Collection{Host: session.Host, User: session.Username, At: started, Samples: samples, Failed: failed}
+	for _, observer := range observers {
+		observer.ObserveCollection(collection)
+	}
+}
+
+// exitedUnexpectedly reports whether a container is stopped after exiting with a non-zero code, being
+// killed for running out of memory or failing to be removed. Exit code 143, termination by the SIGTERM
+// docker stop sends, is taken as a deliberate stop.
+func exitedUnexpectedly(container docker.ContainerStatus) bool {
+	switch container.State {
+	case "dead":
+		return true
+	case "exited":
+		return container.OOMKilled || (container.ExitCode != 0 && container.ExitCode != sigtermExitCode)
+	}
+	return false
+}
+
+// boolValue returns 1 for true and 0 for false
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// collectError formats the error of a source for the collection status
+func collectError(source string, err error) string {
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength] + "..."
+	}
+	return source + ": " + message
+}
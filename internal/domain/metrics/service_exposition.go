@@ -0,0 +1,270 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"remote-server-api/internal/domain/docker"
+	"remote-server-api/internal/domain/server"
+)
+
+// containerStates are the states reported by cerberus_container_state, one sample each
+var containerStates = []string{"created", "running", "paused", "restarting", "removing", "exited", "dead"}
+
+// scrapeResults holds the measurements of a scrape, which are taken concurrently
+type scrapeResults struct {
+	cpu        *server.CPUUsage
+	memory     *server.MemoryUsage
+	disks      []server.DiskUsage
+	network    *server.NetworkInfo
+	containers []docker.ContainerStatus
+	stats      []docker.ContainerStats
+	errs       map[string]error
+}
+
+// ScrapeHost implements the Service interface
+func (s *service) ScrapeHost(ctx context.Context, sessionID string, host string) ([]MetricFamily, error) {
+	sessionID, err := s.hostSession(ctx, sessionID, host)
+	if err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	results := s.scrape(ctx, sessionID)
+
+	fs := newFamilySet()
+	addCPUFamilies(fs, results.cpu)
+	addMemoryFamilies(fs, results.memory)
+	addFilesystemFamilies(fs, results.disks)
+	addNetworkFamilies(fs, results.network)
+	addContainerFamilies(fs, results.containers, results.stats)
+
+	sources := make([]string, 0, len(results.errs))
+	for source := range results.errs {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		up := 1.0
+		if results.errs[source] != nil {
+			up = 0
+		}
+		fs.add("cerberus_scrape_source_up", "Whether the measurements of a source could be taken over SSH.", TypeGauge, up, "source", source)
+	}
+	fs.add("cerberus_scrape_duration_seconds", "Time taken to collect the metrics over SSH.", TypeGauge, time.Since(started).Seconds())
+
+	return fs.families, nil
+}
+
+// hostSession returns the session used to scrape a host. A caller with a session may only scrape the
+// host of that session; a caller without one, authenticated by the metrics token, may scrape any host
+// with an active session.
+func (s *service) hostSession(ctx context.Context, sessionID string, host string) (string, error) {
+	if sessionID != "" {
+		sessionHost, err := s.sessionHost(ctx, sessionID)
+		if err != nil {
+			return "", err
+		}
+		if !matchHost(sessionHost, host) {
+			return "", fmt.Errorf("%w: %s", ErrHostNotFound, host)
+		}
+		return sessionID, nil
+	}
+
+	sessions, err := s.sessionRepo.ListSessions(ctx)
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID < sessions[j].ID })
+	for _, session := range sessions {
+		if matchHost(session.Host, host) {
+			return session.ID, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrHostNotFound, host)
+}
+
+// matchHost reports whether a session host ("203.0.113.7:22") is the requested host, which may leave
+// out the default SSH port
+func matchHost(sessionHost string, host string) bool {
+	if sessionHost == host {
+		return true
+	}
+	address, port, err := net.SplitHostPort(sessionHost)
+	return err == nil && port == "22" && (address == host || "["+address+"]" == host)
+}
+
+// scrape takes the measurements of all sources concurrently
+func (s *service) scrape(ctx context.Context, sessionID string) *scrapeResults {
+	results := &scrapeResults{errs: make(map[string]error)}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	run := func(source string, measure func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := measure()
+			mu.Lock()
+			results.errs[source] = err
+			mu.Unlock()
+		}()
+	}
+
+	run("cpu", func() (err error) {
+		results.cpu, err = s.server.GetCPUUsage(ctx, sessionID, sampleInterval)
+		return err
+	})
+	run("memory", func() (err error) {
+		results.memory, err = s.server.GetMemoryUsage(ctx, sessionID)
+		return err
+	})
+	run("filesystem", func() (err error) {
+		results.disks, err = s.server.GetDiskUsage(ctx, sessionID, server.DiskUsageFilter{})
+		return err
+	})
+	run("network", func() (err error) {
+		results.network, err = s.server.GetNetworkInfo(ctx, sessionID, server.MinSampleInterval)
+		return err
+	})
+	run("docker", func() error {
+		containers, err := s.docker.GetContainerStatuses(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		// Stats fail when no container is running on some Docker versions; states are enough then
+		stats, _ := s.docker.GetContainerStats(ctx, sessionID)
+		results.containers, results.stats = containers, stats
+		return nil
+	})
+
+	wg.Wait()
+	return results
+}
+
+// addCPUFamilies adds load averages and per-core utilization
+func addCPUFamilies(fs *familySet, usage *server.CPUUsage) {
+	if usage == nil {
+		return
+	}
+
+	fs.add("node_load1", "1m load average.", TypeGauge, usage.LoadAverage.Load1)
+	fs.add("node_load5", "5m load average.", TypeGauge, usage.LoadAverage.Load5)
+	fs.add("node_load15", "15m load average.", TypeGauge, usage.LoadAverage.Load15)
+
+	const help = "CPU utilization by mode in percent, sampled over one second."
+	for _, core := range usage.Cores {
+		cpu := strings.TrimPrefix(core.CPU, "cpu")
+		fs.add("cerberus_cpu_utilization_percent", help, TypeGauge, core.User, "cpu", cpu, "mode", "user")
+		fs.add("cerberus_cpu_utilization_percent", help, TypeGauge, core.System, "cpu", cpu, "mode", "system")
+		fs.add("cerberus_cpu_utilization_percent", help, TypeGauge, core.IOWait, "cpu", cpu, "mode", "iowait")
+		fs.add("cerberus_cpu_utilization_percent", help, TypeGauge, core.Steal, "cpu", cpu, "mode", "steal")
+		fs.add("cerberus_cpu_utilization_percent", help, TypeGauge, core.Idle, "cpu", cpu, "mode", "idle")
+	}
+	fs.add("cerberus_cpu_count", "Number of CPUs.", TypeGauge, float64(len(usage.Cores)))
+}
+
+// addMemoryFamilies adds the /proc/meminfo fields node_exporter reports under the same names
+func addMemoryFamilies(fs *familySet, usage *server.MemoryUsage) {
+	if usage == nil {
+		return
+	}
+
+	fs.add("node_memory_MemTotal_bytes", "Memory information field MemTotal_bytes.", TypeGauge, float64(usage.TotalBytes))
+	fs.add("node_memory_MemFree_bytes", "Memory information field MemFree_bytes.", TypeGauge, float64(usage.FreeBytes))
+	fs.add("node_memory_MemAvailable_bytes", "Memory information field MemAvailable_bytes.", TypeGauge, float64(usage.AvailableBytes))
+	fs.add("node_memory_Buffers_bytes", "Memory information field Buffers_bytes.", TypeGauge, float64(usage.BuffersBytes))
+	fs.add("node_memory_Cached_bytes", "Memory information field Cached_bytes, including reclaimable slab.", TypeGauge, float64(usage.CachedBytes))
+	fs.add("node_memory_SwapTotal_bytes", "Memory information field SwapTotal_bytes.", TypeGauge, float64(usage.SwapTotalBytes))
+	fs.add("node_memory_SwapFree_bytes", "Memory information field SwapFree_bytes.", TypeGauge, float64(usage.SwapFreeBytes))
+}
+
+// addFilesystemFamilies adds space and inode usage of the filesystems that are not pseudo filesystems
+func addFilesystemFamilies(fs *familySet, usages []server.DiskUsage) {
+	for _, usage := range usages {
+		labels := []string{"device", usage.Filesystem, "fstype", usage.Type, "mountpoint", usage.MountedOn}
+		readonly := 0.0
+		if slices.Contains(usage.MountOptions, "ro") {
+			readonly = 1
+		}
+
+		fs.add("node_filesystem_size_bytes", "Filesystem size in bytes.", TypeGauge, float64(usage.SizeBytes), labels...)
+		fs.add("node_filesystem_free_bytes", "Filesystem free space in bytes.", TypeGauge, float64(usage.SizeBytes-min(usage.UsedBytes, usage.SizeBytes)), labels...)
+		fs.add("node_filesystem_avail_bytes", "Filesystem space available to non-root users in bytes.", TypeGauge, float64(usage.AvailableBytes), labels...)
+		fs.add("node_filesystem_files", "Filesystem total file nodes.", TypeGauge, float64(usage.Inodes), labels...)
+		fs.add("node_filesystem_files_free", "Filesystem total free file nodes.", TypeGauge, float64(usage.InodesFree), labels...)
+		fs.add("node_filesystem_readonly", "Filesystem read-only status.", TypeGauge, readonly, labels...)
+	}
+}
+
+// addNetworkFamilies adds the traffic counters and link state of the network interfaces
+func addNetworkFamilies(fs *familySet, network *server.NetworkInfo) {
+	if network == nil {
+		return
+	}
+
+	for _, iface := range network.Interfaces {
+		device := iface.Name
+		counters := iface.Counters
+		fs.add("node_network_receive_bytes_total", "Network device statistic receive_bytes.", TypeCounter, float64(counters.RxBytes), "device", device)
+		fs.add("node_network_transmit_bytes_total", "Network device statistic transmit_bytes.", TypeCounter, float64(counters.TxBytes), "device", device)
+		fs.add("node_network_receive_packets_total", "Network device statistic receive_packets.", TypeCounter, float64(counters.RxPackets), "device", device)
+		fs.add("node_network_transmit_packets_total", "Network device statistic transmit_packets.", TypeCounter, float64(counters.TxPackets), "device", device)
+		fs.add("node_network_receive_errs_total", "Network device statistic receive_errs.", TypeCounter, float64(counters.RxErrors), "device", device)
+		fs.add("node_network_transmit_errs_total", "Network device statistic transmit_errs.", TypeCounter, float64(counters.TxErrors), "device", device)
+		fs.add("node_network_receive_drop_total", "Network device statistic receive_drop.", TypeCounter, float64(counters.RxDropped), "device", device)
+		fs.add("node_network_transmit_drop_total", "Network device statistic transmit_drop.", TypeCounter, float64(counters.TxDropped), "device", device)
+
+		up := 0.0
+		if iface.State == "up" {
+			up = 1
+		}
+		fs.add("node_network_up", "Value is 1 if operstate is 'up', 0 otherwise.", TypeGauge, up, "device", device)
+		fs.add("node_network_mtu_bytes", "Network device property mtu_bytes.", TypeGauge, float64(iface.MTU), "device", device)
+		if iface.SpeedMbps > 0 {
+			fs.add("node_network_speed_bytes", "Network device property speed_bytes.", TypeGauge, float64(iface.SpeedMbps)*125000, "device", device)
+		}
+	}
+}
+
+// addContainerFamilies adds the state of every container and the resource usage of the running ones
+func addContainerFamilies(fs *familySet, containers []docker.ContainerStatus, stats []docker.ContainerStats) {
+	for _, container := range containers {
+		for _, state := range containerStates {
+			value := 0.0
+			if container.State == state {
+				value = 1
+			}
+			fs.add("cerberus_container_state", "Whether the container is in the state.", TypeGauge, value,
+				"container", container.Name, "image", container.Image, "state", state)
+		}
+
+		oomKilled := 0.0
+		if container.OOMKilled {
+			oomKilled = 1
+		}
+		fs.add("cerberus_container_exit_code", "Exit code of the last run of the container.", TypeGauge, float64(container.ExitCode), "container", container.Name)
+		fs.add("cerberus_container_oom_killed", "Whether the last run of the container was killed for running out of memory.", TypeGauge, oomKilled, "container", container.Name)
+		fs.add("cerberus_container_restarts_total", "Number of times Docker restarted the container.", TypeCounter, float64(container.RestartCount), "container", container.Name)
+		if container.StartedAt != nil {
+			fs.add("cerberus_container_start_time_seconds", "Start time of the container since unix epoch in seconds.", TypeGauge, float64(container.StartedAt.Unix()), "container", container.Name)
+		}
+	}
+
+	for _, stat := range stats {
+		fs.add("cerberus_container_cpu_percent", "CPU usage of the container in percent of one CPU.", TypeGauge, stat.CPUPercentage, "container", stat.Name)
+		fs.add("cerberus_container_memory_usage_bytes", "Memory usage of the container in bytes.", TypeGauge, float64(stat.MemoryUsageBytes), "container", stat.Name)
+		fs.add("cerberus_container_memory_limit_bytes", "Memory limit of the container in bytes.", TypeGauge, float64(stat.MemoryLimitBytes), "container", stat.Name)
+		fs.add("cerberus_container_network_receive_bytes_total", "Bytes received by the container.", TypeCounter, float64(stat.NetworkRxBytes), "container", stat.Name)
+		fs.add("cerberus_container_network_transmit_bytes_total", "Bytes sent by the container.", TypeCounter, float64(stat.NetworkTxBytes), "container", stat.Name)
+		fs.add("cerberus_container_block_read_bytes_total", "Bytes read from block devices by the container.", TypeCounter, float64(stat.BlockReadBytes), "container", stat.Name)
+		fs.add("cerberus_container_block_written_bytes_total", "Bytes written to block devices by the container.", TypeCounter, float64(stat.BlockWrittenBytes), "container", stat.Name)
+		fs.add("cerberus_container_pids", "Number of processes in the container.", TypeGauge, float64(stat.PIDs), "container", stat.Name)
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import "time"
+
+// Userland flavours of the remote core utilities
+const (
+	UserlandGNU     = "gnu"
+	UserlandBusyBox = "busybox"
+	UserlandBSD     = "bsd"
+	UserlandUnknown = "unknown"
+)
+
+// Capabilities describes the userland of a server, probed once per session, so that commands can use
+// the dialect the server understands
+type Capabilities struct {
+	OSID           string    `json:"os_id"`
+	OSVersion      string    `json:"os_version"`
+	Userland       string    `json:"userland"`        // gnu, busybox, bsd or unknown
+	PackageManager string    `json:"package_manager"` // Empty when none is supported
+	StatFormat     bool      `json:"stat_format"`     // stat -c FORMAT is supported
+	FindExecPlus   bool      `json:"find_exec_plus"`  // find -exec ... {} + is supported
+	PSFormat       bool      `json:"ps_format"`       // ps -eo with procps columns such as etimes is supported
+	DFOutput       bool      `json:"df_output"`       // df --output is supported
+	ProcFS         bool      `json:"procfs"`          // /proc is mounted
+	Root           bool      `json:"root"`            // The SSH user is root
+	Sudo           bool      `json:"sudo"`            // Passwordless sudo is available
+	Tools          []string  `json:"tools"`           // Optional tools found in PATH
+	ProbedAt       time.Time `json:"probed_at"`
+}
+
+// HasTool reports whether a tool was found in PATH when the server was probed
+func (c *Capabilities) HasTool(name string) bool {
+	for _, tool := range c.Tools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
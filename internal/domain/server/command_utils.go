@@ -0,0 +1,35 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Bounds for the interval over which counters, e.g. of CPU time or network traffic, are sampled
+const (
+	MinSampleInterval = 100 * time.Millisecond
+	MaxSampleInterval = 60 * time.Second
+)
+
+// ErrInvalidInterval is returned when a sampling interval is out of bounds
+var ErrInvalidInterval = errors.New("invalid sampling interval")
+
+// validateSampleInterval checks that a sampling interval is within bounds
+func validateSampleInterval(interval time.Duration) error {
+	if interval < MinSampleInterval || interval > MaxSampleInterval {
+		return fmt.Errorf("%w: must be between %s and %s", ErrInvalidInterval, MinSampleInterval, MaxSampleInterval)
+	}
+	return nil
+}
+
+// formatSleepSeconds formats a duration as a fractional number of seconds for 'sleep'
+func formatSleepSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+// roundHundredths rounds a value, e.g. a percentage or a rate, to two decimal places
+func roundHundredths(value float64) float64 {
+	return float64(int64(value*100+0.5)) / 100
+}
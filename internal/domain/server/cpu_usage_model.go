@@ -0,0 +1,30 @@
+package server
+
+import "time"
+
+// CPUCoreUsage contains utilization percentages for a single CPU or the aggregate of all CPUs
+type CPUCoreUsage struct {
+	CPU    string  `json:"cpu"`    // "all" for the aggregate, "cpu0", "cpu1", ... for cores
+	User   float64 `json:"user"`   // Time spent in user mode (including nice), in percent
+	System float64 `json:"system"` // Time spent in kernel mode (including irq and softirq), in percent
+	IOWait float64 `json:"iowait"` // Time spent waiting for I/O, in percent
+	Steal  float64 `json:"steal"`  // Time stolen by the hypervisor, in percent
+	Idle   float64 `json:"idle"`   // Idle time, in percent
+	Usage  float64 `json:"usage"`  // Busy time (100 - idle - iowait), in percent
+}
+
+// LoadAverage contains the system load averages
+type LoadAverage struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+// CPUUsage contains CPU utilization computed from two /proc/stat samples
+type CPUUsage struct {
+	Timestamp   time.Time      `json:"timestamp"`    // Time at which the second sample was taken
+	IntervalMS  int64          `json:"interval_ms"`  // Sampling interval in milliseconds
+	Total       CPUCoreUsage   `json:"total"`        // Aggregate utilization over all cores
+	Cores       []CPUCoreUsage `json:"cores"`        // Per-core utilization
+	LoadAverage LoadAverage    `json:"load_average"` // Load averages from /proc/loadavg
+}
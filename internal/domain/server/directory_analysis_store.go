@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Background analysis limits
+const (
+	maxRunningAnalysesPerSession = 2
+	analysisRetention            = time.Hour
+)
+
+// Background analysis errors
+var (
+	ErrAnalysisNotFound   = errors.New("analysis not found")
+	ErrTooManyAnalyses    = errors.New("too many running analyses")
+	ErrAnalysisNotRunning = errors.New("analysis is not running")
+)
+
+// analysisEntry tracks a background analysis and the means to cancel it
+type analysisEntry struct {
+	sessionID string
+	analysis  DirectoryAnalysis
+	progress  int64
+	cancel    context.CancelFunc
+}
+
+// analysisStore keeps background directory analyses in memory. Finished analyses are dropped after
+// analysisRetention.
+type analysisStore struct {
+	mu      sync.Mutex
+	entries map[string]*analysisEntry
+}
+
+// newAnalysisStore creates an empty analysis store
+func newAnalysisStore() *analysisStore {
+	return &analysisStore{
+		entries: make(map[string]*analysisEntry),
+	}
+}
+
+// StartDirectoryAnalysis implements the Service interface
+func (s *service) StartDirectoryAnalysis(ctx context.Context, sessionID string, query DirectoryUsageQuery) (*DirectoryAnalysis, error) {
+	if err := normalizeDirectoryUsageQuery(&query); err != nil {
+		return nil, err
+	}
+
+	id, err := newAnalysisID()
+	if err != nil {
+		return nil, err
+	}
+
+	// The analysis outlives the request, so it gets its own cancellable context
+	runCtx, cancel := context.WithCancel(context.Background())
+	entry := &analysisEntry{
+		sessionID: sessionID,
+		cancel:    cancel,
+		analysis: DirectoryAnalysis{
+			ID:        id,
+			Query:     query,
+			Status:    AnalysisStatusRunning,
+			StartedAt: time.Now(),
+		},
+	}
+
+	if err := s.analyses.add(entry); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer cancel()
+		result, err := s.runDirectoryUsage(runCtx, sessionID, query, &entry.progress)
+		s.analyses.finish(entry, result, err)
+	}()
+
+	return s.analyses.snapshot(entry), nil
+}
+
+// GetDirectoryAnalysis implements the Service interface
+func (s *service) GetDirectoryAnalysis(ctx context.Context, sessionID string, id string) (*DirectoryAnalysis, error) {
+	entry, err := s.analyses.get(sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.analyses.snapshot(entry), nil
+}
+
+// CancelDirectoryAnalysis implements the Service interface
+func (s *service) CancelDirectoryAnalysis(ctx context.Context, sessionID string, id string) (*DirectoryAnalysis, error) {
+	entry, err := s.analyses.get(sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.analyses.mu.Lock()
+	running := entry.analysis.Status == AnalysisStatusRunning
+	if running {
+		entry.analysis.Status = AnalysisStatusCancelled
+	}
+	s.analyses.mu.Unlock()
+
+	if !running {
+		return nil, fmt.Errorf("%w: %s", ErrAnalysisNotRunning, id)
+	}
+
+	// Cancelling the context terminates the remote command
+	entry.cancel()
+	return s.analyses.snapshot(entry), nil
+}
+
+// add registers a new analysis, enforcing the per-session limit of running analyses
+func (st *analysisStore) add(entry *analysisEntry) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.pruneLocked()
+
+	running := 0
+	for _, existing := range st.entries {
+		if existing.sessionID == entry.sessionID && existing.analysis.Status == AnalysisStatusRunning {
+			running++
+		}
+	}
+	if running >= maxRunningAnalysesPerSession {
+		return fmt.Errorf("%w: at most %d analyses can run per session", ErrTooManyAnalyses, maxRunningAnalysesPerSession)
+	}
+
+	st.entries[entry.analysis.ID] = entry
+	return nil
+}
+
+// get returns the analysis with the given ID if it belongs to the session
+func (st *analysisStore) get(sessionID string, id string) (*analysisEntry, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.pruneLocked()
+
+	entry, ok := st.entries[id]
+	if !ok || entry.sessionID != sessionID {
+		return nil, fmt.Errorf("%w: %s", ErrAnalysisNotFound, id)
+	}
+	return entry, nil
+}
+
+// finish records the outcome of an analysis. Analyses cancelled by the user keep their status.
+func (st *analysisStore) finish(entry *analysisEntry, result *DirectoryUsageResult, err error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := time.Now()
+	entry.analysis.FinishedAt = &now
+
+	switch {
+	case entry.analysis.Status == AnalysisStatusCancelled:
+	case err != nil:
+		entry.analysis.Status = AnalysisStatusFailed
+		entry.analysis.Error = err.Error()
+	default:
+		entry.analysis.Status = AnalysisStatusCompleted
+		entry.analysis.Result = result
+	}
+}
+
+// snapshot returns a copy of the analysis with the current progress
+func (st *analysisStore) snapshot(entry *analysisEntry) *DirectoryAnalysis {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	analysis := entry.analysis
+	analysis.EntriesScanned = atomic.LoadInt64(&entry.progress)
+	return &analysis
+}
+
+// pruneLocked drops finished analyses past their retention. The caller must hold the lock.
+func (st *analysisStore) pruneLocked() {
+	cutoff := time.Now().Add(-analysisRetention)
+	for id, entry := range st.entries {
+		if entry.analysis.FinishedAt != nil && entry.analysis.FinishedAt.Before(cutoff) {
+			delete(st.entries, id)
+		}
+	}
+}
+
+// newAnalysisID generates a random analysis identifier
+func newAnalysisID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate analysis ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
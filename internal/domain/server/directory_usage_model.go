@@ -0,0 +1,62 @@
+package server
+
+import "time"
+
+// Directory usage analysis modes
+const (
+	DirectoryUsageModeTree         = "tree"
+	DirectoryUsageModeLargestFiles = "largest-files"
+)
+
+// Directory analysis states
+const (
+	AnalysisStatusRunning   = "running"
+	AnalysisStatusCompleted = "completed"
+	AnalysisStatusFailed    = "failed"
+	AnalysisStatusCancelled = "cancelled"
+)
+
+// DirectoryUsageQuery describes a directory size analysis
+type DirectoryUsageQuery struct {
+	Path     string `json:"path"`      // Absolute directory to analyze
+	Mode     string `json:"mode"`      // "tree" (default) or "largest-files"
+	MaxDepth int    `json:"max_depth"` // Depth of the tree below the path (tree mode)
+	Limit    int    `json:"limit"`     // Children kept per directory in tree mode, number of files in largest-files mode
+}
+
+// DirectoryUsageNode is a directory in the size tree
+type DirectoryUsageNode struct {
+	Path            string               `json:"path"`
+	Name            string               `json:"name"`
+	SizeBytes       uint64               `json:"size_bytes"`                 // Disk usage of the directory including all descendants
+	FileCount       int64                `json:"file_count"`                 // Regular files in the directory and all descendants
+	OmittedChildren int                  `json:"omitted_children,omitempty"` // Subdirectories dropped by the per-directory limit
+	Children        []DirectoryUsageNode `json:"children,omitempty"`
+}
+
+// LargeFile is a file in the largest-files listing
+type LargeFile struct {
+	Path      string `json:"path"`
+	SizeBytes uint64 `json:"size_bytes"`
+}
+
+// DirectoryUsageResult contains the outcome of a directory size analysis
+type DirectoryUsageResult struct {
+	Path         string              `json:"path"`
+	Mode         string              `json:"mode"`
+	Tree         *DirectoryUsageNode `json:"tree,omitempty"`
+	LargestFiles []LargeFile         `json:"largest_files,omitempty"`
+	DurationMS   int64               `json:"duration_ms"`
+}
+
+// DirectoryAnalysis is a directory size analysis running in the background
+type DirectoryAnalysis struct {
+	ID             string                `json:"id"`
+	Query          DirectoryUsageQuery   `json:"query"`
+	Status         string                `json:"status"` // "running", "completed", "failed" or "cancelled"
+	StartedAt      time.Time             `json:"started_at"`
+	FinishedAt     *time.Time            `json:"finished_at,omitempty"`
+	EntriesScanned int64                 `json:"entries_scanned"` // Output lines processed so far, as a progress indicator
+	Error          string                `json:"error,omitempty"`
+	Result         *DirectoryUsageResult `json:"result,omitempty"`
+}
@@ -0,0 +1,23 @@
+package server
+
+// DiskUsage contains space and inode usage of a mounted filesystem
+type DiskUsage struct {
+	Filesystem       string   `json:"filesystem"` // Source device, e.g. "/dev/sda1"
+	Type             string   `json:"type"`       // Filesystem type, e.g. "ext4"
+	MountedOn        string   `json:"mounted_on"`
+	MountOptions     []string `json:"mount_options"`
+	SizeBytes        uint64   `json:"size_bytes"`
+	UsedBytes        uint64   `json:"used_bytes"`
+	AvailableBytes   uint64   `json:"available_bytes"` // Space available to unprivileged users
+	UsePercentage    float64  `json:"use_percentage"`  // Used space relative to used plus available space, as df reports it
+	Inodes           uint64   `json:"inodes"`          // Zero for filesystems without a fixed inode table
+	InodesUsed       uint64   `json:"inodes_used"`
+	InodesFree       uint64   `json:"inodes_free"`
+	InodesUsePercent float64  `json:"inodes_use_percentage"`
+	Pseudo           bool     `json:"pseudo"` // Whether the filesystem is virtual or memory-backed, such as tmpfs or overlay
+}
+
+// DiskUsageFilter narrows down the filesystems reported
+type DiskUsageFilter struct {
+	IncludePseudo bool // Include pseudo filesystems such as tmpfs, overlay and squashfs
+}
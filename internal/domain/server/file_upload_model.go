@@ -0,0 +1,23 @@
+package server
+
+// FileUpload describes where and how an uploaded file is written
+type FileUpload struct {
+	Path          string // Absolute path of the file; a symbolic link is resolved and its target replaced
+	Mode          string // Octal permissions, e.g. "0640"; defaults to those of the replaced file, or 0644
+	Owner         string // User name or ID; defaults to the owner of the replaced file
+	Group         string // Group name or ID; defaults to the group of the replaced file
+	CreateParents bool   // Create missing parent directories
+	SHA256        string // Expected checksum of the content, verified before the file is replaced
+	Sudo          bool   // Write through passwordless sudo instead of as the SSH user
+}
+
+// FileUploadResult describes a file written by an upload
+type FileUploadResult struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+	Mode     string `json:"mode"`
+	Owner    string `json:"owner"`
+	Group    string `json:"group"`
+	Replaced bool   `json:"replaced"` // Whether an existing file was replaced
+}
@@ -9,29 +9,65 @@ import (
 	"time"
 )
 
-// getEnhancedFileInfo gets detailed file information using stat
-func getEnhancedFileInfo(ctx context.Context, sessionRepo SessionRepository, sessionID string, path string) (*FileSystemEntry, error) {
-	// Use stat to get detailed file information
-	statCmd := fmt.Sprintf("stat -c '%%n|%%F|%%s|%%U|%%G|%%A|%%Y' %s", sanitizePath(path))
-	output, err := sessionRepo.RunCommand(ctx, sessionID, statCmd)
-	if err != nil {
-		return nil, err
+// fileStatFormat prints name, type, size, owner, group, permissions and modification time of a path.
+// GNU and BusyBox stat both understand it.
+const fileStatFormat = "%n|%F|%s|%U|%G|%A|%Y"
+
+// statFindCommand runs find with the given arguments and prints the details of every match, using the
+// fastest form the server supports: one stat for many paths, one stat per path, or ls -ld where stat
+// does not accept a format string
+func statFindCommand(caps *Capabilities, findArgs string) string {
+	switch {
+	case caps.StatFormat && caps.FindExecPlus:
+		return fmt.Sprintf("find %s -exec stat -c '%s' {} + 2>/dev/null", findArgs, fileStatFormat)
+	case caps.StatFormat:
+		return fmt.Sprintf(`find %s 2>/dev/null | while IFS= read -r f; do stat -c '%s' "$f"; done`, findArgs, fileStatFormat)
+	default:
+		return fmt.Sprintf(`find %s 2>/dev/null | while IFS= read -r f; do ls -ld "$f"; done`, findArgs)
 	}
+}
 
-	// Parse the stat output
-	parts := strings.Split(strings.TrimSpace(output), "|")
+// parseStatEntries parses the output of a command built by statFindCommand
+func parseStatEntries(output string, caps *Capabilities) []FileSystemEntry {
+	var entries []FileSystemEntry
+
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+
+		var entry *FileSystemEntry
+		if caps.StatFormat {
+			entry = parseStatLine(line)
+		} else {
+			entry = parseLongListingPath(line)
+		}
+		if entry != nil {
+			entries = append(entries, *entry)
+		}
+	}
+
+	return entries
+}
+
+// parseStatLine parses a line printed with fileStatFormat. The fields are taken from the end so that
+// names containing the separator are kept intact.
+func parseStatLine(line string) *FileSystemEntry {
+	parts := strings.Split(line, "|")
 	if len(parts) < 7 {
-		return nil, fmt.Errorf("invalid stat output format")
+		return nil
 	}
+	n := len(parts)
 
 	// Extract information
-	name := filepath.Base(parts[0])
-	fileType := parts[1]
-	sizeStr := parts[2]
-	owner := parts[3]
-	group := parts[4]
-	permissions := parts[5]
-	modTimeStr := parts[6]
+	path := strings.Join(parts[:n-6], "|")
+	name := filepath.Base(path)
+	fileType := parts[n-6]
+	sizeStr := parts[n-5]
+	owner := parts[n-4]
+	group := parts[n-3]
+	permissions := parts[n-2]
+	modTimeStr := parts[n-1]
 
 	// Parse size
 	size, _ := strconv.ParseInt(sizeStr, 10, 64)
@@ -63,45 +99,59 @@ func getEnhancedFileInfo(ctx context.Context, sessionRepo SessionRepository, ses
 		Group:        group,
 		LastModified: modTime,
 		IsHidden:     isHidden,
-	}, nil
+	}
 }
 
-// getRecursiveDirectoryContents gets detailed file information for all files in a directory recursively
-func getRecursiveDirectoryContents(ctx context.Context, sessionRepo SessionRepository, sessionID string, path string, includeHidden bool) ([]FileSystemEntry, error) {
-	var entries []FileSystemEntry
+// parseLongListingPath parses an 'ls -ld <path>' line, where the name column holds the full path
+func parseLongListingPath(line string) *FileSystemEntry {
+	entry := parseFileEntryLine(line, "")
+	if entry == nil {
+		return nil
+	}
 
-	// Build the find command
-	findCmd := ""
-	if includeHidden {
-		findCmd = fmt.Sprintf("find %s -type f -o -type d -o -type l", sanitizePath(path))
-	} else {
-		findCmd = fmt.Sprintf("find %s -not -path \"*/\\.*\" -type f -o -type d -o -type l", sanitizePath(path))
+	entry.Path = entry.Name
+	entry.Name = filepath.Base(entry.Name)
+	entry.IsHidden = strings.HasPrefix(entry.Name, ".")
+
+	return entry
+}
+
+// getEnhancedFileInfo gets detailed file information using stat, or ls where stat has no format option
+func getEnhancedFileInfo(ctx context.Context, sessionRepo SessionRepository, sessionID string, caps *Capabilities, path string) (*FileSystemEntry, error) {
+	statCmd := fmt.Sprintf("stat -c '%s' %s", fileStatFormat, sanitizePath(path))
+	if !caps.StatFormat {
+		statCmd = fmt.Sprintf("ls -ld %s", sanitizePath(path))
 	}
 
-	// Execute the find command
-	output, err := sessionRepo.RunCommand(ctx, sessionID, findCmd)
+	output, err := sessionRepo.RunCommand(ctx, sessionID, statCmd)
 	if err != nil {
 		return nil, err
 	}
 
-	// Process each line (each file path)
-	paths := strings.Split(output, "\n")
-	for _, filePath := range paths {
-		if filePath == "" {
-			continue
-		}
+	// Parse the stat output
+	entries := parseStatEntries(strings.TrimSpace(output), caps)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("invalid stat output format")
+	}
 
-		// Get detailed file info for this path
-		fileInfo, err := getEnhancedFileInfo(ctx, sessionRepo, sessionID, filePath)
-		if err != nil {
-			// Skip files we can't stat
-			continue
-		}
+	return &entries[0], nil
+}
+
+// getRecursiveDirectoryContents gets detailed file information for all files in a directory recursively
+func getRecursiveDirectoryContents(ctx context.Context, sessionRepo SessionRepository, sessionID string, caps *Capabilities, path string, includeHidden bool) ([]FileSystemEntry, error) {
+	// Build the find arguments
+	findArgs := fmt.Sprintf(`%s \( -type f -o -type d -o -type l \)`, sanitizePath(path))
+	if !includeHidden {
+		findArgs += ` ! -path '*/.*'`
+	}
 
-		entries = append(entries, *fileInfo)
+	// Execute find and stat in a single round trip
+	output, err := sessionRepo.RunCommand(ctx, sessionID, statFindCommand(caps, findArgs))
+	if err != nil {
+		return nil, err
 	}
 
-	return entries, nil
+	return parseStatEntries(output, caps), nil
 }
 
 // getNonRecursiveDirectoryContents gets detailed file information for all files in a directory (non-recursively)
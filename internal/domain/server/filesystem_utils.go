@@ -87,13 +87,15 @@ func parseRecursiveFileListing(output string, basePath string) []FileSystemEntry
 	return entries
 }
 
+// longListingPattern parses 'ls -l' lines of GNU and BusyBox ls.
+// Format: perms links owner group size month day time name. The permissions include the sticky
+// (t/T) and setuid/setgid (s/S) bits and an optional ACL or SELinux marker, and device files show
+// "major, minor" in place of the size.
+var longListingPattern = regexp.MustCompile(`^([-bcdlps][-rwxsStT]{9}[.+@]?)\s+(\d+)\s+(\S+)\s+(\S+)\s+(\d+|\d+,\s*\d+)\s+(\w{3})\s+(\d+)\s+(\d+:\d+|\d{4})\s+(.+)$`)
+
 // parseFileEntryLine parses a single line from 'ls -l' output
 func parseFileEntryLine(line string, basePath string) *FileSystemEntry {
-	// Regex to parse ls -l output
-	// Format: perms links owner group size month day time name
-	re := regexp.MustCompile(`^([d-lcrwxsS]+)\s+(\d+)\s+(\S+)\s+(\S+)\s+(\d+)\s+(\w{3})\s+(\d+)\s+(\d+:\d+|\d{4})\s+(.+)$`)
-
-	matches := re.FindStringSubmatch(line)
+	matches := longListingPattern.FindStringSubmatch(line)
 	if matches == nil || len(matches) < 10 {
 		return nil
 	}
@@ -149,7 +151,7 @@ func parseFileEntryLine(line string, basePath string) *FileSystemEntry {
 
 	return &FileSystemEntry{
 		Name:         name,
-		Path:         basePath + name,
+		Path:         filepath.Join(basePath, name),
 		Type:         entryType,
 		Size:         size,
 		Permissions:  permissions,
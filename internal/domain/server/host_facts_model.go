@@ -0,0 +1,55 @@
+package server
+
+import "time"
+
+// HostFacts is a consolidated inventory of a server, gathered in a single round trip
+type HostFacts struct {
+	Hostname         string         `json:"hostname"`
+	FQDN             string         `json:"fqdn"`
+	OS               OSRelease      `json:"os"`
+	Kernel           KernelInfo     `json:"kernel"`
+	Architecture     string         `json:"architecture"`
+	Virtualization   Virtualization `json:"virtualization"`
+	CPU              CPUSummary     `json:"cpu"`
+	MemoryTotalBytes uint64         `json:"memory_total_bytes"`
+	SwapTotalBytes   uint64         `json:"swap_total_bytes"`
+	Timezone         string         `json:"timezone"`
+	BootTime         time.Time      `json:"boot_time"`
+	UptimeSeconds    int64          `json:"uptime_seconds"`
+	MachineID        string         `json:"machine_id"`
+	CollectedAt      time.Time      `json:"collected_at"`
+}
+
+// OSRelease holds the identification fields of /etc/os-release
+type OSRelease struct {
+	ID              string   `json:"id"`
+	IDLike          []string `json:"id_like"`
+	Name            string   `json:"name"`
+	VersionID       string   `json:"version_id"`
+	VersionCodename string   `json:"version_codename,omitempty"`
+	PrettyName      string   `json:"pretty_name"`
+}
+
+// KernelInfo describes the running kernel
+type KernelInfo struct {
+	Name    string `json:"name"`    // e.g. "Linux"
+	Release string `json:"release"` // e.g. "6.1.0-18-amd64"
+	Version string `json:"version"` // Build string
+}
+
+// Virtualization describes the hypervisor and container the server runs in
+type Virtualization struct {
+	Type      string `json:"type"`      // Hypervisor such as "kvm" or "vmware", "none" on bare metal
+	Container string `json:"container"` // Container runtime such as "docker" or "lxc", "none" outside containers
+	Role      string `json:"role"`      // "guest" when running virtualized or containerized, "host" otherwise
+}
+
+// CPUSummary aggregates the per-core CPU information
+type CPUSummary struct {
+	ModelName      string `json:"model_name"`
+	Vendor         string `json:"vendor"`
+	Sockets        int    `json:"sockets"`
+	Cores          int    `json:"cores"`   // Physical cores across all sockets
+	Threads        int    `json:"threads"` // Logical processors
+	ThreadsPerCore int    `json:"threads_per_core"`
+}
@@ -6,4 +6,5 @@ type Library struct {
 	Version string `json:"version"`
 	Status  string `json:"status"`
 	Arch    string `json:"architecture"`
+	Manager string `json:"manager"` // Package manager that installed the package, e.g. "apt" or "dnf"
 }
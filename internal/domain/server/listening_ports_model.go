@@ -0,0 +1,21 @@
+package server
+
+// ListeningSocket represents a socket that accepts connections or datagrams on the server
+type ListeningSocket struct {
+	Protocol      string `json:"protocol"` // "tcp", "tcp6", "udp" or "udp6"
+	LocalAddress  string `json:"local_address"`
+	LocalPort     int    `json:"local_port"`
+	State         string `json:"state"`                    // "LISTEN" for TCP, "UNCONN" for UDP
+	PID           int    `json:"pid,omitempty"`            // Owning process, if visible to the SSH user
+	ProcessName   string `json:"process_name,omitempty"`   // Name of the owning process
+	PIDs          []int  `json:"pids,omitempty"`           // All processes sharing the socket (e.g. pre-forked workers)
+	ContainerID   string `json:"container_id,omitempty"`   // Docker container the owning process belongs to
+	ContainerName string `json:"container_name,omitempty"` // Name of that Docker container
+	Source        string `json:"source"`                   // "ss" or "procfs", depending on how the socket was discovered
+}
+
+// ListeningSocketFilter narrows down the listening socket inventory
+type ListeningSocketFilter struct {
+	Protocol string // "tcp" or "udp"; matches both IPv4 and IPv6 sockets
+	Port     int    // Local port, 0 for any
+}
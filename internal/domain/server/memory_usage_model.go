@@ -0,0 +1,16 @@
+package server
+
+// MemoryUsage contains memory and swap usage from /proc/meminfo
+type MemoryUsage struct {
+	TotalBytes        uint64  `json:"total_bytes"`
+	UsedBytes         uint64  `json:"used_bytes"`      // Total minus available memory
+	AvailableBytes    uint64  `json:"available_bytes"` // Memory available to new processes without swapping
+	FreeBytes         uint64  `json:"free_bytes"`      // Memory not used at all
+	BuffersBytes      uint64  `json:"buffers_bytes"`
+	CachedBytes       uint64  `json:"cached_bytes"` // Page cache and reclaimable slab
+	UsePercentage     float64 `json:"use_percentage"`
+	SwapTotalBytes    uint64  `json:"swap_total_bytes"`
+	SwapUsedBytes     uint64  `json:"swap_used_bytes"`
+	SwapFreeBytes     uint64  `json:"swap_free_bytes"`
+	SwapUsePercentage float64 `json:"swap_use_percentage"`
+}
@@ -1,5 +1,7 @@
 package server
 
+import "time"
+
 // ServerDetails contains basic information about the server
 type ServerDetails struct {
 	Hostname      string `json:"hostname"`
@@ -37,26 +39,41 @@ type CPUInfo struct {
 	PowerManagement string `json:"power_management"`
 }
 
-// DiskUsage contains information about disk usage
-type DiskUsage struct {
-	Filesystem    string `json:"filesystem"`
-	Size          string `json:"size"`
-	Used          string `json:"used"`
-	Available     string `json:"available"`
-	UsePercentage string `json:"use_percentage"`
-	MountedOn     string `json:"mounted_on"`
-}
-
 // ProcessInfo contains information about a running process
 type ProcessInfo struct {
-	User  string `json:"user"`
-	PID   string `json:"process_id"`
-	CPU   string `json:"cpu_consumption"`
-	VSZ   string `json:"vsz"`
-	RSS   string `json:"rss"`
-	TTY   string `json:"tty"`
-	Stat  string `json:"stat"`
-	Start string `json:"started"`
-	Time  string `json:"time"`
-	CMD   string `json:"command"`
+	User     string        `json:"user"`
+	PID      int           `json:"process_id"`
+	PPID     int           `json:"parent_process_id"`
+	CPU      float64       `json:"cpu_consumption"`    // CPU usage in percent
+	Mem      float64       `json:"memory_consumption"` // Share of physical memory in percent
+	VSZ      int64         `json:"vsz"`                // Virtual memory size in KiB
+	RSS      int64         `json:"rss"`                // Resident set size in KiB
+	TTY      string        `json:"tty"`
+	Stat     string        `json:"stat"`
+	Start    time.Time     `json:"started"`
+	Time     string        `json:"time"` // Accumulated CPU time
+	CMD      string        `json:"command"`
+	Children []ProcessInfo `json:"children,omitempty"` // Child processes, only populated in tree mode
+}
+
+// ProcessQuery controls filtering, sorting and pagination of the process list
+type ProcessQuery struct {
+	User       string // Only processes of this user
+	Command    string // Regular expression matched against the command line
+	States     string // Process state letters to include (e.g. "RD"), matched against the first STAT character
+	SortBy     string // "cpu", "mem", "rss", "start" or "pid"
+	Descending bool
+	Page       int  // 1-based page number
+	PageSize   int  // Number of processes (or root processes in tree mode) per page
+	Tree       bool // Nest children under their parent process
+	Unpaged    bool // Return all matching processes on one page, ignoring Page and PageSize
+}
+
+// ProcessList contains a page of processes
+type ProcessList struct {
+	Total     int           `json:"total"` // Number of matching processes (root processes in tree mode)
+	Page      int           `json:"page"`
+	PageSize  int           `json:"page_size"`
+	Tree      bool          `json:"tree"`
+	Processes []ProcessInfo `json:"processes"`
 }
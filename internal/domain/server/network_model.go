@@ -0,0 +1,71 @@
+package server
+
+import "time"
+
+// NetworkInfo contains the network configuration and traffic statistics of the server
+type NetworkInfo struct {
+	Timestamp  time.Time          `json:"timestamp"`   // Time at which the second counter sample was taken
+	IntervalMS int64              `json:"interval_ms"` // Interval between the two counter samples in milliseconds
+	Interfaces []NetworkInterface `json:"interfaces"`
+	Routes     []Route            `json:"routes"`
+	DNS        DNSConfig          `json:"dns"`
+}
+
+// NetworkInterface represents a network interface with its addresses and traffic statistics
+type NetworkInterface struct {
+	Name      string             `json:"name"`
+	MAC       string             `json:"mac,omitempty"`
+	State     string             `json:"state"` // Operational state ("up", "down", "unknown", ...)
+	MTU       int                `json:"mtu"`
+	SpeedMbps int                `json:"speed_mbps,omitempty"` // Link speed, only reported for physical links
+	Addresses []InterfaceAddress `json:"addresses"`
+	Counters  InterfaceCounters  `json:"counters"` // Cumulative counters from /proc/net/dev
+	Rates     InterfaceRates     `json:"rates"`    // Throughput derived from two counter samples
+}
+
+// InterfaceAddress represents an IP address assigned to an interface
+type InterfaceAddress struct {
+	Family       string `json:"family"` // "inet" or "inet6"
+	Address      string `json:"address"`
+	PrefixLength int    `json:"prefix_length"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// InterfaceCounters contains cumulative traffic counters of an interface
+type InterfaceCounters struct {
+	RxBytes   uint64 `json:"rx_bytes"`
+	RxPackets uint64 `json:"rx_packets"`
+	RxErrors  uint64 `json:"rx_errors"`
+	RxDropped uint64 `json:"rx_dropped"`
+	TxBytes   uint64 `json:"tx_bytes"`
+	TxPackets uint64 `json:"tx_packets"`
+	TxErrors  uint64 `json:"tx_errors"`
+	TxDropped uint64 `json:"tx_dropped"`
+}
+
+// InterfaceRates contains per-second traffic rates of an interface
+type InterfaceRates struct {
+	RxBytesPerSec   float64 `json:"rx_bytes_per_sec"`
+	TxBytesPerSec   float64 `json:"tx_bytes_per_sec"`
+	RxPacketsPerSec float64 `json:"rx_packets_per_sec"`
+	TxPacketsPerSec float64 `json:"tx_packets_per_sec"`
+	RxErrorsPerSec  float64 `json:"rx_errors_per_sec"`
+	TxErrorsPerSec  float64 `json:"tx_errors_per_sec"`
+}
+
+// Route represents an entry of the kernel routing table
+type Route struct {
+	Family      string `json:"family"`      // "inet" or "inet6"
+	Destination string `json:"destination"` // Destination network in CIDR notation
+	Gateway     string `json:"gateway,omitempty"`
+	Interface   string `json:"interface"`
+	Metric      int    `json:"metric"`
+	Default     bool   `json:"default"`
+}
+
+// DNSConfig contains the resolver configuration from /etc/resolv.conf
+type DNSConfig struct {
+	Nameservers []string `json:"nameservers"`
+	Search      []string `json:"search,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
@@ -0,0 +1,53 @@
+package server
+
+// Supported package managers
+const (
+	PackageManagerApt    = "apt"
+	PackageManagerDnf    = "dnf"
+	PackageManagerYum    = "yum"
+	PackageManagerZypper = "zypper"
+	PackageManagerPacman = "pacman"
+	PackageManagerApk    = "apk"
+)
+
+// Package operations
+const (
+	PackageOperationInstall = "install"
+	PackageOperationRemove  = "remove"
+	PackageOperationUpgrade = "upgrade"
+)
+
+// PendingUpdate is a package with a newer version available
+type PendingUpdate struct {
+	Name             string `json:"name"`
+	CurrentVersion   string `json:"current_version,omitempty"`
+	AvailableVersion string `json:"available_version"`
+	Arch             string `json:"architecture,omitempty"`
+	Repository       string `json:"repository,omitempty"`
+	Security         bool   `json:"security"` // Whether the update fixes a security issue
+}
+
+// PendingUpdates lists the available package updates
+type PendingUpdates struct {
+	Manager               string          `json:"manager"`
+	Refreshed             bool            `json:"refreshed"`               // Whether the package metadata was refreshed before checking
+	SecurityInfoAvailable bool            `json:"security_info_available"` // Whether the package manager reports security updates
+	SecurityCount         int             `json:"security_count"`
+	Updates               []PendingUpdate `json:"updates"`
+}
+
+// PackageOperationRequest describes packages to install, remove or upgrade
+type PackageOperationRequest struct {
+	Packages []string `json:"packages"` // Package names; an empty list upgrades all packages
+	DryRun   bool     `json:"dry_run"`  // Only simulate the transaction
+}
+
+// PackageOperationResult is the outcome of a package operation
+type PackageOperationResult struct {
+	Manager   string   `json:"manager"`
+	Operation string   `json:"operation"`
+	Packages  []string `json:"packages"`
+	DryRun    bool     `json:"dry_run"`
+	ExitCode  int      `json:"exit_code"`
+	Success   bool     `json:"success"`
+}
@@ -0,0 +1,41 @@
+package server
+
+// ProcessDetail contains detailed information about a single process
+type ProcessDetail struct {
+	PID         int               `json:"pid"`
+	ParentPID   int               `json:"parent_pid"`
+	Name        string            `json:"name"`
+	State       string            `json:"state"` // State from /proc/<pid>/status, e.g. "S (sleeping)"
+	User        string            `json:"user"`
+	UID         int               `json:"uid"`
+	Nice        int               `json:"nice"`
+	Threads     int               `json:"threads"`
+	Executable  string            `json:"executable,omitempty"`
+	WorkingDir  string            `json:"working_dir,omitempty"`
+	CommandLine []string          `json:"command_line"`
+	Environment map[string]string `json:"environment,omitempty"` // Sensitive values are redacted
+	OpenFiles   *int              `json:"open_files,omitempty"`  // Number of open file descriptors, omitted if not readable
+	Cgroups     []string          `json:"cgroups"`
+	VmRSSKB     int64             `json:"vm_rss_kb"`
+	VmSizeKB    int64             `json:"vm_size_kb"`
+}
+
+// ProcessSignalRequest represents a request to send a signal to a process
+type ProcessSignalRequest struct {
+	Signal string `json:"signal" validate:"required"` // Signal name (e.g. "TERM", "SIGHUP") or number
+}
+
+// ProcessReniceRequest represents a request to change the scheduling priority of a process
+type ProcessReniceRequest struct {
+	Priority int `json:"priority"` // Nice value between -20 (highest priority) and 19 (lowest priority)
+}
+
+// ProcessActionResult describes the outcome of an action performed on a process
+type ProcessActionResult struct {
+	PID     int    `json:"pid"`
+	Action  string `json:"action"`           // "signal", "kill" or "renice"
+	Signal  string `json:"signal,omitempty"` // Signal that was sent, for signal and kill actions
+	Nice    *int   `json:"nice,omitempty"`   // Nice value after the action, for renice actions
+	Running bool   `json:"running"`          // Whether the process still exists after the action
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// processListCommand prints the remote epoch followed by typed ps columns. The user column is widened
+// so long user names are not truncated, and etimes gives the elapsed seconds since the process started.
+const processListCommand = "date +%s; ps -eo pid=,ppid=,user:32=,pcpu=,pmem=,vsz=,rss=,tty=,stat=,etimes=,time=,args="
+
+// procProcessListScript prints the same columns as processListCommand from /proc, for servers whose
+// ps does not accept procps columns, such as BusyBox. CPU and memory percentages are averaged over the
+// lifetime of the process like procps does, and the state lacks the BSD-style modifier flags.
+const procProcessListScript = `date +%s
+up=$(cut -d' ' -f1 /proc/uptime)
+hz=$(getconf CLK_TCK 2>/dev/null || echo 100)
+page=$(getconf PAGESIZE 2>/dev/null || echo 4096)
+mem=$(awk '/^MemTotal:/ { print $2 }' /proc/meminfo)
+for d in /proc/[0-9]*; do
+  read -r stat 2>/dev/null < "$d/stat" || continue
+  uid=$(awk '/^Uid:/ { print $2; exit }' "$d/status" 2>/dev/null)
+  args=$(tr '\0' ' ' 2>/dev/null < "$d/cmdline")
+  printf '%s\t%s\t%s\n' "$uid" "$stat" "$args"
+done | awk -F'\t' -v up="$up" -v hz="$hz" -v page="$page" -v mem="$mem" '
+BEGIN { while ((getline line < "/etc/passwd") > 0) { split(line, f, ":"); users[f[3]] = f[1] } }
+{
+  # The command name is in parentheses and may itself contain spaces and parentheses
+  lp = index($2, "("); rp = 0
+  for (i = length($2); i > lp; i--) if (substr($2, i, 1) == ")") { rp = i; break }
+  if (!lp || !rp) next
+  comm = substr($2, lp + 1, rp - lp - 1)
+  split(substr($2, rp + 2), s, " ")
+
+  cpu = (s[12] + s[13]) / hz
+  elapsed = up - s[20] / hz; if (elapsed < 1) elapsed = 1
+  rss = s[22] * page / 1024
+
+  tty = "?"; major = int(s[5] / 256) % 4096; minor = s[5] % 256 + int(s[5] / 1048576) * 256
+  if (major >= 136 && major <= 143) tty = "pts/" ((major - 136) * 256 + minor)
+  else if (major == 4) tty = "tty" minor
+
+  user = ($1 in users) ? users[$1] : $1
+  args = $3; sub(/ +$/, "", args); if (args == "") args = "[" comm "]"
+  t = int(cpu)
+  printf "%d %d %s %.1f %.1f %d %d %s %s %d %02d:%02d:%02d %s\n", $2, s[2], user, 100 * cpu / elapsed,
+    (mem > 0 ? 100 * rss / mem : 0), s[21] / 1024, rss, tty, s[1], elapsed, int(t / 3600), int(t % 3600 / 60), t % 60, args
+}'`
+
+// Process list paging defaults
+const (
+	DefaultProcessPageSize = 100
+	MaxProcessPageSize     = 1000
+)
+
+// ErrInvalidProcessQuery is returned when the process list query cannot be applied
+var ErrInvalidProcessQuery = errors.New("invalid process query")
+
+// processSorters compares two processes for each supported sort key, in ascending order
+var processSorters = map[string]func(a, b *ProcessInfo) bool{
+	"cpu":   func(a, b *ProcessInfo) bool { return a.CPU < b.CPU },
+	"mem":   func(a, b *ProcessInfo) bool { return a.Mem < b.Mem },
+	"rss":   func(a, b *ProcessInfo) bool { return a.RSS < b.RSS },
+	"start": func(a, b *ProcessInfo) bool { return a.Start.Before(b.Start) },
+	"pid":   func(a, b *ProcessInfo) bool { return a.PID < b.PID },
+}
+
+// compileProcessQuery validates the query, applies defaults and compiles the command pattern
+func compileProcessQuery(query *ProcessQuery) (*regexp.Regexp, error) {
+	if query.SortBy == "" {
+		query.SortBy = "pid"
+	}
+	if _, ok := processSorters[query.SortBy]; !ok {
+		return nil, fmt.Errorf("%w: unsupported sort key %q (use cpu, mem, rss, start or pid)", ErrInvalidProcessQuery, query.SortBy)
+	}
+
+	if query.Page < 1 || query.Unpaged {
+		query.Page = 1
+	}
+	if query.Unpaged {
+		query.PageSize = 0
+	} else if query.PageSize < 1 {
+		query.PageSize = DefaultProcessPageSize
+	}
+	if query.PageSize > MaxProcessPageSize {
+		query.PageSize = MaxProcessPageSize
+	}
+
+	query.States = strings.ToUpper(strings.NewReplacer(",", "", " ", "").Replace(query.States))
+
+	if query.Command == "" {
+		return nil, nil
+	}
+
+	pattern, err := regexp.Compile(query.Command)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid command pattern: %v", ErrInvalidProcessQuery, err)
+	}
+	return pattern, nil
+}
+
+// buildProcessList filters, sorts and paginates processes, nesting them in tree mode
+func buildProcessList(processes []ProcessInfo, query ProcessQuery, commandPattern *regexp.Regexp) *ProcessList {
+	// Filter
+	filtered := make([]ProcessInfo, 0, len(processes))
+	for _, process := range processes {
+		if query.User != "" && process.User != query.User {
+			continue
+		}
+		if query.States != "" && (process.Stat == "" || !strings.ContainsRune(query.States, rune(process.Stat[0]))) {
+			continue
+		}
+		if commandPattern != nil && !commandPattern.MatchString(process.CMD) {
+			continue
+		}
+		filtered = append(filtered, process)
+	}
+
+	// Sort (and nest) the result
+	less := processSorters[query.SortBy]
+	sortProcesses := func(list []ProcessInfo) {
+		sort.SliceStable(list, func(i, j int) bool {
+			if query.Descending {
+				return less(&list[j], &list[i])
+			}
+			return less(&list[i], &list[j])
+		})
+	}
+
+	items := filtered
+	if query.Tree {
+		items = buildProcessTree(filtered, sortProcesses)
+	} else {
+		sortProcesses(items)
+	}
+
+	// Paginate
+	list := &ProcessList{
+		Total:     len(items),
+		Page:      query.Page,
+		PageSize:  query.PageSize,
+		Tree:      query.Tree,
+		Processes: []ProcessInfo{},
+	}
+
+	if query.Unpaged {
+		list.PageSize = len(items)
+		list.Processes = items
+		return list
+	}
+
+	start := (query.Page - 1) * query.PageSize
+	if start < len(items) {
+		end := start + query.PageSize
+		if end > len(items) {
+			end = len(items)
+		}
+		list.Processes = items[start:end]
+	}
+
+	return list
+}
+
+// buildProcessTree nests processes under their parents. Processes whose parent is not part of the
+// list become roots, so filtering never hides a matching process.
+func buildProcessTree(processes []ProcessInfo, sortProcesses func([]ProcessInfo)) []ProcessInfo {
+	present := make(map[int]bool, len(processes))
+	children := make(map[int][]ProcessInfo)
+	for _, process := range processes {
+		present[process.PID] = true
+	}
+
+	var roots []ProcessInfo
+	for _, process := range processes {
+		// The kernel reports PID 0 as parent of init and kthreadd
+		if process.PPID != process.PID && present[process.PPID] {
+			children[process.PPID] = append(children[process.PPID], process)
+		} else {
+			roots = append(roots, process)
+		}
+	}
+
+	var attach func(list []ProcessInfo, depth int) []ProcessInfo
+	attach = func(list []ProcessInfo, depth int) []ProcessInfo {
+		sortProcesses(list)
+		for i := range list {
+			// Guard against PID reuse producing cycles in malformed input
+			if kids, ok := children[list[i].PID]; ok && depth < len(processes) {
+				delete(children, list[i].PID)
+				list[i].Children = attach(kids, depth+1)
+			}
+		}
+		return list
+	}
+
+	return attach(roots, 0)
+}
@@ -0,0 +1,51 @@
+package server
+
+import "time"
+
+// Severities of security findings
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+// Outcomes of security checks
+const (
+	CheckPassed  = "pass"
+	CheckFailed  = "fail"
+	CheckUnknown = "unknown" // The check could not be evaluated, usually for lack of privileges
+)
+
+// SecurityFinding is the result of a security check. Failed checks deduct their penalty from the
+// audit score.
+type SecurityFinding struct {
+	ID          string   `json:"id"`       // Stable check identifier, e.g. "ssh-root-login"
+	Category    string   `json:"category"` // ssh, filesystem, packages, authentication or docker
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`   // pass, fail or unknown
+	Severity    string   `json:"severity"` // Severity of the issue when the check fails
+	Penalty     int      `json:"penalty"`  // Points deducted from the score
+	Details     string   `json:"details"`
+	Evidence    []string `json:"evidence,omitempty"` // Offending paths, settings or sources, truncated
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// SecurityAuditSummary counts the failed checks by severity
+type SecurityAuditSummary struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Passed   int `json:"passed"`
+	Unknown  int `json:"unknown"`
+}
+
+// SecurityAudit is the result of the read-only security checks of a server
+type SecurityAudit struct {
+	Score       int                  `json:"score"`      // 100 minus the penalties of failed checks, at least 0
+	Privileged  bool                 `json:"privileged"` // Checks ran as root or through passwordless sudo
+	Summary     SecurityAuditSummary `json:"summary"`
+	Findings    []SecurityFinding    `json:"findings"` // Failed checks first, by severity
+	CollectedAt time.Time            `json:"collected_at"`
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
 )
 
 // Common errors
@@ -17,6 +22,12 @@ var (
 type SessionRepository interface {
 	// RunCommand executes a command on the SSH session
 	RunCommand(ctx context.Context, sessionID string, command string) (string, error)
+
+	// StreamCommand executes a long-running command and streams its output to the writer
+	StreamCommand(ctx context.Context, sessionID string, command string, stdout io.Writer) error
+
+	// RunCommandWithInput executes a command on the SSH session, feeding the reader to its standard input
+	RunCommandWithInput(ctx context.Context, sessionID string, command string, stdin io.Reader) (string, error)
 }
 
 // Service defines the server details service
@@ -24,18 +35,72 @@ type Service interface {
 	// GetBasicDetails retrieves basic server information
 	GetBasicDetails(ctx context.Context, sessionID string) (*ServerDetails, error)
 
+	// GetCapabilities retrieves the userland profile of the server, probing it once per session
+	GetCapabilities(ctx context.Context, sessionID string, refresh bool) (*Capabilities, error)
+
+	// GetHostFacts retrieves a consolidated inventory of the server, cached per session
+	GetHostFacts(ctx context.Context, sessionID string, refresh bool) (*HostFacts, error)
+
 	// GetCPUInfo retrieves CPU information
 	GetCPUInfo(ctx context.Context, sessionID string) ([]CPUInfo, error)
 
-	// GetDiskUsage retrieves disk usage information
-	GetDiskUsage(ctx context.Context, sessionID string) ([]DiskUsage, error)
+	// GetCPUUsage samples CPU utilization over the given interval
+	GetCPUUsage(ctx context.Context, sessionID string, interval time.Duration) (*CPUUsage, error)
+
+	// StreamCPUUsage continuously samples CPU utilization until the context is cancelled
+	StreamCPUUsage(ctx context.Context, sessionID string, interval time.Duration, onUsage func(*CPUUsage) error) error
+
+	// GetMemoryUsage retrieves memory and swap usage
+	GetMemoryUsage(ctx context.Context, sessionID string) (*MemoryUsage, error)
+
+	// GetDiskUsage retrieves byte-accurate space and inode usage of the mounted filesystems
+	GetDiskUsage(ctx context.Context, sessionID string, filter DiskUsageFilter) ([]DiskUsage, error)
+
+	// GetStorageInfo retrieves block devices, LVM volumes and software RAID arrays in one call
+	GetStorageInfo(ctx context.Context, sessionID string) (*StorageInfo, error)
+
+	// GetBlockDevices retrieves disks, partitions and other block devices from lsblk
+	GetBlockDevices(ctx context.Context, sessionID string) ([]BlockDevice, error)
+
+	// GetLVMInfo retrieves LVM physical volumes, volume groups and logical volumes
+	GetLVMInfo(ctx context.Context, sessionID string) (*LVMInfo, error)
+
+	// GetRAIDInfo retrieves software RAID arrays from /proc/mdstat, flagging degraded arrays
+	GetRAIDInfo(ctx context.Context, sessionID string) (*RAIDInfo, error)
+
+	// GetNetworkInfo retrieves network interfaces, traffic rates, routes and DNS resolvers
+	GetNetworkInfo(ctx context.Context, sessionID string, interval time.Duration) (*NetworkInfo, error)
+
+	// GetListeningSockets retrieves the listening TCP and UDP sockets with their owning processes
+	GetListeningSockets(ctx context.Context, sessionID string, filter ListeningSocketFilter) ([]ListeningSocket, error)
 
 	// GetRunningProcesses retrieves information about running processes
-	GetRunningProcesses(ctx context.Context, sessionID string) ([]ProcessInfo, error)
+	GetRunningProcesses(ctx context.Context, sessionID string, query ProcessQuery) (*ProcessList, error)
+
+	// GetProcessDetail retrieves detailed information about a single process
+	GetProcessDetail(ctx context.Context, sessionID string, pid int) (*ProcessDetail, error)
+
+	// SignalProcess sends a signal to a process
+	SignalProcess(ctx context.Context, sessionID string, pid int, signal string) (*ProcessActionResult, error)
+
+	// KillProcess forcefully terminates a process with SIGKILL
+	KillProcess(ctx context.Context, sessionID string, pid int) (*ProcessActionResult, error)
+
+	// ReniceProcess changes the scheduling priority of a process
+	ReniceProcess(ctx context.Context, sessionID string, pid int, priority int) (*ProcessActionResult, error)
 
-	// GetInstalledLibraries retrieves information about installed libraries
+	// GetInstalledLibraries retrieves the installed packages from the system package manager
 	GetInstalledLibraries(ctx context.Context, sessionID string) ([]Library, error)
 
+	// GetPendingUpdates lists the packages with available updates, flagging security updates
+	GetPendingUpdates(ctx context.Context, sessionID string, refresh bool) (*PendingUpdates, error)
+
+	// GetSecurityAudit runs read-only security checks and scores the findings
+	GetSecurityAudit(ctx context.Context, sessionID string) (*SecurityAudit, error)
+
+	// RunPackageOperation installs, removes or upgrades packages, streaming the package manager output
+	RunPackageOperation(ctx context.Context, sessionID string, operation string, request PackageOperationRequest, onLine func(string) error) (*PackageOperationResult, error)
+
 	// ListFileSystem retrieves a listing of files and directories
 	ListFileSystem(ctx context.Context, sessionID string, path string, recursive bool, includeHidden bool) (*FileSystemListing, error)
 
@@ -44,48 +109,66 @@ type Service interface {
 
 	// SearchFiles searches for files matching a pattern
 	SearchFiles(ctx context.Context, sessionID string, path string, pattern string, maxDepth int) ([]FileSystemEntry, error)
+
+	// OpenFile opens a regular file for download, reading its size, modification time and MIME type.
+	// The file is read as the SSH user, or through sudo when requested. The caller must close the file.
+	OpenFile(ctx context.Context, sessionID string, path string, sudo bool) (*RemoteFile, error)
+
+	// UploadFile streams content to a temporary file next to the target, verifies its checksum and
+	// renames it into place
+	UploadFile(ctx context.Context, sessionID string, upload FileUpload, content io.Reader) (*FileUploadResult, error)
+
+	// AnalyzeDirectoryUsage builds a size-sorted directory tree or lists the largest files below a path
+	AnalyzeDirectoryUsage(ctx context.Context, sessionID string, query DirectoryUsageQuery) (*DirectoryUsageResult, error)
+
+	// StartDirectoryAnalysis runs a directory usage analysis in the background
+	StartDirectoryAnalysis(ctx context.Context, sessionID string, query DirectoryUsageQuery) (*DirectoryAnalysis, error)
+
+	// GetDirectoryAnalysis retrieves the state and, once completed, the result of a background analysis
+	GetDirectoryAnalysis(ctx context.Context, sessionID string, id string) (*DirectoryAnalysis, error)
+
+	// CancelDirectoryAnalysis stops a running background analysis
+	CancelDirectoryAnalysis(ctx context.Context, sessionID string, id string) (*DirectoryAnalysis, error)
 }
 
 type service struct {
-	sessionRepo SessionRepository
+	sessionRepo  SessionRepository
+	analyses     *analysisStore
+	capabilities *sessionCache[*Capabilities]
+	facts        *sessionCache[*HostFacts]
 }
 
 // NewService creates a new server details service
 func NewService(sessionRepo SessionRepository) Service {
 	return &service{
-		sessionRepo: sessionRepo,
+		sessionRepo:  sessionRepo,
+		analyses:     newAnalysisStore(),
+		capabilities: newSessionCache[*Capabilities](capabilitiesTTL),
+		facts:        newSessionCache[*HostFacts](hostFactsTTL),
 	}
 }
 
+// basicDetailsScript prints the hostname, uname -a, the kernel release and uptime in one round trip
+const basicDetailsScript = `echo '### hostname'; hostname
+echo '### os'; uname -a
+echo '### kernel'; uname -r
+echo '### uptime'; uptime`
+
 // GetBasicDetails implements the Service interface
 func (s *service) GetBasicDetails(ctx context.Context, sessionID string) (*ServerDetails, error) {
-	// Execute commands to get basic server information
-	hostname, err := s.sessionRepo.RunCommand(ctx, sessionID, "hostname")
-	if err != nil {
-		return nil, err
-	}
-
-	osInfo, err := s.sessionRepo.RunCommand(ctx, sessionID, "uname -a")
-	if err != nil {
-		return nil, err
-	}
-
-	kernelVersion, err := s.sessionRepo.RunCommand(ctx, sessionID, "uname -r")
-	if err != nil {
-		return nil, err
-	}
-
-	uptime, err := s.sessionRepo.RunCommand(ctx, sessionID, "uptime")
+	// Execute a single script to get basic server information
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, basicDetailsScript)
 	if err != nil {
 		return nil, err
 	}
+	sections := shell.ParseSections(output)
 
 	// Create and return server details
 	return &ServerDetails{
-		Hostname:      strings.TrimSpace(hostname),
-		OS:            strings.TrimSpace(osInfo),
-		KernelVersion: strings.TrimSpace(kernelVersion),
-		Uptime:        strings.TrimSpace(uptime),
+		Hostname:      strings.TrimSpace(sections["hostname"]),
+		OS:            strings.TrimSpace(sections["os"]),
+		KernelVersion: strings.TrimSpace(sections["kernel"]),
+		Uptime:        strings.TrimSpace(sections["uptime"]),
 	}, nil
 }
 
@@ -101,28 +184,35 @@ func (s *service) GetCPUInfo(ctx context.Context, sessionID string) ([]CPUInfo,
 	return parseCPUInfo(cpuInfoOutput), nil
 }
 
-// GetDiskUsage implements the Service interface
-func (s *service) GetDiskUsage(ctx context.Context, sessionID string) ([]DiskUsage, error) {
-	// Execute command to get disk usage
-	diskUsageOutput, err := s.sessionRepo.RunCommand(ctx, sessionID, "df -h")
+// GetRunningProcesses implements the Service interface
+func (s *service) GetRunningProcesses(ctx context.Context, sessionID string, query ProcessQuery) (*ProcessList, error) {
+	// Validate the query before touching the server
+	commandPattern, err := compileProcessQuery(&query)
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse disk usage
-	return parseDiskUsage(diskUsageOutput), nil
-}
+	// Read /proc directly when ps lacks the procps columns, as BusyBox ps does
+	command := processListCommand
+	caps, err := s.sessionCapabilities(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !caps.PSFormat && caps.ProcFS {
+		command = procProcessListScript
+	}
 
-// GetRunningProcesses implements the Service interface
-func (s *service) GetRunningProcesses(ctx context.Context, sessionID string) ([]ProcessInfo, error) {
-	// Execute command to get running processes
-	processesOutput, err := s.sessionRepo.RunCommand(ctx, sessionID, "ps aux")
+	// Execute command to get running processes with typed columns and the remote clock for start times
+	processesOutput, err := s.sessionRepo.RunCommand(ctx, sessionID, command)
 	if err != nil {
 		return nil, err
 	}
 
 	// Parse processes info
-	return parseProcessInfo(processesOutput), nil
+	processes := parseProcessInfo(processesOutput)
+
+	// Filter, sort, nest and paginate
+	return buildProcessList(processes, query, commandPattern), nil
 }
 
 // parseCPUInfo parses the output of 'cat /proc/cpuinfo'
@@ -211,183 +301,57 @@ func parseCPUInfo(cpuInfoData string) []CPUInfo {
 	return cpuInfos
 }
 
-// parseDiskUsage parses the output of 'df -h'
-func parseDiskUsage(dfOutput string) []DiskUsage {
-	var diskUsages []DiskUsage
-	lines := strings.Split(dfOutput, "\n")
-
-	// Skip the first line (headers)
-	for _, line := range lines[1:] {
-		if line == "" {
-			continue
-		}
-
-		// Split the line by spaces
-		fields := strings.Fields(line)
-		if len(fields) < 6 {
-			continue
-		}
-
-		diskUsage := DiskUsage{
-			Filesystem:    fields[0],
-			Size:          fields[1],
-			Used:          fields[2],
-			Available:     fields[3],
-			UsePercentage: fields[4],
-			MountedOn:     fields[5],
-		}
-
-		diskUsages = append(diskUsages, diskUsage)
-	}
-
-	return diskUsages
-}
-
-// parseProcessInfo parses the output of 'ps aux'
+// parseProcessInfo parses the output of processListCommand: the remote epoch on the first line,
+// followed by 'ps -eo pid,ppid,user,pcpu,pmem,vsz,rss,tty,stat,etimes,time,args' rows without headers
 func parseProcessInfo(psOutput string) []ProcessInfo {
 	var processes []ProcessInfo
 	lines := strings.Split(psOutput, "\n")
 
-	// Skip the first line (headers)
-	for _, line := range lines[1:] {
-		if line == "" {
-			continue
-		}
-
-		// Split the line by spaces
-		fields := strings.Fields(line)
-		if len(fields) < 11 {
-			continue
-		}
-
-		process := ProcessInfo{
-			User:  fields[0],
-			PID:   fields[1],
-			CPU:   fields[2],
-			VSZ:   fields[3],
-			RSS:   fields[4],
-			TTY:   fields[5],
-			Stat:  fields[6],
-			Start: fields[7],
-			Time:  fields[8],
-			// Combine remaining fields for command
-			CMD: strings.Join(fields[10:], " "),
-		}
-
-		processes = append(processes, process)
+	// The first line holds the remote clock, used to turn elapsed seconds into start times
+	now, err := strconv.ParseInt(strings.TrimSpace(lines[0]), 10, 64)
+	if err != nil {
+		now = time.Now().Unix()
 	}
 
-	return processes
-}
-
-// parseLibrariesInfo parses the output of package manager queries
-func parseLibrariesInfo(librariesOutput string) []Library {
-	var libraries []Library
-	lines := strings.Split(librariesOutput, "\n")
-
-	for _, line := range lines {
+	for _, line := range lines[1:] {
 		if line == "" {
 			continue
 		}
 
 		// Split the line by spaces
 		fields := strings.Fields(line)
-		if len(fields) < 3 {
+		if len(fields) < 12 {
 			continue
 		}
 
-		// Parse fields based on standard format
-		name := fields[0]
-		version := fields[1]
-
-		// Set default values
-		status := "unknown"
-		arch := "unknown"
-
-		// Check for status (may be formatted differently based on package manager)
-		if len(fields) >= 4 && strings.Contains(fields[2], "install") {
-			status = "installed"
-			if len(fields) >= 4 {
-				arch = fields[3]
-			}
-		} else if len(fields) >= 3 {
-			if strings.Contains(fields[2], "install") {
-				status = "installed"
-				if len(fields) >= 4 {
-					arch = fields[3]
-				}
-			} else {
-				// Last field could be architecture
-				arch = fields[2]
-			}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
 		}
 
-		library := Library{
-			Name:    name,
-			Version: version,
-			Status:  status,
-			Arch:    arch,
+		process := ProcessInfo{
+			PID:  pid,
+			User: fields[2],
+			TTY:  fields[7],
+			Stat: fields[8],
+			Time: fields[10],
+			// Combine remaining fields for command
+			CMD: strings.Join(fields[11:], " "),
 		}
-
-		libraries = append(libraries, library)
-	}
-
-	return libraries
-}
-
-// GetInstalledLibraries implements the Service interface
-func (s *service) GetInstalledLibraries(ctx context.Context, sessionID string) ([]Library, error) {
-	// Try to detect the Linux distribution
-	distroCmd := "cat /etc/os-release | grep -E '^ID=' | cut -d'=' -f2 | tr -d '\"'"
-	distro, err := s.sessionRepo.RunCommand(ctx, sessionID, distroCmd)
-	if err != nil {
-		// Fallback if we can't detect the distribution
-		distro = ""
-	}
-	distro = strings.TrimSpace(distro)
-
-	var command string
-	switch strings.ToLower(distro) {
-	case "ubuntu", "debian", "pop", "mint", "elementary", "kali", "zorin":
-		// Debian-based distributions
-		command = "dpkg-query -W -f='${Package} ${Version} ${Status} ${Architecture}\n'"
-	case "fedora", "rhel", "centos", "rocky", "alma":
-		// Red Hat-based distributions
-		command = "rpm -qa --queryformat '%{NAME} %{VERSION} installed %{ARCH}\n'"
-	case "arch", "manjaro", "endeavouros":
-		// Arch-based distributions
-		command = "pacman -Q | awk '{print $1 \" \" $2 \" installed \"}'$(uname -m)"
-	case "opensuse", "suse":
-		// SUSE-based distributions
-		command = "rpm -qa --queryformat '%{NAME} %{VERSION} installed %{ARCH}\n'"
-	default:
-		// Try to detect package manager if distro detection failed
-		aptCheck, _ := s.sessionRepo.RunCommand(ctx, sessionID, "which apt &>/dev/null && echo found || echo not found")
-		if strings.Contains(aptCheck, "found") {
-			command = "dpkg-query -W -f='${Package} ${Version} ${Status} ${Architecture}\n'"
-		} else {
-			rpmCheck, _ := s.sessionRepo.RunCommand(ctx, sessionID, "which rpm &>/dev/null && echo found || echo not found")
-			if strings.Contains(rpmCheck, "found") {
-				command = "rpm -qa --queryformat '%{NAME} %{VERSION} installed %{ARCH}\n'"
-			} else {
-				pacmanCheck, _ := s.sessionRepo.RunCommand(ctx, sessionID, "which pacman &>/dev/null && echo found || echo not found")
-				if strings.Contains(pacmanCheck, "found") {
-					command = "pacman -Q | awk '{print $1 \" \" $2 \" installed \"}'$(uname -m)"
-				} else {
-					return nil, errors.New("could not detect a supported package manager")
-				}
-			}
+		process.PPID, _ = strconv.Atoi(fields[1])
+		process.CPU, _ = strconv.ParseFloat(fields[3], 64)
+		process.Mem, _ = strconv.ParseFloat(fields[4], 64)
+		process.VSZ, _ = strconv.ParseInt(fields[5], 10, 64)
+		process.RSS, _ = strconv.ParseInt(fields[6], 10, 64)
+
+		if elapsed, err := strconv.ParseInt(fields[9], 10, 64); err == nil {
+			process.Start = time.Unix(now-elapsed, 0).UTC()
 		}
-	}
 
-	// Execute the appropriate command based on the detected distribution/package manager
-	librariesOutput, err := s.sessionRepo.RunCommand(ctx, sessionID, command)
-	if err != nil {
-		return nil, err
+		processes = append(processes, process)
 	}
 
-	// Parse the libraries info
-	return parseLibrariesInfo(librariesOutput), nil
+	return processes
 }
 
 // ListFileSystem implements the Service interface
@@ -395,12 +359,17 @@ func (s *service) ListFileSystem(ctx context.Context, sessionID string, path str
 	// Sanitize the path to prevent command injection
 	sanitizedPath := strings.Trim(sanitizePath(path), "'")
 
+	// Get the userland profile to pick the command dialect
+	caps, err := s.sessionCapabilities(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
 	var entries []FileSystemEntry
-	var err error
 
 	// Get directory contents based on recursive flag
 	if recursive {
-		entries, err = getRecursiveDirectoryContents(ctx, s.sessionRepo, sessionID, sanitizedPath, includeHidden)
+		entries, err = getRecursiveDirectoryContents(ctx, s.sessionRepo, sessionID, caps, sanitizedPath, includeHidden)
 	} else {
 		entries, err = getNonRecursiveDirectoryContents(ctx, s.sessionRepo, sessionID, sanitizedPath, includeHidden)
 	}
@@ -424,14 +393,21 @@ func (s *service) GetFileDetails(ctx context.Context, sessionID string, path str
 	// Sanitize the path to prevent command injection
 	sanitizedPath := strings.Trim(sanitizePath(path), "'")
 
+	// Get the userland profile to pick the command dialect
+	caps, err := s.sessionCapabilities(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get detailed file information
-	fileInfo, err := getEnhancedFileInfo(ctx, s.sessionRepo, sessionID, sanitizedPath)
+	fileInfo, err := getEnhancedFileInfo(ctx, s.sessionRepo, sessionID, caps, sanitizedPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file details: %w", err)
 	}
 
-	// For files, get additional information such as mime type and a preview (for text files)
-	if fileInfo.Type == "file" {
+	// For files, get additional information such as mime type and a preview (for text files).
+	// Minimal systems such as Alpine ship without the file utility.
+	if fileInfo.Type == "file" && caps.HasTool("file") {
 		// Get file mime type
 		mimeTypeCmd := fmt.Sprintf("file --mime-type -b %s", sanitizePath(sanitizedPath))
 		mimeTypeOutput, err := s.sessionRepo.RunCommand(ctx, sessionID, mimeTypeCmd)
@@ -454,72 +430,25 @@ func (s *service) GetFileDetails(ctx context.Context, sessionID string, path str
 
 // SearchFiles implements the Service interface
 func (s *service) SearchFiles(ctx context.Context, sessionID string, path string, pattern string, maxDepth int) ([]FileSystemEntry, error) {
-	// Sanitize the path and pattern to prevent command injection
+	// Sanitize the path to prevent command injection
 	sanitizedPath := strings.Trim(sanitizePath(path), "'")
-	sanitizedPattern := strings.ReplaceAll(pattern, "'", "'\\''") // Escape single quotes
 
-	// Build the find command for searching files
-	// The -maxdepth parameter limits the search depth to avoid searching the entire filesystem
-	// We're searching for files that match the pattern in either their name or content
-	findNameCmd := fmt.Sprintf("find %s -maxdepth %d -type f -name '*%s*' -o -type d -name '*%s*'",
-		sanitizePath(sanitizedPath), maxDepth, sanitizedPattern, sanitizedPattern)
-
-	// Execute the find command
-	nameOutput, err := s.sessionRepo.RunCommand(ctx, sessionID, findNameCmd)
+	// Get the userland profile to pick the command dialect
+	caps, err := s.sessionCapabilities(ctx, sessionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search files by name: %w", err)
-	}
-
-	// For content search (grep), we'll search only in text files for the pattern
-	// This can be resource-intensive, so we'll limit it to files within the maxdepth
-	grepCmd := fmt.Sprintf("find %s -maxdepth %d -type f -exec grep -l '%s' {} \\; 2>/dev/null",
-		sanitizePath(sanitizedPath), maxDepth, sanitizedPattern)
-
-	// Execute the grep command
-	contentOutput, err := s.sessionRepo.RunCommand(ctx, sessionID, grepCmd)
-	// We don't check for error here as grep might return non-zero if no matches are found
-
-	// Combine and deduplicate the results
-	var allPaths []string
-
-	// Process the name search results
-	if nameOutput != "" {
-		namePaths := strings.Split(nameOutput, "\n")
-		for _, path := range namePaths {
-			if path != "" {
-				allPaths = append(allPaths, path)
-			}
-		}
+		return nil, err
 	}
 
-	// Process the content search results
-	if contentOutput != "" {
-		contentPaths := strings.Split(contentOutput, "\n")
-		for _, path := range contentPaths {
-			if path != "" {
-				found := false
-				for _, existingPath := range allPaths {
-					if existingPath == path {
-						found = true
-						break
-					}
-				}
-				if !found {
-					allPaths = append(allPaths, path)
-				}
-			}
-		}
-	}
+	// Match files and directories by name, or files by content. The -maxdepth parameter limits the
+	// search depth to avoid searching the entire filesystem, and find reports every match only once.
+	findArgs := fmt.Sprintf(`%s -maxdepth %d \( -name %s \( -type f -o -type d \) -o -type f -exec grep -q -e %s {} \; \)`,
+		sanitizePath(sanitizedPath), maxDepth, shell.Quote("*"+pattern+"*"), shell.Quote(pattern))
 
-	// Get detailed information for each found file/directory
-	var entries []FileSystemEntry
-	for _, filePath := range allPaths {
-		fileInfo, err := getEnhancedFileInfo(ctx, s.sessionRepo, sessionID, filePath)
-		if err != nil {
-			continue
-		}
-		entries = append(entries, *fileInfo)
+	// Execute find and stat in a single round trip
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, statFindCommand(caps, findArgs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search files: %w", err)
 	}
 
-	return entries, nil
+	return parseStatEntries(output, caps), nil
 }
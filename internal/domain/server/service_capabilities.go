@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// capabilitiesTTL bounds how long a probed profile is kept
+const capabilitiesTTL = 12 * time.Hour
+
+// capabilitiesScript probes the userland of the server. BusyBox applets print "BusyBox" in their
+// help text, GNU coreutils identify themselves with --version.
+const capabilitiesScript = detectPackageManagerScript + `echo '### os'
+( . /etc/os-release 2>/dev/null; echo "$ID"; echo "$VERSION_ID" )
+echo '### userland'
+if ls --version 2>/dev/null | grep -q GNU; then echo gnu
+elif ls --help 2>&1 | grep -q BusyBox; then echo busybox
+elif [ "$(uname -s)" != Linux ]; then echo bsd
+else echo unknown; fi
+echo '### features'
+stat -c %n / >/dev/null 2>&1 && echo stat-format
+find / -maxdepth 0 -exec true {} + >/dev/null 2>&1 && echo find-exec-plus
+ps -eo pid=,etimes= >/dev/null 2>&1 && echo ps-format
+df --output=source / >/dev/null 2>&1 && echo df-output
+[ -r /proc/self/stat ] && echo procfs
+[ "$(id -u)" -eq 0 ] && echo root
+sudo -n true 2>/dev/null && echo sudo
+echo '### tools'
+for t in ss netstat ip lsblk systemctl journalctl docker file findmnt getent; do
+  command -v "$t" >/dev/null 2>&1 && echo "$t"
+done
+true`
+
+// GetCapabilities implements the Service interface
+func (s *service) GetCapabilities(ctx context.Context, sessionID string, refresh bool) (*Capabilities, error) {
+	if !refresh {
+		if profile, ok := s.capabilities.get(sessionID); ok {
+			return profile, nil
+		}
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, capabilitiesScript)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := parseCapabilities(output)
+	s.capabilities.put(sessionID, profile)
+
+	return profile, nil
+}
+
+// sessionCapabilities returns the cached profile of a session, probing the server on first use
+func (s *service) sessionCapabilities(ctx context.Context, sessionID string) (*Capabilities, error) {
+	return s.GetCapabilities(ctx, sessionID, false)
+}
+
+// parseCapabilities builds a profile from the probe output
+func parseCapabilities(output string) *Capabilities {
+	sections := shell.ParseSections(output)
+
+	profile := &Capabilities{
+		PackageManager: strings.TrimSpace(sections["manager"]),
+		Userland:       strings.TrimSpace(sections["userland"]),
+		Tools:          []string{},
+		ProbedAt:       time.Now(),
+	}
+	if profile.Userland == "" {
+		profile.Userland = UserlandUnknown
+	}
+
+	osLines := strings.Split(sections["os"], "\n")
+	profile.OSID = strings.TrimSpace(osLines[0])
+	if len(osLines) > 1 {
+		profile.OSVersion = strings.TrimSpace(osLines[1])
+	}
+
+	features := parseNameList(sections["features"])
+	profile.StatFormat = features["stat-format"]
+	profile.FindExecPlus = features["find-exec-plus"]
+	profile.PSFormat = features["ps-format"]
+	profile.DFOutput = features["df-output"]
+	profile.ProcFS = features["procfs"]
+	profile.Root = features["root"]
+	profile.Sudo = features["sudo"]
+
+	for _, line := range strings.Split(sections["tools"], "\n") {
+		if tool := strings.TrimSpace(line); tool != "" {
+			profile.Tools = append(profile.Tools, tool)
+		}
+	}
+
+	return profile
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// cpuSampleSeparator marks the end of one /proc/stat sample in the command output
+const cpuSampleSeparator = "---"
+
+// cpuTimes holds the raw jiffy counters of a single /proc/stat cpu line
+type cpuTimes struct {
+	name                                                  string
+	user, nice, system, idle, iowait, irq, softirq, steal uint64
+}
+
+// total returns the sum of all counters relevant for utilization
+func (t cpuTimes) total() uint64 {
+	return t.user + t.nice + t.system + t.idle + t.iowait + t.irq + t.softirq + t.steal
+}
+
+// cpuSample is a single reading of /proc/stat and /proc/loadavg
+type cpuSample struct {
+	times []cpuTimes
+	load  LoadAverage
+}
+
+// GetCPUUsage implements the Service interface
+func (s *service) GetCPUUsage(ctx context.Context, sessionID string, interval time.Duration) (*CPUUsage, error) {
+	if err := validateSampleInterval(interval); err != nil {
+		return nil, err
+	}
+
+	// Take both samples in a single remote invocation so the interval is measured on the host
+	command := fmt.Sprintf("grep '^cpu' /proc/stat; cat /proc/loadavg; echo '%s'; sleep %s; grep '^cpu' /proc/stat; cat /proc/loadavg; echo '%s'",
+		cpuSampleSeparator, formatSleepSeconds(interval), cpuSampleSeparator)
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, command)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := parseCPUSamples(output)
+	if len(samples) < 2 {
+		return nil, fmt.Errorf("%w: unexpected /proc/stat output", ErrCommandFailed)
+	}
+
+	return computeCPUUsage(samples[0], samples[1], interval, time.Now()), nil
+}
+
+// StreamCPUUsage implements the Service interface
+func (s *service) StreamCPUUsage(ctx context.Context, sessionID string, interval time.Duration, onUsage func(*CPUUsage) error) error {
+	if err := validateSampleInterval(interval); err != nil {
+		return err
+	}
+
+	// Keep sampling on the remote host until the stream is closed
+	command := fmt.Sprintf("while true; do grep '^cpu' /proc/stat; cat /proc/loadavg; echo '%s'; sleep %s; done",
+		cpuSampleSeparator, formatSleepSeconds(interval))
+
+	var previous *cpuSample
+	var lines []string
+
+	return shell.StreamLines(ctx, s.sessionRepo, sessionID, command, func(line string) error {
+		if strings.TrimSpace(line) != cpuSampleSeparator {
+			lines = append(lines, line)
+			return nil
+		}
+
+		sample := parseCPUSample(lines)
+		lines = lines[:0]
+
+		if previous != nil {
+			if err := onUsage(computeCPUUsage(*previous, sample, interval, time.Now())); err != nil {
+				return err
+			}
+		}
+		previous = &sample
+		return nil
+	})
+}
+
+// parseCPUSamples splits the command output into individual samples
+func parseCPUSamples(output string) []cpuSample {
+	var samples []cpuSample
+	var lines []string
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == cpuSampleSeparator {
+			samples = append(samples, parseCPUSample(lines))
+			lines = nil
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return samples
+}
+
+// parseCPUSample parses the cpu lines of /proc/stat followed by the /proc/loadavg line
+func parseCPUSample(lines []string) cpuSample {
+	var sample cpuSample
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// /proc/loadavg: "0.52 0.58 0.59 1/467 12345"
+		if !strings.HasPrefix(fields[0], "cpu") {
+			if len(fields) >= 3 {
+				sample.load.Load1, _ = strconv.ParseFloat(fields[0], 64)
+				sample.load.Load5, _ = strconv.ParseFloat(fields[1], 64)
+				sample.load.Load15, _ = strconv.ParseFloat(fields[2], 64)
+			}
+			continue
+		}
+
+		// /proc/stat: "cpu0 user nice system idle iowait irq softirq steal guest guest_nice"
+		if len(fields) < 5 {
+			continue
+		}
+
+		values := make([]uint64, 8)
+		for i := 0; i < len(values) && i+1 < len(fields); i++ {
+			values[i], _ = strconv.ParseUint(fields[i+1], 10, 64)
+		}
+
+		sample.times = append(sample.times, cpuTimes{
+			name:    fields[0],
+			user:    values[0],
+			nice:    values[1],
+			system:  values[2],
+			idle:    values[3],
+			iowait:  values[4],
+			irq:     values[5],
+			softirq: values[6],
+			steal:   values[7],
+		})
+	}
+
+	return sample
+}
+
+// computeCPUUsage derives utilization percentages from two consecutive samples
+func computeCPUUsage(first, second cpuSample, interval time.Duration, timestamp time.Time) *CPUUsage {
+	previous := make(map[string]cpuTimes, len(first.times))
+	for _, t := range first.times {
+		previous[t.name] = t
+	}
+
+	usage := &CPUUsage{
+		Timestamp:   timestamp,
+		IntervalMS:  interval.Milliseconds(),
+		Cores:       []CPUCoreUsage{},
+		LoadAverage: second.load,
+	}
+
+	for _, current := range second.times {
+		before, ok := previous[current.name]
+		if !ok {
+			continue
+		}
+
+		core := cpuTimesDelta(before, current)
+		if current.name == "cpu" {
+			core.CPU = "all"
+			usage.Total = core
+		} else {
+			usage.Cores = append(usage.Cores, core)
+		}
+	}
+
+	return usage
+}
+
+// cpuTimesDelta converts the difference between two counter readings into percentages
+func cpuTimesDelta(before, after cpuTimes) CPUCoreUsage {
+	total := float64(counterDelta(before.total(), after.total()))
+	if total == 0 {
+		return CPUCoreUsage{CPU: after.name, Idle: 100}
+	}
+
+	percent := func(b, a uint64) float64 {
+		return roundHundredths(float64(counterDelta(b, a)) / total * 100)
+	}
+
+	core := CPUCoreUsage{
+		CPU:    after.name,
+		User:   percent(before.user+before.nice, after.user+after.nice),
+		System: percent(before.system+before.irq+before.softirq, after.system+after.irq+after.softirq),
+		IOWait: percent(before.iowait, after.iowait),
+		Steal:  percent(before.steal, after.steal),
+		Idle:   percent(before.idle, after.idle),
+	}
+	core.Usage = roundHundredths(100 - core.Idle - core.IOWait)
+	if core.Usage < 0 {
+		core.Usage = 0
+	}
+
+	return core
+}
+
+// counterDelta returns the increase of a counter, treating resets as zero
+func counterDelta(before, after uint64) uint64 {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// Directory usage analysis bounds
+const (
+	DefaultDirectoryUsageDepth = 3
+	MaxDirectoryUsageDepth     = 10
+	DefaultDirectoryUsageLimit = 20
+	MaxDirectoryUsageLimit     = 1000
+)
+
+// Directory usage errors
+var (
+	ErrInvalidDirectoryQuery = errors.New("invalid directory usage query")
+	ErrDirectoryNotFound     = errors.New("directory not found")
+)
+
+// directoryTreeScript prints the disk usage in kilobytes of every directory down to the given depth,
+// followed by the number of regular files per directory. Both stay on the filesystem of the path and
+// run through passwordless sudo when available so unreadable directories are counted too.
+// The placeholders receive the quoted path and the depth.
+const directoryTreeScript = `p=%[1]s
+[ -d "$p" ] || { echo '### missing'; exit 0; }
+S="` + shell.SudoIfAvailable + `"
+echo '### du'
+$S du -xk -d %[2]d -- "$p" 2>/dev/null
+echo '### counts'
+$S find "$p" -xdev -type f 2>/dev/null | awk -v base="$p" -v depth=%[2]d '
+{
+  root = (base == "/") ? "" : base
+  n = split(substr($0, length(root) + 2), parts, "/")
+  count[base]++
+  prefix = root
+  for (i = 1; i < n && i <= depth; i++) { prefix = prefix "/" parts[i]; count[prefix]++ }
+}
+END { for (d in count) printf "%%d\t%%s\n", count[d], d }'
+true`
+
+// largestFilesScript prints the size and path of the largest regular files below a path. find -printf
+// is a GNU extension, so other implementations fall back to stat. The placeholders receive the quoted
+// path and the number of files.
+const largestFilesScript = `p=%[1]s
+[ -d "$p" ] || { echo '### missing'; exit 0; }
+S="` + shell.SudoIfAvailable + `"
+echo '### files'
+if find "$p" -maxdepth 0 -printf '' >/dev/null 2>&1; then
+  $S find "$p" -xdev -type f -printf '%%s %%p\n' 2>/dev/null
+else
+  $S find "$p" -xdev -type f -exec stat -c '%%s %%n' {} + 2>/dev/null
+fi | sort -rn | head -n %[2]d
+true`
+
+// AnalyzeDirectoryUsage implements the Service interface
+func (s *service) AnalyzeDirectoryUsage(ctx context.Context, sessionID string, query DirectoryUsageQuery) (*DirectoryUsageResult, error) {
+	if err := normalizeDirectoryUsageQuery(&query); err != nil {
+		return nil, err
+	}
+
+	return s.runDirectoryUsage(ctx, sessionID, query, nil)
+}
+
+// runDirectoryUsage runs the analysis command, counting processed lines in progress when given.
+// The command is streamed so that cancelling the context stops it on the server.
+func (s *service) runDirectoryUsage(ctx context.Context, sessionID string, query DirectoryUsageQuery, progress *int64) (*DirectoryUsageResult, error) {
+	started := time.Now()
+
+	var command string
+	if query.Mode == DirectoryUsageModeLargestFiles {
+		command = fmt.Sprintf(largestFilesScript, shell.Quote(query.Path), query.Limit)
+	} else {
+		command = fmt.Sprintf(directoryTreeScript, shell.Quote(query.Path), query.MaxDepth)
+	}
+
+	sections := make(map[string][]string)
+	section := ""
+	err := shell.StreamLines(ctx, s.sessionRepo, sessionID, command, func(line string) error {
+		if progress != nil {
+			atomic.AddInt64(progress, 1)
+		}
+		if name, found := strings.CutPrefix(line, shell.SectionMarker); found {
+			section = name
+			sections[section] = []string{}
+			return nil
+		}
+		sections[section] = append(sections[section], line)
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	if _, missing := sections["missing"]; missing {
+		return nil, fmt.Errorf("%w: %s", ErrDirectoryNotFound, query.Path)
+	}
+
+	result := &DirectoryUsageResult{
+		Path: query.Path,
+		Mode: query.Mode,
+	}
+	if query.Mode == DirectoryUsageModeLargestFiles {
+		result.LargestFiles = parseLargestFiles(sections["files"])
+	} else {
+		result.Tree = buildDirectoryUsageTree(query.Path, sections["du"], sections["counts"], query.Limit)
+	}
+	result.DurationMS = time.Since(started).Milliseconds()
+
+	return result, nil
+}
+
+// normalizeDirectoryUsageQuery validates the query and applies defaults
+func normalizeDirectoryUsageQuery(query *DirectoryUsageQuery) error {
+	if query.Path == "" || !path.IsAbs(query.Path) {
+		return fmt.Errorf("%w: path must be absolute", ErrInvalidDirectoryQuery)
+	}
+	query.Path = path.Clean(query.Path)
+
+	switch query.Mode {
+	case "":
+		query.Mode = DirectoryUsageModeTree
+	case DirectoryUsageModeTree, DirectoryUsageModeLargestFiles:
+	default:
+		return fmt.Errorf("%w: unsupported mode %q (use tree or largest-files)", ErrInvalidDirectoryQuery, query.Mode)
+	}
+
+	if query.MaxDepth == 0 {
+		query.MaxDepth = DefaultDirectoryUsageDepth
+	}
+	if query.MaxDepth < 1 || query.MaxDepth > MaxDirectoryUsageDepth {
+		return fmt.Errorf("%w: max_depth must be between 1 and %d", ErrInvalidDirectoryQuery, MaxDirectoryUsageDepth)
+	}
+
+	if query.Limit == 0 {
+		query.Limit = DefaultDirectoryUsageLimit
+	}
+	if query.Limit < 1 || query.Limit > MaxDirectoryUsageLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidDirectoryQuery, MaxDirectoryUsageLimit)
+	}
+
+	return nil
+}
+
+// buildDirectoryUsageTree nests the du output under the root path, attaching file counts and keeping
+// the largest children of every directory
+func buildDirectoryUsageTree(root string, duLines []string, countLines []string, limit int) *DirectoryUsageNode {
+	nodes := make(map[string]*DirectoryUsageNode)
+	node := func(dir string) *DirectoryUsageNode {
+		if existing, ok := nodes[dir]; ok {
+			return existing
+		}
+		created := &DirectoryUsageNode{Path: dir, Name: path.Base(dir)}
+		nodes[dir] = created
+		return created
+	}
+	node(root)
+
+	// du: "<kilobytes>\t<path>"
+	for _, line := range duLines {
+		size, dir, found := strings.Cut(line, "\t")
+		if !found {
+			continue
+		}
+		kilobytes, err := strconv.ParseUint(strings.TrimSpace(size), 10, 64)
+		if err != nil {
+			continue
+		}
+		node(path.Clean(dir)).SizeBytes = kilobytes * 1024
+	}
+
+	// counts: "<files>\t<path>", only for directories du reported
+	for _, line := range countLines {
+		count, dir, found := strings.Cut(line, "\t")
+		if !found {
+			continue
+		}
+		if existing, ok := nodes[path.Clean(dir)]; ok {
+			existing.FileCount, _ = strconv.ParseInt(count, 10, 64)
+		}
+	}
+
+	// Link children to their parents
+	children := make(map[string][]*DirectoryUsageNode)
+	for dir, child := range nodes {
+		if dir == root {
+			continue
+		}
+		if _, ok := nodes[path.Dir(dir)]; ok {
+			children[path.Dir(dir)] = append(children[path.Dir(dir)], child)
+		}
+	}
+
+	var attach func(n *DirectoryUsageNode)
+	attach = func(n *DirectoryUsageNode) {
+		kids := children[n.Path]
+		sort.Slice(kids, func(i, j int) bool {
+			if kids[i].SizeBytes != kids[j].SizeBytes {
+				return kids[i].SizeBytes > kids[j].SizeBytes
+			}
+			return kids[i].Path < kids[j].Path
+		})
+		if len(kids) > limit {
+			n.OmittedChildren = len(kids) - limit
+			kids = kids[:limit]
+		}
+		for _, kid := range kids {
+			attach(kid)
+			n.Children = append(n.Children, *kid)
+		}
+	}
+	tree := nodes[root]
+	attach(tree)
+
+	return tree
+}
+
+// parseLargestFiles parses "<bytes> <path>" lines, which are already sorted by size
+func parseLargestFiles(lines []string) []LargeFile {
+	files := []LargeFile{}
+
+	for _, line := range lines {
+		size, filePath, found := strings.Cut(strings.TrimLeft(line, " "), " ")
+		if !found {
+			continue
+		}
+		bytes, err := strconv.ParseUint(size, 10, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, LargeFile{Path: filePath, SizeBytes: bytes})
+	}
+
+	return files
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// diskUsageScript reports space and inode usage in bytes together with the mount table
+const diskUsageScript = `echo '### df'
+df -B1 --output=source,fstype,size,used,avail,pcent,itotal,iused,iavail,ipcent,target 2>/dev/null
+echo '### mounts'
+cat /proc/self/mounts 2>/dev/null
+true`
+
+// diskUsagePosixScript reports space and inode usage for BusyBox and other df implementations without
+// --output, as POSIX output in kilobytes and inodes, together with the mount table
+const diskUsagePosixScript = `echo '### df-posix'
+df -P -k 2>/dev/null
+echo '### df-inodes'
+df -P -i 2>/dev/null
+echo '### mounts'
+cat /proc/self/mounts 2>/dev/null
+true`
+
+var (
+	// dfOutputPattern matches a row of 'df --output=source,fstype,size,used,avail,pcent,itotal,iused,iavail,ipcent,target'.
+	// Anchoring on the numeric columns keeps sources and mount points containing spaces intact.
+	dfOutputPattern = regexp.MustCompile(`^(.+?)\s+(\S+)\s+(\d+|-)\s+(\d+|-)\s+(\d+|-)\s+(\d+%|-)\s+(\d+|-)\s+(\d+|-)\s+(\d+|-)\s+(\d+%|-)\s+(/.*)$`)
+
+	// dfPosixPattern matches a row of 'df -P', in kilobytes or inodes
+	dfPosixPattern = regexp.MustCompile(`^(.+?)\s+(\d+|-)\s+(\d+|-)\s+(\d+|-)\s+(\d+%|-)\s+(/.*)$`)
+
+	// mountEscapePattern matches the octal escapes used for whitespace and backslashes in /proc/self/mounts
+	mountEscapePattern = regexp.MustCompile(`\\[0-7]{3}`)
+)
+
+// pseudoFilesystems are virtual or memory-backed filesystem types excluded from disk usage by default
+var pseudoFilesystems = map[string]bool{
+	"autofs":      true,
+	"binfmt_misc": true,
+	"bpf":         true,
+	"cgroup":      true,
+	"cgroup2":     true,
+	"configfs":    true,
+	"debugfs":     true,
+	"devpts":      true,
+	"devtmpfs":    true,
+	"efivarfs":    true,
+	"fusectl":     true,
+	"hugetlbfs":   true,
+	"mqueue":      true,
+	"nsfs":        true,
+	"overlay":     true,
+	"proc":        true,
+	"pstore":      true,
+	"ramfs":       true,
+	"rpc_pipefs":  true,
+	"securityfs":  true,
+	"selinuxfs":   true,
+	"shm":         true,
+	"squashfs":    true,
+	"sysfs":       true,
+	"tmpfs":       true,
+	"tracefs":     true,
+}
+
+// mountEntry is a line of /proc/self/mounts
+type mountEntry struct {
+	source  string
+	fsType  string
+	options []string
+}
+
+// GetDiskUsage implements the Service interface
+func (s *service) GetDiskUsage(ctx context.Context, sessionID string, filter DiskUsageFilter) ([]DiskUsage, error) {
+	caps, err := s.sessionCapabilities(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	script := diskUsageScript
+	if !caps.DFOutput {
+		script = diskUsagePosixScript
+	}
+
+	// Execute command to get disk usage
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	mounts := parseMounts(sections["mounts"])
+
+	// Parse disk usage
+	var usages []DiskUsage
+	if posix, ok := sections["df-posix"]; ok {
+		usages = parseDiskUsagePosix(posix, sections["df-inodes"], mounts)
+	} else {
+		usages = parseDiskUsage(sections["df"])
+	}
+
+	filtered := []DiskUsage{}
+	for _, usage := range usages {
+		if mount, ok := mounts[usage.MountedOn]; ok {
+			usage.MountOptions = mount.options
+			if usage.Type == "" {
+				usage.Type = mount.fsType
+			}
+		}
+		if usage.MountOptions == nil {
+			usage.MountOptions = []string{}
+		}
+
+		usage.Pseudo = pseudoFilesystems[usage.Type]
+		if usage.Pseudo && !filter.IncludePseudo {
+			continue
+		}
+		filtered = append(filtered, usage)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].MountedOn < filtered[j].MountedOn
+	})
+
+	return filtered, nil
+}
+
+// parseDiskUsage parses the output of 'df -B1 --output=...'
+func parseDiskUsage(dfOutput string) []DiskUsage {
+	var usages []DiskUsage
+
+	for _, line := range strings.Split(dfOutput, "\n") {
+		// The header row does not match because its mount point column does not start with a slash
+		match := dfOutputPattern.FindStringSubmatch(strings.TrimRight(line, " \t\r"))
+		if match == nil {
+			continue
+		}
+
+		usages = append(usages, DiskUsage{
+			Filesystem:       match[1],
+			Type:             match[2],
+			SizeBytes:        parseDFNumber(match[3]),
+			UsedBytes:        parseDFNumber(match[4]),
+			AvailableBytes:   parseDFNumber(match[5]),
+			UsePercentage:    parseDFPercent(match[6]),
+			Inodes:           parseDFNumber(match[7]),
+			InodesUsed:       parseDFNumber(match[8]),
+			InodesFree:       parseDFNumber(match[9]),
+			InodesUsePercent: parseDFPercent(match[10]),
+			MountedOn:        match[11],
+		})
+	}
+
+	return usages
+}
+
+// parseDiskUsagePosix combines 'df -P -k' and 'df -P -i' output, taking the filesystem types from the mount table
+func parseDiskUsagePosix(blocksOutput string, inodesOutput string, mounts map[string]mountEntry) []DiskUsage {
+	inodes := make(map[string][]string)
+	for _, line := range strings.Split(inodesOutput, "\n") {
+		if match := dfPosixPattern.FindStringSubmatch(strings.TrimRight(line, " \t\r")); match != nil {
+			inodes[match[6]] = match
+		}
+	}
+
+	var usages []DiskUsage
+	for _, line := range strings.Split(blocksOutput, "\n") {
+		match := dfPosixPattern.FindStringSubmatch(strings.TrimRight(line, " \t\r"))
+		if match == nil {
+			continue
+		}
+
+		usage := DiskUsage{
+			Filesystem:     match[1],
+			SizeBytes:      parseDFNumber(match[2]) * 1024,
+			UsedBytes:      parseDFNumber(match[3]) * 1024,
+			AvailableBytes: parseDFNumber(match[4]) * 1024,
+			UsePercentage:  parseDFPercent(match[5]),
+			MountedOn:      match[6],
+			Type:           mounts[match[6]].fsType,
+		}
+
+		if inode, ok := inodes[usage.MountedOn]; ok {
+			usage.Inodes = parseDFNumber(inode[2])
+			usage.InodesUsed = parseDFNumber(inode[3])
+			usage.InodesFree = parseDFNumber(inode[4])
+			usage.InodesUsePercent = parseDFPercent(inode[5])
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return usages
+}
+
+// parseMounts parses /proc/self/mounts keyed by mount point. Later entries win, as they are mounted on top.
+func parseMounts(output string) map[string]mountEntry {
+	mounts := make(map[string]mountEntry)
+
+	for _, line := range strings.Split(output, "\n") {
+		// source target fstype options dump pass
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+
+		mounts[unescapeMountField(fields[1])] = mountEntry{
+			source:  unescapeMountField(fields[0]),
+			fsType:  fields[2],
+			options: strings.Split(fields[3], ","),
+		}
+	}
+
+	return mounts
+}
+
+// unescapeMountField decodes octal escapes such as "\040" for a space
+func unescapeMountField(value string) string {
+	return mountEscapePattern.ReplaceAllStringFunc(value, func(escape string) string {
+		code, err := strconv.ParseUint(escape[1:], 8, 8)
+		if err != nil {
+			return escape
+		}
+		return string(rune(code))
+	})
+}
+
+// parseDFNumber parses a df number column, where "-" means not applicable
+func parseDFNumber(value string) uint64 {
+	number, _ := strconv.ParseUint(value, 10, 64)
+	return number
+}
+
+// parseDFPercent parses a df percentage column such as "85%"
+func parseDFPercent(value string) float64 {
+	percent, _ := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	return percent
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// File transfer errors
+var (
+	ErrInvalidFilePath = errors.New("invalid file path")
+	ErrFileNotFound    = errors.New("file not found")
+	ErrNotRegularFile  = errors.New("not a regular file")
+)
+
+// fileStatScript prints the size and modification time of a regular file and its MIME type. Symbolic
+// links are followed. The placeholders receive the quoted path, the command prefix the file is read
+// with, empty or shell.SudoPrefix, and the command printing the MIME type.
+const fileStatScript = `p=%[1]s
+S="%[2]s"
+$S test -e "$p" || { echo '### missing'; exit 0; }
+$S test -f "$p" || { echo '### not-regular'; exit 0; }
+$S test -r "$p" || { echo '### denied'; exit 0; }
+echo '### stat'
+$S stat -L -c '%%s %%Y' -- "$p" 2>&1
+echo '### mime'
+%[3]s
+true`
+
+// fileMimeCommand prints the MIME type of the file in fileStatScript, when the file utility is installed
+const fileMimeCommand = `$S file --mime-type -b -L -- "$p" 2>/dev/null`
+
+// RemoteFile is a regular file on the server opened for download. Reading streams the file from the
+// current offset with tail, or cat from the start, so that it is never buffered as a whole. Seeking
+// stops the running stream and the next read starts a new one at the new offset, which is how ranges
+// are served. Reads stop at the size the file had when it was opened.
+type RemoteFile struct {
+	Path     string
+	Size     int64
+	ModTime  time.Time
+	MimeType string // Empty when the file utility is not installed
+	ETag     string // Derived from the modification time and size, e.g. "65f1a2b3-1f4"
+
+	ctx         context.Context
+	sessionRepo SessionRepository
+	sessionID   string
+	prefix      string // Empty or shell.SudoPrefix
+	offset      int64
+
+	reader *io.PipeReader
+	cancel context.CancelFunc
+	done   chan error
+}
+
+// OpenFile implements the Service interface
+func (s *service) OpenFile(ctx context.Context, sessionID string, filePath string, sudo bool) (*RemoteFile, error) {
+	if !path.IsAbs(filePath) {
+		return nil, fmt.Errorf("%w: path must be absolute", ErrInvalidFilePath)
+	}
+	filePath = path.Clean(filePath)
+
+	caps, err := s.sessionCapabilities(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	prefix := ""
+	if sudo {
+		if !caps.Root && !caps.Sudo {
+			return nil, fmt.Errorf("%w: reading %s through sudo needs root or passwordless sudo", ErrPermissionDenied, filePath)
+		}
+		prefix = shell.SudoPrefix
+	}
+	mimeCommand := ":"
+	if caps.HasTool("file") {
+		mimeCommand = fileMimeCommand
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(fileStatScript, shell.Quote(filePath), prefix, mimeCommand))
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	sections := shell.ParseSections(output)
+	if _, missing := sections["missing"]; missing {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, filePath)
+	}
+	if _, notRegular := sections["not-regular"]; notRegular {
+		return nil, fmt.Errorf("%w: %s", ErrNotRegularFile, filePath)
+	}
+	if _, denied := sections["denied"]; denied {
+		if !sudo {
+			return nil, fmt.Errorf("%w: %s is not readable by the SSH user (set sudo to read it through sudo)", ErrPermissionDenied, filePath)
+		}
+		return nil, fmt.Errorf("%w: %s is not readable", ErrPermissionDenied, filePath)
+	}
+
+	fields := strings.Fields(sections["stat"])
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, strings.TrimSpace(sections["stat"]))
+	}
+	size, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unexpected size %q", ErrCommandFailed, fields[0])
+	}
+	modified, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unexpected modification time %q", ErrCommandFailed, fields[1])
+	}
+
+	return &RemoteFile{
+		Path:        filePath,
+		Size:        size,
+		ModTime:     time.Unix(modified, 0).UTC(),
+		MimeType:    strings.TrimSpace(sections["mime"]),
+		ETag:        fmt.Sprintf(`"%x-%x"`, modified, size),
+		ctx:         ctx,
+		sessionRepo: s.sessionRepo,
+		sessionID:   sessionID,
+		prefix:      prefix,
+	}, nil
+}
+
+// Read implements io.Reader
+func (f *RemoteFile) Read(p []byte) (int, error) {
+	if f.offset >= f.Size {
+		return 0, io.EOF
+	}
+	if remaining := f.Size - f.offset; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	if f.reader == nil {
+		f.start()
+	}
+
+	n, err := f.reader.Read(p)
+	f.offset += int64(n)
+	if errors.Is(err, io.EOF) && f.offset < f.Size {
+		return n, fmt.Errorf("%w: file shrank while reading", io.ErrUnexpectedEOF)
+	}
+	return n, err
+}
+
+// Seek implements io.Seeker
+func (f *RemoteFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.Size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if offset != f.offset {
+		f.stop()
+		f.offset = offset
+	}
+	return offset, nil
+}
+
+// Close stops the running stream, if any
+func (f *RemoteFile) Close() error {
+	f.stop()
+	return nil
+}
+
+// start streams the file from the current offset into a pipe
+func (f *RemoteFile) start() {
+	command := f.prefix + " cat -- " + shell.Quote(f.Path)
+	if f.offset > 0 {
+		command = fmt.Sprintf("%s tail -c +%d -- %s", f.prefix, f.offset+1, shell.Quote(f.Path))
+	}
+
+	ctx, cancel := context.WithCancel(f.ctx)
+	reader, writer := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := f.sessionRepo.StreamCommand(ctx, f.sessionID, command, writer)
+		writer.CloseWithError(err)
+		done <- err
+	}()
+
+	f.reader, f.cancel, f.done = reader, cancel, done
+}
+
+// stop terminates the running stream and waits for the remote command to exit
+func (f *RemoteFile) stop() {
+	if f.reader == nil {
+		return
+	}
+	f.cancel()
+	f.reader.Close()
+	<-f.done
+	f.reader, f.cancel, f.done = nil, nil, nil
+}
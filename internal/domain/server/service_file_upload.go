@@ -0,0 +1,302 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// File upload errors
+var (
+	ErrInvalidUpload    = errors.New("invalid file upload")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// defaultUploadMode is the mode of uploaded files that do not replace an existing file
+const defaultUploadMode = "0644"
+
+// cleanupTimeout bounds the removal of the temporary file of a failed upload, which also runs when
+// the request was cancelled
+const cleanupTimeout = 10 * time.Second
+
+var (
+	// uploadModePattern matches octal permissions, e.g. 640 or 0640
+	uploadModePattern = regexp.MustCompile(`^0?[0-7]{3,4}$`)
+
+	// accountNamePattern matches user and group names or numeric IDs
+	accountNamePattern = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_.-]*\$?|[0-9]+)$`)
+
+	// checksumPattern matches SHA-256 checksums
+	checksumPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+// uploadPrepareScript resolves a symbolic link at the target path, creates the parent directories on
+// request and a temporary file next to the target, so that it can be renamed into place atomically.
+// It prints the target, the mode, owner and group of the file it replaces, if any, the user and group
+// of the SSH user, which new files get, and the temporary file. The placeholder receives the command
+// prefix, empty or shell.SudoPrefix. Positional parameters: path and 1 to create parent directories.
+const uploadPrepareScript = `p="$1"
+S="%s"
+if $S test -L "$p"; then p=$($S readlink -f "$p"); fi
+d=$(dirname "$p")
+if [ "$2" = 1 ] && ! $S test -d "$d"; then
+  e=$($S mkdir -p "$d" 2>&1) || { echo '### failed'; printf '%%s\n' "$e"; exit 0; }
+fi
+$S test -d "$d" || { echo '### no-parent'; exit 0; }
+$S test -d "$p" && { echo '### is-directory'; exit 0; }
+echo '### target'
+printf '%%s\n' "$p"
+if $S test -e "$p"; then
+  echo '### existing'
+  $S stat -c '%%a %%U %%G' "$p" 2>&1
+fi
+echo '### user'
+printf '%%s %%s\n' "$(id -un)" "$(id -gn)"
+t=$($S mktemp "$d/.$(basename "$p").upload-XXXXXX" 2>&1) || { echo '### failed'; printf '%%s\n' "$t"; exit 0; }
+echo '### temp'
+printf '%%s\n' "$t"
+true`
+
+// uploadWriteScript copies its standard input to the temporary file and prints the size, owner, group
+// and SHA-256 of what was written. The placeholder receives the command prefix. Positional
+// parameter: temporary file.
+const uploadWriteScript = `t="$1"
+S="%s"
+$S sh -c 'cat > "$1"' sh "$t" || exit 1
+echo '### stat'
+$S stat -c '%%s %%U %%G' "$t"
+echo '### sha256'
+if command -v sha256sum >/dev/null 2>&1; then
+  $S cat "$t" | sha256sum
+else
+  $S cat "$t" | openssl dgst -sha256 -r
+fi
+true`
+
+// uploadFinalizeScript sets the mode and, when given, the ownership of the temporary file and renames
+// it over the target. The temporary file is removed when any step fails. Positional parameters:
+// temporary file, target, mode and ownership as owner, owner:group or :group. The placeholder receives
+// the command prefix.
+const uploadFinalizeScript = `t="$1"
+p="$2"
+S="%s"
+fail() { $S rm -f "$t"; echo '### failed'; printf '%%s\n' "$1"; exit 0; }
+e=$($S chmod "$3" "$t" 2>&1) || fail "$e"
+if [ -n "$4" ]; then e=$($S chown "$4" "$t" 2>&1) || fail "$e"; fi
+e=$($S mv -f "$t" "$p" 2>&1) || fail "$e"
+echo '### done'
+$S stat -c '%%a %%U %%G %%s' "$p"
+true`
+
+// hashingReader computes the SHA-256 and size of what is read through it
+type hashingReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	size   int64
+}
+
+// Read implements io.Reader
+func (r *hashingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	r.size += int64(n)
+	return n, err
+}
+
+// UploadFile implements the Service interface
+func (s *service) UploadFile(ctx context.Context, sessionID string, upload FileUpload, content io.Reader) (*FileUploadResult, error) {
+	if err := validateFileUpload(&upload); err != nil {
+		return nil, err
+	}
+
+	// Write as the SSH user unless sudo was requested
+	prefix := ""
+	if upload.Sudo {
+		caps, err := s.sessionCapabilities(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if !caps.Root && !caps.Sudo {
+			return nil, fmt.Errorf("%w: writing %s through sudo needs root or passwordless sudo", ErrPermissionDenied, upload.Path)
+		}
+		prefix = shell.SudoPrefix
+	}
+
+	// Prepare the temporary file next to the target
+	createParents := "0"
+	if upload.CreateParents {
+		createParents = "1"
+	}
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, "sh -c "+shell.Quote(fmt.Sprintf(uploadPrepareScript, prefix))+" sh "+shell.Quote(upload.Path)+" "+createParents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare upload: %w", err)
+	}
+	sections := shell.ParseSections(output)
+	if message, failed := sections["failed"]; failed {
+		return nil, uploadCommandError(message)
+	}
+	if _, noParent := sections["no-parent"]; noParent {
+		return nil, fmt.Errorf("%w: parent directory of %s does not exist", ErrFileNotFound, upload.Path)
+	}
+	if _, isDirectory := sections["is-directory"]; isDirectory {
+		return nil, fmt.Errorf("%w: %s is a directory", ErrNotRegularFile, upload.Path)
+	}
+	target := strings.TrimSpace(sections["target"])
+	temp := strings.TrimSpace(sections["temp"])
+	if target == "" || temp == "" {
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, strings.TrimSpace(output))
+	}
+
+	// The temporary file may be created through sudo, so new files are given to the SSH user explicitly
+	var existingMode string
+	defaultOwnership := strings.Fields(sections["user"])
+	existing, replaced := sections["existing"]
+	if replaced {
+		fields := strings.Fields(existing)
+		if len(fields) != 3 {
+			s.removeTempFile(ctx, sessionID, prefix, temp)
+			return nil, fmt.Errorf("%w: %s", ErrCommandFailed, strings.TrimSpace(existing))
+		}
+		existingMode, defaultOwnership = normalizeFileMode(fields[0]), fields[1:]
+	}
+	if len(defaultOwnership) != 2 {
+		s.removeTempFile(ctx, sessionID, prefix, temp)
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, strings.TrimSpace(output))
+	}
+
+	// Stream the content to the temporary file
+	reader := &hashingReader{reader: content, hash: sha256.New()}
+	output, err = s.sessionRepo.RunCommandWithInput(ctx, sessionID, "sh -c "+shell.Quote(fmt.Sprintf(uploadWriteScript, prefix))+" sh "+shell.Quote(temp), reader)
+	if err != nil {
+		s.removeTempFile(ctx, sessionID, prefix, temp)
+		return nil, fmt.Errorf("failed to write file: %w", err)
+	}
+	sections = shell.ParseSections(output)
+	stat := strings.Fields(sections["stat"])
+	remoteSum, _, _ := strings.Cut(strings.TrimSpace(sections["sha256"]), " ")
+	if len(stat) != 3 || !checksumPattern.MatchString(remoteSum) {
+		s.removeTempFile(ctx, sessionID, prefix, temp)
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, strings.TrimSpace(output))
+	}
+	tempOwner, tempGroup := stat[1], stat[2]
+
+	// Verify the content before it replaces the target
+	sum := hex.EncodeToString(reader.hash.Sum(nil))
+	if remoteSum != sum || stat[0] != strconv.FormatInt(reader.size, 10) {
+		s.removeTempFile(ctx, sessionID, prefix, temp)
+		return nil, fmt.Errorf("%w: the written file has SHA-256 %s, the uploaded content %s", ErrChecksumMismatch, remoteSum, sum)
+	}
+	if upload.SHA256 != "" && upload.SHA256 != sum {
+		s.removeTempFile(ctx, sessionID, prefix, temp)
+		return nil, fmt.Errorf("%w: the uploaded content has SHA-256 %s, expected %s", ErrChecksumMismatch, sum, upload.SHA256)
+	}
+
+	// Set the mode and ownership, keeping those of the replaced file or giving a new file to the SSH
+	// user by default, and rename the file into place
+	mode := shell.FirstNonEmpty(upload.Mode, existingMode, defaultUploadMode)
+	owner := shell.FirstNonEmpty(upload.Owner, defaultOwnership[0])
+	group := shell.FirstNonEmpty(upload.Group, defaultOwnership[1])
+	var ownership string
+	if owner != "" && owner != tempOwner {
+		ownership = owner
+	}
+	if group != "" && group != tempGroup {
+		ownership += ":" + group
+	}
+
+	command := "sh -c " + shell.Quote(fmt.Sprintf(uploadFinalizeScript, prefix)) + " sh " + strings.Join([]string{
+		shell.Quote(temp), shell.Quote(target), shell.Quote(mode), shell.Quote(ownership),
+	}, " ")
+	output, err = s.sessionRepo.RunCommand(ctx, sessionID, command)
+	if err != nil {
+		s.removeTempFile(ctx, sessionID, prefix, temp)
+		return nil, fmt.Errorf("failed to replace file: %w", err)
+	}
+	sections = shell.ParseSections(output)
+	if message, failed := sections["failed"]; failed {
+		return nil, uploadCommandError(message)
+	}
+	fields := strings.Fields(sections["done"])
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, strings.TrimSpace(output))
+	}
+	size, _ := strconv.ParseInt(fields[3], 10, 64)
+
+	return &FileUploadResult{
+		Path:     target,
+		Size:     size,
+		SHA256:   sum,
+		Mode:     normalizeFileMode(fields[0]),
+		Owner:    fields[1],
+		Group:    fields[2],
+		Replaced: replaced,
+	}, nil
+}
+
+// validateFileUpload checks the target and attributes of an upload
+func validateFileUpload(upload *FileUpload) error {
+	if !path.IsAbs(upload.Path) {
+		return fmt.Errorf("%w: path must be absolute", ErrInvalidFilePath)
+	}
+	upload.Path = path.Clean(upload.Path)
+	if upload.Path == "/" || strings.ContainsAny(upload.Path, "\n\r") {
+		return fmt.Errorf("%w: %q", ErrInvalidFilePath, upload.Path)
+	}
+	if upload.Mode != "" {
+		if !uploadModePattern.MatchString(upload.Mode) {
+			return fmt.Errorf("%w: mode must be octal, e.g. 0644", ErrInvalidUpload)
+		}
+		upload.Mode = normalizeFileMode(upload.Mode)
+	}
+	if upload.Owner != "" && !accountNamePattern.MatchString(upload.Owner) {
+		return fmt.Errorf("%w: invalid owner %q", ErrInvalidUpload, upload.Owner)
+	}
+	if upload.Group != "" && !accountNamePattern.MatchString(upload.Group) {
+		return fmt.Errorf("%w: invalid group %q", ErrInvalidUpload, upload.Group)
+	}
+	upload.SHA256 = strings.ToLower(upload.SHA256)
+	if upload.SHA256 != "" && !checksumPattern.MatchString(upload.SHA256) {
+		return fmt.Errorf("%w: sha256 must be 64 hex digits", ErrInvalidUpload)
+	}
+	return nil
+}
+
+// removeTempFile deletes the temporary file of a failed upload with the prefix it was written with,
+// even when the request was cancelled
+func (s *service) removeTempFile(ctx context.Context, sessionID string, prefix string, temp string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+	_, _ = s.sessionRepo.RunCommand(ctx, sessionID, strings.TrimSpace(prefix+" rm -f "+shell.Quote(temp)))
+}
+
+// uploadCommandError turns the output of a failed upload step into an error
+func uploadCommandError(message string) error {
+	message = strings.TrimSpace(message)
+	lower := strings.ToLower(message)
+	if shell.IsPermissionDenied(lower) {
+		return fmt.Errorf("%w: %s", ErrPermissionDenied, message)
+	}
+	if strings.Contains(lower, "invalid user") || strings.Contains(lower, "invalid group") || strings.Contains(lower, "unknown user") || strings.Contains(lower, "unknown group") {
+		return fmt.Errorf("%w: %s", ErrInvalidUpload, message)
+	}
+	return fmt.Errorf("%w: %s", ErrCommandFailed, message)
+}
+
+// normalizeFileMode writes octal permissions with four digits, e.g. 644 as 0644
+func normalizeFileMode(mode string) string {
+	for len(mode) < 4 {
+		mode = "0" + mode
+	}
+	return mode
+}
@@ -0,0 +1,294 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// hostFactsTTL bounds how long gathered facts are served from the cache
+const hostFactsTTL = 5 * time.Minute
+
+// hostFactsScript gathers the host facts in one round trip. systemd-detect-virt is preferred for
+// virtualization detection; without it the DMI vendor, Xen, container marker files and the
+// environment and cgroups of PID 1 are reported as hints.
+const hostFactsScript = `echo '### hostname'
+hostname 2>/dev/null || cat /proc/sys/kernel/hostname
+echo '### fqdn'
+hostname -f 2>/dev/null
+echo '### os-release'
+cat /etc/os-release 2>/dev/null || cat /usr/lib/os-release 2>/dev/null
+echo '### uname'
+uname -s; uname -r; uname -v; uname -m
+echo '### virt'
+if command -v systemd-detect-virt >/dev/null 2>&1; then
+  echo "vm=$(systemd-detect-virt --vm 2>/dev/null)"
+  echo "container=$(systemd-detect-virt --container 2>/dev/null)"
+fi
+echo "dmi=$(cat /sys/class/dmi/id/sys_vendor 2>/dev/null) $(cat /sys/class/dmi/id/product_name 2>/dev/null)"
+[ -d /proc/xen ] && echo 'hint-vm=xen'
+[ -f /.dockerenv ] && echo 'hint-container=docker'
+[ -f /run/.containerenv ] && echo 'hint-container=podman'
+c=$(tr '\0' '\n' 2>/dev/null < /proc/1/environ | sed -n 's/^container=//p')
+[ -n "$c" ] && echo "hint-container=$c"
+echo '### cgroup'
+cat /proc/1/cgroup 2>/dev/null
+echo '### cpuinfo'
+cat /proc/cpuinfo 2>/dev/null
+echo '### meminfo'
+grep -E '^(MemTotal|SwapTotal):' /proc/meminfo 2>/dev/null
+echo '### timezone'
+tz=$(timedatectl show -p Timezone --value 2>/dev/null)
+[ -n "$tz" ] || tz=$(cat /etc/timezone 2>/dev/null)
+[ -n "$tz" ] || tz=$(readlink /etc/localtime 2>/dev/null | sed 's|.*zoneinfo/||')
+[ -n "$tz" ] || tz=${TZ:-$(date +%Z)}
+echo "$tz"
+echo '### btime'
+awk '/^btime/ { print $2 }' /proc/stat 2>/dev/null
+echo '### uptime'
+cut -d' ' -f1 /proc/uptime 2>/dev/null
+echo '### machine-id'
+cat /etc/machine-id 2>/dev/null || cat /var/lib/dbus/machine-id 2>/dev/null
+true`
+
+// dmiHypervisors maps fragments of the DMI vendor and product name to hypervisor names, using the
+// names systemd-detect-virt reports
+var dmiHypervisors = []struct {
+	fragment string
+	name     string
+}{
+	{"KVM", "kvm"},
+	{"QEMU", "qemu"},
+	{"VMware", "vmware"},
+	{"VirtualBox", "oracle"},
+	{"innotek", "oracle"},
+	{"Microsoft Corporation Virtual Machine", "microsoft"},
+	{"Xen", "xen"},
+	{"Amazon EC2", "amazon"},
+	{"Google Compute Engine", "google"},
+	{"Parallels", "parallels"},
+	{"BHYVE", "bhyve"},
+}
+
+// cgroupContainers maps cgroup path fragments of PID 1 to container runtimes
+var cgroupContainers = []struct {
+	fragment string
+	name     string
+}{
+	{"kubepods", "kubernetes"},
+	{"/docker", "docker"},
+	{"/libpod", "podman"},
+	{"/lxc", "lxc"},
+}
+
+// GetHostFacts implements the Service interface
+func (s *service) GetHostFacts(ctx context.Context, sessionID string, refresh bool) (*HostFacts, error) {
+	if !refresh {
+		if facts, ok := s.facts.get(sessionID); ok {
+			return facts, nil
+		}
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, hostFactsScript)
+	if err != nil {
+		return nil, err
+	}
+
+	facts := parseHostFacts(shell.ParseSections(output))
+	s.facts.put(sessionID, facts)
+
+	return facts, nil
+}
+
+// parseHostFacts builds the facts from the script sections
+func parseHostFacts(sections map[string]string) *HostFacts {
+	facts := &HostFacts{
+		Hostname:    strings.TrimSpace(sections["hostname"]),
+		FQDN:        strings.TrimSpace(sections["fqdn"]),
+		OS:          parseOSRelease(sections["os-release"]),
+		Timezone:    strings.TrimSpace(sections["timezone"]),
+		MachineID:   strings.TrimSpace(sections["machine-id"]),
+		CollectedAt: time.Now().UTC(),
+	}
+	if facts.FQDN == "" {
+		facts.FQDN = facts.Hostname
+	}
+
+	// uname: kernel name, release, version and machine on separate lines
+	uname := strings.Split(strings.TrimRight(sections["uname"], "\n"), "\n")
+	for len(uname) < 4 {
+		uname = append(uname, "")
+	}
+	facts.Kernel = KernelInfo{
+		Name:    strings.TrimSpace(uname[0]),
+		Release: strings.TrimSpace(uname[1]),
+		Version: strings.TrimSpace(uname[2]),
+	}
+	facts.Architecture = strings.TrimSpace(uname[3])
+
+	cpus := parseCPUInfo(sections["cpuinfo"])
+	facts.CPU = summarizeCPUInfo(cpus)
+	facts.Virtualization = detectVirtualization(sections["virt"], sections["cgroup"], cpus, facts.Kernel.Release)
+
+	// meminfo: "MemTotal:       16318480 kB"
+	for _, line := range strings.Split(sections["meminfo"], "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		kilobytes, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			facts.MemoryTotalBytes = kilobytes * 1024
+		case "SwapTotal:":
+			facts.SwapTotalBytes = kilobytes * 1024
+		}
+	}
+
+	if btime, err := strconv.ParseInt(strings.TrimSpace(sections["btime"]), 10, 64); err == nil {
+		facts.BootTime = time.Unix(btime, 0).UTC()
+	}
+	if uptime, err := strconv.ParseFloat(strings.TrimSpace(sections["uptime"]), 64); err == nil {
+		facts.UptimeSeconds = int64(uptime)
+	}
+
+	return facts
+}
+
+// parseOSRelease parses the KEY=value lines of os-release, whose values may be quoted
+func parseOSRelease(output string) OSRelease {
+	release := OSRelease{IDLike: []string{}}
+
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found || strings.HasPrefix(key, "#") {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		} else {
+			value = strings.Trim(value, `"'`)
+		}
+
+		switch key {
+		case "ID":
+			release.ID = value
+		case "ID_LIKE":
+			release.IDLike = strings.Fields(value)
+		case "NAME":
+			release.Name = value
+		case "VERSION_ID":
+			release.VersionID = value
+		case "VERSION_CODENAME":
+			release.VersionCodename = value
+		case "PRETTY_NAME":
+			release.PrettyName = value
+		}
+	}
+
+	return release
+}
+
+// summarizeCPUInfo counts sockets, physical cores and logical processors. Processors without
+// topology information, as on most ARM systems, are counted as one core each in a single socket.
+func summarizeCPUInfo(cpus []CPUInfo) CPUSummary {
+	summary := CPUSummary{Threads: len(cpus)}
+	if len(cpus) == 0 {
+		return summary
+	}
+
+	sockets := make(map[string]bool)
+	cores := make(map[string]bool)
+	for _, cpu := range cpus {
+		if summary.ModelName == "" {
+			summary.ModelName = cpu.ModelName
+		}
+		if summary.Vendor == "" {
+			summary.Vendor = cpu.VendorID
+		}
+		if cpu.PhysicalID != "" {
+			sockets[cpu.PhysicalID] = true
+		}
+		if cpu.CoreID != "" {
+			cores[cpu.PhysicalID+"/"+cpu.CoreID] = true
+		}
+	}
+
+	summary.Sockets = max(len(sockets), 1)
+	summary.Cores = len(cores)
+	if summary.Cores == 0 {
+		summary.Cores = summary.Threads
+	}
+	summary.ThreadsPerCore = max(summary.Threads/summary.Cores, 1)
+
+	return summary
+}
+
+// detectVirtualization combines systemd-detect-virt results with the hints of the facts script
+func detectVirtualization(virt string, cgroup string, cpus []CPUInfo, kernelRelease string) Virtualization {
+	result := Virtualization{Type: "none", Container: "none", Role: "host"}
+
+	values := make(map[string]string)
+	for _, line := range strings.Split(virt, "\n") {
+		if key, value, found := strings.Cut(line, "="); found && values[key] == "" {
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+
+	// Hypervisor
+	switch {
+	case values["vm"] != "":
+		result.Type = values["vm"]
+	case values["hint-vm"] != "":
+		result.Type = values["hint-vm"]
+	default:
+		for _, hypervisor := range dmiHypervisors {
+			if strings.Contains(values["dmi"], hypervisor.fragment) {
+				result.Type = hypervisor.name
+				break
+			}
+		}
+		if result.Type == "none" && len(cpus) > 0 && containsWord(cpus[0].Flags, "hypervisor") {
+			result.Type = "vm-other"
+		}
+	}
+
+	// Container
+	switch {
+	case values["container"] != "":
+		result.Container = values["container"]
+	case values["hint-container"] != "":
+		result.Container = values["hint-container"]
+	case strings.Contains(strings.ToLower(kernelRelease), "microsoft"):
+		result.Container = "wsl"
+	default:
+		for _, runtime := range cgroupContainers {
+			if strings.Contains(cgroup, runtime.fragment) {
+				result.Container = runtime.name
+				break
+			}
+		}
+	}
+
+	if result.Type != "none" || result.Container != "none" {
+		result.Role = "guest"
+	}
+
+	return result
+}
+
+// containsWord reports whether a space-separated list contains the word
+func containsWord(list string, word string) bool {
+	for _, field := range strings.Fields(list) {
+		if field == word {
+			return true
+		}
+	}
+	return false
+}
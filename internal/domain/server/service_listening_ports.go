@@ -0,0 +1,386 @@
+package server
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// listeningSocketsScript lists listening sockets with ss, or dumps /proc/net/* and the socket inode
+// to PID mapping when ss is not installed
+const listeningSocketsScript = `if command -v ss >/dev/null 2>&1; then
+  echo '### ss'
+  ss -tulpn 2>/dev/null
+else
+  for f in tcp tcp6 udp udp6; do echo "### $f"; cat /proc/net/$f 2>/dev/null; done
+  echo '### inodes'
+  for p in /proc/[0-9]*; do
+    for fd in "$p"/fd/*; do
+      l=$(readlink "$fd" 2>/dev/null) || continue
+      case "$l" in socket:\[*) i=${l#socket:[}; echo "${p#/proc/} ${i%]}";; esac
+    done
+  done
+fi
+true`
+
+// socketOwnersScript prints name, cgroup and command line of the given PIDs followed by the running
+// Docker containers with their IP addresses. The %s placeholder receives a space-separated PID list.
+const socketOwnersScript = `echo '### owners'
+for p in %s; do
+  [ -d /proc/$p ] || continue
+  printf '%%s|%%s|%%s|%%s\n' "$p" "$(cat /proc/$p/comm 2>/dev/null)" "$(tr '\n' ' ' < /proc/$p/cgroup 2>/dev/null)" "$(tr '\0' ' ' < /proc/$p/cmdline 2>/dev/null)"
+done
+echo '### containers'
+docker ps -q 2>/dev/null | xargs -r docker inspect -f '{{.Id}}|{{.Name}}|{{range .NetworkSettings.Networks}}{{.IPAddress}} {{end}}' 2>/dev/null
+true`
+
+// TCP and UDP socket states as reported in /proc/net/{tcp,udp}
+const (
+	procNetStateListen = "0A"
+	procNetStateClose  = "07"
+)
+
+var (
+	// ssProcessPattern matches a single process entry in the ss "users:((...))" column
+	ssProcessPattern = regexp.MustCompile(`\("([^"]*)",pid=(\d+)`)
+
+	// containerIDPattern matches a full container ID inside a cgroup path
+	containerIDPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+)
+
+// socketOwner describes a process that owns sockets
+type socketOwner struct {
+	name        string
+	containerID string
+	commandLine string
+}
+
+// dockerContainerRef identifies a running container by ID and name
+type dockerContainerRef struct {
+	id   string
+	name string
+}
+
+// GetListeningSockets implements the Service interface
+func (s *service) GetListeningSockets(ctx context.Context, sessionID string, filter ListeningSocketFilter) ([]ListeningSocket, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, listeningSocketsScript)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+
+	var sockets []ListeningSocket
+	if ssOutput, ok := sections["ss"]; ok {
+		sockets = parseSSOutput(ssOutput)
+	} else {
+		sockets = parseProcNetSockets(sections)
+	}
+
+	sockets = filterListeningSockets(sockets, filter)
+
+	// Resolve process names and containers for the owning processes
+	if pids := collectSocketPIDs(sockets); len(pids) > 0 {
+		ownersOutput, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(socketOwnersScript, strings.Join(pids, " ")))
+		if err == nil {
+			ownerSections := shell.ParseSections(ownersOutput)
+			attachSocketOwners(sockets, parseSocketOwners(ownerSections["owners"]), parseDockerContainerRefs(ownerSections["containers"]))
+		}
+	}
+
+	sort.SliceStable(sockets, func(i, j int) bool {
+		if sockets[i].LocalPort != sockets[j].LocalPort {
+			return sockets[i].LocalPort < sockets[j].LocalPort
+		}
+		return sockets[i].Protocol < sockets[j].Protocol
+	})
+
+	return sockets, nil
+}
+
+// parseSSOutput parses the output of 'ss -tulpn'
+func parseSSOutput(output string) []ListeningSocket {
+	sockets := []ListeningSocket{}
+
+	for _, line := range strings.Split(output, "\n") {
+		// Netid State Recv-Q Send-Q Local-Address:Port Peer-Address:Port [Process]
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[0] == "Netid" {
+			continue
+		}
+
+		protocol := fields[0]
+		if protocol != "tcp" && protocol != "udp" {
+			continue
+		}
+
+		address, port, ok := splitHostPort(fields[4])
+		if !ok {
+			continue
+		}
+
+		socket := ListeningSocket{
+			Protocol:     protocol,
+			LocalAddress: address,
+			LocalPort:    port,
+			State:        fields[1],
+			Source:       "ss",
+		}
+
+		// Distinguish IPv6 sockets the same way /proc/net does
+		if strings.Contains(address, ":") {
+			socket.Protocol += "6"
+		}
+
+		// Process column: users:(("nginx",pid=1234,fd=6),("nginx",pid=1235,fd=6))
+		if len(fields) > 6 {
+			for _, match := range ssProcessPattern.FindAllStringSubmatch(strings.Join(fields[6:], " "), -1) {
+				pid, _ := strconv.Atoi(match[2])
+				if socket.PID == 0 {
+					socket.PID = pid
+					socket.ProcessName = match[1]
+				}
+				socket.PIDs = appendUniqueInt(socket.PIDs, pid)
+			}
+		}
+
+		sockets = append(sockets, socket)
+	}
+
+	return sockets
+}
+
+// splitHostPort splits an ss address such as "0.0.0.0:22", "[::]:22", "*:68" or "127.0.0.53%lo:53"
+func splitHostPort(value string) (string, int, bool) {
+	index := strings.LastIndex(value, ":")
+	if index < 0 {
+		return "", 0, false
+	}
+
+	port, err := strconv.Atoi(value[index+1:])
+	if err != nil {
+		return "", 0, false
+	}
+
+	host := strings.Trim(value[:index], "[]")
+	if zone := strings.Index(host, "%"); zone >= 0 {
+		host = host[:zone]
+	}
+	if host == "*" {
+		host = "0.0.0.0"
+	}
+
+	return host, port, true
+}
+
+// parseProcNetSockets parses /proc/net/{tcp,tcp6,udp,udp6} and resolves owners from the inode mapping
+func parseProcNetSockets(sections map[string]string) []ListeningSocket {
+	owners := make(map[string][]int)
+	for _, line := range strings.Split(sections["inodes"], "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if pid, err := strconv.Atoi(fields[0]); err == nil {
+			owners[fields[1]] = appendUniqueInt(owners[fields[1]], pid)
+		}
+	}
+
+	sockets := []ListeningSocket{}
+	for _, protocol := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		wantState, state := procNetStateListen, "LISTEN"
+		if strings.HasPrefix(protocol, "udp") {
+			wantState, state = procNetStateClose, "UNCONN"
+		}
+
+		for _, line := range strings.Split(sections[protocol], "\n") {
+			// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+			fields := strings.Fields(line)
+			if len(fields) < 10 || fields[0] == "sl" || fields[3] != wantState {
+				continue
+			}
+
+			hexAddress, hexPort, found := strings.Cut(fields[1], ":")
+			if !found {
+				continue
+			}
+
+			address, err := parseProcNetAddress(hexAddress)
+			if err != nil {
+				continue
+			}
+			port, err := strconv.ParseUint(hexPort, 16, 16)
+			if err != nil {
+				continue
+			}
+
+			socket := ListeningSocket{
+				Protocol:     protocol,
+				LocalAddress: address.String(),
+				LocalPort:    int(port),
+				State:        state,
+				Source:       "procfs",
+			}
+
+			if pids := owners[fields[9]]; len(pids) > 0 {
+				sort.Ints(pids)
+				socket.PID = pids[0]
+				socket.PIDs = pids
+			}
+
+			sockets = append(sockets, socket)
+		}
+	}
+
+	return sockets
+}
+
+// parseProcNetAddress decodes an address from /proc/net/*, stored as little-endian 32-bit words
+func parseProcNetAddress(value string) (net.IP, error) {
+	raw, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != net.IPv4len && len(raw) != net.IPv6len {
+		return nil, fmt.Errorf("invalid address length: %d", len(raw))
+	}
+
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:], binary.LittleEndian.Uint32(raw[i:]))
+	}
+	return ip, nil
+}
+
+// filterListeningSockets applies the protocol and port filter
+func filterListeningSockets(sockets []ListeningSocket, filter ListeningSocketFilter) []ListeningSocket {
+	filtered := []ListeningSocket{}
+	for _, socket := range sockets {
+		if filter.Protocol != "" && !strings.HasPrefix(socket.Protocol, filter.Protocol) {
+			continue
+		}
+		if filter.Port != 0 && socket.LocalPort != filter.Port {
+			continue
+		}
+		filtered = append(filtered, socket)
+	}
+	return filtered
+}
+
+// collectSocketPIDs returns the distinct PIDs owning the sockets as strings
+func collectSocketPIDs(sockets []ListeningSocket) []string {
+	seen := make(map[int]bool)
+	var pids []string
+	for _, socket := range sockets {
+		for _, pid := range socket.PIDs {
+			if !seen[pid] {
+				seen[pid] = true
+				pids = append(pids, strconv.Itoa(pid))
+			}
+		}
+	}
+	return pids
+}
+
+// parseSocketOwners parses "pid|comm|cgroup|cmdline" lines
+func parseSocketOwners(output string) map[int]socketOwner {
+	owners := make(map[int]socketOwner)
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "|", 4)
+		if len(parts) < 4 {
+			continue
+		}
+		pid, err := strconv.Atoi(parts[0])
+		if err != nil {
+			continue
+		}
+		owners[pid] = socketOwner{
+			name:        strings.TrimSpace(parts[1]),
+			containerID: containerIDPattern.FindString(parts[2]),
+			commandLine: strings.TrimSpace(parts[3]),
+		}
+	}
+	return owners
+}
+
+// parseDockerContainerRefs parses "id|/name|ip ip ..." lines into lookups by ID and by IP address
+func parseDockerContainerRefs(output string) map[string]dockerContainerRef {
+	refs := make(map[string]dockerContainerRef)
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "|", 3)
+		if len(parts) < 2 || parts[0] == "" {
+			continue
+		}
+		ref := dockerContainerRef{id: parts[0], name: strings.TrimPrefix(parts[1], "/")}
+		refs[ref.id] = ref
+		if len(parts) == 3 {
+			for _, ip := range strings.Fields(parts[2]) {
+				refs[ip] = ref
+			}
+		}
+	}
+	return refs
+}
+
+// attachSocketOwners fills in process names and container information
+func attachSocketOwners(sockets []ListeningSocket, owners map[int]socketOwner, containers map[string]dockerContainerRef) {
+	for i := range sockets {
+		socket := &sockets[i]
+		owner, ok := owners[socket.PID]
+		if !ok {
+			continue
+		}
+
+		if socket.ProcessName == "" {
+			socket.ProcessName = owner.name
+		}
+
+		// Processes inside a container carry the container ID in their cgroup path
+		if owner.containerID != "" {
+			socket.ContainerID = owner.containerID
+			if ref, ok := containers[owner.containerID]; ok {
+				socket.ContainerName = ref.name
+			}
+			continue
+		}
+
+		// Published ports are held by docker-proxy, which forwards to the container IP
+		if owner.name == "docker-proxy" {
+			if ip := commandLineFlag(owner.commandLine, "-container-ip"); ip != "" {
+				if ref, ok := containers[ip]; ok {
+					socket.ContainerID = ref.id
+					socket.ContainerName = ref.name
+				}
+			}
+		}
+	}
+}
+
+// commandLineFlag returns the value following a flag in a space-separated command line
+func commandLineFlag(commandLine, flag string) string {
+	fields := strings.Fields(commandLine)
+	for i := 0; i < len(fields)-1; i++ {
+		if fields[i] == flag {
+			return fields[i+1]
+		}
+	}
+	return ""
+}
+
+// appendUniqueInt appends a value to the slice if it is not already present
+func appendUniqueInt(values []int, value int) []int {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
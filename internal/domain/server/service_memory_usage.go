@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetMemoryUsage implements the Service interface
+func (s *service) GetMemoryUsage(ctx context.Context, sessionID string) (*MemoryUsage, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, "cat /proc/meminfo")
+	if err != nil {
+		return nil, err
+	}
+
+	usage := parseMemoryUsage(output)
+	if usage.TotalBytes == 0 {
+		return nil, fmt.Errorf("%w: unexpected /proc/meminfo output", ErrCommandFailed)
+	}
+
+	return usage, nil
+}
+
+// parseMemoryUsage parses /proc/meminfo, whose values are given in KiB
+func parseMemoryUsage(output string) *MemoryUsage {
+	values := make(map[string]uint64)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		if kilobytes, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			values[key] = kilobytes * 1024
+		}
+	}
+
+	usage := &MemoryUsage{
+		TotalBytes:     values["MemTotal"],
+		FreeBytes:      values["MemFree"],
+		BuffersBytes:   values["Buffers"],
+		CachedBytes:    values["Cached"] + values["SReclaimable"],
+		SwapTotalBytes: values["SwapTotal"],
+		SwapFreeBytes:  values["SwapFree"],
+	}
+
+	// Kernels before 3.14 do not report MemAvailable
+	if available, ok := values["MemAvailable"]; ok {
+		usage.AvailableBytes = available
+	} else {
+		usage.AvailableBytes = usage.FreeBytes + usage.BuffersBytes + usage.CachedBytes
+	}
+	usage.AvailableBytes = min(usage.AvailableBytes, usage.TotalBytes)
+	usage.UsedBytes = usage.TotalBytes - usage.AvailableBytes
+	usage.SwapUsedBytes = usage.SwapTotalBytes - min(usage.SwapFreeBytes, usage.SwapTotalBytes)
+
+	if usage.TotalBytes > 0 {
+		usage.UsePercentage = roundHundredths(float64(usage.UsedBytes) / float64(usage.TotalBytes) * 100)
+	}
+	if usage.SwapTotalBytes > 0 {
+		usage.SwapUsePercentage = roundHundredths(float64(usage.SwapUsedBytes) / float64(usage.SwapTotalBytes) * 100)
+	}
+
+	return usage
+}
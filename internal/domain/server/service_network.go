@@ -0,0 +1,336 @@
+package server
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// networkInfoScript gathers interface, address, counter, route and resolver information in one invocation.
+// The %s placeholder receives the number of seconds to wait between the two /proc/net/dev samples.
+const networkInfoScript = `echo '### interfaces'
+for i in /sys/class/net/*; do
+  printf '%%s|%%s|%%s|%%s|%%s\n' "${i##*/}" "$(cat "$i/mtu" 2>/dev/null)" "$(cat "$i/operstate" 2>/dev/null)" "$(cat "$i/address" 2>/dev/null)" "$(cat "$i/speed" 2>/dev/null)"
+done
+echo '### addresses'
+ip -o addr show 2>/dev/null
+echo '### netdev1'
+cat /proc/net/dev
+sleep %s
+echo '### netdev2'
+cat /proc/net/dev
+echo '### routes'
+cat /proc/net/route 2>/dev/null
+echo '### routes6'
+cat /proc/net/ipv6_route 2>/dev/null
+echo '### resolv'
+cat /etc/resolv.conf 2>/dev/null
+true`
+
+// GetNetworkInfo implements the Service interface
+func (s *service) GetNetworkInfo(ctx context.Context, sessionID string, interval time.Duration) (*NetworkInfo, error) {
+	if err := validateSampleInterval(interval); err != nil {
+		return nil, err
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(networkInfoScript, formatSleepSeconds(interval)))
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+
+	// Build interfaces and attach addresses, counters and rates
+	interfaces := parseNetworkInterfaces(sections["interfaces"])
+	addresses := parseInterfaceAddresses(sections["addresses"])
+	before := parseNetDev(sections["netdev1"])
+	after := parseNetDev(sections["netdev2"])
+
+	for i := range interfaces {
+		iface := &interfaces[i]
+		iface.Addresses = addresses[iface.Name]
+		if iface.Addresses == nil {
+			iface.Addresses = []InterfaceAddress{}
+		}
+
+		counters, ok := after[iface.Name]
+		if !ok {
+			continue
+		}
+		iface.Counters = counters
+
+		if previous, ok := before[iface.Name]; ok {
+			iface.Rates = computeInterfaceRates(previous, counters, interval)
+		}
+	}
+
+	routes := append(parseIPv4Routes(sections["routes"]), parseIPv6Routes(sections["routes6"])...)
+
+	return &NetworkInfo{
+		Timestamp:  time.Now(),
+		IntervalMS: interval.Milliseconds(),
+		Interfaces: interfaces,
+		Routes:     routes,
+		DNS:        parseResolvConf(sections["resolv"]),
+	}, nil
+}
+
+// parseNetworkInterfaces parses "name|mtu|operstate|address|speed" lines built from /sys/class/net
+func parseNetworkInterfaces(output string) []NetworkInterface {
+	interfaces := []NetworkInterface{}
+
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.Split(strings.TrimSpace(line), "|")
+		if len(parts) < 5 || parts[0] == "" {
+			continue
+		}
+
+		iface := NetworkInterface{
+			Name:  parts[0],
+			State: parts[2],
+			MAC:   parts[3],
+		}
+		iface.MTU, _ = strconv.Atoi(parts[1])
+
+		// Virtual interfaces report -1 or fail to report a speed
+		if speed, err := strconv.Atoi(parts[4]); err == nil && speed > 0 {
+			iface.SpeedMbps = speed
+		}
+
+		interfaces = append(interfaces, iface)
+	}
+
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].Name < interfaces[j].Name
+	})
+
+	return interfaces
+}
+
+// parseInterfaceAddresses parses the output of 'ip -o addr show' grouped by interface name
+func parseInterfaceAddresses(output string) map[string][]InterfaceAddress {
+	addresses := make(map[string][]InterfaceAddress)
+
+	// Format: "2: eth0    inet 10.0.0.5/24 brd 10.0.0.255 scope global eth0\       valid_lft forever ..."
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+
+		family := fields[2]
+		if family != "inet" && family != "inet6" {
+			continue
+		}
+
+		// Interface names may carry a "@parent" suffix for VLANs and veth pairs
+		name := strings.SplitN(fields[1], "@", 2)[0]
+
+		address := InterfaceAddress{Family: family, Address: fields[3]}
+		if ip, network, err := net.ParseCIDR(fields[3]); err == nil {
+			address.Address = ip.String()
+			address.PrefixLength, _ = network.Mask.Size()
+		}
+
+		for i := 4; i < len(fields)-1; i++ {
+			if fields[i] == "scope" {
+				address.Scope = fields[i+1]
+				break
+			}
+		}
+
+		addresses[name] = append(addresses[name], address)
+	}
+
+	return addresses
+}
+
+// parseNetDev parses /proc/net/dev into counters keyed by interface name
+func parseNetDev(output string) map[string]InterfaceCounters {
+	counters := make(map[string]InterfaceCounters)
+
+	for _, line := range strings.Split(output, "\n") {
+		// Header lines do not contain a colon-separated interface name
+		name, values, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		fields := strings.Fields(values)
+		if len(fields) < 16 {
+			continue
+		}
+
+		numbers := make([]uint64, 16)
+		for i := range numbers {
+			numbers[i], _ = strconv.ParseUint(fields[i], 10, 64)
+		}
+
+		// Receive: bytes packets errs drop fifo frame compressed multicast
+		// Transmit: bytes packets errs drop fifo colls carrier compressed
+		counters[strings.TrimSpace(name)] = InterfaceCounters{
+			RxBytes:   numbers[0],
+			RxPackets: numbers[1],
+			RxErrors:  numbers[2],
+			RxDropped: numbers[3],
+			TxBytes:   numbers[8],
+			TxPackets: numbers[9],
+			TxErrors:  numbers[10],
+			TxDropped: numbers[11],
+		}
+	}
+
+	return counters
+}
+
+// computeInterfaceRates derives per-second rates from two counter samples
+func computeInterfaceRates(before, after InterfaceCounters, interval time.Duration) InterfaceRates {
+	seconds := interval.Seconds()
+	rate := func(b, a uint64) float64 {
+		return roundHundredths(float64(counterDelta(b, a)) / seconds)
+	}
+
+	return InterfaceRates{
+		RxBytesPerSec:   rate(before.RxBytes, after.RxBytes),
+		TxBytesPerSec:   rate(before.TxBytes, after.TxBytes),
+		RxPacketsPerSec: rate(before.RxPackets, after.RxPackets),
+		TxPacketsPerSec: rate(before.TxPackets, after.TxPackets),
+		RxErrorsPerSec:  rate(before.RxErrors, after.RxErrors),
+		TxErrorsPerSec:  rate(before.TxErrors, after.TxErrors),
+	}
+}
+
+// parseIPv4Routes parses /proc/net/route, where addresses are little-endian hexadecimal
+func parseIPv4Routes(output string) []Route {
+	routes := []Route{}
+
+	for _, line := range strings.Split(output, "\n") {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask MTU Window IRTT
+		fields := strings.Fields(line)
+		if len(fields) < 8 || fields[0] == "Iface" {
+			continue
+		}
+
+		destination, err1 := parseHexIPv4(fields[1])
+		gateway, err2 := parseHexIPv4(fields[2])
+		mask, err3 := strconv.ParseUint(fields[7], 16, 32)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+
+		prefixLength := bits.OnesCount32(uint32(mask))
+		metric, _ := strconv.Atoi(fields[6])
+
+		route := Route{
+			Family:      "inet",
+			Destination: fmt.Sprintf("%s/%d", destination, prefixLength),
+			Interface:   fields[0],
+			Metric:      metric,
+			Default:     prefixLength == 0,
+		}
+		if !gateway.IsUnspecified() {
+			route.Gateway = gateway.String()
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes
+}
+
+// parseIPv6Routes parses /proc/net/ipv6_route, skipping loopback entries
+func parseIPv6Routes(output string) []Route {
+	routes := []Route{}
+
+	for _, line := range strings.Split(output, "\n") {
+		// dest dest_prefix src src_prefix next_hop metric refcnt use flags iface
+		fields := strings.Fields(line)
+		if len(fields) < 10 || fields[9] == "lo" {
+			continue
+		}
+
+		destination, err1 := parseHexIPv6(fields[0])
+		gateway, err2 := parseHexIPv6(fields[4])
+		prefixLength, err3 := strconv.ParseUint(fields[1], 16, 8)
+		metric, err4 := strconv.ParseUint(fields[5], 16, 32)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			continue
+		}
+
+		route := Route{
+			Family:      "inet6",
+			Destination: fmt.Sprintf("%s/%d", destination, prefixLength),
+			Interface:   fields[9],
+			Metric:      int(metric),
+			Default:     prefixLength == 0,
+		}
+		if !gateway.IsUnspecified() {
+			route.Gateway = gateway.String()
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes
+}
+
+// parseHexIPv4 converts a little-endian hexadecimal IPv4 address as found in /proc/net/route
+func parseHexIPv4(value string) (net.IP, error) {
+	raw, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := make(net.IP, 4)
+	binary.LittleEndian.PutUint32(ip, uint32(raw))
+	return ip, nil
+}
+
+// parseHexIPv6 converts a hexadecimal IPv6 address as found in /proc/net/ipv6_route
+func parseHexIPv6(value string) (net.IP, error) {
+	raw, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != net.IPv6len {
+		return nil, fmt.Errorf("invalid IPv6 address length: %d", len(raw))
+	}
+	return net.IP(raw), nil
+}
+
+// parseResolvConf parses nameserver, search and options directives from /etc/resolv.conf
+func parseResolvConf(output string) DNSConfig {
+	config := DNSConfig{Nameservers: []string{}}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "nameserver":
+			config.Nameservers = append(config.Nameservers, fields[1])
+		case "search", "domain":
+			config.Search = append(config.Search, fields[1:]...)
+		case "options":
+			config.Options = append(config.Options, fields[1:]...)
+		}
+	}
+
+	return config
+}
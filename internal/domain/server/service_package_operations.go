@@ -0,0 +1,337 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// MaxPackagesPerOperation bounds the number of packages in a single operation
+const MaxPackagesPerOperation = 50
+
+// Package operation errors
+var (
+	ErrInvalidPackageOperation = errors.New("invalid package operation")
+	ErrProtectedPackage        = errors.New("refusing to remove a protected package")
+)
+
+// packageNamePattern matches package names of all supported package managers and rejects anything
+// that could be taken for an option
+var packageNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.+_@:~-]*$`)
+
+// protectedPackages cannot be removed because the server, or access to it, would not survive it
+var protectedPackages = map[string]bool{
+	"apk-tools":      true,
+	"apt":            true,
+	"base":           true,
+	"bash":           true,
+	"busybox":        true,
+	"coreutils":      true,
+	"dnf":            true,
+	"dpkg":           true,
+	"glibc":          true,
+	"libc6":          true,
+	"linux-firmware": true,
+	"musl":           true,
+	"openssh":        true,
+	"openssh-server": true,
+	"pacman":         true,
+	"rpm":            true,
+	"sudo":           true,
+	"systemd":        true,
+	"yum":            true,
+	"zypper":         true,
+}
+
+// packageExitMarker prefixes the line carrying the exit status of the package manager
+const packageExitMarker = shell.SectionMarker + shell.ExitStatusMarker
+
+// pacmanPendingUpgradesCommand lists the pending upgrades without syncing the package databases, so
+// that a dry run leaves the server unchanged. checkupdates syncs a temporary copy of the databases;
+// without it, the upgrades known to the current databases are listed. Neither has updates to list
+// as a failure.
+const pacmanPendingUpgradesCommand = `(if command -v checkupdates >/dev/null 2>&1; then checkupdates; s=$?; [ $s -eq 2 ] && s=0; else pacman -Qu; s=$?; [ $s -eq 1 ] && s=0; fi; exit $s)`
+
+var (
+	// aptRemovalPattern matches the packages in the output of 'apt-get -s remove', e.g. "Remv openssh-server [1:9.2p1-2]"
+	aptRemovalPattern = regexp.MustCompile(`(?m)^Remv (\S+)`)
+
+	// apkRemovalPattern matches the packages in the output of 'apk del -s', e.g. "(1/2) Purging openssh-server (9.7_p1-r4)"
+	apkRemovalPattern = regexp.MustCompile(`(?m)^\(\d+/\d+\) Purging (\S+)`)
+)
+
+// RunPackageOperation implements the Service interface
+func (s *service) RunPackageOperation(ctx context.Context, sessionID string, operation string, request PackageOperationRequest, onLine func(string) error) (*PackageOperationResult, error) {
+	if err := validatePackageOperation(operation, request.Packages); err != nil {
+		return nil, err
+	}
+
+	caps, err := s.sessionCapabilities(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	manager := caps.PackageManager
+	if manager == "" {
+		return nil, ErrNoPackageManager
+	}
+	if !caps.Root && !caps.Sudo {
+		return nil, fmt.Errorf("%w: package operations require root or passwordless sudo", ErrPermissionDenied)
+	}
+
+	// Removals also take the packages depending on the removed ones, which must not include protected ones
+	if operation == PackageOperationRemove {
+		if err := s.checkRemovalSet(ctx, sessionID, manager, request.Packages); err != nil {
+			return nil, err
+		}
+	}
+
+	command := packageOperationCommand(manager, operation, request)
+	if command == "" {
+		return nil, fmt.Errorf("%w: %s is not supported by %s", ErrInvalidPackageOperation, operation, manager)
+	}
+	command = fmt.Sprintf("%s </dev/null 2>&1; echo '%s'$?", command, packageExitMarker)
+
+	result := &PackageOperationResult{
+		Manager:   manager,
+		Operation: operation,
+		Packages:  request.Packages,
+		DryRun:    request.DryRun,
+		ExitCode:  -1,
+	}
+	if result.Packages == nil {
+		result.Packages = []string{}
+	}
+
+	// An interrupted transaction can leave the package database inconsistent, so the command is not
+	// tied to the request: when the client goes away its output is drained until the command exits
+	var clientErr error
+	err = shell.StreamLines(context.WithoutCancel(ctx), s.sessionRepo, sessionID, command, func(line string) error {
+		if code, found := strings.CutPrefix(line, packageExitMarker); found {
+			result.ExitCode, _ = strconv.Atoi(code)
+			return nil
+		}
+		if clientErr == nil {
+			clientErr = onLine(line)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Success = result.ExitCode == 0
+	return result, nil
+}
+
+// validatePackageOperation checks the operation and package names. Upgrades without packages upgrade
+// the whole system; installs and removals need at least one package.
+func validatePackageOperation(operation string, packages []string) error {
+	switch operation {
+	case PackageOperationInstall, PackageOperationRemove:
+		if len(packages) == 0 {
+			return fmt.Errorf("%w: %s requires at least one package", ErrInvalidPackageOperation, operation)
+		}
+	case PackageOperationUpgrade:
+	default:
+		return fmt.Errorf("%w: unsupported operation %q (use install, remove or upgrade)", ErrInvalidPackageOperation, operation)
+	}
+
+	if len(packages) > MaxPackagesPerOperation {
+		return fmt.Errorf("%w: at most %d packages per operation", ErrInvalidPackageOperation, MaxPackagesPerOperation)
+	}
+
+	for _, pkg := range packages {
+		if !packageNamePattern.MatchString(pkg) {
+			return fmt.Errorf("%w: invalid package name %q", ErrInvalidPackageOperation, pkg)
+		}
+		if operation == PackageOperationRemove && protectedPackages[pkg] {
+			return fmt.Errorf("%w: %s", ErrProtectedPackage, pkg)
+		}
+	}
+
+	return nil
+}
+
+// checkRemovalSet simulates the removal of packages and refuses it when the packages that would be
+// removed, including dependent ones, contain a protected package
+func (s *service) checkRemovalSet(ctx context.Context, sessionID string, manager string, packages []string) error {
+	command := removalSimulationCommand(manager, packages)
+	if command == "" {
+		return fmt.Errorf("%w: remove is not supported by %s", ErrInvalidPackageOperation, manager)
+	}
+
+	// The exit status is not checked: dnf reports the declined transaction as a failure
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, command+" </dev/null 2>&1; true")
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range parseRemovalSet(manager, output) {
+		if protectedPackages[pkg] {
+			return fmt.Errorf("%w: %s would be removed as a dependent package", ErrProtectedPackage, pkg)
+		}
+	}
+	return nil
+}
+
+// removalSimulationCommand builds the command listing the packages a removal would remove, without
+// changing the server
+func removalSimulationCommand(manager string, packages []string) string {
+	quoted := make([]string, len(packages))
+	for i, pkg := range packages {
+		quoted[i] = shell.Quote(pkg)
+	}
+
+	var args string
+	switch manager {
+	case PackageManagerApt:
+		args = "apt-get -s remove"
+	case PackageManagerDnf, PackageManagerYum:
+		args = manager + " --assumeno remove"
+	case PackageManagerZypper:
+		args = "zypper --non-interactive remove --dry-run"
+	case PackageManagerPacman:
+		args = "pacman -R --print --print-format %n"
+	case PackageManagerApk:
+		args = "apk del -s"
+	default:
+		return ""
+	}
+
+	return shell.SudoPrefix + " " + args + " " + strings.Join(quoted, " ")
+}
+
+// parseRemovalSet extracts the names of the packages a removal would remove from the output of
+// removalSimulationCommand
+func parseRemovalSet(manager string, output string) []string {
+	var packages []string
+	switch manager {
+	case PackageManagerApt:
+		for _, match := range aptRemovalPattern.FindAllStringSubmatch(output, -1) {
+			packages = append(packages, match[1])
+		}
+	case PackageManagerApk:
+		for _, match := range apkRemovalPattern.FindAllStringSubmatch(output, -1) {
+			packages = append(packages, match[1])
+		}
+	case PackageManagerPacman:
+		for _, line := range strings.Split(output, "\n") {
+			if fields := strings.Fields(line); len(fields) == 1 {
+				packages = append(packages, fields[0])
+			}
+		}
+	case PackageManagerDnf, PackageManagerYum:
+		// The transaction table lists packages in indented rows, starting with the name, under
+		// "Removing:", "Removing dependent packages:" and "Removing unused dependencies:"
+		removing := false
+		for _, line := range strings.Split(output, "\n") {
+			if line == "" || !strings.HasPrefix(line, " ") {
+				removing = strings.HasPrefix(line, "Removing") && strings.HasSuffix(strings.TrimSpace(line), ":")
+				continue
+			}
+			if fields := strings.Fields(line); removing && len(fields) > 0 {
+				packages = append(packages, fields[0])
+			}
+		}
+	case PackageManagerZypper:
+		// "The following 2 packages are going to be REMOVED:" followed by indented names
+		removing := false
+		for _, line := range strings.Split(output, "\n") {
+			switch {
+			case strings.Contains(line, "going to be REMOVED"):
+				removing = true
+			case removing && strings.HasPrefix(line, " "):
+				packages = append(packages, strings.Fields(line)...)
+			default:
+				removing = false
+			}
+		}
+	}
+	return packages
+}
+
+// packageOperationCommand builds the non-interactive package manager command for an operation
+func packageOperationCommand(manager string, operation string, request PackageOperationRequest) string {
+	quoted := make([]string, len(request.Packages))
+	for i, pkg := range request.Packages {
+		quoted[i] = shell.Quote(pkg)
+	}
+	packages := strings.Join(quoted, " ")
+	all := len(request.Packages) == 0
+
+	var args string
+	switch manager {
+	case PackageManagerApt:
+		args = "env DEBIAN_FRONTEND=noninteractive apt-get -y -o Dpkg::Options::=--force-confdef -o Dpkg::Options::=--force-confold"
+		if request.DryRun {
+			args += " -s"
+		}
+		switch {
+		case operation == PackageOperationInstall:
+			args += " install " + packages
+		case operation == PackageOperationRemove:
+			args += " remove " + packages
+		case all:
+			args += " upgrade"
+		default:
+			args += " install --only-upgrade " + packages
+		}
+	case PackageManagerDnf, PackageManagerYum:
+		args = manager + " -y"
+		if request.DryRun {
+			args += " --setopt=tsflags=test"
+		}
+		args += " " + operation + " " + packages
+	case PackageManagerZypper:
+		subcommand := operation
+		if operation == PackageOperationUpgrade {
+			subcommand = "update"
+		}
+		args = "zypper --non-interactive " + subcommand
+		if request.DryRun {
+			args += " --dry-run"
+		}
+		args += " " + packages
+	case PackageManagerPacman:
+		// A dry run must not sync the databases, which would leave a partial upgrade for the next -S
+		if request.DryRun && operation == PackageOperationUpgrade && all {
+			return pacmanPendingUpgradesCommand
+		}
+		switch {
+		case operation == PackageOperationRemove:
+			args = "pacman -R --noconfirm"
+		case operation == PackageOperationUpgrade && all:
+			args = "pacman -Syu --noconfirm"
+		default:
+			args = "pacman -S --noconfirm --needed"
+		}
+		if request.DryRun {
+			args += " --print"
+		}
+		args += " " + packages
+	case PackageManagerApk:
+		switch {
+		case operation == PackageOperationInstall:
+			args = "apk add"
+		case operation == PackageOperationRemove:
+			args = "apk del"
+		case all:
+			args = "apk upgrade"
+		default:
+			args = "apk add --upgrade"
+		}
+		if request.DryRun {
+			args += " --simulate"
+		}
+		args += " " + packages
+	default:
+		return ""
+	}
+
+	return shell.SudoPrefix + " " + strings.TrimSpace(args)
+}
@@ -0,0 +1,295 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// pendingUpdatesScript lists the available updates of the detected package manager in the "updates"
+// section and, where the package manager knows about advisories, the packages with security updates in
+// the "security" section. When $R is 1 the package metadata is refreshed first, which needs root or
+// passwordless sudo. checkupdates refreshes a private copy of the pacman database by itself. The
+// package manager command is expected in $m, see packageManagerScript.
+const pendingUpdatesScript = `S="` + shell.SudoIfAvailable + `"
+case "$m" in
+apt-get)
+  [ "$R" = 1 ] && $S apt-get update -qq >/dev/null 2>&1 && echo '### refreshed'
+  echo '### updates'
+  LC_ALL=C apt list --upgradable 2>/dev/null ;;
+dnf|yum)
+  [ "$R" = 1 ] && $S $m -q makecache >/dev/null 2>&1 && echo '### refreshed'
+  echo '### updates'
+  LC_ALL=C $m -q check-update 2>/dev/null
+  echo '### security'
+  LC_ALL=C $m -q updateinfo list security 2>/dev/null ;;
+zypper)
+  [ "$R" = 1 ] && $S zypper -q --non-interactive refresh >/dev/null 2>&1 && echo '### refreshed'
+  echo '### updates'
+  LC_ALL=C zypper -q --non-interactive list-updates 2>/dev/null ;;
+pacman)
+  echo '### updates'
+  if command -v checkupdates >/dev/null 2>&1; then
+    checkupdates 2>/dev/null
+  else
+    [ "$R" = 1 ] && $S pacman -Sy >/dev/null 2>&1
+    LC_ALL=C pacman -Qu 2>/dev/null
+  fi
+  if command -v arch-audit >/dev/null 2>&1; then
+    echo '### security'
+    arch-audit -uq 2>/dev/null
+  fi ;;
+apk)
+  [ "$R" = 1 ] && $S apk update -q >/dev/null 2>&1 && echo '### refreshed'
+  echo '### updates'
+  apk version -l '<' 2>/dev/null ;;
+esac
+true`
+
+// GetPendingUpdates implements the Service interface
+func (s *service) GetPendingUpdates(ctx context.Context, sessionID string, refresh bool) (*PendingUpdates, error) {
+	caps, err := s.sessionCapabilities(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	command := "R=0\n" + pendingUpdatesScript
+	if refresh {
+		command = "R=1\n" + pendingUpdatesScript
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, packageManagerScript(caps, command))
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePendingUpdates(caps.PackageManager, shell.ParseSections(output))
+}
+
+// parsePendingUpdates builds the pending updates of a package manager from the sections printed by
+// pendingUpdatesScript
+func parsePendingUpdates(manager string, sections map[string]string) (*PendingUpdates, error) {
+	if manager == "" {
+		return nil, ErrNoPackageManager
+	}
+
+	updates := &PendingUpdates{
+		Manager: manager,
+		Updates: []PendingUpdate{},
+	}
+	_, updates.Refreshed = sections["refreshed"]
+
+	switch manager {
+	case PackageManagerApt:
+		updates.Updates = parseAptUpgradable(sections["updates"])
+		updates.SecurityInfoAvailable = true
+	case PackageManagerDnf, PackageManagerYum:
+		updates.Updates = parseDnfCheckUpdate(sections["updates"])
+		markSecurityUpdates(updates.Updates, parseDnfSecurityPackages(sections["security"]))
+		updates.SecurityInfoAvailable = true
+	case PackageManagerZypper:
+		updates.Updates = parseZypperUpdates(sections["updates"])
+	case PackageManagerPacman:
+		updates.Updates = parsePacmanUpdates(sections["updates"])
+		if security, ok := sections["security"]; ok {
+			markSecurityUpdates(updates.Updates, parseNameList(security))
+			updates.SecurityInfoAvailable = true
+		}
+	case PackageManagerApk:
+		updates.Updates = parseAPKUpdates(sections["updates"])
+	}
+
+	for _, update := range updates.Updates {
+		if update.Security {
+			updates.SecurityCount++
+		}
+	}
+
+	return updates, nil
+}
+
+// markSecurityUpdates flags the updates of the given packages as security updates
+func markSecurityUpdates(updates []PendingUpdate, security map[string]bool) {
+	for i := range updates {
+		if security[updates[i].Name] {
+			updates[i].Security = true
+		}
+	}
+}
+
+// parseAptUpgradable parses apt list --upgradable lines such as
+// "openssl/jammy-updates,jammy-security 3.0.2-0ubuntu1.15 amd64 [upgradable from: 3.0.2-0ubuntu1.14]".
+// Updates from a "-security" suite are security updates.
+func parseAptUpgradable(output string) []PendingUpdate {
+	updates := []PendingUpdate{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !strings.Contains(fields[0], "/") {
+			continue
+		}
+
+		name, suites, _ := strings.Cut(fields[0], "/")
+		update := PendingUpdate{
+			Name:             name,
+			AvailableVersion: fields[1],
+			Arch:             fields[2],
+			Repository:       suites,
+		}
+		if _, current, found := strings.Cut(line, "upgradable from: "); found {
+			update.CurrentVersion = strings.TrimSuffix(strings.TrimSpace(current), "]")
+		}
+		for _, suite := range strings.Split(suites, ",") {
+			if strings.HasSuffix(suite, "-security") || strings.HasSuffix(suite, "/updates") {
+				update.Security = true
+			}
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates
+}
+
+// parseDnfCheckUpdate parses check-update lines of the form "name.arch version repository".
+// Long package names push the remaining columns onto the next line, and the list of obsoleted
+// packages at the end is skipped.
+func parseDnfCheckUpdate(output string) []PendingUpdate {
+	updates := []PendingUpdate{}
+
+	var pending []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "Obsoleting") {
+			break
+		}
+
+		fields := append(pending, strings.Fields(line)...)
+		if len(fields) < 3 {
+			pending = fields
+			continue
+		}
+		pending = nil
+		if len(fields) > 3 {
+			continue
+		}
+
+		name, arch := fields[0], ""
+		if dot := strings.LastIndex(name, "."); dot > 0 {
+			name, arch = name[:dot], name[dot+1:]
+		}
+		updates = append(updates, PendingUpdate{
+			Name:             name,
+			AvailableVersion: fields[1],
+			Arch:             arch,
+			Repository:       fields[2],
+		})
+	}
+
+	return updates
+}
+
+// parseDnfSecurityPackages extracts the package names from updateinfo lines such as
+// "RHSA-2024:1234 Important/Sec. openssl-libs-1:3.0.7-25.el9.x86_64"
+func parseDnfSecurityPackages(output string) map[string]bool {
+	packages := make(map[string]bool)
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+
+		// Strip the architecture, release and version from the NEVRA
+		nevra := fields[len(fields)-1]
+		if dot := strings.LastIndex(nevra, "."); dot > 0 {
+			nevra = nevra[:dot]
+		}
+		for i := 0; i < 2; i++ {
+			if dash := strings.LastIndex(nevra, "-"); dash > 0 {
+				nevra = nevra[:dash]
+			}
+		}
+		packages[nevra] = true
+	}
+
+	return packages
+}
+
+// parseZypperUpdates parses the list-updates table
+// "v | repository | name | current version | available version | arch"
+func parseZypperUpdates(output string) []PendingUpdate {
+	updates := []PendingUpdate{}
+
+	for _, line := range strings.Split(output, "\n") {
+		columns := strings.Split(line, "|")
+		if len(columns) < 6 || strings.TrimSpace(columns[0]) != "v" {
+			continue
+		}
+
+		updates = append(updates, PendingUpdate{
+			Repository:       strings.TrimSpace(columns[1]),
+			Name:             strings.TrimSpace(columns[2]),
+			CurrentVersion:   strings.TrimSpace(columns[3]),
+			AvailableVersion: strings.TrimSpace(columns[4]),
+			Arch:             strings.TrimSpace(columns[5]),
+		})
+	}
+
+	return updates
+}
+
+// parsePacmanUpdates parses "name current -> available" lines from checkupdates and pacman -Qu
+func parsePacmanUpdates(output string) []PendingUpdate {
+	updates := []PendingUpdate{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[2] != "->" {
+			continue
+		}
+
+		updates = append(updates, PendingUpdate{
+			Name:             fields[0],
+			CurrentVersion:   fields[1],
+			AvailableVersion: fields[3],
+		})
+	}
+
+	return updates
+}
+
+// parseAPKUpdates parses apk version lines such as "musl-1.2.4-r1 < 1.2.4-r2"
+func parseAPKUpdates(output string) []PendingUpdate {
+	updates := []PendingUpdate{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[1] != "<" {
+			continue
+		}
+
+		name, current, ok := splitAPKPackage(fields[0])
+		if !ok {
+			continue
+		}
+		updates = append(updates, PendingUpdate{
+			Name:             name,
+			CurrentVersion:   current,
+			AvailableVersion: fields[2],
+		})
+	}
+
+	return updates
+}
+
+// parseNameList parses one package name per line
+func parseNameList(output string) map[string]bool {
+	names := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
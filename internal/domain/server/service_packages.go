@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// Package errors
+var (
+	ErrNoPackageManager = errors.New("could not detect a supported package manager")
+)
+
+// detectPackageManagerScript finds the package manager of the server when it is probed. Its name is
+// printed in the "manager" section, with apt-get reported as apt.
+const detectPackageManagerScript = `m=""
+for c in apt-get dnf yum zypper pacman apk; do
+  command -v "$c" >/dev/null 2>&1 && { m=$c; break; }
+done
+echo '### manager'
+echo "${m%-get}"
+`
+
+// installedPackagesScript lists the installed packages as tab separated name, version, architecture
+// and status fields. dpkg reports its status as "want flag state", so only the state is kept. apk
+// list is missing from older apk-tools, which fall back to apk info without architectures. The
+// package manager command is expected in $m, see packageManagerScript.
+const installedPackagesScript = `echo '### packages'
+case "$m" in
+apt-get) dpkg-query -W -f='${Package}\t${Version}\t${Architecture}\t${Status}\n' 2>/dev/null ;;
+dnf|yum|zypper) rpm -qa --queryformat '%{NAME}\t%|EPOCH?{%{EPOCH}:}:{}|%{VERSION}-%{RELEASE}\t%{ARCH}\tinstalled\n' 2>/dev/null ;;
+pacman) LC_ALL=C pacman -Qi 2>/dev/null | awk '
+  { value = $0; sub(/^[^:]*: */, "", value) }
+  /^Name /         { name = value }
+  /^Version /      { version = value }
+  /^Architecture / { printf "%s\t%s\t%s\tinstalled\n", name, version, value }' ;;
+apk) apk list --installed 2>/dev/null || apk info -v 2>/dev/null ;;
+esac
+true`
+
+// GetInstalledLibraries implements the Service interface
+func (s *service) GetInstalledLibraries(ctx context.Context, sessionID string) ([]Library, error) {
+	caps, err := s.sessionCapabilities(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if caps.PackageManager == "" {
+		return nil, ErrNoPackageManager
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, packageManagerScript(caps, installedPackagesScript))
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	manager := caps.PackageManager
+	if manager == PackageManagerApk {
+		return parseAPKPackages(sections["packages"]), nil
+	}
+	return parseTabSeparatedPackages(manager, sections["packages"]), nil
+}
+
+// packageManagerScript prefixes a package script with the command of the package manager in the
+// capability profile, stored in $m, e.g. apt-get for apt
+func packageManagerScript(caps *Capabilities, script string) string {
+	command := caps.PackageManager
+	if command == PackageManagerApt {
+		command = "apt-get"
+	}
+	return "m=" + shell.Quote(command) + "\n" + script
+}
+
+// parseTabSeparatedPackages parses "name\tversion\tarch\tstatus" lines. The status is the last word,
+// which turns the dpkg "install ok installed" triple into "installed".
+func parseTabSeparatedPackages(manager string, output string) []Library {
+	libraries := []Library{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 4 || fields[0] == "" {
+			continue
+		}
+
+		status := "unknown"
+		if words := strings.Fields(fields[3]); len(words) > 0 {
+			status = words[len(words)-1]
+		}
+
+		libraries = append(libraries, Library{
+			Name:    fields[0],
+			Version: fields[1],
+			Arch:    fields[2],
+			Status:  status,
+			Manager: manager,
+		})
+	}
+
+	return libraries
+}
+
+// parseAPKPackages parses apk list lines such as
+// "musl-1.2.4-r2 x86_64 {musl} (MIT) [installed]" or, from apk info -v, "musl-1.2.4-r2"
+func parseAPKPackages(output string) []Library {
+	libraries := []Library{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "WARNING") {
+			continue
+		}
+
+		name, version, ok := splitAPKPackage(fields[0])
+		if !ok {
+			continue
+		}
+
+		library := Library{
+			Name:    name,
+			Version: version,
+			Arch:    "unknown",
+			Status:  "installed",
+			Manager: PackageManagerApk,
+		}
+		if len(fields) > 1 {
+			library.Arch = fields[1]
+		}
+		libraries = append(libraries, library)
+	}
+
+	return libraries
+}
+
+// splitAPKPackage splits "name-version-rN" into the name and "version-rN". Package names may contain
+// dashes, so the version starts at the second to last dash.
+func splitAPKPackage(pkg string) (string, string, bool) {
+	release := strings.LastIndex(pkg, "-")
+	if release <= 0 {
+		return "", "", false
+	}
+	version := strings.LastIndex(pkg[:release], "-")
+	if version <= 0 {
+		return "", "", false
+	}
+	return pkg[:version], pkg[version+1:], true
+}
@@ -0,0 +1,722 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"remote-server-api/pkg/shell"
+)
+
+// securityAuditScript runs the read-only checks of the security audit in one round trip, using
+// passwordless sudo when available. The sshd configuration files are prefixed with "|" so that
+// comments cannot be taken for section headers. Failed logins are counted over the last 24 hours
+// from the journal, or over today and yesterday from the syslog auth files. The package manager command
+// is expected in $m, see packageManagerScript.
+const securityAuditScript = `S="` + shell.SudoIfAvailable + `"
+echo '### privileged'
+{ [ "$(id -u)" -eq 0 ] || [ -n "$S" ]; } && echo yes
+d=$(command -v sshd 2>/dev/null)
+[ -z "$d" ] && [ -x /usr/sbin/sshd ] && d=/usr/sbin/sshd
+{ [ -n "$d" ] || [ -f /etc/ssh/sshd_config ]; } && echo '### sshd-installed'
+echo '### sshd-effective'
+[ -n "$d" ] && $S "$d" -T 2>/dev/null
+for f in /etc/ssh/sshd_config /etc/ssh/sshd_config.d/*.conf; do
+  [ -f "$f" ] || continue
+  echo "### sshd-file $f"
+  $S cat "$f" 2>/dev/null | sed 's/^/|/'
+done
+echo '### world-writable-files'
+$S find /bin /sbin /usr /lib /lib64 /etc /boot /opt -xdev -type f -perm -0002 2>/dev/null | head -n 100
+echo '### world-writable-dirs'
+$S find /bin /sbin /usr /lib /lib64 /etc /boot /opt -xdev -type d -perm -0002 ! -perm -1000 2>/dev/null | head -n 100
+echo '### suid'
+$S find /bin /sbin /usr /lib /lib64 /opt /snap -xdev -type f -perm -4000 2>/dev/null | head -n 500
+R=0
+` + pendingUpdatesScript + `
+echo '### failed-logins'
+p='Failed password|Invalid user|authentication failure'
+if command -v journalctl >/dev/null 2>&1; then
+  echo 'source=journal'
+  l=$($S journalctl -q --no-pager --since '-24h' SYSLOG_FACILITY=4 SYSLOG_FACILITY=10 2>/dev/null | grep -E "$p")
+else
+  l=
+  for f in /var/log/auth.log /var/log/secure; do
+    [ -f "$f" ] || continue
+    echo "source=$f"
+    d1=$(date '+%b %e'); d2=$(date -d yesterday '+%b %e' 2>/dev/null || echo "$d1")
+    i1=$(date +%Y-%m-%d); i2=$(date -d yesterday +%Y-%m-%d 2>/dev/null || echo "$i1")
+    l=$($S grep -E "^($d1|$d2|$i1|$i2)" "$f" 2>/dev/null | grep -E "$p")
+    break
+  done
+fi
+echo "count=$(printf '%s\n' "$l" | grep -c -E "$p")"
+printf '%s\n' "$l" | grep -oE 'from [0-9a-fA-F.:]+' | sort | uniq -c | sort -rn | head -n 5 | sed 's/^ */top=/'
+for u in fail2ban sshguard crowdsec; do
+  systemctl is-active --quiet "$u" 2>/dev/null && echo "protection=$u"
+done
+echo '### auto-updates'
+if command -v apt-get >/dev/null 2>&1; then
+  echo 'tool=unattended-upgrades'
+  dpkg-query -W -f='${Status}' unattended-upgrades 2>/dev/null | grep -q 'install ok installed' && echo 'installed=1'
+  echo "enabled=$(apt-config dump 2>/dev/null | sed -n 's/^APT::Periodic::Unattended-Upgrade "\(.*\)";/\1/p' | tail -n 1)"
+elif command -v dnf >/dev/null 2>&1 || command -v yum >/dev/null 2>&1; then
+  echo 'tool=dnf-automatic'
+  for t in dnf-automatic-install.timer dnf-automatic.timer dnf5-automatic.timer yum-cron.service; do
+    [ "$(systemctl is-enabled "$t" 2>/dev/null)" = enabled ] && echo "unit=$t"
+  done
+  sed -n 's/^apply_updates *= *//p' /etc/dnf/automatic.conf 2>/dev/null | head -n 1 | sed 's/^/apply=/'
+fi
+echo '### docker'
+if command -v dockerd >/dev/null 2>&1 || [ -S /var/run/docker.sock ]; then
+  echo 'installed=1'
+  for c in /proc/[0-9]*/comm; do
+    [ "$(cat "$c" 2>/dev/null)" = dockerd ] && { tr '\0' ' ' < "${c%/comm}/cmdline"; echo; } | sed 's/^/cmd=/'
+  done 2>/dev/null
+  systemctl cat docker.service 2>/dev/null | sed -n 's/^ExecStart=\(..*\)/cmd=\1/p'
+  echo '### docker-daemon-json'
+  $S cat /etc/docker/daemon.json 2>/dev/null
+fi
+echo '### listeners'
+{ ss -Htln 2>/dev/null || netstat -tln 2>/dev/null; } | awk '{ print $4 }'
+true`
+
+// severityPenalties are the points a failed check of each severity deducts from the score
+var severityPenalties = map[string]int{
+	SeverityCritical: 30,
+	SeverityHigh:     15,
+	SeverityMedium:   8,
+	SeverityLow:      3,
+}
+
+// Failed login counts from which the failed-logins check fails
+const (
+	failedLoginsLow    = 20
+	failedLoginsMedium = 200
+)
+
+// maxEvidence bounds the evidence listed per finding
+const maxEvidence = 25
+
+// knownSUIDBinaries are setuid programs shipped by common distributions
+var knownSUIDBinaries = map[string]bool{
+	"at": true, "chage": true, "chfn": true, "chsh": true, "crontab": true, "dbus-daemon-launch-helper": true,
+	"doas": true, "expiry": true, "fusermount": true, "fusermount3": true, "gpasswd": true, "ksu": true,
+	"mount": true, "mount.cifs": true, "mount.nfs": true, "newgidmap": true, "newgrp": true, "newuidmap": true,
+	"pam_timestamp_check": true, "passwd": true, "ping": true, "ping6": true, "pkexec": true,
+	"polkit-agent-helper-1": true, "sg": true, "snap-confine": true, "ssh-keysign": true, "su": true,
+	"sudo": true, "sudoedit": true, "traceroute6.iputils": true, "umount": true, "unix_chkpwd": true,
+	"userhelper": true, "Xorg.wrap": true, "chrome-sandbox": true, "ntfs-3g": true, "vmware-user-suid-wrapper": true,
+	"staprun": true, "write": true, "wall": true, "bwrap": true, "usernetctl": true, "grub2-set-bootflag": true,
+}
+
+// GetSecurityAudit implements the Service interface
+func (s *service) GetSecurityAudit(ctx context.Context, sessionID string) (*SecurityAudit, error) {
+	caps, err := s.sessionCapabilities(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, packageManagerScript(caps, securityAuditScript))
+	if err != nil {
+		return nil, err
+	}
+	sections := shell.ParseSections(output)
+
+	audit := &SecurityAudit{
+		Score:       100,
+		Privileged:  strings.TrimSpace(sections["privileged"]) == "yes",
+		CollectedAt: time.Now().UTC(),
+	}
+
+	audit.Findings = append(audit.Findings, checkSSHConfig(sections)...)
+	audit.Findings = append(audit.Findings,
+		checkWorldWritableFiles(sections),
+		checkWorldWritableDirs(sections),
+		checkSUIDBinaries(sections),
+		checkSecurityUpdates(caps.PackageManager, sections),
+		checkFailedLogins(sections, audit.Privileged),
+		checkAutomaticUpdates(sections),
+		checkDockerExposure(sections),
+	)
+
+	for i := range audit.Findings {
+		finding := &audit.Findings[i]
+		switch finding.Status {
+		case CheckPassed:
+			finding.Remediation = ""
+			audit.Summary.Passed++
+		case CheckUnknown:
+			audit.Summary.Unknown++
+		case CheckFailed:
+			finding.Penalty = severityPenalties[finding.Severity]
+			audit.Score -= finding.Penalty
+			switch finding.Severity {
+			case SeverityCritical:
+				audit.Summary.Critical++
+			case SeverityHigh:
+				audit.Summary.High++
+			case SeverityMedium:
+				audit.Summary.Medium++
+			case SeverityLow:
+				audit.Summary.Low++
+			}
+		}
+	}
+	if audit.Score < 0 {
+		audit.Score = 0
+	}
+
+	// Failed checks first, the most severe on top
+	statusRank := map[string]int{CheckFailed: 0, CheckUnknown: 1, CheckPassed: 2}
+	severityRank := map[string]int{SeverityCritical: 0, SeverityHigh: 1, SeverityMedium: 2, SeverityLow: 3}
+	sort.SliceStable(audit.Findings, func(i, j int) bool {
+		a, b := audit.Findings[i], audit.Findings[j]
+		if statusRank[a.Status] != statusRank[b.Status] {
+			return statusRank[a.Status] < statusRank[b.Status]
+		}
+		return severityRank[a.Severity] < severityRank[b.Severity]
+	})
+
+	return audit, nil
+}
+
+// checkSSHConfig checks root login, password authentication and empty passwords of sshd. The
+// effective configuration of 'sshd -T' is preferred; without privileges the configuration files are
+// read, where the first value of a keyword wins as in sshd.
+func checkSSHConfig(sections map[string]string) []SecurityFinding {
+	rootLogin := SecurityFinding{
+		ID:          "ssh-root-login",
+		Category:    "ssh",
+		Title:       "SSH root login",
+		Severity:    SeverityHigh,
+		Remediation: "Set 'PermitRootLogin no' (or 'prohibit-password') in /etc/ssh/sshd_config and reload sshd",
+	}
+	passwordAuth := SecurityFinding{
+		ID:          "ssh-password-authentication",
+		Category:    "ssh",
+		Title:       "SSH password authentication",
+		Severity:    SeverityMedium,
+		Remediation: "Set 'PasswordAuthentication no' and 'KbdInteractiveAuthentication no' in /etc/ssh/sshd_config after installing SSH keys, and reload sshd",
+	}
+	emptyPasswords := SecurityFinding{
+		ID:          "ssh-empty-passwords",
+		Category:    "ssh",
+		Title:       "SSH logins with empty passwords",
+		Severity:    SeverityCritical,
+		Remediation: "Set 'PermitEmptyPasswords no' in /etc/ssh/sshd_config and reload sshd",
+	}
+	findings := []SecurityFinding{rootLogin, passwordAuth, emptyPasswords}
+
+	if _, installed := sections["sshd-installed"]; !installed {
+		for i := range findings {
+			findings[i].Status = CheckPassed
+			findings[i].Details = "sshd is not installed"
+		}
+		return findings
+	}
+
+	config, source := parseSSHDEffective(sections["sshd-effective"]), "effective configuration (sshd -T)"
+	if len(config) == 0 {
+		config, source = parseSSHDConfigFiles(sections), "configuration files"
+	}
+	if len(config) == 0 {
+		for i := range findings {
+			findings[i].Status = CheckUnknown
+			findings[i].Details = "The sshd configuration could not be read"
+		}
+		return findings
+	}
+	setting := func(key string, defaultValue string) string {
+		if value, ok := config[key]; ok {
+			return strings.ToLower(value)
+		}
+		return defaultValue
+	}
+
+	// Defaults of OpenSSH 7.0 and later
+	permitRootLogin := setting("permitrootlogin", "prohibit-password")
+	findings[0].Evidence = []string{"PermitRootLogin " + permitRootLogin}
+	if permitRootLogin == "yes" {
+		findings[0].Status = CheckFailed
+		findings[0].Details = "root can log in with a password according to the " + source
+	} else {
+		findings[0].Status = CheckPassed
+		findings[0].Details = "root cannot log in with a password according to the " + source
+	}
+
+	password := setting("passwordauthentication", "yes")
+	keyboardInteractive := setting("kbdinteractiveauthentication", setting("challengeresponseauthentication", "yes"))
+	usePAM := setting("usepam", "no")
+	findings[1].Evidence = []string{"PasswordAuthentication " + password, "KbdInteractiveAuthentication " + keyboardInteractive, "UsePAM " + usePAM}
+	switch {
+	case password == "yes":
+		findings[1].Status = CheckFailed
+		findings[1].Details = "Password logins are allowed according to the " + source
+	case keyboardInteractive == "yes" && usePAM == "yes":
+		findings[1].Status = CheckFailed
+		findings[1].Details = "Passwords are still accepted through keyboard-interactive authentication with PAM according to the " + source
+	default:
+		findings[1].Status = CheckPassed
+		findings[1].Details = "Password logins are disabled according to the " + source
+	}
+
+	permitEmpty := setting("permitemptypasswords", "no")
+	findings[2].Evidence = []string{"PermitEmptyPasswords " + permitEmpty}
+	if permitEmpty == "yes" {
+		findings[2].Status = CheckFailed
+		findings[2].Details = "Accounts without a password can log in according to the " + source
+	} else {
+		findings[2].Status = CheckPassed
+		findings[2].Details = "Accounts without a password cannot log in according to the " + source
+	}
+
+	return findings
+}
+
+// parseSSHDEffective parses 'sshd -T' output of lowercase "keyword value" lines
+func parseSSHDEffective(output string) map[string]string {
+	config := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if ok {
+			config[strings.ToLower(key)] = strings.TrimSpace(value)
+		}
+	}
+	return config
+}
+
+// parseSSHDConfigFiles reads the global settings of the sshd configuration files, following Include
+// directives into the files of sshd_config.d. Settings after a Match block are conditional and skipped.
+func parseSSHDConfigFiles(sections map[string]string) map[string]string {
+	config := make(map[string]string)
+	visited := make(map[string]bool)
+
+	var read func(file string)
+	read = func(file string) {
+		content, ok := sections["sshd-file "+file]
+		if !ok || visited[file] {
+			return
+		}
+		visited[file] = true
+
+		for _, line := range strings.Split(shell.TrimLinePrefix(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			fields := strings.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == '\t' || r == '=' })
+			if len(fields) < 2 {
+				continue
+			}
+			key := strings.ToLower(fields[0])
+			switch key {
+			case "match":
+				return
+			case "include":
+				for _, pattern := range fields[1:] {
+					if !strings.HasPrefix(pattern, "/") {
+						pattern = "/etc/ssh/" + pattern
+					}
+					var files []string
+					for name := range sections {
+						included, ok := strings.CutPrefix(name, "sshd-file ")
+						if matched, _ := path.Match(pattern, included); ok && matched {
+							files = append(files, included)
+						}
+					}
+					sort.Strings(files)
+					for _, included := range files {
+						read(included)
+					}
+				}
+			default:
+				if _, set := config[key]; !set {
+					config[key] = fields[1]
+				}
+			}
+		}
+	}
+	read("/etc/ssh/sshd_config")
+
+	return config
+}
+
+// checkWorldWritableFiles reports files in system paths that any user can modify
+func checkWorldWritableFiles(sections map[string]string) SecurityFinding {
+	files := nonEmptyLines(sections["world-writable-files"])
+	finding := SecurityFinding{
+		ID:       "world-writable-files",
+		Category: "filesystem",
+		Title:    "World-writable files in system paths",
+		Severity: SeverityHigh,
+		Status:   CheckPassed,
+		Details:  "No world-writable files in /bin, /sbin, /usr, /lib, /etc, /boot and /opt",
+	}
+	if len(files) > 0 {
+		finding.Status = CheckFailed
+		finding.Details = fmt.Sprintf("%s world-writable files in system paths, which any user can modify", upperFirst(countLabel(len(files), 100)))
+		finding.Evidence = truncateEvidence(files)
+		finding.Remediation = "Remove write access for others with 'chmod o-w <file>'"
+	}
+	return finding
+}
+
+// checkWorldWritableDirs reports directories in system paths that any user can write to without the
+// sticky bit, so that users can replace each other's files
+func checkWorldWritableDirs(sections map[string]string) SecurityFinding {
+	dirs := nonEmptyLines(sections["world-writable-dirs"])
+	finding := SecurityFinding{
+		ID:       "world-writable-directories",
+		Category: "filesystem",
+		Title:    "World-writable directories without sticky bit in system paths",
+		Severity: SeverityMedium,
+		Status:   CheckPassed,
+		Details:  "No world-writable directories without sticky bit in system paths",
+	}
+	if len(dirs) > 0 {
+		finding.Status = CheckFailed
+		finding.Details = fmt.Sprintf("%s world-writable directories without sticky bit, in which any user can replace files", upperFirst(countLabel(len(dirs), 100)))
+		finding.Evidence = truncateEvidence(dirs)
+		finding.Remediation = "Remove write access for others with 'chmod o-w <dir>', or set the sticky bit with 'chmod +t <dir>'"
+	}
+	return finding
+}
+
+// checkSUIDBinaries reports setuid-root programs that are not part of common distributions
+func checkSUIDBinaries(sections map[string]string) SecurityFinding {
+	binaries := nonEmptyLines(sections["suid"])
+	var unexpected []string
+	for _, binary := range binaries {
+		if !knownSUIDBinaries[path.Base(binary)] {
+			unexpected = append(unexpected, binary)
+		}
+	}
+
+	finding := SecurityFinding{
+		ID:       "suid-binaries",
+		Category: "filesystem",
+		Title:    "Unexpected setuid binaries",
+		Severity: SeverityMedium,
+		Status:   CheckPassed,
+		Details:  fmt.Sprintf("%s setuid binaries, all commonly shipped by distributions", upperFirst(countLabel(len(binaries), 500))),
+	}
+	if len(unexpected) > 0 {
+		finding.Status = CheckFailed
+		finding.Details = fmt.Sprintf("%d of %s setuid binaries are not commonly shipped by distributions", len(unexpected), countLabel(len(binaries), 500))
+		finding.Evidence = truncateEvidence(unexpected)
+		finding.Remediation = "Check that each program needs to run as its owner; remove the bit with 'chmod u-s <file>' or uninstall it"
+	}
+	return finding
+}
+
+// checkSecurityUpdates reports pending security updates, or pending updates where the package manager
+// does not know about advisories
+func checkSecurityUpdates(manager string, sections map[string]string) SecurityFinding {
+	finding := SecurityFinding{
+		ID:       "security-updates",
+		Category: "packages",
+		Title:    "Pending security updates",
+		Severity: SeverityHigh,
+	}
+
+	updates, err := parsePendingUpdates(manager, sections)
+	if err != nil {
+		finding.Status = CheckUnknown
+		finding.Details = "No supported package manager found"
+		return finding
+	}
+
+	var security []string
+	for _, update := range updates.Updates {
+		if update.Security {
+			security = append(security, update.Name+" "+update.AvailableVersion)
+		}
+	}
+	switch {
+	case len(security) > 0:
+		finding.Status = CheckFailed
+		finding.Details = fmt.Sprintf("%d security updates pending (%d updates in total, from the cached package metadata)", len(security), len(updates.Updates))
+		finding.Evidence = truncateEvidence(security)
+		finding.Remediation = "Install the updates, e.g. through POST /server-details/packages/upgrade"
+	case !updates.SecurityInfoAvailable && len(updates.Updates) > 0:
+		finding.Status = CheckUnknown
+		finding.Details = fmt.Sprintf("%d updates pending; %s does not report which are security updates", len(updates.Updates), updates.Manager)
+		finding.Remediation = "Review and install the pending updates"
+	default:
+		finding.Status = CheckPassed
+		finding.Details = fmt.Sprintf("No security updates pending according to the cached %s metadata", updates.Manager)
+	}
+	return finding
+}
+
+// checkFailedLogins reports the failed login attempts of the last day and the most frequent sources
+func checkFailedLogins(sections map[string]string, privileged bool) SecurityFinding {
+	finding := SecurityFinding{
+		ID:          "failed-logins",
+		Category:    "authentication",
+		Title:       "Failed login attempts",
+		Severity:    SeverityLow,
+		Remediation: "Disable password logins and block repeated offenders with fail2ban, sshguard or CrowdSec",
+	}
+
+	values := parseKeyValueLines(sections["failed-logins"])
+	source := values["source"]
+	if source == "" || !privileged {
+		finding.Status = CheckUnknown
+		finding.Details = "The authentication log could not be read; this check needs root or passwordless sudo"
+		return finding
+	}
+
+	count, _ := strconv.Atoi(values["count"])
+	var protection []string
+	for _, line := range nonEmptyLines(sections["failed-logins"]) {
+		if top, ok := strings.CutPrefix(line, "top="); ok {
+			// "123 from 203.0.113.7"
+			if attempts, address, ok := strings.Cut(top, " from "); ok {
+				finding.Evidence = append(finding.Evidence, fmt.Sprintf("%s attempts from %s", attempts, address))
+			}
+		}
+		if name, ok := strings.CutPrefix(line, "protection="); ok {
+			protection = append(protection, name)
+		}
+	}
+
+	period := "in the last 24 hours"
+	if source != "journal" {
+		period = "today and yesterday in " + source
+	}
+	finding.Details = fmt.Sprintf("%d failed login attempts %s", count, period)
+	if len(protection) > 0 {
+		finding.Details += "; brute-force protection is active (" + strings.Join(protection, ", ") + ")"
+	}
+
+	switch {
+	case count >= failedLoginsMedium && len(protection) == 0:
+		finding.Status = CheckFailed
+		finding.Severity = SeverityMedium
+	case count >= failedLoginsLow && len(protection) == 0:
+		finding.Status = CheckFailed
+	default:
+		finding.Status = CheckPassed
+	}
+	return finding
+}
+
+// checkAutomaticUpdates reports whether security updates are installed automatically
+func checkAutomaticUpdates(sections map[string]string) SecurityFinding {
+	finding := SecurityFinding{
+		ID:       "automatic-updates",
+		Category: "packages",
+		Title:    "Automatic security updates",
+		Severity: SeverityMedium,
+	}
+
+	values := parseKeyValueLines(sections["auto-updates"])
+	switch values["tool"] {
+	case "unattended-upgrades":
+		enabled := values["enabled"]
+		switch {
+		case values["installed"] != "1":
+			finding.Status = CheckFailed
+			finding.Details = "unattended-upgrades is not installed"
+		case enabled == "" || enabled == "0":
+			finding.Status = CheckFailed
+			finding.Details = "unattended-upgrades is installed but APT::Periodic::Unattended-Upgrade is not enabled"
+		default:
+			finding.Status = CheckPassed
+			finding.Details = "unattended-upgrades is enabled (APT::Periodic::Unattended-Upgrade \"" + enabled + "\")"
+		}
+		if finding.Status == CheckFailed {
+			finding.Remediation = "Install unattended-upgrades and enable it with 'dpkg-reconfigure -plow unattended-upgrades'"
+		}
+	case "dnf-automatic":
+		var units []string
+		for _, line := range nonEmptyLines(sections["auto-updates"]) {
+			if unit, ok := strings.CutPrefix(line, "unit="); ok {
+				units = append(units, unit)
+			}
+		}
+		applies := false
+		for _, unit := range units {
+			// dnf-automatic.timer only downloads updates unless apply_updates is set
+			if unit != "dnf-automatic.timer" || strings.EqualFold(values["apply"], "yes") || values["apply"] == "1" || strings.EqualFold(values["apply"], "true") {
+				applies = true
+			}
+		}
+		finding.Evidence = units
+		switch {
+		case applies:
+			finding.Status = CheckPassed
+			finding.Details = "Updates are installed automatically by " + strings.Join(units, ", ")
+		case len(units) > 0:
+			finding.Status = CheckFailed
+			finding.Details = "dnf-automatic.timer is enabled but only downloads updates (apply_updates is not set)"
+			finding.Remediation = "Set 'apply_updates = yes' in /etc/dnf/automatic.conf or enable dnf-automatic-install.timer"
+		default:
+			finding.Status = CheckFailed
+			finding.Details = "No dnf-automatic or yum-cron unit is enabled"
+			finding.Remediation = "Install dnf-automatic and run 'systemctl enable --now dnf-automatic-install.timer'"
+		}
+	default:
+		finding.Status = CheckUnknown
+		finding.Details = "Automatic updates are only checked for apt and dnf/yum based systems"
+	}
+	return finding
+}
+
+// checkDockerExposure reports a Docker daemon listening on TCP, which grants root access to anyone
+// reaching it unless TLS client verification is enabled
+func checkDockerExposure(sections map[string]string) SecurityFinding {
+	finding := SecurityFinding{
+		ID:       "docker-tcp-exposure",
+		Category: "docker",
+		Title:    "Docker daemon exposed on TCP",
+		Severity: SeverityCritical,
+		Status:   CheckPassed,
+	}
+
+	values := parseKeyValueLines(sections["docker"])
+	if values["installed"] != "1" {
+		finding.Details = "Docker is not installed"
+		return finding
+	}
+
+	// Hosts and TLS settings from the daemon command line and daemon.json
+	var hosts []string
+	tlsVerify := false
+	for _, line := range nonEmptyLines(sections["docker"]) {
+		command, ok := strings.CutPrefix(line, "cmd=")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(command)
+		for i, field := range fields {
+			switch {
+			case field == "--tlsverify" || field == "--tlsverify=true":
+				tlsVerify = true
+			case (field == "-H" || field == "--host") && i+1 < len(fields):
+				hosts = append(hosts, fields[i+1])
+			case strings.HasPrefix(field, "-H=") || strings.HasPrefix(field, "--host="):
+				hosts = append(hosts, field[strings.Index(field, "=")+1:])
+			case strings.HasPrefix(field, "-Htcp://"):
+				hosts = append(hosts, strings.TrimPrefix(field, "-H"))
+			}
+		}
+	}
+	var daemonConfig struct {
+		Hosts     []string `json:"hosts"`
+		TLSVerify bool     `json:"tlsverify"`
+	}
+	if json.Unmarshal([]byte(sections["docker-daemon-json"]), &daemonConfig) == nil {
+		hosts = append(hosts, daemonConfig.Hosts...)
+		tlsVerify = tlsVerify || daemonConfig.TLSVerify
+	}
+
+	var exposed, local []string
+	seen := make(map[string]bool)
+	for _, host := range hosts {
+		address, ok := strings.CutPrefix(host, "tcp://")
+		if !ok || seen[host] {
+			continue
+		}
+		seen[host] = true
+		if isLoopbackListener(address) {
+			local = append(local, host)
+		} else {
+			exposed = append(exposed, host)
+		}
+	}
+
+	// Listeners on the Docker ports catch daemons configured elsewhere
+	for _, listener := range nonEmptyLines(sections["listeners"]) {
+		if !strings.HasSuffix(listener, ":2375") && !strings.HasSuffix(listener, ":2376") {
+			continue
+		}
+		if isLoopbackListener(listener) {
+			continue
+		}
+		host := "tcp://" + listener
+		if !seen[host] {
+			seen[host] = true
+			exposed = append(exposed, host+" (listening)")
+		}
+		// 2376 is the TLS port by convention when the configuration is not visible
+		if strings.HasSuffix(listener, ":2376") && len(hosts) == 0 {
+			tlsVerify = true
+		}
+	}
+
+	switch {
+	case len(exposed) > 0 && !tlsVerify:
+		finding.Status = CheckFailed
+		finding.Details = "The Docker API is reachable over the network without TLS client verification, which gives anyone reaching it root access"
+		finding.Evidence = exposed
+		finding.Remediation = "Remove tcp:// hosts from the dockerd options and daemon.json, use SSH (DOCKER_HOST=ssh://) for remote access, or enable --tlsverify with client certificates"
+	case len(exposed) > 0:
+		finding.Status = CheckFailed
+		finding.Severity = SeverityLow
+		finding.Details = "The Docker API is reachable over the network, protected by TLS client verification"
+		finding.Evidence = exposed
+		finding.Remediation = "Restrict access to the Docker port with a firewall, or use SSH for remote access"
+	case len(local) > 0:
+		finding.Details = "The Docker API listens on TCP on the loopback interface only"
+		finding.Evidence = local
+	default:
+		finding.Details = "The Docker API listens on its Unix socket only"
+	}
+	return finding
+}
+
+// isLoopbackListener reports whether a listen address ("127.0.0.1:2375", "[::1]:2375", "localhost:2375")
+// is only reachable from the server itself
+func isLoopbackListener(address string) bool {
+	host := address
+	if index := strings.LastIndex(address, ":"); index >= 0 {
+		host = address[:index]
+	}
+	host = strings.Trim(host, "[]")
+	return host == "localhost" || strings.HasPrefix(host, "127.") || host == "::1"
+}
+
+// parseKeyValueLines parses "key=value" lines; the first value of a key wins
+func parseKeyValueLines(output string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range nonEmptyLines(output) {
+		key, value, ok := strings.Cut(line, "=")
+		if _, set := values[key]; ok && !set {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// truncateEvidence keeps the first maxEvidence items and notes how many were left out
+func truncateEvidence(items []string) []string {
+	if len(items) <= maxEvidence {
+		return items
+	}
+	truncated := append([]string{}, items[:maxEvidence]...)
+	return append(truncated, fmt.Sprintf("... and %d more", len(items)-maxEvidence))
+}
+
+// countLabel formats a count that was capped remotely at limit, for use within a sentence
+func countLabel(count int, limit int) string {
+	if count >= limit {
+		return "at least " + strconv.Itoa(count)
+	}
+	return strconv.Itoa(count)
+}
+
+// upperFirst capitalizes the first letter of a label starting a sentence
+func upperFirst(label string) string {
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
@@ -0,0 +1,414 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// blockDevicesScript lists block devices as JSON with sizes in bytes. util-linux before 2.33 does not know
+// the PATH column, so the list is retried without it.
+const blockDevicesScript = `echo '### lsblk'
+lsblk -J -b -o NAME,KNAME,PATH,TYPE,SIZE,MODEL,SERIAL,ROTA,RO,RM,TRAN,FSTYPE,UUID,LABEL,MOUNTPOINT 2>/dev/null ||
+  lsblk -J -b -o NAME,KNAME,TYPE,SIZE,MODEL,SERIAL,ROTA,RO,RM,TRAN,FSTYPE,UUID,LABEL,MOUNTPOINT 2>/dev/null
+true
+`
+
+// lvmScript lists LVM physical volumes, volume groups and logical volumes with sizes in bytes. The LVM
+// tools need root, so they run through passwordless sudo when the SSH user is not root.
+const lvmScript = `if command -v vgs >/dev/null 2>&1 || [ -x /sbin/vgs ] || [ -x /usr/sbin/vgs ]; then
+  PATH="$PATH:/sbin:/usr/sbin"
+  S="` + shell.SudoIfAvailable + `"
+  o="--noheadings --separator | --units b --nosuffix"
+  echo '### pvs'
+  $S pvs $o -o pv_name,vg_name,pv_fmt,pv_size,pv_free 2>/dev/null
+  echo '### vgs'
+  $S vgs $o -o vg_name,vg_size,vg_free,pv_count,lv_count,vg_attr 2>/dev/null
+  echo '### lvs'
+  $S lvs $o -o lv_name,vg_name,lv_path,lv_size,lv_attr,segtype,pool_lv,data_percent 2>/dev/null
+fi
+true
+`
+
+// raidScript prints the software RAID status
+const raidScript = `if [ -r /proc/mdstat ]; then
+  echo '### mdstat'
+  cat /proc/mdstat
+fi
+true
+`
+
+var (
+	// mdstatArrayPattern matches the first line of an array in /proc/mdstat, e.g. "md0 : active raid1 sdb1[1] sda1[0]"
+	mdstatArrayPattern = regexp.MustCompile(`^(md\S*)\s*:\s*(.*)$`)
+
+	// mdstatMemberPattern matches an array member such as "sdb1[1]" or "sdc1[2](F)"
+	mdstatMemberPattern = regexp.MustCompile(`^(\S+)\[(\d+)\]((?:\([A-Z]\))*)$`)
+
+	// mdstatStatusPattern matches the device count and status map, e.g. "[2/1] [U_]"
+	mdstatStatusPattern = regexp.MustCompile(`\[(\d+)/(\d+)\]\s+\[([U_]+)\]`)
+
+	// mdstatBlocksPattern matches the array size in 1K blocks
+	mdstatBlocksPattern = regexp.MustCompile(`^(\d+) blocks`)
+
+	// mdstatSyncPattern matches a sync progress line, e.g. "recovery = 12.6% (...) finish=10.2min"
+	mdstatSyncPattern = regexp.MustCompile(`(recovery|resync|check|reshape|repair)\s*=\s*([\d.]+)%(?:.*finish=(\S+))?`)
+)
+
+// lsblkDevice mirrors an entry of 'lsblk -J'. Older util-linux versions print every value as a string,
+// newer ones use numbers and booleans, so scalar columns are decoded leniently.
+type lsblkDevice struct {
+	Name       string          `json:"name"`
+	KName      string          `json:"kname"`
+	Path       string          `json:"path"`
+	Type       string          `json:"type"`
+	Size       json.RawMessage `json:"size"`
+	Model      *string         `json:"model"`
+	Serial     *string         `json:"serial"`
+	Rota       json.RawMessage `json:"rota"`
+	RO         json.RawMessage `json:"ro"`
+	RM         json.RawMessage `json:"rm"`
+	Tran       *string         `json:"tran"`
+	FSType     *string         `json:"fstype"`
+	UUID       *string         `json:"uuid"`
+	Label      *string         `json:"label"`
+	Mountpoint *string         `json:"mountpoint"`
+	Children   []lsblkDevice   `json:"children"`
+}
+
+// GetStorageInfo implements the Service interface
+func (s *service) GetStorageInfo(ctx context.Context, sessionID string) (*StorageInfo, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, blockDevicesScript+lvmScript+raidScript)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+
+	return &StorageInfo{
+		BlockDevices: parseBlockDevices(sections["lsblk"]),
+		LVM:          parseLVMInfo(sections),
+		RAID:         parseRAIDInfo(sections),
+	}, nil
+}
+
+// GetBlockDevices implements the Service interface
+func (s *service) GetBlockDevices(ctx context.Context, sessionID string) ([]BlockDevice, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, blockDevicesScript)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBlockDevices(shell.ParseSections(output)["lsblk"]), nil
+}
+
+// GetLVMInfo implements the Service interface
+func (s *service) GetLVMInfo(ctx context.Context, sessionID string) (*LVMInfo, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, lvmScript)
+	if err != nil {
+		return nil, err
+	}
+
+	info := parseLVMInfo(shell.ParseSections(output))
+	return &info, nil
+}
+
+// GetRAIDInfo implements the Service interface
+func (s *service) GetRAIDInfo(ctx context.Context, sessionID string) (*RAIDInfo, error) {
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, raidScript)
+	if err != nil {
+		return nil, err
+	}
+
+	info := parseRAIDInfo(shell.ParseSections(output))
+	return &info, nil
+}
+
+// parseBlockDevices parses the output of 'lsblk -J -b'
+func parseBlockDevices(output string) []BlockDevice {
+	var report struct {
+		BlockDevices []lsblkDevice `json:"blockdevices"`
+	}
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		return []BlockDevice{}
+	}
+
+	return convertLsblkDevices(report.BlockDevices)
+}
+
+// convertLsblkDevices converts lsblk entries and their children into block devices
+func convertLsblkDevices(entries []lsblkDevice) []BlockDevice {
+	devices := make([]BlockDevice, 0, len(entries))
+
+	for _, entry := range entries {
+		device := BlockDevice{
+			Name:        entry.Name,
+			Path:        entry.Path,
+			Type:        entry.Type,
+			SizeBytes:   lenientUint(entry.Size),
+			Model:       strings.TrimSpace(stringValue(entry.Model)),
+			Serial:      strings.TrimSpace(stringValue(entry.Serial)),
+			Transport:   stringValue(entry.Tran),
+			Rotational:  lenientBool(entry.Rota),
+			ReadOnly:    lenientBool(entry.RO),
+			Removable:   lenientBool(entry.RM),
+			FSType:      stringValue(entry.FSType),
+			UUID:        stringValue(entry.UUID),
+			Label:       stringValue(entry.Label),
+			Mountpoints: []string{},
+		}
+
+		// Older lsblk versions have no PATH column
+		if device.Path == "" {
+			name := entry.KName
+			if name == "" {
+				name = entry.Name
+			}
+			device.Path = "/dev/" + name
+		}
+
+		if mountpoint := stringValue(entry.Mountpoint); mountpoint != "" {
+			device.Mountpoints = append(device.Mountpoints, mountpoint)
+		}
+
+		if len(entry.Children) > 0 {
+			device.Children = convertLsblkDevices(entry.Children)
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices
+}
+
+// parseLVMInfo parses the pvs, vgs and lvs sections
+func parseLVMInfo(sections map[string]string) LVMInfo {
+	info := LVMInfo{
+		PhysicalVolumes: []PhysicalVolume{},
+		VolumeGroups:    []VolumeGroup{},
+		LogicalVolumes:  []LogicalVolume{},
+	}
+
+	_, info.Available = sections["vgs"]
+
+	// pv_name|vg_name|pv_fmt|pv_size|pv_free
+	for _, fields := range splitLVMReport(sections["pvs"], 5) {
+		info.PhysicalVolumes = append(info.PhysicalVolumes, PhysicalVolume{
+			Name:        fields[0],
+			VolumeGroup: fields[1],
+			Format:      fields[2],
+			SizeBytes:   parseDFNumber(fields[3]),
+			FreeBytes:   parseDFNumber(fields[4]),
+		})
+	}
+
+	// vg_name|vg_size|vg_free|pv_count|lv_count|vg_attr
+	for _, fields := range splitLVMReport(sections["vgs"], 6) {
+		vg := VolumeGroup{
+			Name:       fields[0],
+			SizeBytes:  parseDFNumber(fields[1]),
+			FreeBytes:  parseDFNumber(fields[2]),
+			Attributes: fields[5],
+		}
+		vg.PVCount, _ = strconv.Atoi(fields[3])
+		vg.LVCount, _ = strconv.Atoi(fields[4])
+		info.VolumeGroups = append(info.VolumeGroups, vg)
+	}
+
+	// lv_name|vg_name|lv_path|lv_size|lv_attr|segtype|pool_lv|data_percent
+	for _, fields := range splitLVMReport(sections["lvs"], 8) {
+		lv := LogicalVolume{
+			Name:        fields[0],
+			VolumeGroup: fields[1],
+			Path:        fields[2],
+			SizeBytes:   parseDFNumber(fields[3]),
+			Attributes:  fields[4],
+			SegmentType: fields[5],
+			Pool:        fields[6],
+		}
+
+		// The fifth attribute character is the activation state
+		lv.Active = len(lv.Attributes) > 4 && lv.Attributes[4] == 'a'
+
+		if percent, err := strconv.ParseFloat(fields[7], 64); err == nil {
+			lv.DataPercent = &percent
+		}
+
+		info.LogicalVolumes = append(info.LogicalVolumes, lv)
+	}
+
+	return info
+}
+
+// splitLVMReport splits '--noheadings --separator |' report rows into trimmed fields
+func splitLVMReport(output string, columns int) [][]string {
+	var rows [][]string
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "|")
+		if len(fields) < columns {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		rows = append(rows, fields)
+	}
+
+	return rows
+}
+
+// parseRAIDInfo parses /proc/mdstat
+func parseRAIDInfo(sections map[string]string) RAIDInfo {
+	info := RAIDInfo{
+		Personalities: []string{},
+		Arrays:        []RAIDArray{},
+	}
+
+	mdstat, ok := sections["mdstat"]
+	if !ok {
+		return info
+	}
+	info.Available = true
+
+	var current *RAIDArray
+	for _, line := range strings.Split(mdstat, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "Personalities"):
+			_, list, _ := strings.Cut(trimmed, ":")
+			for _, personality := range strings.Fields(list) {
+				info.Personalities = append(info.Personalities, strings.Trim(personality, "[]"))
+			}
+			continue
+		case trimmed == "" || strings.HasPrefix(trimmed, "unused devices"):
+			current = nil
+			continue
+		}
+
+		if match := mdstatArrayPattern.FindStringSubmatch(trimmed); match != nil {
+			info.Arrays = append(info.Arrays, parseMdstatArrayLine(match[1], match[2]))
+			current = &info.Arrays[len(info.Arrays)-1]
+			continue
+		}
+
+		if current != nil {
+			parseMdstatDetailLine(current, trimmed)
+		}
+	}
+
+	for i := range info.Arrays {
+		array := &info.Arrays[i]
+		array.Degraded = isRAIDArrayDegraded(array)
+		if array.Degraded {
+			info.Degraded = true
+		}
+	}
+
+	return info
+}
+
+// parseMdstatArrayLine parses the state, level and members following "mdX : "
+func parseMdstatArrayLine(name string, rest string) RAIDArray {
+	array := RAIDArray{
+		Name:    name,
+		Members: []RAIDMember{},
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return array
+	}
+	array.State = fields[0]
+	fields = fields[1:]
+
+	// Flags such as "(read-only)" or "(auto-read-only)" follow the state
+	for len(fields) > 0 && strings.HasPrefix(fields[0], "(") {
+		array.State += " " + fields[0]
+		fields = fields[1:]
+	}
+
+	for _, field := range fields {
+		match := mdstatMemberPattern.FindStringSubmatch(field)
+		if match == nil {
+			// Inactive arrays list no level, only members
+			if array.Level == "" && !strings.Contains(field, "[") {
+				array.Level = field
+			}
+			continue
+		}
+
+		member := RAIDMember{Device: match[1]}
+		member.Role, _ = strconv.Atoi(match[2])
+		member.Failed = strings.Contains(match[3], "(F)")
+		member.Spare = strings.Contains(match[3], "(S)")
+		member.WriteMostly = strings.Contains(match[3], "(W)")
+		array.Members = append(array.Members, member)
+	}
+
+	return array
+}
+
+// parseMdstatDetailLine parses the size, status map and sync progress lines of an array
+func parseMdstatDetailLine(array *RAIDArray, line string) {
+	if match := mdstatBlocksPattern.FindStringSubmatch(line); match != nil {
+		array.SizeBytes = parseDFNumber(match[1]) * 1024
+	}
+
+	if match := mdstatStatusPattern.FindStringSubmatch(line); match != nil {
+		array.TotalDevices, _ = strconv.Atoi(match[1])
+		array.ActiveDevices, _ = strconv.Atoi(match[2])
+		array.Status = match[3]
+	}
+
+	if match := mdstatSyncPattern.FindStringSubmatch(line); match != nil {
+		array.SyncAction = match[1]
+		array.SyncProgress, _ = strconv.ParseFloat(match[2], 64)
+		array.SyncFinish = match[3]
+	}
+}
+
+// isRAIDArrayDegraded reports whether an array is missing devices or has failed members
+func isRAIDArrayDegraded(array *RAIDArray) bool {
+	if array.ActiveDevices < array.TotalDevices || strings.Contains(array.Status, "_") {
+		return true
+	}
+
+	for _, member := range array.Members {
+		if member.Failed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stringValue dereferences an optional string
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// lenientUint decodes a JSON number or numeric string
+func lenientUint(raw json.RawMessage) uint64 {
+	number, _ := strconv.ParseUint(strings.Trim(string(raw), `"`), 10, 64)
+	return number
+}
+
+// lenientBool decodes a JSON boolean or a "0"/"1" string
+func lenientBool(raw json.RawMessage) bool {
+	value := strings.Trim(string(raw), `"`)
+	return value == "true" || value == "1"
+}
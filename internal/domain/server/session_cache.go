@@ -0,0 +1,55 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// sessionCache keeps one value per session for a limited time. Sessions are not notified when they
+// end, so entries of sessions that are no longer used are pruned once they expire.
+type sessionCache[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]sessionCacheEntry[T]
+}
+
+// sessionCacheEntry is a cached value with the time it was stored
+type sessionCacheEntry[T any] struct {
+	value    T
+	storedAt time.Time
+}
+
+// newSessionCache creates an empty cache whose entries expire after the given duration
+func newSessionCache[T any](ttl time.Duration) *sessionCache[T] {
+	return &sessionCache[T]{
+		ttl:     ttl,
+		entries: make(map[string]sessionCacheEntry[T]),
+	}
+}
+
+// get returns the cached value of a session, if it has not expired
+func (c *sessionCache[T]) get(sessionID string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[sessionID]
+	if !ok || time.Since(entry.storedAt) > c.ttl {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// put stores the value of a session and prunes expired entries
+func (c *sessionCache[T]) put(sessionID string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for id, entry := range c.entries {
+		if now.Sub(entry.storedAt) > c.ttl {
+			delete(c.entries, id)
+		}
+	}
+	c.entries[sessionID] = sessionCacheEntry[T]{value: value, storedAt: now}
+}
@@ -0,0 +1,100 @@
+package server
+
+// StorageInfo combines block devices, LVM and software RAID information
+type StorageInfo struct {
+	BlockDevices []BlockDevice `json:"block_devices"`
+	LVM          LVMInfo       `json:"lvm"`
+	RAID         RAIDInfo      `json:"raid"`
+}
+
+// BlockDevice represents a disk, partition or other block device as reported by lsblk
+type BlockDevice struct {
+	Name        string        `json:"name"`
+	Path        string        `json:"path"`
+	Type        string        `json:"type"` // e.g. "disk", "part", "lvm", "raid1", "loop", "rom"
+	SizeBytes   uint64        `json:"size_bytes"`
+	Model       string        `json:"model,omitempty"`
+	Serial      string        `json:"serial,omitempty"`
+	Transport   string        `json:"transport,omitempty"` // e.g. "sata", "nvme", "usb"
+	Rotational  bool          `json:"rotational"`          // True for spinning disks
+	ReadOnly    bool          `json:"read_only"`
+	Removable   bool          `json:"removable"`
+	FSType      string        `json:"fs_type,omitempty"`
+	UUID        string        `json:"uuid,omitempty"` // Filesystem UUID
+	Label       string        `json:"label,omitempty"`
+	Mountpoints []string      `json:"mountpoints"`
+	Children    []BlockDevice `json:"children,omitempty"`
+}
+
+// LVMInfo contains the LVM physical volumes, volume groups and logical volumes
+type LVMInfo struct {
+	Available       bool             `json:"available"` // Whether the LVM tools are installed
+	PhysicalVolumes []PhysicalVolume `json:"physical_volumes"`
+	VolumeGroups    []VolumeGroup    `json:"volume_groups"`
+	LogicalVolumes  []LogicalVolume  `json:"logical_volumes"`
+}
+
+// PhysicalVolume represents an LVM physical volume
+type PhysicalVolume struct {
+	Name        string `json:"name"`
+	VolumeGroup string `json:"volume_group,omitempty"`
+	Format      string `json:"format"`
+	SizeBytes   uint64 `json:"size_bytes"`
+	FreeBytes   uint64 `json:"free_bytes"`
+}
+
+// VolumeGroup represents an LVM volume group
+type VolumeGroup struct {
+	Name       string `json:"name"`
+	SizeBytes  uint64 `json:"size_bytes"`
+	FreeBytes  uint64 `json:"free_bytes"`
+	PVCount    int    `json:"pv_count"`
+	LVCount    int    `json:"lv_count"`
+	Attributes string `json:"attributes"`
+}
+
+// LogicalVolume represents an LVM logical volume
+type LogicalVolume struct {
+	Name        string   `json:"name"`
+	VolumeGroup string   `json:"volume_group"`
+	Path        string   `json:"path,omitempty"`
+	SizeBytes   uint64   `json:"size_bytes"`
+	Attributes  string   `json:"attributes"`
+	SegmentType string   `json:"segment_type"`           // e.g. "linear", "striped", "thin-pool"
+	Pool        string   `json:"pool,omitempty"`         // Thin pool of a thin volume
+	DataPercent *float64 `json:"data_percent,omitempty"` // Data usage of thin pools, thin volumes and snapshots
+	Active      bool     `json:"active"`
+}
+
+// RAIDInfo contains the Linux software RAID (md) arrays from /proc/mdstat
+type RAIDInfo struct {
+	Available     bool        `json:"available"` // Whether the md driver is loaded
+	Personalities []string    `json:"personalities"`
+	Arrays        []RAIDArray `json:"arrays"`
+	Degraded      bool        `json:"degraded"` // True if any array is degraded
+}
+
+// RAIDArray represents a software RAID array
+type RAIDArray struct {
+	Name          string       `json:"name"`
+	State         string       `json:"state"` // "active" or "inactive", optionally followed by e.g. "(read-only)"
+	Level         string       `json:"level,omitempty"`
+	SizeBytes     uint64       `json:"size_bytes"`
+	TotalDevices  int          `json:"total_devices"`
+	ActiveDevices int          `json:"active_devices"`
+	Status        string       `json:"status,omitempty"` // Member status map, e.g. "UU" or "U_"
+	Members       []RAIDMember `json:"members"`
+	Degraded      bool         `json:"degraded"`
+	SyncAction    string       `json:"sync_action,omitempty"`   // e.g. "recovery", "resync", "check", "reshape"
+	SyncProgress  float64      `json:"sync_progress,omitempty"` // Progress of the sync action in percent
+	SyncFinish    string       `json:"sync_finish,omitempty"`   // Estimated time to completion, e.g. "12.5min"
+}
+
+// RAIDMember represents a device that belongs to a software RAID array
+type RAIDMember struct {
+	Device      string `json:"device"`
+	Role        int    `json:"role"`
+	Failed      bool   `json:"failed"`
+	Spare       bool   `json:"spare"`
+	WriteMostly bool   `json:"write_mostly"`
+}
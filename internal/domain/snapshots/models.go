@@ -0,0 +1,85 @@
+package snapshots
+
+import "time"
+
+// Snapshot sections, in the order they are captured and compared
+const (
+	SectionPackages   = "packages"
+	SectionServices   = "services"
+	SectionContainers = "containers"
+	SectionImages     = "images"
+	SectionPorts      = "ports"
+	SectionUsers      = "users"
+	SectionCron       = "cron"
+	SectionSysctl     = "sysctl"
+)
+
+// Sections lists the sections of a snapshot
+var Sections = []string{
+	SectionPackages, SectionServices, SectionContainers, SectionImages,
+	SectionPorts, SectionUsers, SectionCron, SectionSysctl,
+}
+
+// Item is an element of a snapshot section, e.g. an installed package. The key identifies the item
+// across snapshots and hosts; the attributes are compared.
+type Item struct {
+	Key        string            `json:"key"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Section holds the items of one kind captured from a host
+type Section struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"` // Reason the section could not be captured, e.g. Docker missing
+	Items []Item `json:"items"`
+}
+
+// SnapshotInfo describes a stored snapshot
+type SnapshotInfo struct {
+	ID        string         `json:"id"`
+	Host      string         `json:"host"`
+	Label     string         `json:"label,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	Items     map[string]int `json:"items"` // Number of items per captured section
+}
+
+// Snapshot is the state of a host at a point in time
+type Snapshot struct {
+	SnapshotInfo
+	Sections []Section `json:"sections"`
+}
+
+// SnapshotRequest describes a snapshot to take
+type SnapshotRequest struct {
+	Label string `json:"label,omitempty"` // Free-form label, e.g. "before upgrade"
+}
+
+// AttributeChange is an attribute of an item with different values in two snapshots
+type AttributeChange struct {
+	Attribute string `json:"attribute"`
+	A         string `json:"a"`
+	B         string `json:"b"`
+}
+
+// ItemChange is an item present in both snapshots with different attributes
+type ItemChange struct {
+	Key     string            `json:"key"`
+	Changes []AttributeChange `json:"changes"`
+}
+
+// SectionDiff lists the differences of a section between two snapshots
+type SectionDiff struct {
+	Name    string       `json:"name"`
+	Error   string       `json:"error,omitempty"` // Why the section was not compared, when it is missing from a snapshot
+	Added   []Item       `json:"added"`           // Items only in b
+	Removed []Item       `json:"removed"`         // Items only in a
+	Changed []ItemChange `json:"changed"`
+}
+
+// Diff lists what changed from snapshot a to snapshot b
+type Diff struct {
+	A        SnapshotInfo  `json:"a"`
+	B        SnapshotInfo  `json:"b"`
+	Changes  int           `json:"changes"` // Total number of added, removed and changed items
+	Sections []SectionDiff `json:"sections"`
+}
@@ -0,0 +1,239 @@
+package snapshots
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"remote-server-api/internal/domain/accounts"
+	"remote-server-api/internal/domain/auth"
+	"remote-server-api/internal/domain/cron"
+	"remote-server-api/internal/domain/docker"
+	"remote-server-api/internal/domain/kernel"
+	"remote-server-api/internal/domain/server"
+	"remote-server-api/internal/domain/systemd"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound  = errors.New("session not found or expired")
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	ErrInvalidDiff      = errors.New("invalid snapshot diff")
+)
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// GetSession retrieves an SSH session by ID
+	GetSession(ctx context.Context, sessionID string) (*auth.Session, error)
+
+	// ListSessions returns all stored SSH sessions
+	ListSessions(ctx context.Context) ([]*auth.Session, error)
+}
+
+// ServerSource provides the packages and listening sockets of the server details service
+type ServerSource interface {
+	GetInstalledLibraries(ctx context.Context, sessionID string) ([]server.Library, error)
+	GetListeningSockets(ctx context.Context, sessionID string, filter server.ListeningSocketFilter) ([]server.ListeningSocket, error)
+}
+
+// SystemdSource provides the units of the systemd service
+type SystemdSource interface {
+	ListUnits(ctx context.Context, sessionID string, filter systemd.UnitFilter) ([]systemd.Unit, error)
+}
+
+// DockerSource provides the containers and images of the Docker service
+type DockerSource interface {
+	GetContainerStatuses(ctx context.Context, sessionID string) ([]docker.ContainerStatus, error)
+	GetImages(ctx context.Context, sessionID string) ([]docker.Image, error)
+}
+
+// AccountsSource provides the users of the accounts service
+type AccountsSource interface {
+	ListUsers(ctx context.Context, sessionID string, filter accounts.UserFilter) ([]accounts.User, error)
+}
+
+// CronSource provides the scheduled jobs of the cron service
+type CronSource interface {
+	ListJobs(ctx context.Context, sessionID string) (*cron.JobInventory, error)
+}
+
+// KernelSource provides the kernel parameters of the kernel service
+type KernelSource interface {
+	ListSysctl(ctx context.Context, sessionID string, prefix string) (*kernel.SysctlReport, error)
+}
+
+// Sources are the services a snapshot is captured from
+type Sources struct {
+	Server   ServerSource
+	Systemd  SystemdSource
+	Docker   DockerSource
+	Accounts AccountsSource
+	Cron     CronSource
+	Kernel   KernelSource
+}
+
+// Service defines the snapshot service. Snapshots are stored on the API server. A session may read
+// and compare the snapshots of every host its user has an active session to, so that hosts can be
+// compared with each other, but only sessions of the host a snapshot was taken from may delete it.
+// Snapshots of other hosts are not found.
+type Service interface {
+	// CreateSnapshot captures the state of the session's host and stores it
+	CreateSnapshot(ctx context.Context, sessionID string, request SnapshotRequest) (*Snapshot, error)
+
+	// ListSnapshots lists the stored snapshots of the readable hosts, or of one of them when host is
+	// set, newest first
+	ListSnapshots(ctx context.Context, sessionID string, host string) ([]SnapshotInfo, error)
+
+	// GetSnapshot retrieves a stored snapshot of a readable host
+	GetSnapshot(ctx context.Context, sessionID string, id string) (*Snapshot, error)
+
+	// DeleteSnapshot removes a stored snapshot of the session's host
+	DeleteSnapshot(ctx context.Context, sessionID string, id string) (*SnapshotInfo, error)
+
+	// DiffSnapshots compares two snapshots of readable hosts, of the same host or of different hosts,
+	// optionally limited to some sections
+	DiffSnapshots(ctx context.Context, sessionID string, a string, b string, sections []string) (*Diff, error)
+}
+
+type service struct {
+	sessionRepo SessionRepository
+	sources     Sources
+	store       *snapshotStore
+}
+
+// NewService creates a new snapshot service storing snapshots in a directory of the API server
+func NewService(sessionRepo SessionRepository, sources Sources, dir string) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+		sources:     sources,
+		store:       newSnapshotStore(dir),
+	}
+}
+
+// CreateSnapshot implements the Service interface
+func (s *service) CreateSnapshot(ctx context.Context, sessionID string, request SnapshotRequest) (*Snapshot, error) {
+	session, err := s.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+
+	createdAt := time.Now().UTC()
+	id, err := newSnapshotID(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		SnapshotInfo: SnapshotInfo{
+			ID:        id,
+			Host:      session.Host,
+			Label:     request.Label,
+			CreatedAt: createdAt,
+			Items:     make(map[string]int),
+		},
+		Sections: s.capture(ctx, sessionID),
+	}
+	for _, section := range snapshot.Sections {
+		if section.Error == "" {
+			snapshot.Items[section.Name] = len(section.Items)
+		}
+	}
+
+	if err := s.store.save(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ListSnapshots implements the Service interface
+func (s *service) ListSnapshots(ctx context.Context, sessionID string, host string) ([]SnapshotInfo, error) {
+	readable, err := s.readableHosts(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos, err := s.store.list(host)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(infos, func(info SnapshotInfo) bool { return !readable[info.Host] }), nil
+}
+
+// GetSnapshot implements the Service interface
+func (s *service) GetSnapshot(ctx context.Context, sessionID string, id string) (*Snapshot, error) {
+	readable, err := s.readableHosts(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.readableSnapshot(id, readable)
+}
+
+// DeleteSnapshot implements the Service interface
+func (s *service) DeleteSnapshot(ctx context.Context, sessionID string, id string) (*SnapshotInfo, error) {
+	session, err := s.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+	return s.store.remove(id, session.Host)
+}
+
+// DiffSnapshots implements the Service interface
+func (s *service) DiffSnapshots(ctx context.Context, sessionID string, a string, b string, sections []string) (*Diff, error) {
+	readable, err := s.readableHosts(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if a == "" || b == "" {
+		return nil, fmt.Errorf("%w: two snapshot IDs are required", ErrInvalidDiff)
+	}
+	for _, name := range sections {
+		if !slices.Contains(Sections, name) {
+			return nil, fmt.Errorf("%w: unknown section %q", ErrInvalidDiff, name)
+		}
+	}
+
+	snapshotA, err := s.readableSnapshot(a, readable)
+	if err != nil {
+		return nil, err
+	}
+	snapshotB, err := s.readableSnapshot(b, readable)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(snapshotA, snapshotB, sections), nil
+}
+
+// readableHosts returns the hosts whose snapshots a session may read: those the session's user has an
+// active session to
+func (s *service) readableHosts(ctx context.Context, sessionID string) (map[string]bool, error) {
+	session, err := s.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+
+	owners, err := auth.OwnerSessions(ctx, s.sessionRepo)
+	if err != nil {
+		return nil, err
+	}
+	readable := map[string]bool{session.Host: true}
+	for _, owner := range owners {
+		if owner.Username == session.Username {
+			readable[owner.Host] = true
+		}
+	}
+	return readable, nil
+}
+
+// readableSnapshot retrieves a stored snapshot. Snapshots of hosts that are not readable are not found.
+func (s *service) readableSnapshot(id string, readable map[string]bool) (*Snapshot, error) {
+	snapshot, err := s.store.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !readable[snapshot.Host] {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotNotFound, id)
+	}
+	return snapshot, nil
+}
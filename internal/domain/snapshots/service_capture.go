@@ -0,0 +1,257 @@
+package snapshots
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"remote-server-api/internal/domain/accounts"
+	"remote-server-api/internal/domain/cron"
+	"remote-server-api/internal/domain/server"
+	"remote-server-api/internal/domain/systemd"
+)
+
+// volatileSysctls are kernel parameters that are counters or random values rather than settings, and
+// volatileSysctlPrefixes the subtrees made of them
+var (
+	volatileSysctls = map[string]bool{
+		"fs.aio-nr":                        true,
+		"fs.dentry-state":                  true,
+		"fs.file-nr":                       true,
+		"fs.inode-nr":                      true,
+		"fs.inode-state":                   true,
+		"kernel.ns_last_pid":               true,
+		"kernel.pty.nr":                    true,
+		"net.netfilter.nf_conntrack_count": true,
+	}
+	volatileSysctlPrefixes = []string{"kernel.random.", "fs.quota."}
+)
+
+// capture takes every section of a snapshot concurrently. A section that cannot be captured, e.g.
+// containers on a host without Docker, carries the error instead of items.
+func (s *service) capture(ctx context.Context, sessionID string) []Section {
+	captures := map[string]func() ([]Item, error){
+		SectionPackages: func() ([]Item, error) {
+			packages, err := s.sources.Server.GetInstalledLibraries(ctx, sessionID)
+			return packageItems(packages), err
+		},
+		SectionServices: func() ([]Item, error) {
+			units, err := s.sources.Systemd.ListUnits(ctx, sessionID, systemd.UnitFilter{Type: "service"})
+			return serviceItems(units), err
+		},
+		SectionContainers: func() ([]Item, error) {
+			containers, err := s.sources.Docker.GetContainerStatuses(ctx, sessionID)
+			items := make([]Item, 0, len(containers))
+			for _, container := range containers {
+				items = append(items, Item{Key: container.Name, Attributes: map[string]string{
+					"image":          container.Image,
+					"image_id":       container.ImageID,
+					"state":          container.State,
+					"restart_policy": container.RestartPolicy,
+				}})
+			}
+			return items, err
+		},
+		SectionImages: func() ([]Item, error) {
+			images, err := s.sources.Docker.GetImages(ctx, sessionID)
+			items := make([]Item, 0, len(images))
+			for _, image := range images {
+				key := image.Repository + ":" + image.Tag
+				if image.Repository == "<none>" {
+					key = image.ImageID
+				}
+				items = append(items, Item{Key: key, Attributes: map[string]string{
+					"image_id": image.ImageID,
+					"digest":   image.Digest,
+				}})
+			}
+			return items, err
+		},
+		SectionPorts: func() ([]Item, error) {
+			sockets, err := s.sources.Server.GetListeningSockets(ctx, sessionID, server.ListeningSocketFilter{})
+			return portItems(sockets), err
+		},
+		SectionUsers: func() ([]Item, error) {
+			users, err := s.sources.Accounts.ListUsers(ctx, sessionID, accounts.UserFilter{IncludeSystem: true})
+			return userItems(users), err
+		},
+		SectionCron: func() ([]Item, error) {
+			inventory, err := s.sources.Cron.ListJobs(ctx, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			return cronItems(inventory.Jobs), nil
+		},
+		SectionSysctl: func() ([]Item, error) {
+			report, err := s.sources.Kernel.ListSysctl(ctx, sessionID, "")
+			if err != nil {
+				return nil, err
+			}
+			items := make([]Item, 0, len(report.Parameters))
+			for _, parameter := range report.Parameters {
+				if isVolatileSysctl(parameter.Key) {
+					continue
+				}
+				items = append(items, Item{Key: parameter.Key, Attributes: map[string]string{"value": parameter.Value}})
+			}
+			return items, nil
+		},
+	}
+
+	sections := make([]Section, len(Sections))
+	var wg sync.WaitGroup
+	for i, name := range Sections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, err := captures[name]()
+			if err != nil {
+				sections[i] = Section{Name: name, Error: err.Error(), Items: []Item{}}
+				return
+			}
+			sections[i] = Section{Name: name, Items: uniqueItems(items)}
+		}()
+	}
+	wg.Wait()
+
+	return sections
+}
+
+// packageItems keys packages by name, adding the architecture to packages installed for several
+func packageItems(packages []server.Library) []Item {
+	count := make(map[string]int)
+	for _, pkg := range packages {
+		count[pkg.Name]++
+	}
+
+	items := make([]Item, 0, len(packages))
+	for _, pkg := range packages {
+		key := pkg.Name
+		if count[pkg.Name] > 1 && pkg.Arch != "" {
+			key += ":" + pkg.Arch
+		}
+		items = append(items, Item{Key: key, Attributes: map[string]string{
+			"version":      pkg.Version,
+			"architecture": pkg.Arch,
+			"manager":      pkg.Manager,
+		}})
+	}
+	return items
+}
+
+// serviceItems keys service units by name
+func serviceItems(units []systemd.Unit) []Item {
+	items := make([]Item, 0, len(units))
+	for _, unit := range units {
+		items = append(items, Item{Key: unit.Name, Attributes: map[string]string{
+			"load_state":      unit.LoadState,
+			"active_state":    unit.ActiveState,
+			"sub_state":       unit.SubState,
+			"unit_file_state": unit.UnitFileState,
+		}})
+	}
+	return items
+}
+
+// portItems keys listening sockets by protocol, address and port, e.g. "tcp 0.0.0.0:22"
+func portItems(sockets []server.ListeningSocket) []Item {
+	items := make([]Item, 0, len(sockets))
+	for _, socket := range sockets {
+		address := socket.LocalAddress
+		if strings.Contains(address, ":") {
+			address = "[" + address + "]"
+		}
+		items = append(items, Item{
+			Key: fmt.Sprintf("%s %s:%d", socket.Protocol, address, socket.LocalPort),
+			Attributes: map[string]string{
+				"process":   socket.ProcessName,
+				"container": socket.ContainerName,
+			},
+		})
+	}
+	return items
+}
+
+// userItems keys users by name, leaving out the last login, which changes with every login
+func userItems(users []accounts.User) []Item {
+	items := make([]Item, 0, len(users))
+	for _, user := range users {
+		items = append(items, Item{Key: user.Name, Attributes: map[string]string{
+			"uid":             strconv.Itoa(user.UID),
+			"gid":             strconv.Itoa(user.GID),
+			"home":            user.Home,
+			"shell":           user.Shell,
+			"groups":          strings.Join(user.Groups, ","),
+			"locked":          strconv.FormatBool(user.Locked),
+			"sudo":            strconv.FormatBool(user.Sudo),
+			"authorized_keys": strconv.Itoa(user.AuthorizedKeys),
+		}})
+	}
+	return items
+}
+
+// cronItems keys timers by unit, periodic jobs by script and crontab entries by file, schedule and
+// command, leaving out the next and last runs
+func cronItems(jobs []cron.Job) []Item {
+	items := make([]Item, 0, len(jobs))
+	for _, job := range jobs {
+		attributes := map[string]string{
+			"kind":    job.Kind,
+			"user":    job.User,
+			"enabled": strconv.FormatBool(job.Enabled),
+		}
+
+		var key string
+		switch job.Kind {
+		case cron.JobKindTimer:
+			key = job.Source
+			attributes["schedule"] = job.Schedule
+			attributes["unit"] = job.Unit
+		case cron.JobKindPeriodic:
+			key = job.Command
+			attributes["schedule"] = job.Schedule
+		default:
+			key = job.Source + ": " + job.Schedule + " " + job.Command
+		}
+		items = append(items, Item{Key: key, Attributes: attributes})
+	}
+	return items
+}
+
+// isVolatileSysctl reports whether a kernel parameter changes on its own
+func isVolatileSysctl(key string) bool {
+	if volatileSysctls[key] {
+		return true
+	}
+	for _, prefix := range volatileSysctlPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueItems sorts items by key, numbers the repeats of a key, e.g. the same crontab line twice, so
+// that every key identifies one item, and drops empty attributes
+func uniqueItems(items []Item) []Item {
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+
+	seen := make(map[string]int)
+	for i := range items {
+		for name, value := range items[i].Attributes {
+			if value == "" {
+				delete(items[i].Attributes, name)
+			}
+		}
+
+		key := items[i].Key
+		seen[key]++
+		if seen[key] > 1 {
+			items[i].Key = fmt.Sprintf("%s #%d", key, seen[key])
+		}
+	}
+	return items
+}
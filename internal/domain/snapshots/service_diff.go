@@ -0,0 +1,101 @@
+package snapshots
+
+import (
+	"slices"
+	"sort"
+)
+
+// diffSnapshots compares the sections of two snapshots, all of them when names is empty
+func diffSnapshots(a *Snapshot, b *Snapshot, names []string) *Diff {
+	diff := &Diff{
+		A:        a.SnapshotInfo,
+		B:        b.SnapshotInfo,
+		Sections: []SectionDiff{},
+	}
+
+	for _, name := range Sections {
+		if len(names) > 0 && !slices.Contains(names, name) {
+			continue
+		}
+
+		sectionA, sectionB := findSection(a, name), findSection(b, name)
+		sectionDiff := SectionDiff{Name: name, Added: []Item{}, Removed: []Item{}, Changed: []ItemChange{}}
+		switch {
+		case sectionA == nil || sectionA.Error != "":
+			sectionDiff.Error = "not captured in a" + sectionError(sectionA)
+		case sectionB == nil || sectionB.Error != "":
+			sectionDiff.Error = "not captured in b" + sectionError(sectionB)
+		default:
+			diffItems(&sectionDiff, sectionA.Items, sectionB.Items)
+			diff.Changes += len(sectionDiff.Added) + len(sectionDiff.Removed) + len(sectionDiff.Changed)
+		}
+		diff.Sections = append(diff.Sections, sectionDiff)
+	}
+
+	return diff
+}
+
+// diffItems adds the items only in a, only in b and with different attributes to the section diff
+func diffItems(sectionDiff *SectionDiff, itemsA []Item, itemsB []Item) {
+	byKey := make(map[string]Item, len(itemsA))
+	for _, item := range itemsA {
+		byKey[item.Key] = item
+	}
+
+	for _, itemB := range itemsB {
+		itemA, ok := byKey[itemB.Key]
+		if !ok {
+			sectionDiff.Added = append(sectionDiff.Added, itemB)
+			continue
+		}
+		delete(byKey, itemB.Key)
+
+		if changes := diffAttributes(itemA.Attributes, itemB.Attributes); len(changes) > 0 {
+			sectionDiff.Changed = append(sectionDiff.Changed, ItemChange{Key: itemB.Key, Changes: changes})
+		}
+	}
+
+	for _, item := range itemsA {
+		if _, ok := byKey[item.Key]; ok {
+			sectionDiff.Removed = append(sectionDiff.Removed, item)
+		}
+	}
+}
+
+// diffAttributes lists the attributes with different values, a missing attribute being empty
+func diffAttributes(a map[string]string, b map[string]string) []AttributeChange {
+	names := make(map[string]bool)
+	for name := range a {
+		names[name] = true
+	}
+	for name := range b {
+		names[name] = true
+	}
+
+	var changes []AttributeChange
+	for name := range names {
+		if a[name] != b[name] {
+			changes = append(changes, AttributeChange{Attribute: name, A: a[name], B: b[name]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Attribute < changes[j].Attribute })
+	return changes
+}
+
+// findSection returns the section of a snapshot with the given name
+func findSection(snapshot *Snapshot, name string) *Section {
+	for i := range snapshot.Sections {
+		if snapshot.Sections[i].Name == name {
+			return &snapshot.Sections[i]
+		}
+	}
+	return nil
+}
+
+// sectionError formats the capture error of a section for a diff
+func sectionError(section *Section) string {
+	if section == nil {
+		return ""
+	}
+	return ": " + section.Error
+}
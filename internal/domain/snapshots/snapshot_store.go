@@ -0,0 +1,173 @@
+package snapshots
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// snapshotIDPattern matches the identifiers generated by newSnapshotID, which are used as file names
+var snapshotIDPattern = regexp.MustCompile(`^[0-9]{8}T[0-9]{6}Z-[0-9a-f]{8}$`)
+
+// snapshotStore keeps snapshots as JSON files in a directory on the API server, one file per
+// snapshot, so that they survive restarts. The infos of all snapshots are indexed in memory.
+type snapshotStore struct {
+	dir string
+
+	mu     sync.Mutex
+	index  map[string]SnapshotInfo
+	loaded bool
+}
+
+// newSnapshotStore creates a store for the snapshots in a directory, which is created on first use
+func newSnapshotStore(dir string) *snapshotStore {
+	return &snapshotStore{
+		dir:   dir,
+		index: make(map[string]SnapshotInfo),
+	}
+}
+
+// save writes a snapshot to a new file, replacing it atomically if it exists
+func (st *snapshotStore) save(snapshot *Snapshot) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if err := st.loadLocked(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(st.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(st.dir, ".snapshot-*")
+	if err != nil {
+		return fmt.Errorf("failed to store snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to store snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to store snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), st.path(snapshot.ID)); err != nil {
+		return fmt.Errorf("failed to store snapshot: %w", err)
+	}
+
+	st.index[snapshot.ID] = snapshot.SnapshotInfo
+	return nil
+}
+
+// get reads a snapshot
+func (st *snapshotStore) get(id string) (*Snapshot, error) {
+	if !snapshotIDPattern.MatchString(id) {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotNotFound, id)
+	}
+
+	data, err := os.ReadFile(st.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", id, err)
+	}
+	return &snapshot, nil
+}
+
+// list returns the infos of the snapshots, of all hosts when host is empty, newest first
+func (st *snapshotStore) list(host string) ([]SnapshotInfo, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if err := st.loadLocked(); err != nil {
+		return nil, err
+	}
+
+	infos := []SnapshotInfo{}
+	for _, info := range st.index {
+		if host == "" || info.Host == host {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID > infos[j].ID })
+	return infos, nil
+}
+
+// remove deletes a snapshot of a host and returns its info. Snapshots of other hosts are not found.
+func (st *snapshotStore) remove(id string, host string) (*SnapshotInfo, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if err := st.loadLocked(); err != nil {
+		return nil, err
+	}
+	info, ok := st.index[id]
+	if !ok || info.Host != host {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotNotFound, id)
+	}
+	if err := os.Remove(st.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+	delete(st.index, id)
+	return &info, nil
+}
+
+// loadLocked indexes the snapshot files of the directory on first use. Files that cannot be read are
+// skipped. The caller must hold the lock.
+func (st *snapshotStore) loadLocked() error {
+	if st.loaded {
+		return nil
+	}
+
+	entries, err := os.ReadDir(st.dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || !snapshotIDPattern.MatchString(id) {
+			continue
+		}
+		snapshot, err := st.get(id)
+		if err != nil {
+			continue
+		}
+		st.index[id] = snapshot.SnapshotInfo
+	}
+
+	st.loaded = true
+	return nil
+}
+
+// path returns the file of a snapshot
+func (st *snapshotStore) path(id string) string {
+	return filepath.Join(st.dir, id+".json")
+}
+
+// newSnapshotID generates an identifier that sorts by creation time, e.g. 20240501T120000Z-1a2b3c4d
+func newSnapshotID(createdAt time.Time) (string, error) {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate snapshot ID: %w", err)
+	}
+	return createdAt.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(buf), nil
+}
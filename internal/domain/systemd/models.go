@@ -0,0 +1,17 @@
+package systemd
+
+// Unit represents a systemd unit as listed by systemctl list-units
+type Unit struct {
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	LoadState     string `json:"load_state"`                // e.g. "loaded", "not-found", "masked"
+	ActiveState   string `json:"active_state"`              // e.g. "active", "inactive", "failed"
+	SubState      string `json:"sub_state"`                 // e.g. "running", "exited", "dead"
+	UnitFileState string `json:"unit_file_state,omitempty"` // e.g. "enabled", "disabled", "static"
+}
+
+// UnitFilter narrows down the unit list
+type UnitFilter struct {
+	Type  string // Unit type such as "service", "timer" or "socket"; empty for all types
+	State string // Matched against the load, active or sub state; empty for all units
+}
@@ -0,0 +1,215 @@
+package systemd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// Common errors
+var (
+	ErrSessionNotFound  = errors.New("session not found or expired")
+	ErrCommandFailed    = errors.New("command execution failed")
+	ErrInvalidUnitName  = errors.New("invalid unit name")
+	ErrInvalidUnitType  = errors.New("invalid unit type")
+	ErrInvalidAction    = errors.New("invalid unit action")
+	ErrUnitNotFound     = errors.New("unit not found")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrSystemdMissing   = errors.New("systemd is not available on this server")
+)
+
+// SessionRepository defines methods to access SSH sessions
+type SessionRepository interface {
+	// RunCommand executes a command on the SSH session
+	RunCommand(ctx context.Context, sessionID string, command string) (string, error)
+}
+
+// Service defines the systemd service management service
+type Service interface {
+	// ListUnits retrieves the units known to systemd with their load, active and sub state
+	ListUnits(ctx context.Context, sessionID string, filter UnitFilter) ([]Unit, error)
+
+	// GetUnit retrieves the properties of a single unit
+	GetUnit(ctx context.Context, sessionID string, name string) (*UnitDetail, error)
+
+	// PerformAction starts, stops, restarts, reloads, enables or disables a unit
+	PerformAction(ctx context.Context, sessionID string, name string, action string) (*UnitActionResult, error)
+}
+
+type service struct {
+	sessionRepo SessionRepository
+}
+
+// NewService creates a new systemd service
+func NewService(sessionRepo SessionRepository) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// unitListScript lists units as JSON together with the unit file states. The placeholders receive
+// the --type argument (possibly empty) for list-units and list-unit-files.
+const unitListScript = `command -v systemctl >/dev/null 2>&1 || { echo '### missing'; exit 0; }
+echo '### units'
+systemctl list-units --all %[1]s --output=json --no-pager 2>/dev/null
+echo '### unit-files'
+systemctl list-unit-files %[1]s --no-legend --no-pager --plain 2>/dev/null
+true`
+
+// unitListPlainCommand lists units in the plain table format for systemd versions without JSON output
+const unitListPlainCommand = "systemctl list-units --all %s --no-legend --no-pager --plain 2>/dev/null; true"
+
+var (
+	// unitNamePattern matches valid unit names, including template instances and escaped characters
+	unitNamePattern = regexp.MustCompile(`^[a-zA-Z0-9:_.@\\-]+$`)
+
+	// unitTypePattern matches valid unit types
+	unitTypePattern = regexp.MustCompile(`^(service|socket|target|device|mount|automount|swap|timer|path|slice|scope)$`)
+)
+
+// jsonUnit mirrors an entry of 'systemctl list-units --output=json'
+type jsonUnit struct {
+	Unit        string `json:"unit"`
+	Load        string `json:"load"`
+	Active      string `json:"active"`
+	Sub         string `json:"sub"`
+	Description string `json:"description"`
+}
+
+// ListUnits implements the Service interface
+func (s *service) ListUnits(ctx context.Context, sessionID string, filter UnitFilter) ([]Unit, error) {
+	typeArg := ""
+	if filter.Type != "" {
+		if !unitTypePattern.MatchString(filter.Type) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidUnitType, filter.Type)
+		}
+		typeArg = "--type=" + filter.Type
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(unitListScript, typeArg))
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	if _, missing := sections["missing"]; missing {
+		return nil, ErrSystemdMissing
+	}
+
+	// Older systemd versions silently ignore --output=json for list-units and print a table instead
+	units, err := parseUnitListJSON(sections["units"])
+	if err != nil {
+		plainOutput, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(unitListPlainCommand, typeArg))
+		if err != nil {
+			return nil, err
+		}
+		units = parseUnitListPlain(plainOutput)
+	}
+
+	// Attach enablement state from the unit files
+	fileStates := parseUnitFileStates(sections["unit-files"])
+	for i := range units {
+		units[i].UnitFileState = fileStates[units[i].Name]
+	}
+
+	return filterUnits(units, filter.State), nil
+}
+
+// parseUnitListJSON parses the output of 'systemctl list-units --output=json'
+func parseUnitListJSON(output string) ([]Unit, error) {
+	output = strings.TrimSpace(output)
+	if !strings.HasPrefix(output, "[") {
+		return nil, fmt.Errorf("%w: list-units did not return JSON", ErrCommandFailed)
+	}
+
+	var entries []jsonUnit
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse list-units output: %w", err)
+	}
+
+	units := make([]Unit, 0, len(entries))
+	for _, entry := range entries {
+		units = append(units, Unit{
+			Name:        entry.Unit,
+			Description: entry.Description,
+			LoadState:   entry.Load,
+			ActiveState: entry.Active,
+			SubState:    entry.Sub,
+		})
+	}
+
+	return units, nil
+}
+
+// parseUnitListPlain parses the output of 'systemctl list-units --plain --no-legend'
+func parseUnitListPlain(output string) []Unit {
+	units := []Unit{}
+
+	for _, line := range strings.Split(output, "\n") {
+		// Some versions still mark failed or not-found units with a bullet
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "●*"))
+
+		// UNIT LOAD ACTIVE SUB DESCRIPTION...
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+
+		units = append(units, Unit{
+			Name:        fields[0],
+			LoadState:   fields[1],
+			ActiveState: fields[2],
+			SubState:    fields[3],
+			Description: strings.Join(fields[4:], " "),
+		})
+	}
+
+	return units
+}
+
+// parseUnitFileStates parses 'systemctl list-unit-files --plain --no-legend' into a state per unit
+func parseUnitFileStates(output string) map[string]string {
+	states := make(map[string]string)
+
+	for _, line := range strings.Split(output, "\n") {
+		// UNIT FILE STATE [VENDOR PRESET]
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		states[fields[0]] = fields[1]
+	}
+
+	return states
+}
+
+// filterUnits keeps units whose load, active or sub state matches the given state
+func filterUnits(units []Unit, state string) []Unit {
+	filtered := []Unit{}
+	for _, unit := range units {
+		if state != "" && unit.LoadState != state && unit.ActiveState != state && unit.SubState != state {
+			continue
+		}
+		filtered = append(filtered, unit)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+
+	return filtered
+}
+
+// validateUnitName checks that a unit name is safe to pass to systemctl
+func validateUnitName(name string) error {
+	if name == "" || len(name) > 256 || strings.HasPrefix(name, "-") || !unitNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidUnitName, name)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// unitActionScript runs a systemctl action, escalating through passwordless sudo when the SSH user is
+// not root, and prints the resulting unit state. The placeholders receive the action and the quoted unit name.
+const unitActionScript = `command -v systemctl >/dev/null 2>&1 || { echo '### missing'; exit 0; }
+echo '### output'
+` + shell.SudoPrefix + ` systemctl --no-ask-password %[1]s -- %[2]s 2>&1
+echo "exit=$?"
+echo '### state'
+systemctl show --no-pager -p Id,LoadState,ActiveState,SubState,UnitFileState -- %[2]s 2>/dev/null
+true`
+
+// allowedActions lists the actions accepted by PerformAction
+var allowedActions = map[string]bool{
+	ActionStart:   true,
+	ActionStop:    true,
+	ActionRestart: true,
+	ActionReload:  true,
+	ActionEnable:  true,
+	ActionDisable: true,
+}
+
+// PerformAction implements the Service interface
+func (s *service) PerformAction(ctx context.Context, sessionID string, name string, action string) (*UnitActionResult, error) {
+	if err := validateUnitName(name); err != nil {
+		return nil, err
+	}
+	if !allowedActions[action] {
+		return nil, fmt.Errorf("%w: %q (use start, stop, restart, reload, enable or disable)", ErrInvalidAction, action)
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(unitActionScript, action, shell.Quote(name)))
+	if err != nil {
+		return nil, err
+	}
+
+	sections := shell.ParseSections(output)
+	if _, missing := sections["missing"]; missing {
+		return nil, ErrSystemdMissing
+	}
+
+	// Split the command output from the trailing exit status line
+	commandOutput, exitStatus := shell.SplitExitStatus(sections["output"])
+
+	state := parseUnitProperties(sections["state"])
+
+	if exitStatus != "0" {
+		lower := strings.ToLower(commandOutput)
+		switch {
+		case state["LoadState"] == "not-found" || (strings.Contains(lower, "not found") && strings.Contains(lower, "unit")):
+			return nil, fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+		case shell.IsPermissionDenied(lower):
+			return nil, fmt.Errorf("%w: %s", ErrPermissionDenied, commandOutput)
+		default:
+			return nil, fmt.Errorf("%w: systemctl %s %s: %s", ErrCommandFailed, action, name, commandOutput)
+		}
+	}
+
+	return &UnitActionResult{
+		Unit:          shell.FirstNonEmpty(state["Id"], name),
+		Action:        action,
+		LoadState:     state["LoadState"],
+		ActiveState:   state["ActiveState"],
+		SubState:      state["SubState"],
+		UnitFileState: state["UnitFileState"],
+		Output:        commandOutput,
+	}, nil
+}
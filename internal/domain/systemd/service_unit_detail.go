@@ -0,0 +1,84 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"remote-server-api/pkg/shell"
+)
+
+// unitShowCommand prints all properties of a unit. The %s placeholder receives the quoted unit name.
+const unitShowCommand = "command -v systemctl >/dev/null 2>&1 || { echo '### missing'; exit 0; }; systemctl show --no-pager -- %s 2>&1; true"
+
+// GetUnit implements the Service interface
+func (s *service) GetUnit(ctx context.Context, sessionID string, name string) (*UnitDetail, error) {
+	if err := validateUnitName(name); err != nil {
+		return nil, err
+	}
+
+	output, err := s.sessionRepo.RunCommand(ctx, sessionID, fmt.Sprintf(unitShowCommand, shell.Quote(name)))
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(output, "### missing") {
+		return nil, ErrSystemdMissing
+	}
+
+	properties := parseUnitProperties(output)
+	if len(properties) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCommandFailed, strings.TrimSpace(output))
+	}
+
+	// systemctl show succeeds for unknown units and reports them as not-found
+	if properties["LoadState"] == "not-found" {
+		return nil, fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+	}
+
+	return buildUnitDetail(properties), nil
+}
+
+// parseUnitProperties parses the Key=Value lines printed by 'systemctl show'
+func parseUnitProperties(output string) map[string]string {
+	properties := make(map[string]string)
+
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if !found || key == "" || strings.ContainsAny(key, " \t") {
+			continue
+		}
+		properties[key] = strings.TrimSpace(value)
+	}
+
+	return properties
+}
+
+// buildUnitDetail extracts the commonly used properties into typed fields
+func buildUnitDetail(properties map[string]string) *UnitDetail {
+	detail := &UnitDetail{
+		Name:                 properties["Id"],
+		Description:          properties["Description"],
+		LoadState:            properties["LoadState"],
+		ActiveState:          properties["ActiveState"],
+		SubState:             properties["SubState"],
+		UnitFileState:        properties["UnitFileState"],
+		FragmentPath:         properties["FragmentPath"],
+		Restart:              properties["Restart"],
+		ActiveEnterTimestamp: properties["ActiveEnterTimestamp"],
+		Requires:             strings.Fields(properties["Requires"]),
+		Wants:                strings.Fields(properties["Wants"]),
+		After:                strings.Fields(properties["After"]),
+		Properties:           properties,
+	}
+
+	detail.MainPID, _ = strconv.Atoi(properties["MainPID"])
+	detail.ExecMainStatus, _ = strconv.Atoi(properties["ExecMainStatus"])
+
+	// MemoryCurrent is "[not set]" or the maximum uint64 when memory accounting is disabled
+	if memory, err := strconv.ParseUint(properties["MemoryCurrent"], 10, 64); err == nil && memory != ^uint64(0) {
+		detail.MemoryCurrent = &memory
+	}
+
+	return detail
+}
@@ -0,0 +1,22 @@
+package systemd
+
+// Supported unit actions
+const (
+	ActionStart   = "start"
+	ActionStop    = "stop"
+	ActionRestart = "restart"
+	ActionReload  = "reload"
+	ActionEnable  = "enable"
+	ActionDisable = "disable"
+)
+
+// UnitActionResult describes the outcome of an action on a unit and the state that followed it
+type UnitActionResult struct {
+	Unit          string `json:"unit"`
+	Action        string `json:"action"`
+	LoadState     string `json:"load_state"`
+	ActiveState   string `json:"active_state"`
+	SubState      string `json:"sub_state"`
+	UnitFileState string `json:"unit_file_state,omitempty"`
+	Output        string `json:"output,omitempty"` // Output of the systemctl command, e.g. symlinks created by enable
+}
@@ -0,0 +1,21 @@
+package systemd
+
+// UnitDetail contains the properties of a unit as reported by systemctl show
+type UnitDetail struct {
+	Name                 string            `json:"name"`
+	Description          string            `json:"description"`
+	LoadState            string            `json:"load_state"`
+	ActiveState          string            `json:"active_state"`
+	SubState             string            `json:"sub_state"`
+	UnitFileState        string            `json:"unit_file_state,omitempty"`
+	FragmentPath         string            `json:"fragment_path,omitempty"` // Path of the unit file
+	MainPID              int               `json:"main_pid,omitempty"`
+	ExecMainStatus       int               `json:"exec_main_status"` // Exit status of the main process
+	Restart              string            `json:"restart,omitempty"`
+	ActiveEnterTimestamp string            `json:"active_enter_timestamp,omitempty"`
+	MemoryCurrent        *uint64           `json:"memory_current,omitempty"` // Memory usage in bytes, if accounting is enabled
+	Requires             []string          `json:"requires,omitempty"`
+	Wants                []string          `json:"wants,omitempty"`
+	After                []string          `json:"after,omitempty"`
+	Properties           map[string]string `json:"properties"` // All properties reported by systemctl show
+}
@@ -3,7 +3,9 @@ package memory
 import (
 	"context"
 	"errors"
+	"io"
 	"sync"
+	"time"
 
 	"golang.org/x/crypto/ssh"
 	"remote-server-api/internal/domain/auth"
@@ -23,26 +25,37 @@ func NewSessionRepository() *SessionRepository {
 	}
 }
 
-// StoreSession stores a new SSH session
-func (r *SessionRepository) StoreSession(ctx context.Context, sessionID string, username string, client *ssh.Client) error {
+// StoreSession stores a new SSH session. The session is removed when it expires or the SSH server
+// closes the connection.
+func (r *SessionRepository) StoreSession(ctx context.Context, sessionID string, username string, client *ssh.Client, expiresAt time.Time) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.sessions[sessionID] = &auth.Session{
-		ID:       sessionID,
-		Username: username,
-		Client:   client,
+	session := &auth.Session{
+		ID:        sessionID,
+		Username:  username,
+		Host:      client.RemoteAddr().String(),
+		Client:    client,
+		ExpiresAt: expiresAt,
 	}
+	r.sessions[sessionID] = session
+
+	// Remove the session once its connection is gone, unless it was replaced by a new login
+	go func() {
+		_ = client.Wait()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.sessions[sessionID] == session {
+			delete(r.sessions, sessionID)
+		}
+	}()
 
 	return nil
 }
 
 // GetSession retrieves an SSH session by ID
 func (r *SessionRepository) GetSession(ctx context.Context, sessionID string) (*auth.Session, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	session, exists := r.sessions[sessionID]
+	session, exists := r.activeSession(sessionID)
 	if !exists {
 		return nil, errors.New("session not found")
 	}
@@ -50,6 +63,46 @@ func (r *SessionRepository) GetSession(ctx context.Context, sessionID string) (*
 	return session, nil
 }
 
+// ListSessions returns all active SSH sessions
+func (r *SessionRepository) ListSessions(ctx context.Context) ([]*auth.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	sessions := make([]*auth.Session, 0, len(r.sessions))
+	for id, session := range r.sessions {
+		if r.expireLocked(id, session, now) {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// activeSession returns a session that has not expired
+func (r *SessionRepository) activeSession(sessionID string) (*auth.Session, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, exists := r.sessions[sessionID]
+	if !exists || r.expireLocked(sessionID, session, time.Now()) {
+		return nil, false
+	}
+	return session, true
+}
+
+// expireLocked removes an expired session and closes its connection, reporting whether it expired.
+// The caller must hold the lock.
+func (r *SessionRepository) expireLocked(sessionID string, session *auth.Session, now time.Time) bool {
+	if session.ExpiresAt.IsZero() || now.Before(session.ExpiresAt) {
+		return false
+	}
+	delete(r.sessions, sessionID)
+	go session.Client.Close()
+	return true
+}
+
 // RemoveSession removes an SSH session by ID
 func (r *SessionRepository) RemoveSession(ctx context.Context, sessionID string) error {
 	r.mu.Lock()
@@ -65,13 +118,30 @@ func (r *SessionRepository) RemoveSession(ctx context.Context, sessionID string)
 
 // RunCommand executes a command on an SSH session
 func (r *SessionRepository) RunCommand(ctx context.Context, sessionID string, command string) (string, error) {
-	r.mu.RLock()
-	session, exists := r.sessions[sessionID]
-	r.mu.RUnlock()
-
+	session, exists := r.activeSession(sessionID)
 	if !exists {
 		return "", errors.New("session not found")
 	}
 
 	return sshClient.RunCommand(session.Client, command)
 }
+
+// StreamCommand executes a command on an SSH session and streams its output to the writer
+func (r *SessionRepository) StreamCommand(ctx context.Context, sessionID string, command string, stdout io.Writer) error {
+	session, exists := r.activeSession(sessionID)
+	if !exists {
+		return errors.New("session not found")
+	}
+
+	return sshClient.StreamCommand(ctx, session.Client, command, stdout)
+}
+
+// RunCommandWithInput executes a command on an SSH session, feeding the reader to its standard input
+func (r *SessionRepository) RunCommandWithInput(ctx context.Context, sessionID string, command string, stdin io.Reader) (string, error) {
+	session, exists := r.activeSession(sessionID)
+	if !exists {
+		return "", errors.New("session not found")
+	}
+
+	return sshClient.RunCommandWithInput(ctx, session.Client, command, stdin)
+}
@@ -2,7 +2,9 @@ package ssh
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"golang.org/x/crypto/ssh"
@@ -60,3 +62,82 @@ func RunCommand(client *ssh.Client, command string) (string, error) {
 
 	return stdoutBuf.String(), nil
 }
+
+// StreamCommand executes a command on an established SSH session and copies its
+// standard output to the given writer as it is produced. The remote session is
+// closed as soon as the context is cancelled.
+func StreamCommand(ctx context.Context, client *ssh.Client, command string, stdout io.Writer) error {
+	// Create a new session
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	defer session.Close()
+
+	session.Stdout = stdout
+
+	// Start the command without waiting for it to finish
+	if err := session.Start(command); err != nil {
+		return fmt.Errorf("failed to start command '%s': %w", command, err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to run command '%s': %w", command, err)
+		}
+		return nil
+	case <-ctx.Done():
+		// Closing the session tears down the channel, which terminates the remote command
+		_ = session.Signal(ssh.SIGTERM)
+		_ = session.Close()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// RunCommandWithInput executes a command on an established SSH session, copying the reader to its
+// standard input, and returns its standard output. The remote session is closed as soon as the
+// context is cancelled. An error reading the input fails the command.
+func RunCommandWithInput(ctx context.Context, client *ssh.Client, command string, stdin io.Reader) (string, error) {
+	// Create a new session
+	session, err := client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+	defer session.Close()
+
+	// Feed the input and capture output
+	var stdoutBuf bytes.Buffer
+	session.Stdin = stdin
+	session.Stdout = &stdoutBuf
+
+	// Start the command without waiting for it to finish
+	if err := session.Start(command); err != nil {
+		return "", fmt.Errorf("failed to start command '%s': %w", command, err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", fmt.Errorf("failed to run command '%s': %w", command, err)
+		}
+		return stdoutBuf.String(), nil
+	case <-ctx.Done():
+		// Closing the session tears down the channel, which terminates the remote command
+		_ = session.Signal(ssh.SIGTERM)
+		_ = session.Close()
+		<-done
+		return "", ctx.Err()
+	}
+}
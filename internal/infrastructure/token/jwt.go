@@ -43,6 +43,11 @@ func (s *JWTService) GenerateToken(username, sessionID string) (string, error) {
 	return tokenString, nil
 }
 
+// ExpiresIn returns the lifetime of the generated tokens
+func (s *JWTService) ExpiresIn() time.Duration {
+	return s.expiresIn
+}
+
 // ValidateToken validates a JWT token and returns the claims
 func (s *JWTService) ValidateToken(tokenString string) (*auth.Claims, error) {
 	claims := &auth.Claims{}
@@ -0,0 +1,41 @@
+package keylock
+
+import "sync"
+
+// Locks holds one mutex per key, e.g. per session, created on first use and removed by its last
+// holder, so that keys that are no longer used do not accumulate. The zero value is ready to use.
+type Locks struct {
+	mu    sync.Mutex
+	locks map[string]*lock
+}
+
+// lock is the mutex of a key with the number of callers holding or waiting for it
+type lock struct {
+	sync.Mutex
+	holders int
+}
+
+// Lock acquires the mutex of a key and returns the function releasing it
+func (l *Locks) Lock(key string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*lock)
+	}
+	entry, ok := l.locks[key]
+	if !ok {
+		entry = &lock{}
+		l.locks[key] = entry
+	}
+	entry.holders++
+	l.mu.Unlock()
+
+	entry.Lock()
+	return func() {
+		entry.Unlock()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if entry.holders--; entry.holders == 0 {
+			delete(l.locks, key)
+		}
+	}
+}
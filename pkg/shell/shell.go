@@ -0,0 +1,131 @@
+package shell
+
+import (
+	"sort"
+	"strings"
+)
+
+// SudoPrefix expands to "sudo -n" unless the remote user is already root. Commands prefixed with it
+// run with elevated privileges when passwordless sudo is available and fail fast otherwise.
+const SudoPrefix = `$([ "$(id -u)" -eq 0 ] || echo sudo -n)`
+
+// SudoIfAvailable expands to "sudo -n" when the remote user is not root and passwordless sudo works,
+// and to nothing otherwise. Commands prefixed with it run unprivileged rather than failing without sudo.
+const SudoIfAvailable = `$([ "$(id -u)" -eq 0 ] || ! sudo -n true 2>/dev/null || echo sudo -n)`
+
+// SectionMarker prefixes the header line of each section in multi-part script output
+const SectionMarker = "### "
+
+// ExitStatusMarker prefixes the line scripts print with the exit status of a command, e.g. echo "exit=$?"
+const ExitStatusMarker = "exit="
+
+// permissionDeniedMessages are fragments of shell, sudo, polkit and common tool output that indicate
+// missing privileges
+var permissionDeniedMessages = []string{
+	"permission denied",
+	"operation not permitted",
+	"access denied",
+	"authentication is required",
+	"interactive authentication required",
+	"a password is required",
+	"not in the sudoers file",
+	"sudo: not found",
+	"must be privileged",
+	"you must be root",
+	"need to be root",
+}
+
+// Quote wraps a value in single quotes so it is passed to the remote shell as a single literal word
+func Quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "'\\''") + "'"
+}
+
+// ParseSections splits the output of a script that prints "### name" headers into named sections
+func ParseSections(output string) map[string]string {
+	sections := make(map[string]string)
+
+	var name string
+	var body strings.Builder
+	flush := func() {
+		if name != "" {
+			sections[name] = body.String()
+		}
+		body.Reset()
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, SectionMarker) {
+			flush()
+			name = strings.TrimSpace(strings.TrimPrefix(line, SectionMarker))
+			continue
+		}
+		body.WriteString(line)
+		body.WriteString("\n")
+	}
+	flush()
+
+	return sections
+}
+
+// SortedSectionNames returns the names of the sections starting with the prefix in sorted order
+func SortedSectionNames(sections map[string]string, prefix string) []string {
+	var names []string
+	for name := range sections {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SplitExitStatus splits command output from its trailing ExitStatusMarker line
+func SplitExitStatus(output string) (string, string) {
+	output = strings.TrimSpace(output)
+	exitStatus := ""
+	if index := strings.LastIndex(output, ExitStatusMarker); index >= 0 {
+		exitStatus = strings.TrimSpace(output[index+len(ExitStatusMarker):])
+		output = strings.TrimSpace(output[:index])
+	}
+	return output, exitStatus
+}
+
+// IsPermissionDenied reports whether command output indicates missing privileges. Callers can add
+// fragments specific to the tools they run.
+func IsPermissionDenied(output string, fragments ...string) bool {
+	lower := strings.ToLower(output)
+	return ContainsAny(lower, permissionDeniedMessages) || ContainsAny(lower, fragments)
+}
+
+// ContainsAny reports whether the value contains any of the fragments
+func ContainsAny(value string, fragments []string) bool {
+	for _, fragment := range fragments {
+		if strings.Contains(value, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// FirstNonEmpty returns the first non-empty value
+func FirstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// LinePrefix is put in front of every line of file content a script prints into a section, e.g. with
+// sed 's/^/|/', so that content lines starting with SectionMarker cannot be taken for headers
+const LinePrefix = "|"
+
+// TrimLinePrefix removes LinePrefix from the lines of a section
+func TrimLinePrefix(section string) string {
+	lines := strings.Split(section, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, LinePrefix)
+	}
+	return strings.Join(lines, "\n")
+}
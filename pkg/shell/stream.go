@@ -0,0 +1,52 @@
+package shell
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+)
+
+// Streamer runs long-lived commands on an SSH session, writing their output as it is produced
+type Streamer interface {
+	StreamCommand(ctx context.Context, sessionID string, command string, stdout io.Writer) error
+}
+
+// StreamLines runs a long-lived command and invokes the callback for every line of output.
+// The command is terminated when the context is cancelled or the callback returns an error.
+func StreamLines(ctx context.Context, streamer Streamer, sessionID string, command string, onLine func(line string) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reader, writer := io.Pipe()
+
+	// Run the command in the background, feeding its output into the pipe
+	done := make(chan error, 1)
+	go func() {
+		err := streamer.StreamCommand(ctx, sessionID, command, writer)
+		writer.CloseWithError(err)
+		done <- err
+	}()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if err := onLine(scanner.Text()); err != nil {
+			cancel()
+			reader.Close()
+			<-done
+			return err
+		}
+	}
+
+	// Stop the remote command if we stopped reading early
+	scanErr := scanner.Err()
+	cancel()
+	reader.Close()
+	err := <-done
+
+	if scanErr != nil && !errors.Is(scanErr, err) {
+		return scanErr
+	}
+	return err
+}